@@ -0,0 +1,179 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build ece
+
+package acc
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// eceMatrixCase is a resource scenario of the ECE acceptance matrix. The
+// checks receive the endpoint so the expectations can be gated on the ECE
+// version.
+type eceMatrixCase struct {
+	name     string
+	config   string
+	template string
+	features []feature
+	checks   func(e eceEndpoint, name string) []resource.TestCheckFunc
+	destroy  resource.TestCheckFunc
+}
+
+var eceMatrix = []eceMatrixCase{
+	{
+		name:     "deployment_basic",
+		config:   "testdata/ece_deployment_basic.tf",
+		template: defaultTemplate,
+		checks: func(e eceEndpoint, name string) []resource.TestCheckFunc {
+			resName := "ec_deployment.ece"
+			checks := []resource.TestCheckFunc{
+				testAccCheckDeploymentExists(resName),
+				resource.TestCheckResourceAttr(resName, "name", name),
+				resource.TestCheckResourceAttr(resName, "region", eceRegion),
+				resource.TestCheckResourceAttr(resName, "deployment_template_id", "default"),
+				resource.TestCheckResourceAttr(resName, "elasticsearch.#", "1"),
+				resource.TestCheckResourceAttr(resName, "kibana.#", "1"),
+				resource.TestCheckResourceAttrSet(resName, "elasticsearch_username"),
+			}
+
+			// Versions without autoscaling support don't return the flag.
+			if e.supports(featureAutoscaling) {
+				checks = append(checks, resource.TestCheckResourceAttr(resName, "elasticsearch.0.autoscale", "false"))
+			} else {
+				checks = append(checks, resource.TestCheckResourceAttr(resName, "elasticsearch.0.autoscale", ""))
+			}
+			return checks
+		},
+	},
+	{
+		name:     "deployment_hot_warm",
+		config:   "testdata/ece_deployment_hotwarm.tf",
+		template: hotWarmTemplate,
+		checks: func(e eceEndpoint, name string) []resource.TestCheckFunc {
+			resName := "ec_deployment.ece"
+			return []resource.TestCheckFunc{
+				testAccCheckDeploymentExists(resName),
+				resource.TestCheckResourceAttr(resName, "deployment_template_id", "hot-warm"),
+				resource.TestCheckResourceAttr(resName, "elasticsearch.0.topology.#", "2"),
+				resource.TestCheckResourceAttr(resName, "elasticsearch.0.topology.0.id", "hot_content"),
+				resource.TestCheckResourceAttr(resName, "elasticsearch.0.topology.1.id", "warm"),
+			}
+		},
+	},
+	{
+		name:     "deployment_traffic_filter",
+		config:   "testdata/ece_deployment_traffic_filter.tf",
+		template: defaultTemplate,
+		features: []feature{featureTrafficFilter},
+		checks: func(e eceEndpoint, name string) []resource.TestCheckFunc {
+			resName := "ec_deployment.ece"
+			filterName := "ec_deployment_traffic_filter.ece"
+			return []resource.TestCheckFunc{
+				testAccCheckDeploymentExists(resName),
+				resource.TestCheckResourceAttr(resName, "traffic_filter.#", "1"),
+				resource.TestCheckResourceAttr(filterName, "region", eceRegion),
+				resource.TestCheckResourceAttr(filterName, "rule.#", "1"),
+			}
+		},
+		destroy: func(s *terraform.State) error {
+			if err := testAccDeploymentDestroy(s); err != nil {
+				return err
+			}
+			return testAccDeploymentTrafficFilterDestroy(s)
+		},
+	},
+	{
+		name:     "deployment_integrations_server",
+		config:   "testdata/ece_deployment_integrations_server.tf",
+		template: defaultTemplate,
+		features: []feature{featureIntegrationsServer},
+		checks: func(e eceEndpoint, name string) []resource.TestCheckFunc {
+			resName := "ec_deployment.ece"
+			return []resource.TestCheckFunc{
+				testAccCheckDeploymentExists(resName),
+				resource.TestCheckResourceAttr(resName, "integrations_server.#", "1"),
+				resource.TestCheckResourceAttrSet(resName, "integrations_server.0.resource_id"),
+				resource.TestCheckResourceAttr(resName, "apm.#", "0"),
+			}
+		},
+	},
+	{
+		name:     "deployment_autoscaling",
+		config:   "testdata/ece_deployment_autoscaling.tf",
+		template: defaultTemplate,
+		features: []feature{featureAutoscaling},
+		checks: func(e eceEndpoint, name string) []resource.TestCheckFunc {
+			resName := "ec_deployment.ece"
+			return []resource.TestCheckFunc{
+				testAccCheckDeploymentExists(resName),
+				resource.TestCheckResourceAttr(resName, "elasticsearch.0.autoscale", "true"),
+				resource.TestCheckResourceAttrSet(resName, "elasticsearch.0.topology.0.autoscaling.0.max_size"),
+			}
+		},
+	},
+}
+
+// TestAccECE_matrix runs the resource matrix against an ECE installation, set
+// EC_ENDPOINT to the ECE API endpoint and run it with the "ece" build tag.
+func TestAccECE_matrix(t *testing.T) {
+	e := requiresECE(t)
+
+	for _, tc := range eceMatrix {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			requiresECE(t, tc.features...)
+
+			randomName := prefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+			cfg := fixtureAccECEMatrix(t, tc.config, randomName, eceTemplates[tc.template])
+
+			destroy := tc.destroy
+			if destroy == nil {
+				destroy = testAccDeploymentDestroy
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviderFactory,
+				CheckDestroy:      destroy,
+				Steps: []resource.TestStep{
+					{
+						Config: cfg,
+						Check:  resource.ComposeAggregateTestCheckFunc(tc.checks(e, randomName)...),
+					},
+				},
+			})
+		})
+	}
+}
+
+func fixtureAccECEMatrix(t *testing.T, fileName, name, template string) string {
+	t.Helper()
+
+	b, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fmt.Sprintf(string(b), eceRegion, name, eceRegion, template)
+}
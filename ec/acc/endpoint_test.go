@@ -0,0 +1,136 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build ece
+
+package acc
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	semver "github.com/blang/semver/v4"
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi"
+)
+
+const eceRegion = "ece-region"
+
+// feature is an ECE feature some of the matrix cases depend on.
+type feature string
+
+const (
+	featureTrafficFilter      feature = "traffic_filter"
+	featureAutoscaling        feature = "autoscaling"
+	featureIntegrationsServer feature = "integrations_server"
+)
+
+// minimumECEVersion is the first ECE version supporting each feature.
+var minimumECEVersion = map[feature]semver.Version{
+	featureTrafficFilter:      semver.MustParse("2.6.0"),
+	featureAutoscaling:        semver.MustParse("2.9.0"),
+	featureIntegrationsServer: semver.MustParse("3.3.0"),
+}
+
+// eceTemplates maps the acceptance test templates to the ECE default ones.
+var eceTemplates = map[string]string{
+	defaultTemplate: "default",
+	hotWarmTemplate: "hot-warm",
+	ccsTemplate:     "cross-cluster-search",
+}
+
+// eceEndpoint is the ECE installation the matrix runs against.
+type eceEndpoint struct {
+	version semver.Version
+}
+
+var (
+	currentEndpoint     eceEndpoint
+	currentEndpointErr  error
+	currentEndpointOnce sync.Once
+)
+
+func getECEEndpoint() (eceEndpoint, error) {
+	currentEndpointOnce.Do(func() {
+		client, err := newAPI()
+		if err != nil {
+			currentEndpointErr = err
+			return
+		}
+
+		info, err := platformapi.GetInfo(platformapi.GetInfoParams{
+			API: client, Region: eceRegion,
+		})
+		if err != nil {
+			currentEndpointErr = fmt.Errorf("failed obtaining the ECE version: %w", err)
+			return
+		}
+
+		v, err := semver.ParseTolerant(*info.Version)
+		if err != nil {
+			currentEndpointErr = fmt.Errorf("failed parsing the ECE version %q: %w", *info.Version, err)
+			return
+		}
+
+		currentEndpoint = eceEndpoint{version: v}
+	})
+
+	return currentEndpoint, currentEndpointErr
+}
+
+func endpointHost() string {
+	var host = api.ESSEndpoint
+	if h := os.Getenv("EC_HOST"); h != "" {
+		host = h
+	}
+	if h := os.Getenv("EC_ENDPOINT"); h != "" {
+		host = h
+	}
+	return host
+}
+
+func (e eceEndpoint) supports(f feature) bool {
+	if min, ok := minimumECEVersion[f]; ok {
+		return e.version.GTE(min)
+	}
+	return true
+}
+
+// requiresECE skips the test unless it runs against an ECE installation
+// which supports all the features.
+func requiresECE(t *testing.T, features ...feature) eceEndpoint {
+	requiresAPIConn(t)
+
+	if endpointHost() == api.ESSEndpoint {
+		t.Skip("the ECE acceptance matrix requires EC_ENDPOINT to be set to an ECE installation")
+	}
+
+	e, err := getECEEndpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range features {
+		if !e.supports(f) {
+			t.Skipf("ECE %s doesn't support %s", e.version, f)
+		}
+	}
+
+	return e
+}
@@ -0,0 +1,19 @@
+data "ec_stack" "latest" {
+  version_regex = "latest"
+  region        = "%s"
+}
+
+resource "ec_deployment" "ece" {
+  name                   = "%s"
+  region                 = "%s"
+  version                = data.ec_stack.latest.version
+  deployment_template_id = "%s"
+
+  elasticsearch {
+    autoscale = "true"
+
+    topology {
+      id = "hot_content"
+    }
+  }
+}
@@ -0,0 +1,23 @@
+data "ec_stack" "latest" {
+  version_regex = "latest"
+  region        = "%s"
+}
+
+resource "ec_deployment" "ece" {
+  name                   = "%s"
+  region                 = "%s"
+  version                = data.ec_stack.latest.version
+  deployment_template_id = "%s"
+
+  elasticsearch {
+    topology {
+      id = "hot_content"
+    }
+
+    topology {
+      id = "warm"
+    }
+  }
+
+  kibana {}
+}
@@ -0,0 +1,27 @@
+data "ec_stack" "latest" {
+  version_regex = "latest"
+  region        = "%s"
+}
+
+resource "ec_deployment" "ece" {
+  name                   = "%[2]s"
+  region                 = "%[3]s"
+  version                = data.ec_stack.latest.version
+  deployment_template_id = "%[4]s"
+
+  elasticsearch {}
+
+  traffic_filter = [
+    ec_deployment_traffic_filter.ece.id,
+  ]
+}
+
+resource "ec_deployment_traffic_filter" "ece" {
+  name   = "%[2]s"
+  region = "%[3]s"
+  type   = "ip"
+
+  rule {
+    source = "0.0.0.0/0"
+  }
+}
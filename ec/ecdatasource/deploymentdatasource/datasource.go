@@ -21,9 +21,6 @@ import (
 	"context"
 	"time"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
-	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
-	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -46,19 +43,17 @@ func DataSource() *schema.Resource {
 }
 
 func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api.API)
+	client := meta.(*util.ProviderMeta).API
 	deploymentID := d.Get("id").(string)
+	if deploymentID == "" {
+		id, err := lookupDeploymentID(client, d.Get("alias").(string), d.Get("name").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		deploymentID = id
+	}
 
-	res, err := deploymentapi.Get(deploymentapi.GetParams{
-		API:          client,
-		DeploymentID: deploymentID,
-		QueryParams: deputil.QueryParams{
-			ShowPlans:        true,
-			ShowSettings:     true,
-			ShowMetadata:     true,
-			ShowPlanDefaults: true,
-		},
-	})
+	res, err := util.GetDeployment(client, deploymentID)
 	if err != nil {
 		return diag.FromErr(
 			multierror.NewPrefixed("failed retrieving deployment information", err),
@@ -66,6 +61,9 @@ func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diag
 	}
 
 	d.SetId(deploymentID)
+	if err := d.Set("id", deploymentID); err != nil {
+		return diag.FromErr(err)
+	}
 
 	if err := modelToState(d, res); err != nil {
 		return diag.FromErr(err)
@@ -83,6 +81,10 @@ func modelToState(d *schema.ResourceData, res *models.DeploymentGetResponse) err
 		return err
 	}
 
+	if err := d.Set("has_pending_plan", hasPendingPlan(res.Resources)); err != nil {
+		return err
+	}
+
 	if err := d.Set("alias", res.Alias); err != nil {
 		return err
 	}
@@ -154,6 +154,7 @@ func newSampleDeployment() map[string]interface{} {
 		"alias":                  "some-alias",
 		"deployment_template_id": "aws-io-optimized",
 		"healthy":                true,
+		"has_pending_plan":       false,
 		"region":                 "us-east-1",
 		"traffic_filter":         []interface{}{"0.0.0.0/0", "192.168.10.0/24"},
 		"observability":          []interface{}{newObservabilitySample()},
@@ -51,6 +51,16 @@ func flattenApmResources(in []*models.ApmResourceInfo) []interface{} {
 				m["status"] = *res.Info.Status
 			}
 
+			if plans := res.Info.PlanInfo; plans != nil {
+				if plans.Healthy != nil {
+					m["plan_healthy"] = *plans.Healthy
+				}
+
+				if p := plans.Pending; p != nil {
+					m["pending_plan"] = flattenPendingPlan(p.PlanAttemptID, p.PlanAttemptName, p.AttemptStartTime)
+				}
+			}
+
 			if !util.IsCurrentApmPlanEmpty(res) {
 				var plan = res.Info.PlanInfo.Current.Plan
 
@@ -58,7 +68,9 @@ func flattenApmResources(in []*models.ApmResourceInfo) []interface{} {
 					m["version"] = plan.Apm.Version
 				}
 
-				m["topology"] = flattenApmTopology(plan)
+				topology := flattenApmTopology(plan)
+				util.FlattenTopologyInstances(topology, res.Info.Topology)
+				m["topology"] = topology
 			}
 
 			if res.Info.Metadata != nil {
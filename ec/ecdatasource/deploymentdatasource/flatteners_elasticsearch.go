@@ -52,6 +52,16 @@ func flattenElasticsearchResources(in []*models.ElasticsearchResourceInfo) ([]in
 				m["status"] = *res.Info.Status
 			}
 
+			if plans := res.Info.PlanInfo; plans != nil {
+				if plans.Healthy != nil {
+					m["plan_healthy"] = *plans.Healthy
+				}
+
+				if p := plans.Pending; p != nil {
+					m["pending_plan"] = flattenPendingPlan(p.PlanAttemptID, p.PlanAttemptName, p.AttemptStartTime)
+				}
+			}
+
 			if !util.IsCurrentEsPlanEmpty(res) {
 				var plan = res.Info.PlanInfo.Current.Plan
 
@@ -67,6 +77,7 @@ func flattenElasticsearchResources(in []*models.ElasticsearchResourceInfo) ([]in
 				if err != nil {
 					return nil, err
 				}
+				util.FlattenTopologyInstances(top, res.Info.Topology)
 				m["topology"] = top
 			}
 
@@ -93,6 +104,7 @@ func flattenElasticsearchTopology(plan *models.ElasticsearchClusterPlan) ([]inte
 			continue
 		}
 
+		m["id"] = topology.ID
 		m["instance_configuration_id"] = topology.InstanceConfigurationID
 
 		if isSizePopulated(topology) {
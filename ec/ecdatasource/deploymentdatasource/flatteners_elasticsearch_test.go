@@ -59,6 +59,15 @@ func Test_flattenElasticsearchResources(t *testing.T) {
 								HTTPS: ec.Int32(9243),
 							},
 						},
+						Topology: &models.ClusterTopologyInfo{
+							Instances: []*models.ClusterInstanceInfo{{
+								Zone: "us-east-1a",
+								InstanceConfiguration: &models.ClusterInstanceConfigurationInfo{
+									ID:   ec.String("aws.data.highio.i3"),
+									Name: ec.String("aws.data.highio.i3"),
+								},
+							}},
+						},
 						PlanInfo: &models.ElasticsearchClusterPlansInfo{
 							Current: &models.ElasticsearchClusterPlanInfo{
 								Plan: &models.ElasticsearchClusterPlan{
@@ -68,6 +77,7 @@ func Test_flattenElasticsearchResources(t *testing.T) {
 									},
 									ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
 										{
+											ID:                      "hot_content",
 											NodeCountPerZone:        1,
 											ZoneCount:               1,
 											InstanceConfigurationID: "aws.data.highio.i3",
@@ -123,14 +133,17 @@ func Test_flattenElasticsearchResources(t *testing.T) {
 				"healthy":        true,
 				"status":         "started",
 				"topology": []interface{}{map[string]interface{}{
-					"instance_configuration_id": "aws.data.highio.i3",
-					"size":                      "2g",
-					"size_resource":             "memory",
-					"node_type_data":            true,
-					"node_type_ingest":          true,
-					"node_type_master":          true,
-					"node_type_ml":              false,
-					"zone_count":                int32(1),
+					"id":                          "hot_content",
+					"instance_configuration_id":   "aws.data.highio.i3",
+					"instance_configuration_name": "aws.data.highio.i3",
+					"zones":                       map[string]interface{}{"us-east-1a": 1},
+					"size":                        "2g",
+					"size_resource":               "memory",
+					"node_type_data":              true,
+					"node_type_ingest":            true,
+					"node_type_master":            true,
+					"node_type_ml":                false,
+					"zone_count":                  int32(1),
 					"autoscaling": []interface{}{map[string]interface{}{
 						"max_size":          "15g",
 						"max_size_resource": "memory",
@@ -51,6 +51,16 @@ func flattenEnterpriseSearchResources(in []*models.EnterpriseSearchResourceInfo)
 				m["status"] = *res.Info.Status
 			}
 
+			if plans := res.Info.PlanInfo; plans != nil {
+				if plans.Healthy != nil {
+					m["plan_healthy"] = *plans.Healthy
+				}
+
+				if p := plans.Pending; p != nil {
+					m["pending_plan"] = flattenPendingPlan(p.PlanAttemptID, p.PlanAttemptName, p.AttemptStartTime)
+				}
+			}
+
 			if !util.IsCurrentEssPlanEmpty(res) {
 				var plan = res.Info.PlanInfo.Current.Plan
 
@@ -58,7 +68,9 @@ func flattenEnterpriseSearchResources(in []*models.EnterpriseSearchResourceInfo)
 					m["version"] = plan.EnterpriseSearch.Version
 				}
 
-				m["topology"] = flattenEnterpriseSearchTopology(plan)
+				topology := flattenEnterpriseSearchTopology(plan)
+				util.FlattenTopologyInstances(topology, res.Info.Topology)
+				m["topology"] = topology
 			}
 
 			if res.Info.Metadata != nil {
@@ -51,6 +51,16 @@ func flattenIntegrationsServerResources(in []*models.IntegrationsServerResourceI
 				m["status"] = *res.Info.Status
 			}
 
+			if plans := res.Info.PlanInfo; plans != nil {
+				if plans.Healthy != nil {
+					m["plan_healthy"] = *plans.Healthy
+				}
+
+				if p := plans.Pending; p != nil {
+					m["pending_plan"] = flattenPendingPlan(p.PlanAttemptID, p.PlanAttemptName, p.AttemptStartTime)
+				}
+			}
+
 			if !util.IsCurrentIntegrationsServerPlanEmpty(res) {
 				var plan = res.Info.PlanInfo.Current.Plan
 
@@ -58,7 +68,9 @@ func flattenIntegrationsServerResources(in []*models.IntegrationsServerResourceI
 					m["version"] = plan.IntegrationsServer.Version
 				}
 
-				m["topology"] = flattenIntegrationsServerTopology(plan)
+				topology := flattenIntegrationsServerTopology(plan)
+				util.FlattenTopologyInstances(topology, res.Info.Topology)
+				m["topology"] = topology
 			}
 
 			if res.Info.Metadata != nil {
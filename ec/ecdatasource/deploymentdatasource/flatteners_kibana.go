@@ -51,6 +51,16 @@ func flattenKibanaResources(in []*models.KibanaResourceInfo) []interface{} {
 				m["status"] = *res.Info.Status
 			}
 
+			if plans := res.Info.PlanInfo; plans != nil {
+				if plans.Healthy != nil {
+					m["plan_healthy"] = *plans.Healthy
+				}
+
+				if p := plans.Pending; p != nil {
+					m["pending_plan"] = flattenPendingPlan(p.PlanAttemptID, p.PlanAttemptName, p.AttemptStartTime)
+				}
+			}
+
 			if !util.IsCurrentKibanaPlanEmpty(res) {
 				var plan = res.Info.PlanInfo.Current.Plan
 
@@ -58,7 +68,9 @@ func flattenKibanaResources(in []*models.KibanaResourceInfo) []interface{} {
 					m["version"] = plan.Kibana.Version
 				}
 
-				m["topology"] = flattenKibanaTopology(plan)
+				topology := flattenKibanaTopology(plan)
+				util.FlattenTopologyInstances(topology, res.Info.Topology)
+				m["topology"] = topology
 			}
 
 			if res.Info.Metadata != nil {
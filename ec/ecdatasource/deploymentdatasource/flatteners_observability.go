@@ -19,31 +19,51 @@ package deploymentdatasource
 
 import "github.com/elastic/cloud-sdk-go/pkg/models"
 
-// flattenObservability parses a deployment's observability settings.
+// flattenObservability parses a deployment's observability settings. All the
+// keys are always set so consumers get the same keys regardless of which of
+// the logs and metrics destinations are set, or whether their ref_id is.
 func flattenObservability(settings *models.DeploymentSettings) []interface{} {
 	if settings == nil || settings.Observability == nil {
 		return nil
 	}
 
-	var m = make(map[string]interface{})
+	var m = map[string]interface{}{
+		"deployment_id": "",
+		"ref_id":        "",
+		"logs":          false,
+		"metrics":       false,
+	}
 
 	// We are only accepting a single deployment ID and refID for both logs and metrics.
 	// If either of them is not nil the deployment ID and refID will be filled.
-	if settings.Observability.Metrics != nil {
-		m["deployment_id"] = settings.Observability.Metrics.Destination.DeploymentID
-		m["ref_id"] = settings.Observability.Metrics.Destination.RefID
+	var found bool
+	if metrics := settings.Observability.Metrics; metrics != nil && metrics.Destination != nil {
+		flattenObservabilityDestination(m, metrics.Destination)
 		m["metrics"] = true
+		found = true
 	}
 
-	if settings.Observability.Logging != nil {
-		m["deployment_id"] = settings.Observability.Logging.Destination.DeploymentID
-		m["ref_id"] = settings.Observability.Logging.Destination.RefID
+	if logging := settings.Observability.Logging; logging != nil && logging.Destination != nil {
+		flattenObservabilityDestination(m, logging.Destination)
 		m["logs"] = true
+		found = true
 	}
 
-	if len(m) == 0 {
+	if !found {
 		return nil
 	}
 
 	return []interface{}{m}
 }
+
+// flattenObservabilityDestination sets the destination deployment_id and
+// ref_id, a destination without them doesn't override previously set values.
+func flattenObservabilityDestination(m map[string]interface{}, dest *models.ObservabilityAbsoluteDeployment) {
+	if dest.DeploymentID != nil && *dest.DeploymentID != "" {
+		m["deployment_id"] = *dest.DeploymentID
+	}
+
+	if dest.RefID != "" {
+		m["ref_id"] = dest.RefID
+	}
+}
@@ -59,9 +59,10 @@ func TestFlattenObservability(t *testing.T) {
 				},
 			}},
 			want: []interface{}{map[string]interface{}{
-				"deployment_id": &mock.ValidClusterID,
+				"deployment_id": mock.ValidClusterID,
 				"ref_id":        "main-elasticsearch",
 				"logs":          true,
+				"metrics":       false,
 			}},
 		},
 		{
@@ -77,8 +78,9 @@ func TestFlattenObservability(t *testing.T) {
 				},
 			}},
 			want: []interface{}{map[string]interface{}{
-				"deployment_id": &mock.ValidClusterID,
+				"deployment_id": mock.ValidClusterID,
 				"ref_id":        "main-elasticsearch",
+				"logs":          false,
 				"metrics":       true,
 			}},
 		},
@@ -101,12 +103,68 @@ func TestFlattenObservability(t *testing.T) {
 				},
 			}},
 			want: []interface{}{map[string]interface{}{
-				"deployment_id": &mock.ValidClusterID,
+				"deployment_id": mock.ValidClusterID,
 				"ref_id":        "main-elasticsearch",
 				"logs":          true,
 				"metrics":       true,
 			}},
 		},
+		{
+			name: "flattens observability settings without ref_id",
+			args: args{settings: &models.DeploymentSettings{
+				Observability: &models.DeploymentObservabilitySettings{
+					Logging: &models.DeploymentLoggingSettings{
+						Destination: &models.ObservabilityAbsoluteDeployment{
+							DeploymentID: &mock.ValidClusterID,
+						},
+					},
+					Metrics: &models.DeploymentMetricsSettings{
+						Destination: &models.ObservabilityAbsoluteDeployment{
+							DeploymentID: &mock.ValidClusterID,
+						},
+					},
+				},
+			}},
+			want: []interface{}{map[string]interface{}{
+				"deployment_id": mock.ValidClusterID,
+				"ref_id":        "",
+				"logs":          true,
+				"metrics":       true,
+			}},
+		},
+		{
+			name: "keeps the metrics ref_id when the logs destination omits it",
+			args: args{settings: &models.DeploymentSettings{
+				Observability: &models.DeploymentObservabilitySettings{
+					Logging: &models.DeploymentLoggingSettings{
+						Destination: &models.ObservabilityAbsoluteDeployment{
+							DeploymentID: &mock.ValidClusterID,
+						},
+					},
+					Metrics: &models.DeploymentMetricsSettings{
+						Destination: &models.ObservabilityAbsoluteDeployment{
+							DeploymentID: &mock.ValidClusterID,
+							RefID:        "main-elasticsearch",
+						},
+					},
+				},
+			}},
+			want: []interface{}{map[string]interface{}{
+				"deployment_id": mock.ValidClusterID,
+				"ref_id":        "main-elasticsearch",
+				"logs":          true,
+				"metrics":       true,
+			}},
+		},
+		{
+			name: "flattens no observability settings when the destinations are empty",
+			args: args{settings: &models.DeploymentSettings{
+				Observability: &models.DeploymentObservabilitySettings{
+					Logging: &models.DeploymentLoggingSettings{},
+					Metrics: &models.DeploymentMetricsSettings{},
+				},
+			}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentdatasource
+
+import (
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/go-openapi/strfmt"
+)
+
+// flattenPendingPlan takes in the pending plan attempt fields of a resource
+// and returns its flattened form.
+func flattenPendingPlan(attemptID, attemptName string, start strfmt.DateTime) []interface{} {
+	var m = map[string]interface{}{
+		"attempt_id":   attemptID,
+		"attempt_name": attemptName,
+	}
+
+	if t := time.Time(start); !t.IsZero() {
+		m["attempt_start_time"] = t.UTC().Format(time.RFC3339)
+	}
+
+	return []interface{}{m}
+}
+
+// hasPendingPlan returns true when any of the deployment resources has a
+// pending plan.
+func hasPendingPlan(res *models.DeploymentResources) bool {
+	if res == nil {
+		return false
+	}
+
+	for _, r := range res.Elasticsearch {
+		if r.Info != nil && r.Info.PlanInfo != nil && r.Info.PlanInfo.Pending != nil {
+			return true
+		}
+	}
+
+	for _, r := range res.Kibana {
+		if r.Info != nil && r.Info.PlanInfo != nil && r.Info.PlanInfo.Pending != nil {
+			return true
+		}
+	}
+
+	for _, r := range res.Apm {
+		if r.Info != nil && r.Info.PlanInfo != nil && r.Info.PlanInfo.Pending != nil {
+			return true
+		}
+	}
+
+	for _, r := range res.IntegrationsServer {
+		if r.Info != nil && r.Info.PlanInfo != nil && r.Info.PlanInfo.Pending != nil {
+			return true
+		}
+	}
+
+	for _, r := range res.EnterpriseSearch {
+		if r.Info != nil && r.Info.PlanInfo != nil && r.Info.PlanInfo.Pending != nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,119 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentdatasource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flattenPendingPlan(t *testing.T) {
+	start := strfmt.DateTime(time.Date(2022, 10, 4, 12, 30, 0, 0, time.UTC))
+	type args struct {
+		attemptID   string
+		attemptName string
+		start       strfmt.DateTime
+	}
+	tests := []struct {
+		name string
+		args args
+		want []interface{}
+	}{
+		{
+			name: "flattens the pending plan",
+			args: args{attemptID: "some-id", attemptName: "attempt-0000000001", start: start},
+			want: []interface{}{map[string]interface{}{
+				"attempt_id":         "some-id",
+				"attempt_name":       "attempt-0000000001",
+				"attempt_start_time": "2022-10-04T12:30:00Z",
+			}},
+		},
+		{
+			name: "omits the start time when it hasn't been set",
+			args: args{attemptID: "some-id", attemptName: "attempt-0000000001"},
+			want: []interface{}{map[string]interface{}{
+				"attempt_id":   "some-id",
+				"attempt_name": "attempt-0000000001",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flattenPendingPlan(tt.args.attemptID, tt.args.attemptName, tt.args.start)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_hasPendingPlan(t *testing.T) {
+	type args struct {
+		res *models.DeploymentResources
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "returns false on nil resources",
+		},
+		{
+			name: "returns false when there are no pending plans",
+			args: args{res: &models.DeploymentResources{
+				Elasticsearch: []*models.ElasticsearchResourceInfo{{
+					Info: &models.ElasticsearchClusterInfo{
+						PlanInfo: &models.ElasticsearchClusterPlansInfo{
+							Healthy: ec.Bool(true),
+						},
+					},
+				}},
+				Kibana: []*models.KibanaResourceInfo{{
+					Info: &models.KibanaClusterInfo{},
+				}},
+			}},
+		},
+		{
+			name: "returns true when a resource has a pending plan",
+			args: args{res: &models.DeploymentResources{
+				Elasticsearch: []*models.ElasticsearchResourceInfo{{
+					Info: &models.ElasticsearchClusterInfo{
+						PlanInfo: &models.ElasticsearchClusterPlansInfo{},
+					},
+				}},
+				Kibana: []*models.KibanaResourceInfo{{
+					Info: &models.KibanaClusterInfo{
+						PlanInfo: &models.KibanaClusterPlansInfo{
+							Pending: &models.KibanaClusterPlanInfo{PlanAttemptID: "some-id"},
+						},
+					},
+				}},
+			}},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hasPendingPlan(tt.args.res))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentdatasource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// lookupDeploymentID returns the ID of the only deployment whose endpoint
+// alias or name exactly matches the specified one.
+func lookupDeploymentID(client *api.API, alias, name string) (string, error) {
+	// The "keyword" field ensures the name isn't analyzed.
+	field, value, term := "alias", alias, "alias"
+	if alias == "" {
+		field, value, term = "name", name, "name.keyword"
+	}
+
+	res, err := deploymentapi.Search(deploymentapi.SearchParams{
+		API: client,
+		Request: &models.SearchRequest{
+			Query: &models.QueryContainer{
+				Term: map[string]models.TermQuery{
+					term: {Value: ec.String(value)},
+				},
+			},
+			Sort: []interface{}{"id"},
+		},
+	})
+	if err != nil {
+		return "", multierror.NewPrefixed("failed searching deployments", err)
+	}
+
+	return matchDeployment(res, field, value)
+}
+
+// matchDeployment returns the ID of the only deployment in the search
+// response whose field exactly matches the value, returning an error when
+// none or multiple deployments match.
+func matchDeployment(res *models.DeploymentsSearchResponse, field, value string) (string, error) {
+	var ids []string
+	for _, dep := range res.Deployments {
+		if dep == nil || dep.ID == nil {
+			continue
+		}
+
+		var got string
+		switch field {
+		case "alias":
+			got = dep.Alias
+		case "name":
+			if dep.Name != nil {
+				got = *dep.Name
+			}
+		}
+
+		if got == value {
+			ids = append(ids, *dep.ID)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf(`no deployment found with %s "%s"`, field, value)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf(
+			`found %d deployments with %s "%s", use the deployment "id" instead: %s`,
+			len(ids), field, value, strings.Join(ids, ", "),
+		)
+	}
+}
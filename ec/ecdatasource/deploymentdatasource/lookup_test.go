@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentdatasource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_matchDeployment(t *testing.T) {
+	res := &models.DeploymentsSearchResponse{Deployments: []*models.DeploymentSearchResponse{
+		{ID: ec.String("123"), Name: ec.String("my-deployment"), Alias: "my-alias"},
+		{ID: ec.String("456"), Name: ec.String("my-deployment-2"), Alias: "my-alias-2"},
+		{ID: ec.String("789"), Name: ec.String("duplicated"), Alias: "other"},
+		{ID: ec.String("abc"), Name: ec.String("duplicated"), Alias: "another"},
+	}}
+
+	tests := []struct {
+		name  string
+		field string
+		value string
+		want  string
+		err   error
+	}{
+		{
+			name:  "matches a deployment by alias",
+			field: "alias",
+			value: "my-alias",
+			want:  "123",
+		},
+		{
+			name:  "matches a deployment by its exact name",
+			field: "name",
+			value: "my-deployment",
+			want:  "123",
+		},
+		{
+			name:  "fails when no deployment matches",
+			field: "alias",
+			value: "missing",
+			err:   errors.New(`no deployment found with alias "missing"`),
+		},
+		{
+			name:  "fails when multiple deployments match",
+			field: "name",
+			value: "duplicated",
+			err:   errors.New(`found 2 deployments with name "duplicated", use the deployment "id" instead: 789, abc`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchDeployment(res, tt.field, tt.value)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -21,23 +21,36 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// lookupKeys are the arguments which the deployment can be looked up by.
+var lookupKeys = []string{"id", "alias", "name"}
+
 func newSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"alias": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: lookupKeys,
 		},
 		"healthy": {
 			Type:     schema.TypeBool,
 			Computed: true,
 		},
+		"has_pending_plan": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
 		"id": {
-			Type:     schema.TypeString,
-			Required: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: lookupKeys,
 		},
 		"name": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: lookupKeys,
 		},
 		"region": {
 			Type:     schema.TypeString,
@@ -118,3 +131,26 @@ func newObservabilitySettings() *schema.Resource {
 		},
 	}
 }
+
+func pendingPlanSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"attempt_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"attempt_name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"attempt_start_time": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
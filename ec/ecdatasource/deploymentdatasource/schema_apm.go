@@ -52,6 +52,11 @@ func newApmResourceInfo() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"plan_healthy": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"pending_plan": pendingPlanSchema(),
 			"version": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -83,6 +88,17 @@ func apmTopologySchema() *schema.Schema {
 					Type:     schema.TypeInt,
 					Computed: true,
 				},
+				"instance_configuration_name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"zones": {
+					Type:     schema.TypeMap,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeInt,
+					},
+				},
 			},
 		},
 	}
@@ -56,6 +56,11 @@ func newElasticsearchResourceInfo() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"plan_healthy": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"pending_plan": pendingPlanSchema(),
 			"version": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -71,6 +76,10 @@ func elasticsearchTopologySchema() *schema.Schema {
 		Computed: true,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
 				"instance_configuration_id": {
 					Type:     schema.TypeString,
 					Computed: true,
@@ -87,6 +96,17 @@ func elasticsearchTopologySchema() *schema.Schema {
 					Type:     schema.TypeInt,
 					Computed: true,
 				},
+				"instance_configuration_name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"zones": {
+					Type:     schema.TypeMap,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeInt,
+					},
+				},
 				"node_type_data": {
 					Type:     schema.TypeBool,
 					Computed: true,
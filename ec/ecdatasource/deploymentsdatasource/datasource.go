@@ -19,15 +19,18 @@ package deploymentsdatasource
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"time"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/privatelinkdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
 )
 
 // DataSource returns the ec_deployments data source schema.
@@ -44,7 +47,7 @@ func DataSource() *schema.Resource {
 }
 
 func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api.API)
+	client := meta.(*util.ProviderMeta).API
 
 	query, err := expandFilters(d)
 	if err != nil {
@@ -113,6 +116,8 @@ func modelToState(d *schema.ResourceData, res *models.DeploymentsSearchResponse)
 			m["enterprise_search_ref_id"] = *deployment.Resources.EnterpriseSearch[0].RefID
 		}
 
+		flattenPrivatelinkEndpoints(deployment, m)
+
 		result = append(result, m)
 
 		if len(result) > 0 {
@@ -124,3 +129,39 @@ func modelToState(d *schema.ResourceData, res *models.DeploymentsSearchResponse)
 
 	return nil
 }
+
+// flattenPrivatelinkEndpoints sets the privatelink hostnames of the
+// Elasticsearch and Kibana resources, in both their alias and regional
+// resource ID forms, when the deployment region has a privatelink endpoint.
+func flattenPrivatelinkEndpoints(deployment *models.DeploymentSearchResponse, m map[string]interface{}) {
+	if len(deployment.Resources.Elasticsearch) == 0 {
+		return
+	}
+
+	es := deployment.Resources.Elasticsearch[0]
+	if es.Region == nil {
+		return
+	}
+
+	domainName := privatelinkdatasource.DomainName(*es.Region)
+	if domainName == "" {
+		return
+	}
+
+	if es.ID != nil {
+		m["elasticsearch_privatelink_endpoint"] = fmt.Sprintf("%s.%s", *es.ID, domainName)
+	}
+
+	if len(deployment.Resources.Kibana) > 0 && deployment.Resources.Kibana[0].ID != nil {
+		m["kibana_privatelink_endpoint"] = fmt.Sprintf("%s.%s", *deployment.Resources.Kibana[0].ID, domainName)
+	}
+
+	if deployment.Alias == "" {
+		return
+	}
+
+	m["elasticsearch_privatelink_alias_endpoint"] = fmt.Sprintf("%s.es.%s", deployment.Alias, domainName)
+	if len(deployment.Resources.Kibana) > 0 {
+		m["kibana_privatelink_alias_endpoint"] = fmt.Sprintf("%s.kb.%s", deployment.Alias, domainName)
+	}
+}
@@ -181,3 +181,57 @@ func Test_modelToState(t *testing.T) {
 		})
 	}
 }
+
+func Test_flattenPrivatelinkEndpoints(t *testing.T) {
+	newDeployment := func(alias, region string) *models.DeploymentSearchResponse {
+		return &models.DeploymentSearchResponse{
+			Alias: alias,
+			Resources: &models.DeploymentResources{
+				Elasticsearch: []*models.ElasticsearchResourceInfo{{
+					ID:     ec.String("a98dd0dac15a48d5b3953384c7e571b9"),
+					Region: ec.String(region),
+				}},
+				Kibana: []*models.KibanaResourceInfo{{
+					ID: ec.String("c75297d672b54da68faecededf372f87"),
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		deployment *models.DeploymentSearchResponse
+		want       map[string]interface{}
+	}{
+		{
+			name:       "sets the alias and regional hostnames",
+			deployment: newDeployment("dev", "aws-us-east-1"),
+			want: map[string]interface{}{
+				"elasticsearch_privatelink_alias_endpoint": "dev.es.vpce.us-east-1.aws.elastic-cloud.com",
+				"elasticsearch_privatelink_endpoint":       "a98dd0dac15a48d5b3953384c7e571b9.vpce.us-east-1.aws.elastic-cloud.com",
+				"kibana_privatelink_alias_endpoint":        "dev.kb.vpce.us-east-1.aws.elastic-cloud.com",
+				"kibana_privatelink_endpoint":              "c75297d672b54da68faecededf372f87.vpce.us-east-1.aws.elastic-cloud.com",
+			},
+		},
+		{
+			name:       "only sets the regional hostnames without an alias",
+			deployment: newDeployment("", "gcp-asia-east1"),
+			want: map[string]interface{}{
+				"elasticsearch_privatelink_endpoint": "a98dd0dac15a48d5b3953384c7e571b9.psc.asia-east1.gcp.elastic-cloud.com",
+				"kibana_privatelink_endpoint":        "c75297d672b54da68faecededf372f87.psc.asia-east1.gcp.elastic-cloud.com",
+			},
+		},
+		{
+			name:       "doesn't set any hostname when the region has no privatelink endpoint",
+			deployment: newDeployment("dev", "ece-region"),
+			want:       map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(map[string]interface{})
+			flattenPrivatelinkEndpoints(tt.deployment, got)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -146,6 +146,22 @@ func newDeploymentList() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"elasticsearch_privatelink_alias_endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"elasticsearch_privatelink_endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"kibana_privatelink_alias_endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"kibana_privatelink_endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -0,0 +1,108 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymenttemplatesdatasource
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deptemplateapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// DataSource returns the ec_deployment_templates data source schema.
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: read,
+
+		Schema: newSchema(),
+
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+	region := d.Get("region").(string)
+
+	res, err := deptemplateapi.List(deptemplateapi.ListParams{
+		API:          client,
+		Region:       region,
+		StackVersion: d.Get("stack_version").(string),
+		ShowHidden:   d.Get("show_hidden").(bool),
+	})
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed listing deployment templates", err),
+		)
+	}
+
+	filters := make(map[string]bool)
+	for _, c := range capabilities {
+		if v := d.Get(c).(string); v != "" {
+			filters[c], _ = strconv.ParseBool(v)
+		}
+	}
+
+	templates := filterTemplates(res, filters)
+	if d.Id() == "" {
+		var ids []string
+		for _, t := range templates {
+			ids = append(ids, t.(map[string]interface{})["id"].(string))
+		}
+		d.SetId(strconv.Itoa(schema.HashString(region + strings.Join(ids, ","))))
+	}
+
+	if err := d.Set("templates", templates); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// filterTemplates flattens the deployment templates, only returning those
+// which match all of the capability filters.
+func filterTemplates(res []*models.DeploymentTemplateInfoV2, filters map[string]bool) []interface{} {
+	var result = make([]interface{}, 0, len(res))
+	for _, tpl := range res {
+		m := flattenTemplate(tpl)
+
+		var matches = true
+		for capability, want := range filters {
+			if m[capability] != want {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			result = append(result, m)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymenttemplatesdatasource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_filterTemplates(t *testing.T) {
+	templates := []*models.DeploymentTemplateInfoV2{
+		newSampleTemplate("minimal", false),
+		newSampleTemplate("full", true),
+	}
+
+	templateIDs := func(res []interface{}) []string {
+		var ids []string
+		for _, r := range res {
+			ids = append(ids, r.(map[string]interface{})["id"].(string))
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name    string
+		filters map[string]bool
+		want    []string
+	}{
+		{
+			name: "returns all the templates without filters",
+			want: []string{"minimal", "full"},
+		},
+		{
+			name:    "returns the templates which support ML",
+			filters: map[string]bool{"supports_ml": true},
+			want:    []string{"full"},
+		},
+		{
+			name:    "returns the templates which don't enable autoscaling",
+			filters: map[string]bool{"autoscale": false},
+			want:    []string{"minimal"},
+		},
+		{
+			name: "returns no templates when none match all the filters",
+			filters: map[string]bool{
+				"supports_apm":               true,
+				"supports_enterprise_search": false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, templateIDs(filterTemplates(templates, tt.filters)))
+		})
+	}
+}
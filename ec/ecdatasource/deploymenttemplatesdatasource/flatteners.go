@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymenttemplatesdatasource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+)
+
+const mlTopologyID = "ml"
+
+// flattenTemplate flattens a deployment template and its capabilities.
+func flattenTemplate(tpl *models.DeploymentTemplateInfoV2) map[string]interface{} {
+	var m = map[string]interface{}{
+		"description":       tpl.Description,
+		"min_stack_version": tpl.MinVersion,
+	}
+
+	if tpl.ID != nil {
+		m["id"] = *tpl.ID
+	}
+
+	if tpl.Name != nil {
+		m["name"] = *tpl.Name
+	}
+
+	for k, v := range flattenCapabilities(tpl.DeploymentTemplate) {
+		m[k] = v
+	}
+
+	m["topology"] = flattenTopology(tpl.DeploymentTemplate)
+	m["instance_configurations"] = flattenInstanceConfigurations(tpl.InstanceConfigurations)
+
+	return m
+}
+
+// flattenCapabilities returns the capabilities of a deployment template
+// based on the resources which it defines.
+func flattenCapabilities(tpl *models.DeploymentCreateRequest) map[string]interface{} {
+	var m = map[string]interface{}{
+		"supports_apm":                 false,
+		"supports_integrations_server": false,
+		"supports_enterprise_search":   false,
+		"supports_ml":                  false,
+		"autoscale":                    false,
+	}
+
+	if tpl == nil || tpl.Resources == nil {
+		return m
+	}
+
+	m["supports_apm"] = len(tpl.Resources.Apm) > 0
+	m["supports_integrations_server"] = len(tpl.Resources.IntegrationsServer) > 0
+	m["supports_enterprise_search"] = len(tpl.Resources.EnterpriseSearch) > 0
+
+	for _, es := range tpl.Resources.Elasticsearch {
+		if es.Plan == nil {
+			continue
+		}
+
+		if es.Plan.AutoscalingEnabled != nil && *es.Plan.AutoscalingEnabled {
+			m["autoscale"] = true
+		}
+
+		for _, topology := range es.Plan.ClusterTopology {
+			isML := topology.ID == mlTopologyID ||
+				slice.HasString(topology.NodeRoles, mlTopologyID) ||
+				(topology.NodeType != nil && topology.NodeType.Ml != nil && *topology.NodeType.Ml)
+			if isML {
+				m["supports_ml"] = true
+			}
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,154 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymenttemplatesdatasource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flattenTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		tpl  *models.DeploymentTemplateInfoV2
+		want map[string]interface{}
+	}{
+		{
+			name: "flattens a template without resources",
+			tpl: &models.DeploymentTemplateInfoV2{
+				ID:   ec.String("empty"),
+				Name: ec.String("Empty"),
+			},
+			want: map[string]interface{}{
+				"id":                           "empty",
+				"name":                         "Empty",
+				"description":                  "",
+				"min_stack_version":            "",
+				"supports_apm":                 false,
+				"supports_integrations_server": false,
+				"supports_enterprise_search":   false,
+				"supports_ml":                  false,
+				"autoscale":                    false,
+				"topology":                     []interface{}{},
+				"instance_configurations":      []interface{}{},
+			},
+		},
+		{
+			name: "flattens a template with all its capabilities",
+			tpl:  newSampleTemplate("aws-io-optimized-v2", true),
+			want: map[string]interface{}{
+				"id":                           "aws-io-optimized-v2",
+				"name":                         "I/O Optimized",
+				"description":                  "Some description",
+				"min_stack_version":            "6.0.0",
+				"supports_apm":                 true,
+				"supports_integrations_server": true,
+				"supports_enterprise_search":   true,
+				"supports_ml":                  true,
+				"autoscale":                    true,
+				"topology": []interface{}{
+					map[string]interface{}{
+						"resource_kind":             "elasticsearch",
+						"id":                        "hot_content",
+						"instance_configuration_id": "aws.data.highio.i3",
+						"size":                      "8g",
+						"size_resource":             "memory",
+						"zone_count":                2,
+					},
+					map[string]interface{}{
+						"resource_kind":             "elasticsearch",
+						"id":                        "ml",
+						"instance_configuration_id": "",
+						"zone_count":                0,
+					},
+				},
+				"instance_configurations": []interface{}{
+					map[string]interface{}{
+						"id":            "aws.data.highio.i3",
+						"name":          "aws.data.highio.i3",
+						"description":   "I/O optimized instances",
+						"instance_type": "elasticsearch",
+						"default_size":  "8g",
+						"size_resource": "memory",
+						"sizes":         []interface{}{"1g", "2g", "4g", "8g"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, flattenTemplate(tt.tpl))
+		})
+	}
+}
+
+func newSampleTemplate(id string, full bool) *models.DeploymentTemplateInfoV2 {
+	var tpl = models.DeploymentTemplateInfoV2{
+		ID:          ec.String(id),
+		Name:        ec.String("I/O Optimized"),
+		Description: "Some description",
+		MinVersion:  "6.0.0",
+		DeploymentTemplate: &models.DeploymentCreateRequest{
+			Resources: &models.DeploymentCreateResources{
+				Elasticsearch: []*models.ElasticsearchPayload{{
+					Plan: &models.ElasticsearchClusterPlan{
+						AutoscalingEnabled: ec.Bool(false),
+						ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+							{ID: "hot_content", NodeRoles: []string{"data_hot", "master"}},
+						},
+					},
+				}},
+			},
+		},
+	}
+
+	if full {
+		resources := tpl.DeploymentTemplate.Resources
+		resources.Elasticsearch[0].Plan.AutoscalingEnabled = ec.Bool(true)
+		resources.Elasticsearch[0].Plan.ClusterTopology = append(
+			resources.Elasticsearch[0].Plan.ClusterTopology,
+			&models.ElasticsearchClusterTopologyElement{ID: "ml", NodeRoles: []string{"ml", "remote_cluster_client"}},
+		)
+		resources.Elasticsearch[0].Plan.ClusterTopology[0].InstanceConfigurationID = "aws.data.highio.i3"
+		resources.Elasticsearch[0].Plan.ClusterTopology[0].ZoneCount = 2
+		resources.Elasticsearch[0].Plan.ClusterTopology[0].Size = &models.TopologySize{
+			Resource: ec.String("memory"),
+			Value:    ec.Int32(8192),
+		}
+		tpl.InstanceConfigurations = []*models.InstanceConfigurationInfo{{
+			ID:           "aws.data.highio.i3",
+			Name:         ec.String("aws.data.highio.i3"),
+			Description:  "I/O optimized instances",
+			InstanceType: ec.String("elasticsearch"),
+			DiscreteSizes: &models.DiscreteSizes{
+				DefaultSize: ec.Int32(8192),
+				Resource:    ec.String("memory"),
+				Sizes:       []int32{1024, 2048, 4096, 8192},
+			},
+		}}
+		resources.Apm = []*models.ApmPayload{{}}
+		resources.IntegrationsServer = []*models.IntegrationsServerPayload{{}}
+		resources.EnterpriseSearch = []*models.EnterpriseSearchPayload{{}}
+	}
+
+	return &tpl
+}
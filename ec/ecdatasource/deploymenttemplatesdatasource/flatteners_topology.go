@@ -0,0 +1,152 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymenttemplatesdatasource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+
+	ecutil "github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// flattenTopology flattens the default topology elements of all the resource
+// kinds defined by a deployment template.
+func flattenTopology(tpl *models.DeploymentCreateRequest) []interface{} {
+	var result = make([]interface{}, 0)
+	if tpl == nil || tpl.Resources == nil {
+		return result
+	}
+
+	for _, es := range tpl.Resources.Elasticsearch {
+		if es.Plan == nil {
+			continue
+		}
+		for _, t := range es.Plan.ClusterTopology {
+			m := flattenTopologyElement(util.Elasticsearch, t.InstanceConfigurationID, t.Size, t.ZoneCount)
+			m["id"] = t.ID
+			result = append(result, m)
+		}
+	}
+
+	for _, kb := range tpl.Resources.Kibana {
+		if kb.Plan == nil {
+			continue
+		}
+		for _, t := range kb.Plan.ClusterTopology {
+			result = append(result, flattenTopologyElement(
+				util.Kibana, t.InstanceConfigurationID, t.Size, t.ZoneCount,
+			))
+		}
+	}
+
+	for _, apm := range tpl.Resources.Apm {
+		if apm.Plan == nil {
+			continue
+		}
+		for _, t := range apm.Plan.ClusterTopology {
+			result = append(result, flattenTopologyElement(
+				util.Apm, t.InstanceConfigurationID, t.Size, t.ZoneCount,
+			))
+		}
+	}
+
+	for _, is := range tpl.Resources.IntegrationsServer {
+		if is.Plan == nil {
+			continue
+		}
+		for _, t := range is.Plan.ClusterTopology {
+			result = append(result, flattenTopologyElement(
+				util.IntegrationsServer, t.InstanceConfigurationID, t.Size, t.ZoneCount,
+			))
+		}
+	}
+
+	for _, ess := range tpl.Resources.EnterpriseSearch {
+		if ess.Plan == nil {
+			continue
+		}
+		for _, t := range ess.Plan.ClusterTopology {
+			result = append(result, flattenTopologyElement(
+				util.EnterpriseSearch, t.InstanceConfigurationID, t.Size, t.ZoneCount,
+			))
+		}
+	}
+
+	return result
+}
+
+func flattenTopologyElement(kind, icID string, size *models.TopologySize, zones int32) map[string]interface{} {
+	var m = map[string]interface{}{
+		"resource_kind":             kind,
+		"instance_configuration_id": icID,
+		"zone_count":                int(zones),
+	}
+
+	if size != nil && size.Value != nil {
+		m["size"] = ecutil.MemoryToState(*size.Value)
+	}
+
+	if size != nil && size.Resource != nil {
+		m["size_resource"] = *size.Resource
+	}
+
+	return m
+}
+
+// flattenInstanceConfigurations flattens the instance configurations used by
+// a deployment template along with the sizes they support.
+func flattenInstanceConfigurations(ics []*models.InstanceConfigurationInfo) []interface{} {
+	var result = make([]interface{}, 0, len(ics))
+	for _, ic := range ics {
+		if ic == nil {
+			continue
+		}
+
+		var m = map[string]interface{}{
+			"id":          ic.ID,
+			"description": ic.Description,
+		}
+
+		if ic.Name != nil {
+			m["name"] = *ic.Name
+		}
+
+		if ic.InstanceType != nil {
+			m["instance_type"] = *ic.InstanceType
+		}
+
+		if ds := ic.DiscreteSizes; ds != nil {
+			if ds.DefaultSize != nil {
+				m["default_size"] = ecutil.MemoryToState(*ds.DefaultSize)
+			}
+			if ds.Resource != nil {
+				m["size_resource"] = *ds.Resource
+			}
+
+			sizes := make([]interface{}, 0, len(ds.Sizes))
+			for _, s := range ds.Sizes {
+				sizes = append(sizes, ecutil.MemoryToState(s))
+			}
+			m["sizes"] = sizes
+		}
+
+		result = append(result, m)
+	}
+
+	return result
+}
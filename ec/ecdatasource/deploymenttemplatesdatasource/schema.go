@@ -0,0 +1,179 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymenttemplatesdatasource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// capabilities holds the capability attribute names which can be used to
+// filter the deployment templates.
+var capabilities = []string{
+	"supports_apm",
+	"supports_integrations_server",
+	"supports_enterprise_search",
+	"supports_ml",
+	"autoscale",
+}
+
+func newSchema() map[string]*schema.Schema {
+	var s = map[string]*schema.Schema{
+		"region": {
+			Type:        schema.TypeString,
+			Description: "Required region where the deployment templates are available",
+			Required:    true,
+		},
+		"stack_version": {
+			Type:        schema.TypeString,
+			Description: "Optional stack version used to filter the deployment templates which are compatible with it",
+			Optional:    true,
+		},
+		"show_hidden": {
+			Type:        schema.TypeBool,
+			Description: "Optionally include the hidden deployment templates",
+			Optional:    true,
+		},
+
+		// Computed
+		"templates": newTemplatesSchema(),
+	}
+
+	for _, c := range capabilities {
+		s[c] = newCapabilityFilterSchema(c)
+	}
+
+	return s
+}
+
+func newCapabilityFilterSchema(capability string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  `Optional filter on the "` + capability + `" template capability. Accepted values are "true" or "false"`,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice([]string{"true", "false"}, false),
+	}
+}
+
+func newTemplatesSchema() *schema.Schema {
+	var elem = map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"min_stack_version": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+
+	for _, c := range capabilities {
+		elem[c] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Computed: true,
+		}
+	}
+
+	elem["topology"] = newTopologySchema()
+	elem["instance_configurations"] = newInstanceConfigurationsSchema()
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Resource{Schema: elem},
+	}
+}
+
+func newTopologySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"resource_kind": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"instance_configuration_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size_resource": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"zone_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		}},
+	}
+}
+
+func newInstanceConfigurationsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"instance_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"default_size": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size_resource": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"sizes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		}},
+	}
+}
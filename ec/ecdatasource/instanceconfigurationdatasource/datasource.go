@@ -0,0 +1,132 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package instanceconfigurationdatasource
+
+import (
+	"context"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/instanceconfigapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// DataSource returns the ec_instance_configuration data source schema.
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: read,
+
+		Schema: newSchema(),
+
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+	id := d.Get("id").(string)
+
+	res, err := instanceconfigapi.Get(instanceconfigapi.GetParams{
+		API:    client,
+		ID:     id,
+		Region: d.Get("region").(string),
+	})
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed retrieving instance configuration", err),
+		)
+	}
+
+	d.SetId(id)
+
+	if err := modelToState(d, res); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func modelToState(d *schema.ResourceData, res *models.InstanceConfiguration) error {
+	if res.Name != nil {
+		if err := d.Set("name", *res.Name); err != nil {
+			return err
+		}
+	}
+
+	if err := d.Set("description", res.Description); err != nil {
+		return err
+	}
+
+	if res.InstanceType != nil {
+		if err := d.Set("instance_type", *res.InstanceType); err != nil {
+			return err
+		}
+	}
+
+	if err := d.Set("node_types", res.NodeTypes); err != nil {
+		return err
+	}
+
+	if err := d.Set("storage_multiplier", res.StorageMultiplier); err != nil {
+		return err
+	}
+
+	if err := d.Set("cpu_multiplier", res.CPUMultiplier); err != nil {
+		return err
+	}
+
+	if err := d.Set("max_zones", int(res.MaxZones)); err != nil {
+		return err
+	}
+
+	if ds := res.DiscreteSizes; ds != nil {
+		if ds.DefaultSize != nil {
+			if err := d.Set("default_size", util.MemoryToState(*ds.DefaultSize)); err != nil {
+				return err
+			}
+		}
+
+		if ds.Resource != nil {
+			if err := d.Set("size_resource", *ds.Resource); err != nil {
+				return err
+			}
+		}
+
+		if err := d.Set("sizes", flattenSizes(ds.Sizes)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// flattenSizes flattens the discrete sizes to the same notation used by the
+// "size" topology attribute of the ec_deployment resource.
+func flattenSizes(sizes []int32) []interface{} {
+	var result = make([]interface{}, 0, len(sizes))
+	for _, s := range sizes {
+		result = append(result, util.MemoryToState(s))
+	}
+	return result
+}
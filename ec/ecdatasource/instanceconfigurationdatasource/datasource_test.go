@@ -0,0 +1,106 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package instanceconfigurationdatasource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_modelToState(t *testing.T) {
+	instanceConfigurationArg := schema.TestResourceDataRaw(t, newSchema(), nil)
+	instanceConfigurationArg.SetId("aws.data.highio.i3")
+	_ = instanceConfigurationArg.Set("id", "aws.data.highio.i3")
+	_ = instanceConfigurationArg.Set("region", "us-east-1")
+
+	wantInstanceConfiguration := util.NewResourceData(t, util.ResDataParams{
+		ID:     "aws.data.highio.i3",
+		State:  newSampleInstanceConfiguration(),
+		Schema: newSchema(),
+	})
+
+	type args struct {
+		d   *schema.ResourceData
+		res *models.InstanceConfiguration
+	}
+	tests := []struct {
+		name string
+		args args
+		want *schema.ResourceData
+		err  error
+	}{
+		{
+			name: "flattens the instance configuration",
+			want: wantInstanceConfiguration,
+			args: args{
+				d: instanceConfigurationArg,
+				res: &models.InstanceConfiguration{
+					ID:                "aws.data.highio.i3",
+					Name:              ec.String("aws.data.highio.i3"),
+					Description:       "Instance configuration to be used for a higher I/O Elasticsearch data node",
+					InstanceType:      ec.String("elasticsearch"),
+					NodeTypes:         []string{"data", "ingest", "master"},
+					StorageMultiplier: 30,
+					CPUMultiplier:     0.25,
+					MaxZones:          3,
+					DiscreteSizes: &models.DiscreteSizes{
+						DefaultSize: ec.Int32(8192),
+						Resource:    ec.String("memory"),
+						Sizes:       []int32{1024, 2048, 4096, 8192},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := modelToState(tt.args.d, tt.args.res)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want.State().Attributes, tt.args.d.State().Attributes)
+		})
+	}
+}
+
+func newSampleInstanceConfiguration() map[string]interface{} {
+	return map[string]interface{}{
+		"id":     "aws.data.highio.i3",
+		"region": "us-east-1",
+
+		"name":               "aws.data.highio.i3",
+		"description":        "Instance configuration to be used for a higher I/O Elasticsearch data node",
+		"instance_type":      "elasticsearch",
+		"node_types":         []interface{}{"data", "ingest", "master"},
+		"storage_multiplier": 30.0,
+		"cpu_multiplier":     0.25,
+		"max_zones":          3,
+		"default_size":       "8g",
+		"size_resource":      "memory",
+		"sizes":              []interface{}{"1g", "2g", "4g", "8g"},
+	}
+}
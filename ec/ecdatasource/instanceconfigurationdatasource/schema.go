@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package instanceconfigurationdatasource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Description: "Required ID of the instance configuration",
+			Required:    true,
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Description: "Required region where the instance configuration is available",
+			Required:    true,
+		},
+
+		// Computed
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"instance_type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"node_types": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"storage_multiplier": {
+			Type:     schema.TypeFloat,
+			Computed: true,
+		},
+		"cpu_multiplier": {
+			Type:     schema.TypeFloat,
+			Computed: true,
+		},
+		"max_zones": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"default_size": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"size_resource": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"sizes": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
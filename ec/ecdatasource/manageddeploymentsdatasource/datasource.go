@@ -0,0 +1,137 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package manageddeploymentsdatasource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// searchPageSize is the number of deployments obtained on each search.
+const searchPageSize = 100
+
+// DataSource returns the ec_managed_deployments data source schema.
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: read,
+
+		Schema: newSchema(),
+
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+
+	deployments, err := searchAllDeployments(client)
+	if err != nil {
+		return diag.FromErr(multierror.NewPrefixed("failed searching deployments", err))
+	}
+
+	tagKey, tagValue := d.Get("tag_key").(string), d.Get("tag_value").(string)
+	managed, unmanaged := flattenDeployments(
+		deployments, tagKey, tagValue, d.Get("workspace_tag_key").(string),
+	)
+
+	d.SetId(fmt.Sprintf("%s=%s", tagKey, tagValue))
+	if err := d.Set("managed", managed); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("unmanaged", unmanaged); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// searchAllDeployments returns all the deployments of the organization,
+// sorted by ID, searching them in pages of searchPageSize.
+func searchAllDeployments(client *api.API) ([]*models.DeploymentSearchResponse, error) {
+	var result []*models.DeploymentSearchResponse
+	for {
+		res, err := deploymentapi.Search(deploymentapi.SearchParams{
+			API: client,
+			Request: &models.SearchRequest{
+				From: int32(len(result)),
+				Size: searchPageSize,
+				Sort: []interface{}{"id"},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, res.Deployments...)
+		if len(res.Deployments) < searchPageSize {
+			return result, nil
+		}
+	}
+}
+
+// flattenDeployments splits the deployments by whether they have the tagKey
+// tag set to tagValue, setting the workspace of the managed ones to the value
+// of their workspaceTagKey tag.
+func flattenDeployments(deployments []*models.DeploymentSearchResponse, tagKey, tagValue, workspaceTagKey string) (managed, unmanaged []interface{}) {
+	managed, unmanaged = make([]interface{}, 0), make([]interface{}, 0)
+	for _, dep := range deployments {
+		if dep == nil || dep.ID == nil {
+			continue
+		}
+
+		var m = map[string]interface{}{"deployment_id": *dep.ID}
+		if dep.Name != nil {
+			m["name"] = *dep.Name
+		}
+
+		tags := deploymentTags(dep)
+		if tags[tagKey] != tagValue {
+			unmanaged = append(unmanaged, m)
+			continue
+		}
+
+		m["workspace"] = tags[workspaceTagKey]
+		managed = append(managed, m)
+	}
+	return managed, unmanaged
+}
+
+func deploymentTags(dep *models.DeploymentSearchResponse) map[string]string {
+	var result = make(map[string]string)
+	if dep.Metadata == nil {
+		return result
+	}
+	for _, tag := range dep.Metadata.Tags {
+		if tag != nil && tag.Key != nil && tag.Value != nil {
+			result[*tag.Key] = *tag.Value
+		}
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package manageddeploymentsdatasource
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func newDeployment(id, name string, tags map[string]string) *models.DeploymentSearchResponse {
+	var items []*models.MetadataItem
+	for k, v := range tags {
+		items = append(items, &models.MetadataItem{Key: ec.String(k), Value: ec.String(v)})
+	}
+	return &models.DeploymentSearchResponse{
+		ID:       ec.String(id),
+		Name:     ec.String(name),
+		Metadata: &models.DeploymentMetadata{Tags: items},
+	}
+}
+
+func Test_read(t *testing.T) {
+	deployments := []*models.DeploymentSearchResponse{
+		newDeployment("123", "prod", map[string]string{
+			"managed_by": "terraform", "terraform_workspace": "production",
+		}),
+		newDeployment("456", "staging", map[string]string{"managed_by": "terraform"}),
+		newDeployment("789", "shadow", map[string]string{"team": "search"}),
+		newDeployment("abc", "custom", map[string]string{"owner": "platform"}),
+	}
+
+	tests := []struct {
+		name          string
+		raw           map[string]interface{}
+		client        *api.API
+		wantDiags     diag.Diagnostics
+		wantManaged   []interface{}
+		wantUnmanaged []interface{}
+	}{
+		{
+			name: "splits the deployments by their management tag",
+			client: api.NewMock(mock.New200StructResponse(models.DeploymentsSearchResponse{
+				Deployments: deployments,
+				ReturnCount: ec.Int32(4),
+			})),
+			wantManaged: []interface{}{
+				map[string]interface{}{"deployment_id": "123", "name": "prod", "workspace": "production"},
+				map[string]interface{}{"deployment_id": "456", "name": "staging", "workspace": ""},
+			},
+			wantUnmanaged: []interface{}{
+				map[string]interface{}{"deployment_id": "789", "name": "shadow"},
+				map[string]interface{}{"deployment_id": "abc", "name": "custom"},
+			},
+		},
+		{
+			name: "uses the configured management tag",
+			raw: map[string]interface{}{
+				"tag_key": "owner", "tag_value": "platform", "workspace_tag_key": "team",
+			},
+			client: api.NewMock(mock.New200StructResponse(models.DeploymentsSearchResponse{
+				Deployments: deployments,
+				ReturnCount: ec.Int32(4),
+			})),
+			wantManaged: []interface{}{
+				map[string]interface{}{"deployment_id": "abc", "name": "custom", "workspace": ""},
+			},
+			wantUnmanaged: []interface{}{
+				map[string]interface{}{"deployment_id": "123", "name": "prod"},
+				map[string]interface{}{"deployment_id": "456", "name": "staging"},
+				map[string]interface{}{"deployment_id": "789", "name": "shadow"},
+			},
+		},
+		{
+			name: "returns an error when the search fails",
+			client: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				Code: "some", Message: "message",
+			})),
+			wantDiags: diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "failed searching deployments: 1 error occurred:\n\t* api error: some: message\n\n",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, newSchema(), tt.raw)
+			diags := read(context.Background(), d, &util.ProviderMeta{API: tt.client})
+			assert.Equal(t, tt.wantDiags, diags)
+			if tt.wantDiags != nil {
+				return
+			}
+
+			assert.Equal(t, tt.wantManaged, d.Get("managed"))
+			assert.Equal(t, tt.wantUnmanaged, d.Get("unmanaged"))
+		})
+	}
+}
+
+func Test_searchAllDeployments(t *testing.T) {
+	var page []*models.DeploymentSearchResponse
+	for i := 0; i < searchPageSize; i++ {
+		page = append(page, newDeployment(strconv.Itoa(i), "name", nil))
+	}
+
+	client := api.NewMock(
+		mock.New200StructResponse(models.DeploymentsSearchResponse{
+			Deployments: page, ReturnCount: ec.Int32(searchPageSize),
+		}),
+		mock.New200StructResponse(models.DeploymentsSearchResponse{
+			Deployments: page[:1], ReturnCount: ec.Int32(1),
+		}),
+	)
+
+	got, err := searchAllDeployments(client)
+	assert.NoError(t, err)
+	assert.Len(t, got, searchPageSize+1)
+}
@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package manageddeploymentsdatasource
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"tag_key": {
+			Type:        schema.TypeString,
+			Description: `Tag key which marks the deployments managed by Terraform, "managed_by" by default`,
+			Optional:    true,
+			Default:     "managed_by",
+		},
+		"tag_value": {
+			Type:        schema.TypeString,
+			Description: `Value of the tag_key tag which marks the deployments managed by Terraform, "terraform" by default`,
+			Optional:    true,
+			Default:     "terraform",
+		},
+		"workspace_tag_key": {
+			Type:        schema.TypeString,
+			Description: `Tag key which holds the Terraform workspace managing the deployment, "terraform_workspace" by default`,
+			Optional:    true,
+			Default:     "terraform_workspace",
+		},
+
+		// Computed
+		"managed": {
+			Type:        schema.TypeList,
+			Description: "Deployments tagged as managed by Terraform",
+			Computed:    true,
+			Elem:        newDeploymentElem(true),
+		},
+		"unmanaged": {
+			Type:        schema.TypeList,
+			Description: "Deployments which aren't tagged as managed by Terraform",
+			Computed:    true,
+			Elem:        newDeploymentElem(false),
+		},
+	}
+}
+
+func newDeploymentElem(managed bool) *schema.Resource {
+	var s = map[string]*schema.Schema{
+		"deployment_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+
+	if managed {
+		s["workspace"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Description: "Terraform workspace managing the deployment, empty when the deployment has no workspace tag",
+			Computed:    true,
+		}
+	}
+
+	return &schema.Resource{Schema: s}
+}
@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationapikeysdatasource
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/authentication"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// DataSource returns the ec_organization_api_keys data source schema.
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: read,
+
+		Schema: newSchema(),
+
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+
+	var olderThan time.Duration
+	if v := d.Get("older_than").(string); v != "" {
+		var err error
+		if olderThan, err = time.ParseDuration(v); err != nil {
+			return diag.FromErr(fmt.Errorf("failed parsing older_than: %w", err))
+		}
+	}
+
+	keys, err := listKeys(client, d.Get("all_users").(bool))
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed listing api keys", err),
+		)
+	}
+
+	flattened := filterKeys(keys, d.Get("user_id").(string), olderThan, time.Now())
+	if d.Id() == "" {
+		var ids []string
+		for _, k := range flattened {
+			ids = append(ids, k.(map[string]interface{})["id"].(string))
+		}
+		d.SetId(strconv.Itoa(schema.HashString(strings.Join(ids, ","))))
+	}
+
+	if err := d.Set("keys", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// listKeys lists the API keys of the current user, or the API keys of all
+// the users in the organization when allUsers is set.
+func listKeys(client *api.API, allUsers bool) ([]*models.APIKeyResponse, error) {
+	if allUsers {
+		res, err := client.V1API.Authentication.GetUsersAPIKeys(
+			authentication.NewGetUsersAPIKeysParams(),
+			client.AuthWriter,
+		)
+		if err != nil {
+			return nil, apierror.Wrap(err)
+		}
+		return res.Payload.Keys, nil
+	}
+
+	res, err := client.V1API.Authentication.GetAPIKeys(
+		authentication.NewGetAPIKeysParams(),
+		client.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+	return res.Payload.Keys, nil
+}
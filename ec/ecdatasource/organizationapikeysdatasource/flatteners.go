@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationapikeysdatasource
+
+import (
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+// filterKeys flattens the API keys, only returning those owned by userID
+// (when set) and created longer than olderThan ago (when set).
+func filterKeys(keys []*models.APIKeyResponse, userID string, olderThan time.Duration, now time.Time) []interface{} {
+	var result = make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		if key == nil || key.ID == nil {
+			continue
+		}
+
+		if userID != "" && key.UserID != userID {
+			continue
+		}
+
+		var created time.Time
+		if key.CreationDate != nil {
+			created = time.Time(*key.CreationDate)
+		}
+
+		if olderThan > 0 && (created.IsZero() || now.Sub(created) < olderThan) {
+			continue
+		}
+
+		result = append(result, flattenKey(key, created))
+	}
+
+	return result
+}
+
+func flattenKey(key *models.APIKeyResponse, created time.Time) map[string]interface{} {
+	var m = map[string]interface{}{
+		"id":      *key.ID,
+		"user_id": key.UserID,
+	}
+
+	if key.Description != nil {
+		m["description"] = *key.Description
+	}
+
+	if !created.IsZero() {
+		m["creation_date"] = created.UTC().Format(time.RFC3339)
+	}
+
+	return m
+}
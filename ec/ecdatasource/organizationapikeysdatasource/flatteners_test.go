@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationapikeysdatasource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_filterKeys(t *testing.T) {
+	now := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	date := func(t time.Time) *strfmt.DateTime {
+		dt := strfmt.DateTime(t)
+		return &dt
+	}
+	keys := []*models.APIKeyResponse{
+		{
+			ID:           ec.String("old-key"),
+			Description:  ec.String("terraform"),
+			UserID:       "some-user",
+			CreationDate: date(now.AddDate(0, -6, 0)),
+		},
+		{
+			ID:           ec.String("new-key"),
+			Description:  ec.String("ci"),
+			UserID:       "another-user",
+			CreationDate: date(now.AddDate(0, 0, -1)),
+		},
+	}
+	oldKey := map[string]interface{}{
+		"id":            "old-key",
+		"description":   "terraform",
+		"user_id":       "some-user",
+		"creation_date": "2022-04-01T00:00:00Z",
+	}
+	newKey := map[string]interface{}{
+		"id":            "new-key",
+		"description":   "ci",
+		"user_id":       "another-user",
+		"creation_date": "2022-09-30T00:00:00Z",
+	}
+
+	type args struct {
+		userID    string
+		olderThan time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want []interface{}
+	}{
+		{
+			name: "returns all the keys without filters",
+			want: []interface{}{oldKey, newKey},
+		},
+		{
+			name: "returns the keys owned by the user",
+			args: args{userID: "another-user"},
+			want: []interface{}{newKey},
+		},
+		{
+			name: "returns the keys older than 90 days",
+			args: args{olderThan: 90 * 24 * time.Hour},
+			want: []interface{}{oldKey},
+		},
+		{
+			name: "returns no keys when none match all the filters",
+			args: args{userID: "another-user", olderThan: 90 * 24 * time.Hour},
+			want: []interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterKeys(keys, tt.args.userID, tt.args.olderThan, now)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
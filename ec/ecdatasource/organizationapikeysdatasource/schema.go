@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationapikeysdatasource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"all_users": {
+			Type:        schema.TypeBool,
+			Description: "Optionally list the API keys of all the users in the organization, rather than only the keys of the current user. Requires organization owner permissions",
+			Optional:    true,
+		},
+		"user_id": {
+			Type:        schema.TypeString,
+			Description: "Optional filter on the ID of the user who owns the API keys",
+			Optional:    true,
+		},
+		"older_than": {
+			Type:        schema.TypeString,
+			Description: `Optional filter which only returns the API keys created longer than the duration ago, i.e. "2160h"`,
+			Optional:    true,
+		},
+
+		// Computed
+		"keys": newKeysSchema(),
+	}
+}
+
+func newKeysSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"description": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"user_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"creation_date": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
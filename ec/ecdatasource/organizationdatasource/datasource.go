@@ -0,0 +1,125 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationdatasource
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/billing_costs_analysis"
+	"github.com/elastic/cloud-sdk-go/pkg/client/organizations"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+var errNoOrganization = errors.New("the current user doesn't belong to any organization")
+
+// DataSource returns the ec_organization data source schema.
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: read,
+
+		Schema: newSchema(),
+
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+
+	org, err := getOrganization(client, d.Get("id").(string))
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed reading organization", err),
+		)
+	}
+
+	d.SetId(*org.ID)
+	if err := d.Set("name", *org.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The costs aren't available to every user, nor in every environment,
+	// so failing to obtain them doesn't fail the data source.
+	var diags diag.Diagnostics
+	costs, err := getCosts(client, *org.ID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "failed reading the organization billing information",
+			Detail:   err.Error(),
+		})
+	}
+
+	if err := d.Set("billing", flattenBilling(costs)); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
+
+// getOrganization returns the organization with the specified ID, or the
+// organization of the current user when no ID is specified.
+func getOrganization(client *api.API, id string) (*models.Organization, error) {
+	if id != "" {
+		res, err := client.V1API.Organizations.GetOrganization(
+			organizations.NewGetOrganizationParams().WithOrganizationID(id),
+			client.AuthWriter,
+		)
+		if err != nil {
+			return nil, apierror.Wrap(err)
+		}
+		return res.Payload, nil
+	}
+
+	res, err := client.V1API.Organizations.ListOrganizations(
+		organizations.NewListOrganizationsParams(),
+		client.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	if len(res.Payload.Organizations) == 0 {
+		return nil, errNoOrganization
+	}
+	return res.Payload.Organizations[0], nil
+}
+
+// getCosts returns the costs overview of the organization for the current
+// month.
+func getCosts(client *api.API, id string) (*models.CostsOverview, error) {
+	res, err := client.V1API.BillingCostsAnalysis.GetCostsOverview(
+		billing_costs_analysis.NewGetCostsOverviewParams().WithOrganizationID(id),
+		client.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+	return res.Payload, nil
+}
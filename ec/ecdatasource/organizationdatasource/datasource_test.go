@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationdatasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_read(t *testing.T) {
+	org := models.Organization{ID: ec.String("123456"), Name: ec.String("my-org")}
+	costs := models.CostsOverview{
+		HourlyRate: float64Ptr(1.5),
+		Trials:     float64Ptr(0),
+		Costs:      &models.Costs{Total: float64Ptr(250.25)},
+		Balance: &models.Balance{
+			Available: float64Ptr(1000),
+			Remaining: float64Ptr(749.75),
+		},
+	}
+	apiErr := mock.NewErrorResponse(403, mock.APIError{
+		Code: "some", Message: "message",
+	})
+
+	tests := []struct {
+		name        string
+		raw         map[string]interface{}
+		client      *api.API
+		wantDiags   diag.Diagnostics
+		wantID      string
+		wantBilling []interface{}
+	}{
+		{
+			name: "reads the organization of the current user",
+			client: util.NewRegionlessMock(
+				mock.New200StructResponse(models.OrganizationList{
+					Organizations: []*models.Organization{&org},
+				}),
+				mock.New200StructResponse(costs),
+			),
+			wantID: "123456",
+			wantBilling: []interface{}{map[string]interface{}{
+				"hourly_rate":        1.5,
+				"month_to_date_cost": 250.25,
+				"trials_cost":        float64(0),
+				"available_balance":  float64(1000),
+				"remaining_balance":  749.75,
+			}},
+		},
+		{
+			name: "reads the specified organization and warns when the billing isn't available",
+			raw:  map[string]interface{}{"id": "123456"},
+			client: util.NewRegionlessMock(
+				mock.New200StructResponse(org),
+				apiErr,
+			),
+			wantDiags: diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "failed reading the organization billing information",
+				Detail:   "api error: 1 error occurred:\n\t* some: message\n\n",
+			}},
+			wantID:      "123456",
+			wantBilling: []interface{}{},
+		},
+		{
+			name: "fails when the current user doesn't belong to any organization",
+			client: util.NewRegionlessMock(
+				mock.New200StructResponse(models.OrganizationList{}),
+			),
+			wantDiags: diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "failed reading organization: 1 error occurred:\n\t* the current user doesn't belong to any organization\n\n",
+			}},
+			wantBilling: []interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, newSchema(), tt.raw)
+
+			diags := read(context.Background(), d, &util.ProviderMeta{API: tt.client})
+			assert.Equal(t, tt.wantDiags, diags)
+			assert.Equal(t, tt.wantID, d.Id())
+			assert.Equal(t, tt.wantBilling, d.Get("billing"))
+			if tt.wantID != "" {
+				assert.Equal(t, "my-org", d.Get("name"))
+			}
+		})
+	}
+}
+
+func float64Ptr(v float64) *float64 { return &v }
@@ -0,0 +1,51 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationdatasource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+// flattenBilling returns the billing information of the costs overview, or
+// nil when it isn't available.
+func flattenBilling(costs *models.CostsOverview) []interface{} {
+	if costs == nil {
+		return nil
+	}
+
+	m := make(map[string]interface{})
+	if costs.HourlyRate != nil {
+		m["hourly_rate"] = *costs.HourlyRate
+	}
+	if costs.Costs != nil && costs.Costs.Total != nil {
+		m["month_to_date_cost"] = *costs.Costs.Total
+	}
+	if costs.Trials != nil {
+		m["trials_cost"] = *costs.Trials
+	}
+	if costs.Balance != nil {
+		if costs.Balance.Available != nil {
+			m["available_balance"] = *costs.Balance.Available
+		}
+		if costs.Balance.Remaining != nil {
+			m["remaining_balance"] = *costs.Balance.Remaining
+		}
+	}
+
+	return []interface{}{m}
+}
@@ -0,0 +1,78 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationdatasource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Description: "Optional ID of the organization. Defaults to the organization of the current user",
+			Optional:    true,
+			Computed:    true,
+		},
+
+		// Computed
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Name of the organization",
+			Computed:    true,
+		},
+		"billing": newBillingSchema(),
+	}
+}
+
+func newBillingSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Billing information of the organization for the current month, expressed in Elastic Consumption Units (ECU). Empty when it isn't available",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"hourly_rate": {
+					Type:        schema.TypeFloat,
+					Description: "Current hourly rate of the organization",
+					Computed:    true,
+				},
+				"month_to_date_cost": {
+					Type:        schema.TypeFloat,
+					Description: "Total costs of the organization since the start of the month",
+					Computed:    true,
+				},
+				"trials_cost": {
+					Type:        schema.TypeFloat,
+					Description: "Costs of the organization's trials since the start of the month",
+					Computed:    true,
+				},
+				"available_balance": {
+					Type:        schema.TypeFloat,
+					Description: "Balance available to the organization",
+					Computed:    true,
+				},
+				"remaining_balance": {
+					Type:        schema.TypeFloat,
+					Description: "Balance remaining to the organization",
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
@@ -45,6 +45,7 @@ func newAwsSchema() map[string]*schema.Schema {
 			Type:     schema.TypeString,
 			Required: true,
 		},
+		"manifest_url": newManifestURLSchema(),
 
 		// Computed
 		"vpc_service_name": {
@@ -0,0 +1,106 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package privatelinkdatasource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// AwsEndpointsDataSource returns the ec_aws_privatelink_endpoints data source
+// schema, which lists the privatelink configuration of all the AWS regions.
+func AwsEndpointsDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: readAwsEndpoints,
+
+		Schema: newAwsEndpointsSchema(),
+
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func newAwsEndpointsSchema() map[string]*schema.Schema {
+	endpoint := newAwsSchema()
+	delete(endpoint, "manifest_url")
+	endpoint["region"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	return map[string]*schema.Schema{
+		"manifest_url": newManifestURLSchema(),
+
+		// Computed
+		"endpoints": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Resource{Schema: endpoint},
+		},
+	}
+}
+
+func readAwsEndpoints(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	manifestURL, _ := rd.Get("manifest_url").(string)
+	regions, diags := loadRegionMap(ctx, meta, manifestURL)
+	if diags.HasError() {
+		return diags
+	}
+
+	if rd.Id() == "" {
+		rd.SetId("aws")
+	}
+
+	endpoints, err := flattenAwsEndpoints(regions["aws"])
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	return append(diags, diag.FromErr(rd.Set("endpoints", endpoints))...)
+}
+
+// flattenAwsEndpoints returns the privatelink configuration of each region,
+// sorted by region name.
+func flattenAwsEndpoints(regions regionToConfigMap) ([]interface{}, error) {
+	names := make([]string, 0, len(regions))
+	for name := range regions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	endpoints := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		endpoint := map[string]interface{}{"region": name}
+		for _, key := range []string{"vpc_service_name", "domain_name", "zone_ids"} {
+			value, ok := regions[name][key]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", errMissingKey, key)
+			}
+			endpoint[key] = value
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return endpoints, nil
+}
@@ -0,0 +1,64 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package privatelinkdatasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AwsEndpointsDataSource_ReadContext(t *testing.T) {
+	rd := schema.TestResourceDataRaw(t, newAwsEndpointsSchema(), nil)
+
+	diags := AwsEndpointsDataSource().ReadContext(context.Background(), rd, nil)
+	assert.Nil(t, diags)
+	assert.Equal(t, "aws", rd.Id())
+
+	embedded, err := embeddedRegionMap()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	endpoints := rd.Get("endpoints").([]interface{})
+	assert.Len(t, endpoints, len(embedded["aws"]))
+	assert.Contains(t, endpoints, map[string]interface{}{
+		"region":           "ap-northeast-1",
+		"vpc_service_name": "com.amazonaws.vpce.ap-northeast-1.vpce-svc-0e1046d7b48d5cf5f",
+		"domain_name":      "vpce.ap-northeast-1.aws.elastic-cloud.com",
+		"zone_ids":         []interface{}{"apne1-az1", "apne1-az2", "apne1-az4"},
+	})
+	assert.Equal(t, "af-south-1", endpoints[0].(map[string]interface{})["region"])
+}
+
+func Test_flattenAwsEndpoints(t *testing.T) {
+	endpoints, err := flattenAwsEndpoints(regionToConfigMap{
+		"us-east-1": {"vpc_service_name": "svc", "domain_name": "domain", "zone_ids": []interface{}{"use1-az1"}},
+		"eu-west-1": {"vpc_service_name": "other-svc", "domain_name": "other-domain", "zone_ids": []interface{}{}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"region": "eu-west-1", "vpc_service_name": "other-svc", "domain_name": "other-domain", "zone_ids": []interface{}{}},
+		map[string]interface{}{"region": "us-east-1", "vpc_service_name": "svc", "domain_name": "domain", "zone_ids": []interface{}{"use1-az1"}},
+	}, endpoints)
+
+	_, err = flattenAwsEndpoints(regionToConfigMap{"us-east-1": {"domain_name": "domain"}})
+	assert.ErrorIs(t, err, errMissingKey)
+}
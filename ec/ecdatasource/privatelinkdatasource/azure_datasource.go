@@ -45,6 +45,7 @@ func newAzureSchema() map[string]*schema.Schema {
 			Type:     schema.TypeString,
 			Required: true,
 		},
+		"manifest_url": newManifestURLSchema(),
 
 		// Computed
 		"service_alias": {
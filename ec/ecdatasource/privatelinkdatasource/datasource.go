@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -55,12 +56,18 @@ func readContextFor(p provider) func(context.Context, *schema.ResourceData, inte
 			rd.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%s:%s", p.name, regionName))))
 		}
 
-		regionData, err := getRegionData(p.name, regionName)
+		manifestURL, _ := rd.Get("manifest_url").(string)
+		regions, diags := loadRegionMap(ctx, i, manifestURL)
+		if diags.HasError() {
+			return diags
+		}
+
+		regionData, err := lookupRegionData(regions, p.name, regionName)
 		if err != nil {
-			return diag.FromErr(err)
+			return append(diags, diag.FromErr(err)...)
 		}
 
-		return diag.FromErr(p.populateResource(regionData, rd))
+		return append(diags, diag.FromErr(p.populateResource(regionData, rd))...)
 	}
 }
 
@@ -68,12 +75,51 @@ type configMap = map[string]interface{}
 type regionToConfigMap = map[string]configMap
 type providerToRegionMap = map[string]regionToConfigMap
 
+// embeddedRegions holds the region map embedded in the provider, which is
+// only parsed once. It's shared by all the lookups, so it's never modified.
+var embeddedRegions struct {
+	once    sync.Once
+	regions providerToRegionMap
+	err     error
+}
+
+func parsedRegionMap() (providerToRegionMap, error) {
+	embeddedRegions.once.Do(func() {
+		embeddedRegions.err = json.Unmarshal([]byte(privateLinkDataJson), &embeddedRegions.regions)
+	})
+	return embeddedRegions.regions, embeddedRegions.err
+}
+
+// getRegionData returns the privatelink configuration of a region embedded
+// in the provider.
 func getRegionData(providerName string, regionName string) (map[string]interface{}, error) {
-	var providerMap providerToRegionMap
-	if err := json.Unmarshal([]byte(privateLinkDataJson), &providerMap); err != nil {
+	providerMap, err := parsedRegionMap()
+	if err != nil {
 		return nil, err
 	}
 
+	return lookupRegionData(providerMap, providerName, regionName)
+}
+
+// embeddedRegionMap returns a copy of the embedded region map, whose regions
+// can be replaced. The configuration of each region is shared and read-only.
+func embeddedRegionMap() (providerToRegionMap, error) {
+	parsed, err := parsedRegionMap()
+	if err != nil {
+		return nil, err
+	}
+
+	providerMap := make(providerToRegionMap, len(parsed))
+	for providerName, regions := range parsed {
+		providerMap[providerName] = make(regionToConfigMap, len(regions))
+		for regionName, regionData := range regions {
+			providerMap[providerName][regionName] = regionData
+		}
+	}
+	return providerMap, nil
+}
+
+func lookupRegionData(providerMap providerToRegionMap, providerName string, regionName string) (map[string]interface{}, error) {
 	providerData, ok := providerMap[providerName]
 	if !ok {
 		return nil, fmt.Errorf("%w: %s", errUnknownProvider, providerName)
@@ -0,0 +1,44 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package privatelinkdatasource
+
+import (
+	"strings"
+)
+
+// DomainName returns the privatelink domain name of an Elastic Cloud region,
+// such as "aws-us-east-1" or "gcp-us-central1". Regions without a cloud
+// provider prefix are AWS regions. An empty string is returned when the
+// region doesn't have a privatelink endpoint.
+func DomainName(region string) string {
+	providerName, regionName := "aws", region
+	for _, p := range []string{"aws", "gcp", "azure"} {
+		if strings.HasPrefix(region, p+"-") {
+			providerName, regionName = p, strings.TrimPrefix(region, p+"-")
+			break
+		}
+	}
+
+	regionData, err := getRegionData(providerName, regionName)
+	if err != nil {
+		return ""
+	}
+
+	domainName, _ := regionData["domain_name"].(string)
+	return domainName
+}
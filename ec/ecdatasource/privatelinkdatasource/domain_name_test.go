@@ -0,0 +1,43 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package privatelinkdatasource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainName(t *testing.T) {
+	tests := []struct {
+		region string
+		want   string
+	}{
+		{region: "us-east-1", want: "vpce.us-east-1.aws.elastic-cloud.com"},
+		{region: "aws-us-east-1", want: "vpce.us-east-1.aws.elastic-cloud.com"},
+		{region: "gcp-asia-east1", want: "psc.asia-east1.gcp.elastic-cloud.com"},
+		{region: "azure-australiaeast", want: "privatelink.australiaeast.azure.elastic-cloud.com"},
+		{region: "ece-region"},
+		{region: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			assert.Equal(t, tt.want, DomainName(tt.region))
+		})
+	}
+}
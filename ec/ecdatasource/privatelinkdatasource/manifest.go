@@ -0,0 +1,154 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package privatelinkdatasource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+const (
+	manifestURLEnv = "EC_PRIVATELINK_MANIFEST_URL"
+
+	// manifestTimeout is the timeout of the remote manifest requests.
+	manifestTimeout = 30 * time.Second
+)
+
+var (
+	// manifestCache holds the remote manifests which have been fetched, so
+	// they're only fetched once per provider process. The failed fetches
+	// aren't cached, so they're retried by the following reads.
+	manifestCache   = make(map[string]*manifestEntry)
+	manifestCacheMu sync.Mutex
+)
+
+// manifestEntry is the cache entry of a remote manifest. Its lock is held
+// while the manifest is fetched, so the concurrent reads of the same manifest
+// wait for the fetch rather than fetching it again, while the reads of other
+// manifests aren't blocked.
+type manifestEntry struct {
+	lock    chan struct{}
+	regions providerToRegionMap
+}
+
+func newManifestURLSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Optional URL of a JSON manifest with the latest privatelink configuration of each region, which takes precedence over the configuration embedded in the provider. Can also be sourced from the `" + manifestURLEnv + "` environment variable.",
+		Optional:    true,
+		DefaultFunc: schema.EnvDefaultFunc(manifestURLEnv, ""),
+	}
+}
+
+// loadRegionMap returns the region map embedded in the provider, overlaid
+// with the regions of the remote manifest when a manifest URL is set. The
+// manifest is fetched with the provider proxy and TLS settings. When it can't
+// be fetched, the embedded region map is returned along with a warning.
+func loadRegionMap(ctx context.Context, meta interface{}, manifestURL string) (providerToRegionMap, diag.Diagnostics) {
+	regions, err := embeddedRegionMap()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	if manifestURL == "" {
+		return regions, nil
+	}
+
+	m, _ := meta.(*util.ProviderMeta)
+	remote, err := cachedManifest(ctx, m.HTTPClient(manifestTimeout), manifestURL)
+	if err != nil {
+		return regions, diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "failed fetching the privatelink manifest, using the embedded region data",
+			Detail:   err.Error(),
+		}}
+	}
+
+	for providerName, providerRegions := range remote {
+		if regions[providerName] == nil {
+			regions[providerName] = make(regionToConfigMap)
+		}
+		for regionName, regionData := range providerRegions {
+			regions[providerName][regionName] = regionData
+		}
+	}
+
+	return regions, nil
+}
+
+func cachedManifest(ctx context.Context, client *http.Client, manifestURL string) (providerToRegionMap, error) {
+	manifestCacheMu.Lock()
+	entry, ok := manifestCache[manifestURL]
+	if !ok {
+		entry = &manifestEntry{lock: make(chan struct{}, 1)}
+		manifestCache[manifestURL] = entry
+	}
+	manifestCacheMu.Unlock()
+
+	select {
+	case entry.lock <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-entry.lock }()
+
+	if entry.regions != nil {
+		return entry.regions, nil
+	}
+
+	regions, err := fetchManifest(ctx, client, manifestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.regions = regions
+	return regions, nil
+}
+
+func fetchManifest(ctx context.Context, client *http.Client, manifestURL string) (providerToRegionMap, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d fetching %s", res.StatusCode, manifestURL)
+	}
+
+	var regions providerToRegionMap
+	if err := json.NewDecoder(res.Body).Decode(&regions); err != nil {
+		return nil, fmt.Errorf("failed decoding %s: %w", manifestURL, err)
+	}
+
+	return regions, nil
+}
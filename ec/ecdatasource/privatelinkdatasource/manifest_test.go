@@ -0,0 +1,143 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package privatelinkdatasource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loadRegionMap(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"aws": {
+			"us-east-1": {"vpc_service_name": "some-service", "domain_name": "some.domain", "zone_ids": ["use1-az1"]},
+			"some-new-region": {"vpc_service_name": "new-service", "domain_name": "new.domain", "zone_ids": []}
+		}}`))
+	}))
+	defer srv.Close()
+
+	var failingCalls int32
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&failingCalls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	embedded, err := embeddedRegionMap()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("returns the embedded regions when no manifest is set", func(t *testing.T) {
+		regions, diags := loadRegionMap(context.Background(), nil, "")
+		assert.Nil(t, diags)
+		assert.Equal(t, embedded, regions)
+	})
+
+	t.Run("overlays the manifest regions and caches them", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			regions, diags := loadRegionMap(context.Background(), nil, srv.URL)
+			assert.Nil(t, diags)
+			assert.Equal(t, "some-service", regions["aws"]["us-east-1"]["vpc_service_name"])
+			assert.Equal(t, "new.domain", regions["aws"]["some-new-region"]["domain_name"])
+			assert.Equal(t, embedded["aws"]["eu-west-1"], regions["aws"]["eu-west-1"])
+			assert.Equal(t, embedded["gcp"], regions["gcp"])
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("falls back to the embedded regions with a warning", func(t *testing.T) {
+		regions, diags := loadRegionMap(context.Background(), nil, failing.URL)
+		assert.Equal(t, embedded, regions)
+		if assert.Len(t, diags, 1) {
+			assert.Equal(t, diag.Warning, diags[0].Severity)
+			assert.Contains(t, diags[0].Detail, "unexpected status code 500")
+		}
+	})
+
+	t.Run("doesn't cache the failed fetches", func(t *testing.T) {
+		before := atomic.LoadInt32(&failingCalls)
+		_, diags := loadRegionMap(context.Background(), nil, failing.URL)
+		assert.Len(t, diags, 1)
+		assert.Equal(t, before+1, atomic.LoadInt32(&failingCalls))
+	})
+
+	t.Run("doesn't modify the embedded regions", func(t *testing.T) {
+		_, diags := loadRegionMap(context.Background(), nil, srv.URL)
+		assert.Nil(t, diags)
+
+		regionData, err := getRegionData("aws", "us-east-1")
+		assert.NoError(t, err)
+		assert.Equal(t, embedded["aws"]["us-east-1"], regionData)
+
+		_, err = getRegionData("aws", "some-new-region")
+		assert.Error(t, err)
+	})
+}
+
+func Test_cachedManifest(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+		_, _ = w.Write([]byte(`{"aws": {"us-east-1": {"domain_name": "some.domain"}}}`))
+	}))
+	defer srv.Close()
+
+	t.Run("doesn't cache the canceled fetches", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := cachedManifest(ctx, srv.Client(), srv.URL)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("fetches the manifest once for the concurrent reads", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				regions, err := cachedManifest(context.Background(), srv.Client(), srv.URL)
+				assert.NoError(t, err)
+				assert.Equal(t, "some.domain", regions["aws"]["us-east-1"]["domain_name"])
+			}()
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
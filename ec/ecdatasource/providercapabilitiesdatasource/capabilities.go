@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package providercapabilitiesdatasource
+
+import (
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	essPlatform = "ess"
+	ecePlatform = "ece"
+)
+
+// eceAutoscalingVersion is the first Elastic Cloud Enterprise version which
+// supports deployment autoscaling.
+var eceAutoscalingVersion = semver.MustParse("2.12.0")
+
+// capabilities are the features supported by the configured endpoint.
+type capabilities struct {
+	platform    string
+	eceVersion  string
+	serverless  bool
+	autoscaling bool
+}
+
+// newCapabilities returns the capabilities from the platform information,
+// which is nil for the Elasticsearch Service. Serverless projects are only
+// offered by the Elasticsearch Service.
+func newCapabilities(info *models.PlatformInfo) (capabilities, error) {
+	if info == nil {
+		return capabilities{
+			platform:    essPlatform,
+			serverless:  true,
+			autoscaling: true,
+		}, nil
+	}
+
+	var version string
+	if info.Version != nil {
+		version = *info.Version
+	}
+
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return capabilities{}, fmt.Errorf(
+			`failed parsing the Elastic Cloud Enterprise version "%s": %w`, version, err,
+		)
+	}
+
+	return capabilities{
+		platform:    ecePlatform,
+		eceVersion:  version,
+		autoscaling: v.GE(eceAutoscalingVersion),
+	}, nil
+}
+
+func modelToState(d *schema.ResourceData, caps capabilities) error {
+	if err := d.Set("platform", caps.platform); err != nil {
+		return err
+	}
+
+	if err := d.Set("ece_version", caps.eceVersion); err != nil {
+		return err
+	}
+
+	if err := d.Set("serverless_available", caps.serverless); err != nil {
+		return err
+	}
+
+	return d.Set("autoscaling_available", caps.autoscaling)
+}
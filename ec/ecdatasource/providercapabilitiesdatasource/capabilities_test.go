@@ -0,0 +1,67 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package providercapabilitiesdatasource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newCapabilities(t *testing.T) {
+	tests := []struct {
+		name string
+		info *models.PlatformInfo
+		want capabilities
+		err  error
+	}{
+		{
+			name: "the elasticsearch service supports every capability",
+			want: capabilities{platform: "ess", serverless: true, autoscaling: true},
+		},
+		{
+			name: "ece supports autoscaling from 2.12",
+			info: &models.PlatformInfo{Version: ec.String("3.4.0")},
+			want: capabilities{platform: "ece", eceVersion: "3.4.0", autoscaling: true},
+		},
+		{
+			name: "older ece versions don't support autoscaling",
+			info: &models.PlatformInfo{Version: ec.String("2.11.1")},
+			want: capabilities{platform: "ece", eceVersion: "2.11.1"},
+		},
+		{
+			name: "fails with an invalid ece version",
+			info: &models.PlatformInfo{Version: ec.String("unknown")},
+			err:  errors.New(`failed parsing the Elastic Cloud Enterprise version "unknown": Invalid character(s) found in major number "0unknown"`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newCapabilities(tt.info)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package providercapabilitiesdatasource
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// eceRegion is the region of all the Elastic Cloud Enterprise installations.
+const eceRegion = "ece-region"
+
+// DataSource returns the ec_provider_capabilities data source schema.
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: read,
+
+		Schema: newSchema(),
+
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+
+	info, err := platformInfo(client)
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed obtaining the platform information", err),
+		)
+	}
+
+	caps, err := newCapabilities(info)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(caps.platform)
+
+	if err := modelToState(d, caps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// platformInfo returns the platform information of the configured endpoint.
+// The platform endpoints are only available on Elastic Cloud Enterprise, so
+// a nil result is returned when the endpoint is the Elasticsearch Service.
+func platformInfo(client *api.API) (*models.PlatformInfo, error) {
+	info, err := platformapi.GetInfo(platformapi.GetInfoParams{
+		API: client, Region: eceRegion,
+	})
+	if err != nil {
+		if apierror.IsRuntimeStatusCode(err, http.StatusForbidden) ||
+			apierror.IsRuntimeStatusCode(err, http.StatusNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return info, nil
+}
@@ -0,0 +1,47 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package providercapabilitiesdatasource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"platform": {
+			Type:        schema.TypeString,
+			Description: `Platform of the configured endpoint, either "ess" for the Elasticsearch Service or "ece" for Elastic Cloud Enterprise`,
+			Computed:    true,
+		},
+		"ece_version": {
+			Type:        schema.TypeString,
+			Description: "Version of the Elastic Cloud Enterprise installation, empty for the Elasticsearch Service",
+			Computed:    true,
+		},
+		"serverless_available": {
+			Type:        schema.TypeBool,
+			Description: "Whether serverless projects are available on the platform",
+			Computed:    true,
+		},
+		"autoscaling_available": {
+			Type:        schema.TypeBool,
+			Description: "Whether deployment autoscaling is available on the platform",
+			Computed:    true,
+		},
+	}
+}
@@ -0,0 +1,158 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package serverlessregionsdatasource
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// region is a serverless region, as returned by the serverless regions API.
+type region struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CSP       string `json:"csp"`
+	CSPRegion string `json:"csp_region"`
+
+	// ProjectTypes are the project types which can be created in the
+	// region. When it's not returned, all the project types can be created.
+	ProjectTypes []string `json:"project_types,omitempty"`
+}
+
+// DataSource returns the ec_serverless_regions data source schema.
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: read,
+
+		Schema: newSchema(),
+
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+
+	res, err := listRegions(ctx, client)
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed listing serverless regions", err),
+		)
+	}
+
+	providerName := d.Get("provider_name").(string)
+	projectType := d.Get("project_type").(string)
+	regions := filterRegions(res, providerName, projectType)
+	if d.Id() == "" {
+		var ids []string
+		for _, r := range regions {
+			ids = append(ids, r.(map[string]interface{})["id"].(string))
+		}
+		d.SetId(strconv.Itoa(schema.HashString(
+			providerName + projectType + strings.Join(ids, ","),
+		)))
+	}
+
+	if err := d.Set("regions", regions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// listRegions lists the serverless regions. The serverless API isn't part
+// of the generated API client, so the operation is submitted through the
+// client transport, which handles the authentication and retries, and submits
+// the global operations without a region.
+func listRegions(ctx context.Context, client *api.API) ([]region, error) {
+	var regions []region
+	_, err := client.V1API.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "list-serverless-regions",
+		Method:             http.MethodGet,
+		PathPattern:        "/serverless/regions",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params: runtime.ClientRequestWriterFunc(func(runtime.ClientRequest, strfmt.Registry) error {
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, _ runtime.Consumer) (interface{}, error) {
+			b, err := io.ReadAll(response.Body())
+			if err != nil {
+				return nil, err
+			}
+
+			if response.Code() >= http.StatusMultipleChoices {
+				return nil, runtime.NewAPIError("list-serverless-regions", string(b), response.Code())
+			}
+
+			return nil, json.Unmarshal(b, &regions)
+		}),
+		AuthInfo: client.AuthWriter,
+		Context:  ctx,
+	})
+
+	return regions, err
+}
+
+// filterRegions flattens the serverless regions, only returning those of the
+// cloud provider where the project type can be created, when set.
+func filterRegions(regions []region, providerName, projectType string) []interface{} {
+	var result = make([]interface{}, 0, len(regions))
+	for _, r := range regions {
+		if providerName != "" && r.CSP != providerName {
+			continue
+		}
+
+		types := r.ProjectTypes
+		if len(types) == 0 {
+			types = projectTypes
+		}
+
+		if projectType != "" && !slice.HasString(types, projectType) {
+			continue
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":              r.ID,
+			"name":            r.Name,
+			"provider_name":   r.CSP,
+			"provider_region": r.CSPRegion,
+			"project_types":   types,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package serverlessregionsdatasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_listRegions(t *testing.T) {
+	want := []region{
+		{ID: "aws-us-east-1", Name: "N. Virginia", CSP: "aws", CSPRegion: "us-east-1"},
+		{ID: "gcp-us-central1", Name: "Iowa", CSP: "gcp", CSPRegion: "us-central1"},
+	}
+
+	client := util.NewRegionlessMock(mock.New200Response(mock.NewStructBody(want)))
+	got, err := listRegions(context.Background(), client)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	client = util.NewRegionlessMock(mock.NewErrorResponse(404, mock.APIError{
+		Code: "root.resource_not_found", Message: "not found",
+	}))
+	_, err = listRegions(context.Background(), client)
+	assert.Error(t, err)
+}
+
+func Test_filterRegions(t *testing.T) {
+	regions := []region{
+		{ID: "aws-us-east-1", Name: "N. Virginia", CSP: "aws", CSPRegion: "us-east-1"},
+		{ID: "gcp-us-central1", Name: "Iowa", CSP: "gcp", CSPRegion: "us-central1"},
+		{
+			ID: "azure-eastus", Name: "Virginia", CSP: "azure", CSPRegion: "eastus",
+			ProjectTypes: []string{"elasticsearch"},
+		},
+	}
+
+	regionIDs := func(res []interface{}) []string {
+		var ids []string
+		for _, r := range res {
+			ids = append(ids, r.(map[string]interface{})["id"].(string))
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name         string
+		providerName string
+		projectType  string
+		want         []string
+	}{
+		{
+			name: "returns all the regions without filters",
+			want: []string{"aws-us-east-1", "gcp-us-central1", "azure-eastus"},
+		},
+		{
+			name:         "returns the regions of the cloud provider",
+			providerName: "gcp",
+			want:         []string{"gcp-us-central1"},
+		},
+		{
+			name:        "returns the regions where the project type can be created",
+			projectType: "security",
+			want:        []string{"aws-us-east-1", "gcp-us-central1"},
+		},
+		{
+			name:         "returns no regions when none match all the filters",
+			providerName: "azure",
+			projectType:  "observability",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, regionIDs(filterRegions(regions, tt.providerName, tt.projectType)))
+		})
+	}
+
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"id":              "aws-us-east-1",
+		"name":            "N. Virginia",
+		"provider_name":   "aws",
+		"provider_region": "us-east-1",
+		"project_types":   []string{"elasticsearch", "observability", "security"},
+	}}, filterRegions(regions, "aws", ""))
+}
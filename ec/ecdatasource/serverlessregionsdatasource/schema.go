@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package serverlessregionsdatasource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// projectTypes are the serverless project types.
+var projectTypes = []string{"elasticsearch", "observability", "security"}
+
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"provider_name": {
+			Type:         schema.TypeString,
+			Description:  `Optional cloud provider used to filter the regions, one of "aws", "gcp" or "azure"`,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"aws", "gcp", "azure"}, false),
+		},
+		"project_type": {
+			Type:         schema.TypeString,
+			Description:  `Optional serverless project type used to filter the regions, one of "elasticsearch", "observability" or "security"`,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(projectTypes, false),
+		},
+
+		// Computed
+		"regions": newRegionsSchema(),
+	}
+}
+
+func newRegionsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Regions where serverless projects can be created",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeString,
+					Description: "Region identifier, which is set in the serverless project region",
+					Computed:    true,
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Description: "Human readable name of the region",
+					Computed:    true,
+				},
+				"provider_name": {
+					Type:        schema.TypeString,
+					Description: "Cloud provider of the region",
+					Computed:    true,
+				},
+				"provider_region": {
+					Type:        schema.TypeString,
+					Description: "Region identifier of the cloud provider",
+					Computed:    true,
+				},
+				"project_types": {
+					Type:        schema.TypeList,
+					Description: "Serverless project types which can be created in the region",
+					Computed:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stackdatasource
+
+import (
+	"fmt"
+
+	semver "github.com/blang/semver/v4"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+const (
+	// gaChannel selects the latest generally available stack version.
+	gaChannel = "ga"
+	// latestChannel selects the latest stack version, including pre-releases.
+	latestChannel = "latest"
+	// previewChannel selects the latest pre-release stack version.
+	previewChannel = "preview"
+)
+
+var channels = []string{gaChannel, latestChannel, previewChannel}
+
+// stackFromChannel returns the latest stack version of the channel, only
+// considering the stack versions of the major version when it's set. The
+// stack versions are expected to be sorted from the newest to the oldest,
+// as returned by the API. When locked, the current version is kept.
+func stackFromChannel(channel string, major int, version string, locked bool, stacks []*models.StackVersionConfig) (*models.StackVersionConfig, error) {
+	if channel == "" {
+		channel = gaChannel
+	}
+
+	if locked && version != "" {
+		for _, stack := range stacks {
+			if stack.Version == version {
+				return stack, nil
+			}
+		}
+	}
+
+	for _, stack := range stacks {
+		v, err := semver.Parse(stack.Version)
+		if err != nil {
+			continue
+		}
+
+		if major > 0 && v.Major != uint64(major) {
+			continue
+		}
+
+		var preRelease = len(v.Pre) > 0
+		switch channel {
+		case gaChannel:
+			if preRelease {
+				continue
+			}
+		case previewChannel:
+			if !preRelease {
+				continue
+			}
+		}
+
+		return stack, nil
+	}
+
+	if major > 0 {
+		return nil, fmt.Errorf(`failed to obtain a stack version in the "%s" channel with major version %d`, channel, major)
+	}
+
+	return nil, fmt.Errorf(`failed to obtain a stack version in the "%s" channel`, channel)
+}
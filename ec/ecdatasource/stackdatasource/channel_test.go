@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stackdatasource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_stackFromChannel(t *testing.T) {
+	var stackPacks = []*models.StackVersionConfig{
+		{Version: "8.6.0-rc1"},
+		{Version: "8.5.3"},
+		{Version: "8.5.2"},
+		{Version: "7.17.8"},
+		{Version: "7.17.7"},
+	}
+	type args struct {
+		channel string
+		major   int
+		version string
+		locked  bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.StackVersionConfig
+		err  error
+	}{
+		{
+			name: "defaults to the latest GA version",
+			want: &models.StackVersionConfig{Version: "8.5.3"},
+		},
+		{
+			name: "returns the latest GA version",
+			args: args{channel: "ga"},
+			want: &models.StackVersionConfig{Version: "8.5.3"},
+		},
+		{
+			name: "returns the latest version including pre-releases",
+			args: args{channel: "latest"},
+			want: &models.StackVersionConfig{Version: "8.6.0-rc1"},
+		},
+		{
+			name: "returns the latest pre-release version",
+			args: args{channel: "preview"},
+			want: &models.StackVersionConfig{Version: "8.6.0-rc1"},
+		},
+		{
+			name: "returns the latest GA version of the major version",
+			args: args{channel: "ga", major: 7},
+			want: &models.StackVersionConfig{Version: "7.17.8"},
+		},
+		{
+			name: "keeps the locked version",
+			args: args{channel: "ga", major: 7, version: "7.17.7", locked: true},
+			want: &models.StackVersionConfig{Version: "7.17.7"},
+		},
+		{
+			name: "ignores the version when not locked",
+			args: args{channel: "ga", major: 7, version: "7.17.7"},
+			want: &models.StackVersionConfig{Version: "7.17.8"},
+		},
+		{
+			name: "returns an error when there's no pre-release of the major version",
+			args: args{channel: "preview", major: 7},
+			err:  errors.New(`failed to obtain a stack version in the "preview" channel with major version 7`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stackFromChannel(tt.args.channel, tt.args.major, tt.args.version, tt.args.locked, stackPacks)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
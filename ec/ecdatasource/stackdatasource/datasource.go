@@ -24,12 +24,13 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/stackapi"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
 )
 
 // DataSource returns the ec_deployment data source schema.
@@ -46,7 +47,7 @@ func DataSource() *schema.Resource {
 }
 
 func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api.API)
+	client := meta.(*util.ProviderMeta).API
 	region := d.Get("region").(string)
 
 	res, err := stackapi.List(stackapi.ListParams{
@@ -62,7 +63,15 @@ func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diag
 	versionExpr := d.Get("version_regex").(string)
 	version := d.Get("version").(string)
 	lock := d.Get("lock").(bool)
-	stack, err := stackFromFilters(versionExpr, version, lock, res.Stacks)
+
+	var stack *models.StackVersionConfig
+	if versionExpr != "" {
+		stack, err = stackFromFilters(versionExpr, version, lock, res.Stacks)
+	} else {
+		stack, err = stackFromChannel(
+			d.Get("channel").(string), d.Get("major").(int), version, lock, res.Stacks,
+		)
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
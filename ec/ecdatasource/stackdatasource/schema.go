@@ -19,13 +19,26 @@ package stackdatasource
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func newSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"version_regex": {
-			Type:     schema.TypeString,
-			Required: true,
+			Type:          schema.TypeString,
+			Optional:      true,
+			AtLeastOneOf:  []string{"version_regex", "channel", "major"},
+			ConflictsWith: []string{"channel", "major"},
+		},
+		"channel": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(channels, false),
+		},
+		"major": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
 		},
 		"region": {
 			Type:     schema.TypeString,
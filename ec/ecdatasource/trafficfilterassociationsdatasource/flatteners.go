@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfilterassociationsdatasource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const entityType = "deployment"
+
+func modelToState(d *schema.ResourceData, deploymentID string, res *models.TrafficFilterRulesets) error {
+	var ids = make([]interface{}, 0)
+	var rulesets = make([]interface{}, 0)
+	if res != nil {
+		for _, ruleset := range res.Rulesets {
+			if ruleset == nil || ruleset.ID == nil || !isAssociated(ruleset, deploymentID) {
+				continue
+			}
+
+			ids = append(ids, *ruleset.ID)
+			rulesets = append(rulesets, flattenRuleset(ruleset))
+		}
+	}
+
+	if err := d.Set("ruleset_ids", ids); err != nil {
+		return err
+	}
+
+	return d.Set("rulesets", rulesets)
+}
+
+// isAssociated returns true when the ruleset is associated with the
+// deployment.
+func isAssociated(ruleset *models.TrafficFilterRulesetInfo, deploymentID string) bool {
+	for _, assoc := range ruleset.Associations {
+		if assoc == nil || assoc.EntityType == nil || assoc.ID == nil {
+			continue
+		}
+		if *assoc.EntityType == entityType && *assoc.ID == deploymentID {
+			return true
+		}
+	}
+	return false
+}
+
+func flattenRuleset(ruleset *models.TrafficFilterRulesetInfo) map[string]interface{} {
+	var m = map[string]interface{}{
+		"id":          *ruleset.ID,
+		"description": ruleset.Description,
+	}
+
+	if ruleset.Name != nil {
+		m["name"] = *ruleset.Name
+	}
+
+	if ruleset.Type != nil {
+		m["type"] = *ruleset.Type
+	}
+
+	if ruleset.Region != nil {
+		m["region"] = *ruleset.Region
+	}
+
+	if ruleset.IncludeByDefault != nil {
+		m["include_by_default"] = *ruleset.IncludeByDefault
+	}
+
+	var sources = make([]interface{}, 0, len(ruleset.Rules))
+	for _, rule := range ruleset.Rules {
+		if rule != nil && rule.Source != "" {
+			sources = append(sources, rule.Source)
+		}
+	}
+	m["sources"] = sources
+
+	return m
+}
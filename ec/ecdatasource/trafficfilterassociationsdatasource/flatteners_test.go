@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfilterassociationsdatasource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_modelToState(t *testing.T) {
+	const deploymentID = "0a592ab2c5baf0fa95c77ac62135782e"
+
+	rd := schema.TestResourceDataRaw(t, newSchema(), nil)
+	rd.SetId(deploymentID)
+	_ = rd.Set("deployment_id", deploymentID)
+
+	res := &models.TrafficFilterRulesets{Rulesets: []*models.TrafficFilterRulesetInfo{
+		{
+			ID:               ec.String("associated"),
+			Name:             ec.String("office"),
+			Type:             ec.String("ip"),
+			Region:           ec.String("us-east-1"),
+			Description:      "Office IP addresses",
+			IncludeByDefault: ec.Bool(false),
+			Rules: []*models.TrafficFilterRule{
+				{Source: "1.1.1.1"},
+				{Source: "10.0.0.0/16"},
+			},
+			Associations: []*models.FilterAssociation{
+				{EntityType: ec.String("deployment"), ID: ec.String("some-other-deployment")},
+				{EntityType: ec.String("deployment"), ID: ec.String(deploymentID)},
+			},
+		},
+		{
+			ID:     ec.String("not-associated"),
+			Name:   ec.String("vpn"),
+			Type:   ec.String("ip"),
+			Region: ec.String("us-east-1"),
+			Associations: []*models.FilterAssociation{
+				{EntityType: ec.String("deployment"), ID: ec.String("some-other-deployment")},
+			},
+		},
+	}}
+
+	err := modelToState(rd, deploymentID, res)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []interface{}{"associated"}, rd.Get("ruleset_ids"))
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"id":                 "associated",
+		"name":               "office",
+		"type":               "ip",
+		"region":             "us-east-1",
+		"description":        "Office IP addresses",
+		"include_by_default": false,
+		"sources":            []interface{}{"1.1.1.1", "10.0.0.0/16"},
+	}}, rd.Get("rulesets"))
+}
@@ -0,0 +1,87 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfilterlinkidsdatasource
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// DataSource returns the ec_traffic_filter_link_ids data source schema.
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: read,
+
+		Schema: newSchema(),
+
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+
+	res, err := trafficfilterapi.List(trafficfilterapi.ListParams{
+		API:                 client,
+		Region:              d.Get("region").(string),
+		IncludeAssociations: true,
+	})
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed listing traffic filter rulesets", err),
+		)
+	}
+
+	links := filterLinks(flattenLinks(res), linkFilter{
+		kind:         d.Get("type").(string),
+		deploymentID: d.Get("deployment_id").(string),
+		unassociated: d.Get("unassociated").(bool),
+	})
+
+	var ids = make([]string, 0, len(links))
+	var flattened = make([]interface{}, 0, len(links))
+	for _, l := range links {
+		ids = append(ids, l.id)
+		flattened = append(flattened, l.flatten())
+	}
+
+	if d.Id() == "" {
+		d.SetId(strconv.Itoa(schema.HashString(strings.Join(ids, ","))))
+	}
+
+	if err := d.Set("ids", uniqueIDs(ids)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("links", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
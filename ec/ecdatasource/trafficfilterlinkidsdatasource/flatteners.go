@@ -0,0 +1,190 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfilterlinkidsdatasource
+
+import (
+	"sort"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+const (
+	entityType = "deployment"
+
+	awsLinkType   = "vpce"
+	azureLinkType = "azure_private_endpoint"
+	gcpLinkType   = "gcp_private_service_connect_endpoint"
+)
+
+// linkTypes are the ruleset types whose rules hold private link IDs.
+var linkTypes = []string{awsLinkType, azureLinkType, gcpLinkType}
+
+// link is a private link ID set on a traffic filter ruleset.
+type link struct {
+	id            string
+	kind          string
+	region        string
+	rulesetID     string
+	rulesetName   string
+	deploymentIDs []string
+}
+
+func (l link) flatten() map[string]interface{} {
+	var deploymentIDs = make([]interface{}, 0, len(l.deploymentIDs))
+	for _, id := range l.deploymentIDs {
+		deploymentIDs = append(deploymentIDs, id)
+	}
+
+	return map[string]interface{}{
+		"id":             l.id,
+		"type":           l.kind,
+		"region":         l.region,
+		"ruleset_id":     l.rulesetID,
+		"ruleset_name":   l.rulesetName,
+		"deployment_ids": deploymentIDs,
+	}
+}
+
+// flattenLinks returns the link IDs of the private link rulesets, sorted by
+// link ID and ruleset ID. The link ID of the Azure rules is the endpoint GUID,
+// and the rule source for the other types.
+func flattenLinks(res *models.TrafficFilterRulesets) []link {
+	var links []link
+	if res == nil {
+		return links
+	}
+
+	for _, ruleset := range res.Rulesets {
+		if ruleset == nil || ruleset.ID == nil || ruleset.Type == nil || !isLinkType(*ruleset.Type) {
+			continue
+		}
+
+		var region, name string
+		if ruleset.Region != nil {
+			region = *ruleset.Region
+		}
+		if ruleset.Name != nil {
+			name = *ruleset.Name
+		}
+		deploymentIDs := associatedDeployments(ruleset)
+
+		for _, rule := range ruleset.Rules {
+			if rule == nil {
+				continue
+			}
+
+			id := rule.Source
+			if *ruleset.Type == azureLinkType {
+				id = rule.AzureEndpointGUID
+			}
+			if id == "" {
+				continue
+			}
+
+			links = append(links, link{
+				id:            id,
+				kind:          *ruleset.Type,
+				region:        region,
+				rulesetID:     *ruleset.ID,
+				rulesetName:   name,
+				deploymentIDs: deploymentIDs,
+			})
+		}
+	}
+
+	sort.SliceStable(links, func(i, j int) bool {
+		if links[i].id != links[j].id {
+			return links[i].id < links[j].id
+		}
+		return links[i].rulesetID < links[j].rulesetID
+	})
+
+	return links
+}
+
+// linkFilter narrows down the link IDs, its zero value matches all of them.
+type linkFilter struct {
+	kind         string
+	deploymentID string
+	unassociated bool
+}
+
+func (f linkFilter) matches(l link) bool {
+	if f.kind != "" && l.kind != f.kind {
+		return false
+	}
+	if f.unassociated && len(l.deploymentIDs) > 0 {
+		return false
+	}
+	if f.deploymentID != "" {
+		for _, id := range l.deploymentIDs {
+			if id == f.deploymentID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func filterLinks(links []link, f linkFilter) []link {
+	var result = make([]link, 0, len(links))
+	for _, l := range links {
+		if f.matches(l) {
+			result = append(result, l)
+		}
+	}
+	return result
+}
+
+// uniqueIDs returns the sorted IDs without duplicates.
+func uniqueIDs(ids []string) []interface{} {
+	var result = make([]interface{}, 0, len(ids))
+	for i, id := range ids {
+		if i > 0 && ids[i-1] == id {
+			continue
+		}
+		result = append(result, id)
+	}
+	return result
+}
+
+// associatedDeployments returns the sorted IDs of the deployments associated
+// with the ruleset.
+func associatedDeployments(ruleset *models.TrafficFilterRulesetInfo) []string {
+	var ids []string
+	for _, assoc := range ruleset.Associations {
+		if assoc == nil || assoc.EntityType == nil || assoc.ID == nil {
+			continue
+		}
+		if *assoc.EntityType == entityType {
+			ids = append(ids, *assoc.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func isLinkType(kind string) bool {
+	for _, t := range linkTypes {
+		if t == kind {
+			return true
+		}
+	}
+	return false
+}
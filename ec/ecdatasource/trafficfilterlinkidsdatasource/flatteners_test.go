@@ -0,0 +1,141 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfilterlinkidsdatasource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRulesets() *models.TrafficFilterRulesets {
+	return &models.TrafficFilterRulesets{Rulesets: []*models.TrafficFilterRulesetInfo{
+		{
+			ID:     ec.String("office"),
+			Name:   ec.String("office"),
+			Type:   ec.String("ip"),
+			Region: ec.String("us-east-1"),
+			Rules:  []*models.TrafficFilterRule{{Source: "1.1.1.1"}},
+		},
+		{
+			ID:     ec.String("aws-link"),
+			Name:   ec.String("aws link"),
+			Type:   ec.String("vpce"),
+			Region: ec.String("us-east-1"),
+			Rules: []*models.TrafficFilterRule{
+				{Source: "vpce-0b"},
+				{Source: "vpce-0a"},
+			},
+			Associations: []*models.FilterAssociation{
+				{EntityType: ec.String("deployment"), ID: ec.String("some-deployment")},
+			},
+		},
+		{
+			ID:     ec.String("azure-link"),
+			Name:   ec.String("azure link"),
+			Type:   ec.String("azure_private_endpoint"),
+			Region: ec.String("azure-eastus2"),
+			Rules: []*models.TrafficFilterRule{
+				{AzureEndpointName: "endpoint", AzureEndpointGUID: "1a2b3c"},
+			},
+		},
+		{
+			ID:     ec.String("another-aws-link"),
+			Name:   ec.String("another aws link"),
+			Type:   ec.String("vpce"),
+			Region: ec.String("us-east-1"),
+			Rules:  []*models.TrafficFilterRule{{Source: "vpce-0a"}},
+		},
+	}}
+}
+
+func Test_flattenLinks(t *testing.T) {
+	assert.Empty(t, flattenLinks(nil))
+	assert.Equal(t, []link{
+		{
+			id: "1a2b3c", kind: "azure_private_endpoint", region: "azure-eastus2",
+			rulesetID: "azure-link", rulesetName: "azure link",
+		},
+		{
+			id: "vpce-0a", kind: "vpce", region: "us-east-1",
+			rulesetID: "another-aws-link", rulesetName: "another aws link",
+		},
+		{
+			id: "vpce-0a", kind: "vpce", region: "us-east-1",
+			rulesetID: "aws-link", rulesetName: "aws link",
+			deploymentIDs: []string{"some-deployment"},
+		},
+		{
+			id: "vpce-0b", kind: "vpce", region: "us-east-1",
+			rulesetID: "aws-link", rulesetName: "aws link",
+			deploymentIDs: []string{"some-deployment"},
+		},
+	}, flattenLinks(newRulesets()))
+}
+
+func Test_filterLinks(t *testing.T) {
+	links := flattenLinks(newRulesets())
+	ids := func(links []link) []string {
+		var result []string
+		for _, l := range links {
+			result = append(result, l.id+"@"+l.rulesetID)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name   string
+		filter linkFilter
+		want   []string
+	}{
+		{
+			name: "returns all the links without a filter",
+			want: []string{"1a2b3c@azure-link", "vpce-0a@another-aws-link", "vpce-0a@aws-link", "vpce-0b@aws-link"},
+		},
+		{
+			name:   "returns the links of the type",
+			filter: linkFilter{kind: "azure_private_endpoint"},
+			want:   []string{"1a2b3c@azure-link"},
+		},
+		{
+			name:   "returns the links associated with the deployment",
+			filter: linkFilter{deploymentID: "some-deployment"},
+			want:   []string{"vpce-0a@aws-link", "vpce-0b@aws-link"},
+		},
+		{
+			name:   "returns the links which aren't associated with any deployment",
+			filter: linkFilter{kind: "vpce", unassociated: true},
+			want:   []string{"vpce-0a@another-aws-link"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ids(filterLinks(links, tt.filter)))
+		})
+	}
+}
+
+func Test_uniqueIDs(t *testing.T) {
+	assert.Equal(t, []interface{}{}, uniqueIDs(nil))
+	assert.Equal(t,
+		[]interface{}{"1a2b3c", "vpce-0a", "vpce-0b"},
+		uniqueIDs([]string{"1a2b3c", "vpce-0a", "vpce-0a", "vpce-0b"}),
+	)
+}
@@ -0,0 +1,101 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfilterlinkidsdatasource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"region": {
+			Type:        schema.TypeString,
+			Description: "Optional region of the traffic filter rulesets, all the regions are read when unset",
+			Optional:    true,
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Description:  `Optional type of the private link rulesets ("vpce", "azure_private_endpoint" or "gcp_private_service_connect_endpoint"), all of them are read when unset`,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(linkTypes, false),
+		},
+		"deployment_id": {
+			Type:          schema.TypeString,
+			Description:   "Optional ID of a deployment, only the link IDs of the rulesets associated with it are read when set",
+			Optional:      true,
+			ConflictsWith: []string{"unassociated"},
+		},
+		"unassociated": {
+			Type:          schema.TypeBool,
+			Description:   "Optionally only read the link IDs of the rulesets which aren't associated with any deployment yet. Defaults to false",
+			Optional:      true,
+			ConflictsWith: []string{"deployment_id"},
+		},
+
+		// Computed
+		"ids": {
+			Type:        schema.TypeList,
+			Description: "Sorted and unique link IDs of the matching private link rulesets",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"links": {
+			Type:        schema.TypeList,
+			Description: "Link IDs of the matching private link rulesets, with the ruleset they belong to",
+			Computed:    true,
+			Elem:        newLinkSchema(),
+		},
+	}
+}
+
+func newLinkSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ruleset_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ruleset_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"deployment_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
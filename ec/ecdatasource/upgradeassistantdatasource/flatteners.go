@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package upgradeassistantdatasource
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	// The upgrade assistant only reports the number of critical issues as
+	// part of its details message, e.g. "There are 2 Elasticsearch
+	// deprecation issues and 0 Kibana deprecation issues that need to be
+	// resolved before upgrading.".
+	elasticsearchIssuesRegexp = regexp.MustCompile(`(\d+) Elasticsearch deprecation issues?`)
+	kibanaIssuesRegexp        = regexp.MustCompile(`(\d+) Kibana deprecation issues?`)
+)
+
+func modelToState(d *schema.ResourceData, res *models.DeploymentUpgradeAssistantStatusResponse) error {
+	var details string
+	if res.Details != nil {
+		details = *res.Details
+	}
+
+	var ready bool
+	if res.ReadyForUpgrade != nil {
+		ready = *res.ReadyForUpgrade
+	}
+
+	esIssues := countIssues(elasticsearchIssuesRegexp, details)
+	kibanaIssues := countIssues(kibanaIssuesRegexp, details)
+
+	if err := d.Set("ready_for_upgrade", ready); err != nil {
+		return err
+	}
+
+	if err := d.Set("details", details); err != nil {
+		return err
+	}
+
+	if err := d.Set("elasticsearch_critical_issues", esIssues); err != nil {
+		return err
+	}
+
+	if err := d.Set("kibana_critical_issues", kibanaIssues); err != nil {
+		return err
+	}
+
+	return d.Set("critical_issues", esIssues+kibanaIssues)
+}
+
+// countIssues returns the number of issues matched by re in the details
+// message, or 0 when the message doesn't mention them.
+func countIssues(re *regexp.Regexp, details string) int {
+	match := re.FindStringSubmatch(details)
+	if len(match) < 2 {
+		return 0
+	}
+
+	count, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+	return count
+}
@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package upgradeassistantdatasource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_modelToState(t *testing.T) {
+	tests := []struct {
+		name string
+		res  *models.DeploymentUpgradeAssistantStatusResponse
+		want map[string]interface{}
+	}{
+		{
+			name: "flattens a deployment ready for upgrade",
+			res: &models.DeploymentUpgradeAssistantStatusResponse{
+				ReadyForUpgrade: ec.Bool(true),
+				Details:         ec.String("There are 0 Elasticsearch deprecation issues and 0 Kibana deprecation issues that need to be resolved before upgrading."),
+			},
+			want: map[string]interface{}{
+				"ready_for_upgrade":             true,
+				"details":                       "There are 0 Elasticsearch deprecation issues and 0 Kibana deprecation issues that need to be resolved before upgrading.",
+				"elasticsearch_critical_issues": 0,
+				"kibana_critical_issues":        0,
+				"critical_issues":               0,
+			},
+		},
+		{
+			name: "flattens the critical issues of a deployment not ready for upgrade",
+			res: &models.DeploymentUpgradeAssistantStatusResponse{
+				ReadyForUpgrade: ec.Bool(false),
+				Details:         ec.String("There are 3 Elasticsearch deprecation issues and 1 Kibana deprecation issue that need to be resolved before upgrading."),
+			},
+			want: map[string]interface{}{
+				"ready_for_upgrade":             false,
+				"details":                       "There are 3 Elasticsearch deprecation issues and 1 Kibana deprecation issue that need to be resolved before upgrading.",
+				"elasticsearch_critical_issues": 3,
+				"kibana_critical_issues":        1,
+				"critical_issues":               4,
+			},
+		},
+		{
+			name: "flattens an empty response",
+			res:  &models.DeploymentUpgradeAssistantStatusResponse{},
+			want: map[string]interface{}{
+				"ready_for_upgrade":             false,
+				"details":                       "",
+				"elasticsearch_critical_issues": 0,
+				"kibana_critical_issues":        0,
+				"critical_issues":               0,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rd := schema.TestResourceDataRaw(t, newSchema(), nil)
+			rd.SetId("320b7b540dfc967a7a649c18e2fce4ed")
+
+			err := modelToState(rd, tt.res)
+			assert.NoError(t, err)
+
+			for k, v := range tt.want {
+				assert.Equal(t, v, rd.Get(k), k)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package upgradeassistantdatasource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"deployment_id": {
+			Type:        schema.TypeString,
+			Description: "Required ID of the deployment whose upgrade assistant status is read",
+			Required:    true,
+		},
+
+		// Computed
+		"ready_for_upgrade": {
+			Type:        schema.TypeBool,
+			Description: "Whether the deployment has no critical deprecation issues blocking an upgrade",
+			Computed:    true,
+		},
+		"details": {
+			Type:        schema.TypeString,
+			Description: "Message returned by the upgrade assistant with the number of deprecation issues",
+			Computed:    true,
+		},
+		"elasticsearch_critical_issues": {
+			Type:        schema.TypeInt,
+			Description: "Number of Elasticsearch critical deprecation issues to resolve before upgrading",
+			Computed:    true,
+		},
+		"kibana_critical_issues": {
+			Type:        schema.TypeInt,
+			Description: "Number of Kibana critical deprecation issues to resolve before upgrading",
+			Computed:    true,
+		},
+		"critical_issues": {
+			Type:        schema.TypeInt,
+			Description: "Total number of critical deprecation issues to resolve before upgrading",
+			Computed:    true,
+		},
+	}
+}
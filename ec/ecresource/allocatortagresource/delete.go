@@ -0,0 +1,48 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatortagresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/allocatorapi"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func deleteResource(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+
+	if err := allocatorapi.DeleteAllocatorMetadataItem(allocatorapi.MetadataDeleteParams{
+		API:    client,
+		ID:     d.Get("allocator_id").(string),
+		Key:    d.Get("key").(string),
+		Region: tagRegion(d),
+	}); err != nil {
+		// The tag is gone along with the allocator.
+		if !tagNotFound(err) {
+			return diag.FromErr(multierror.NewPrefixed("failed deleting allocator tag", err))
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatortagresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/allocatorapi"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func readResource(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+	allocatorID, key, err := parseTagID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	metadata, err := allocatorapi.GetAllocatorMetadata(allocatorapi.MetadataGetParams{
+		API:    client,
+		ID:     allocatorID,
+		Region: tagRegion(d),
+	})
+	if err != nil {
+		if allocatorNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(multierror.NewPrefixed("failed reading allocator tag", err))
+	}
+
+	// The tag has been removed outside of Terraform.
+	value, ok := getTag(metadata, key)
+	if !ok {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("allocator_id", allocatorID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("key", key); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("value", value); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("region", tagRegion(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// importResource validates the "<allocator_id>/<key>" import ID, the
+// attributes are populated by the subsequent read.
+func importResource(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseTagID(d.Id()); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatortagresource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_readResource(t *testing.T) {
+	newRD := func() *schema.ResourceData {
+		return util.NewResourceData(t, util.ResDataParams{
+			ID:     tagID("192.168.44.10", "instance_family"),
+			Schema: newSchema(),
+			State: map[string]interface{}{
+				"allocator_id": "192.168.44.10",
+				"key":          "instance_family",
+				"value":        "gcp.highcpu.1",
+				"region":       "ece-region",
+			},
+		})
+	}
+
+	t.Run("refreshes the tag value", func(t *testing.T) {
+		d := newRD()
+		got := readResource(context.Background(), d, util.NewMockMeta(
+			mock.New200StructResponse([]*models.MetadataItem{
+				{Key: ec.String("instance_family"), Value: ec.String("gcp.highcpu.2")},
+			}),
+		))
+		assert.Nil(t, got)
+		assert.Equal(t, "192.168.44.10/instance_family", d.Id())
+		assert.Equal(t, "gcp.highcpu.2", d.Get("value"))
+	})
+
+	t.Run("unsets the id when the tag has been removed", func(t *testing.T) {
+		d := newRD()
+		got := readResource(context.Background(), d, util.NewMockMeta(
+			mock.New200StructResponse([]*models.MetadataItem{
+				{Key: ec.String("zone"), Value: ec.String("us-central1-a")},
+			}),
+		))
+		assert.Nil(t, got)
+		assert.Equal(t, "", d.Id())
+	})
+
+	t.Run("unsets the id when the allocator doesn't exist", func(t *testing.T) {
+		d := newRD()
+		got := readResource(context.Background(), d, util.NewMockMeta(
+			mock.NewErrorResponse(404, mock.APIError{Code: "some", Message: "message"}),
+		))
+		assert.Nil(t, got)
+		assert.Equal(t, "", d.Id())
+	})
+
+	t.Run("returns an error when it receives a 500", func(t *testing.T) {
+		d := newRD()
+		got := readResource(context.Background(), d, util.NewMockMeta(
+			mock.NewErrorResponse(500, mock.APIError{Code: "some", Message: "message"}),
+		))
+		assert.Equal(t, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "failed reading allocator tag: 1 error occurred:\n\t* api error: some: message\n\n",
+		}}, got)
+		assert.Equal(t, "192.168.44.10/instance_family", d.Id())
+	})
+}
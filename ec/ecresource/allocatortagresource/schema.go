@@ -0,0 +1,59 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatortagresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// eceRegion is the region of all the Elastic Cloud Enterprise installations.
+const eceRegion = "ece-region"
+
+// newSchema returns the schema for an "ec_allocator_tag" resource.
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"allocator_id": {
+			Type:         schema.TypeString,
+			Description:  "Required ID of the allocator where the tag is set",
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"key": {
+			Type:         schema.TypeString,
+			Description:  "Required tag key",
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"value": {
+			Type:         schema.TypeString,
+			Description:  "Required tag value",
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Description: `Optional region where the allocator is, defaults to "ece-region"`,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     eceRegion,
+		},
+	}
+}
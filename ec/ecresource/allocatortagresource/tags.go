@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatortagresource
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_infrastructure"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// tagID returns the resource ID from the allocator ID and the tag key.
+func tagID(allocatorID, key string) string {
+	return allocatorID + "/" + key
+}
+
+// parseTagID returns the allocator ID and tag key from the resource ID.
+func parseTagID(id string) (allocatorID, key string, err error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf(
+			`invalid ID "%s": expected format <allocator_id>/<key>`, id,
+		)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// getTag returns the value of the tag key set in the allocator metadata, and
+// whether the key is set at all.
+func getTag(metadata []*models.MetadataItem, key string) (string, bool) {
+	for _, item := range metadata {
+		if item.Key != nil && *item.Key == key {
+			var value string
+			if item.Value != nil {
+				value = *item.Value
+			}
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// tagRegion returns the region of the allocator, which isn't set in the state
+// when the tag is imported.
+func tagRegion(d *schema.ResourceData) string {
+	if region, ok := d.Get("region").(string); ok && region != "" {
+		return region
+	}
+	return eceRegion
+}
+
+// allocatorNotFound returns true when the error is the not found response of
+// the allocator metadata.
+func allocatorNotFound(err error) bool {
+	var notFound *platform_infrastructure.GetAllocatorMetadataNotFound
+	return errors.As(err, &notFound)
+}
+
+// tagNotFound returns true when the error is the not found response of the
+// allocator metadata item deletion, returned when either the allocator or
+// the tag no longer exist.
+func tagNotFound(err error) bool {
+	var notFound *platform_infrastructure.DeleteAllocatorMetadataItemNotFound
+	return errors.As(err, &notFound)
+}
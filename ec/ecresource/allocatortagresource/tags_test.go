@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatortagresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseTagID(t *testing.T) {
+	tests := []struct {
+		name            string
+		id              string
+		wantAllocatorID string
+		wantKey         string
+		err             error
+	}{
+		{
+			name:            "parses the allocator ID and key",
+			id:              "192.168.44.10/instance_family",
+			wantAllocatorID: "192.168.44.10",
+			wantKey:         "instance_family",
+		},
+		{
+			name:            "keeps any slashes in the key",
+			id:              "192.168.44.10/hw/instance_family",
+			wantAllocatorID: "192.168.44.10",
+			wantKey:         "hw/instance_family",
+		},
+		{
+			name: "fails when there's no key",
+			id:   "192.168.44.10",
+			err:  errors.New(`invalid ID "192.168.44.10": expected format <allocator_id>/<key>`),
+		},
+		{
+			name: "fails when the allocator ID is empty",
+			id:   "/instance_family",
+			err:  errors.New(`invalid ID "/instance_family": expected format <allocator_id>/<key>`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocatorID, key, err := parseTagID(tt.id)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.wantAllocatorID, allocatorID)
+			assert.Equal(t, tt.wantKey, key)
+		})
+	}
+}
+
+func Test_getTag(t *testing.T) {
+	metadata := []*models.MetadataItem{
+		{Key: ec.String("instance_family"), Value: ec.String("gcp.highcpu.1")},
+		{Key: ec.String("zone"), Value: ec.String("us-central1-a")},
+	}
+
+	value, ok := getTag(metadata, "zone")
+	assert.True(t, ok)
+	assert.Equal(t, "us-central1-a", value)
+
+	value, ok = getTag(metadata, "rack")
+	assert.False(t, ok)
+	assert.Equal(t, "", value)
+
+	_, ok = getTag(nil, "zone")
+	assert.False(t, ok)
+}
@@ -0,0 +1,130 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// aliasConflictingSettings are the user settings which set the public
+	// endpoint of a deployment resource, keyed by the resource kind.
+	aliasConflictingSettings = map[string][]string{
+		"kibana":            {"server.publicBaseUrl"},
+		"enterprise_search": {"ent_search.external_url"},
+	}
+
+	// userSettingsKeys are the config attributes which hold user settings.
+	userSettingsKeys = []string{
+		"user_settings_yaml",
+		"user_settings_json",
+		"user_settings_override_yaml",
+		"user_settings_override_json",
+	}
+)
+
+// checkAliasConflictsDiff fails the plan when the deployment "alias" is set
+// together with user settings which override the public endpoint of any of
+// the deployment resources. The user settings take precedence over the
+// alias-derived endpoint, so the combination is rejected rather than leaving
+// the deployment with endpoints which don't match the alias.
+func checkAliasConflictsDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("alias") {
+		return nil
+	}
+
+	if alias, ok := d.Get("alias").(string); !ok || alias == "" {
+		return nil
+	}
+
+	var conflicts []string
+	for _, kind := range []string{"kibana", "enterprise_search"} {
+		for _, attr := range userSettingsKeys {
+			key := fmt.Sprintf("%s.0.config.0.%s", kind, attr)
+			if !d.NewValueKnown(key) {
+				continue
+			}
+
+			settings, ok := d.Get(key).(string)
+			if !ok || settings == "" {
+				continue
+			}
+
+			for _, setting := range conflictingSettings(settings, aliasConflictingSettings[kind]) {
+				conflicts = append(conflicts,
+					fmt.Sprintf("%s.config.%s: %s", kind, attr, setting),
+				)
+			}
+		}
+	}
+
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(
+		`"alias" cannot be set together with user settings which override the resource endpoint, remove either the alias or the following settings: %s`,
+		strings.Join(conflicts, ", "),
+	)
+}
+
+// conflictingSettings returns the sorted list of settings found in the YAML
+// or JSON encoded user settings. Settings can be specified either in their
+// dotted or nested form. Settings which can't be parsed are ignored since
+// they're validated by the API.
+func conflictingSettings(settings string, keys []string) []string {
+	var parsed map[string]interface{}
+	if err := yaml.Unmarshal([]byte(settings), &parsed); err != nil {
+		return nil
+	}
+
+	flat := make(map[string]struct{})
+	flattenSettings("", parsed, flat)
+
+	var found []string
+	for _, key := range keys {
+		if _, ok := flat[key]; ok {
+			found = append(found, key)
+		}
+	}
+	sort.Strings(found)
+
+	return found
+}
+
+// flattenSettings flattens nested settings into dotted keys.
+func flattenSettings(prefix string, settings map[string]interface{}, flat map[string]struct{}) {
+	for k, v := range settings {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		if nested, ok := v.(map[string]interface{}); ok {
+			flattenSettings(key, nested, flat)
+			continue
+		}
+		flat[key] = struct{}{}
+	}
+}
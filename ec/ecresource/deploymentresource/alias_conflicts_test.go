@@ -0,0 +1,68 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_conflictingSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings string
+		keys     []string
+		want     []string
+	}{
+		{
+			name:     "finds a dotted YAML setting",
+			settings: "server.publicBaseUrl: https://kibana.example.com\nxpack.fleet.enabled: true",
+			keys:     aliasConflictingSettings["kibana"],
+			want:     []string{"server.publicBaseUrl"},
+		},
+		{
+			name:     "finds a nested YAML setting",
+			settings: "server:\n  publicBaseUrl: https://kibana.example.com\n  maxPayload: 1048576",
+			keys:     aliasConflictingSettings["kibana"],
+			want:     []string{"server.publicBaseUrl"},
+		},
+		{
+			name:     "finds a nested JSON setting",
+			settings: `{"ent_search":{"external_url":"https://search.example.com"}}`,
+			keys:     aliasConflictingSettings["enterprise_search"],
+			want:     []string{"ent_search.external_url"},
+		},
+		{
+			name:     "ignores unrelated settings",
+			settings: `{"server.maxPayload":1048576}`,
+			keys:     aliasConflictingSettings["kibana"],
+		},
+		{
+			name:     "ignores settings which can't be parsed",
+			settings: "server.publicBaseUrl: [",
+			keys:     aliasConflictingSettings["kibana"],
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := conflictingSettings(tt.settings, tt.keys)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
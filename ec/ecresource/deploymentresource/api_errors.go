@@ -0,0 +1,342 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// failedReply is implemented by the API error responses which have a
+// *models.BasicFailedReply payload.
+type failedReply interface {
+	GetPayload() *models.BasicFailedReply
+}
+
+// topLevelFields are the deployment payload fields which have the same name
+// as their attribute.
+var topLevelFields = []string{"name", "alias", "region", "version"}
+
+// configFields are the resource plan configuration fields which have the
+// same name as their "config" attribute.
+var configFields = []string{
+	"docker_image",
+	"user_settings_json",
+	"user_settings_override_json",
+	"user_settings_yaml",
+	"user_settings_override_yaml",
+}
+
+// topologyFields maps the resource plan topology element fields to their
+// "topology" attributes.
+var topologyFields = map[string]string{
+	"instance_configuration_id": "instance_configuration_id",
+	"zone_count":                "zone_count",
+	"node_roles":                "node_roles",
+	"size":                      "size",
+	"size.value":                "size",
+	"size.resource":             "size_resource",
+	"node_type.data":            "node_type_data",
+	"node_type.master":          "node_type_master",
+	"node_type.ingest":          "node_type_ingest",
+	"node_type.ml":              "node_type_ml",
+}
+
+// autoscalingFields maps the Elasticsearch topology element autoscaling
+// fields to their "autoscaling" attributes.
+var autoscalingFields = map[string]string{
+	"autoscaling_max":          "max_size",
+	"autoscaling_max.value":    "max_size",
+	"autoscaling_max.resource": "max_size_resource",
+	"autoscaling_min":          "min_size",
+	"autoscaling_min.value":    "min_size",
+	"autoscaling_min.resource": "min_size_resource",
+}
+
+// payloadError is a deployment API error which has fields of the deployment
+// payload mapped back to their attribute paths.
+type payloadError struct {
+	*multierror.Prefixed
+	diags diag.Diagnostics
+}
+
+// newPayloadError prefixes the deployment API error. When the API error
+// refers to fields of the payload, the returned error keeps the diagnostics
+// with their attribute paths, see errorDiagnostics.
+func newPayloadError(d *schema.ResourceData, prefix string, err error, es []*models.ElasticsearchPayload) error {
+	merr := multierror.NewPrefixed(prefix, err)
+	if diags := payloadDiagnostics(d, prefix, err, es); diags != nil {
+		return &payloadError{Prefixed: merr, diags: diags}
+	}
+	return merr
+}
+
+// errorDiagnostics returns the diagnostics of the error, which have the
+// attribute paths of the payload fields when it's a payload error.
+func errorDiagnostics(err error) diag.Diagnostics {
+	var perr *payloadError
+	if errors.As(err, &perr) {
+		return perr.diags
+	}
+	return diag.FromErr(err)
+}
+
+// payloadDiagnostics returns a diagnostic for each of the errors of the API
+// error payload, with the attribute path of the first field they refer to.
+// When none of the fields can be mapped back to an attribute, nil is returned
+// so the error is surfaced as is.
+func payloadDiagnostics(d *schema.ResourceData, summary string, err error, es []*models.ElasticsearchPayload) diag.Diagnostics {
+	var reply failedReply
+	if !errors.As(err, &reply) || reply.GetPayload() == nil {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	var mapped bool
+	for _, e := range reply.GetPayload().Errors {
+		var code, message = "unknown", "unknown"
+		if e.Code != nil {
+			code = *e.Code
+		}
+		if e.Message != nil {
+			message = *e.Message
+		}
+
+		detail := fmt.Sprintf("%s: %s", code, message)
+		if len(e.Fields) > 0 {
+			detail = fmt.Sprintf("%s (%s)", detail, strings.Join(e.Fields, ", "))
+		}
+
+		var path cty.Path
+		for _, field := range e.Fields {
+			if path = fieldPath(d, field, es); path != nil {
+				mapped = true
+				break
+			}
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       summary,
+			Detail:        detail,
+			AttributePath: path,
+		})
+	}
+
+	if !mapped {
+		return nil
+	}
+	return diags
+}
+
+// fieldSegment is a segment of an API payload field, such as "topology[1]".
+type fieldSegment struct {
+	name  string
+	index int
+}
+
+// parseField splits an API payload field such as
+// "resources.elasticsearch[0].plan.cluster_topology[1].size" into its
+// segments. The segments which aren't indexed have a -1 index.
+func parseField(field string) []fieldSegment {
+	field = strings.TrimPrefix(field, "$.")
+	parts := strings.Split(field, ".")
+	segments := make([]fieldSegment, 0, len(parts))
+	for _, p := range parts {
+		seg := fieldSegment{name: p, index: -1}
+		if i := strings.IndexByte(p, '['); i > 0 && strings.HasSuffix(p, "]") {
+			if index, err := strconv.Atoi(p[i+1 : len(p)-1]); err == nil {
+				seg = fieldSegment{name: p[:i], index: index}
+			}
+		}
+		segments = append(segments, seg)
+	}
+	return segments
+}
+
+// joinSegments joins the names of the segments, ignoring their indexes.
+func joinSegments(segments []fieldSegment) string {
+	names := make([]string, 0, len(segments))
+	for _, s := range segments {
+		names = append(names, s.name)
+	}
+	return strings.Join(names, ".")
+}
+
+// fieldPath maps an API payload field back to the path of the attribute it
+// is set from. When only part of the field can be mapped, the path of the
+// closest block is returned. Fields which can't be mapped return nil.
+func fieldPath(d *schema.ResourceData, field string, es []*models.ElasticsearchPayload) cty.Path {
+	segments := parseField(field)
+	if len(segments) == 0 {
+		return nil
+	}
+
+	name := joinSegments(segments)
+	if slice.HasString(topLevelFields, name) {
+		return cty.GetAttrPath(name)
+	}
+
+	switch {
+	case name == "metadata.tags" || strings.HasPrefix(name, "metadata.tags."):
+		return cty.GetAttrPath("tags")
+	case strings.HasPrefix(name, "settings.traffic_filter_settings"):
+		return cty.GetAttrPath("traffic_filter")
+	case strings.HasPrefix(name, "settings.observability"):
+		return cty.GetAttrPath("observability")
+	}
+
+	if len(segments) < 2 || segments[0].name != "resources" {
+		return nil
+	}
+
+	kind := segments[1].name
+	if !slice.HasString(resourceKinds, kind) {
+		return nil
+	}
+
+	return resourceFieldPath(d, kind, segments[1].index, segments[2:], es)
+}
+
+// resourceFieldPath maps the fields of a resource kind payload back to the
+// attributes of its block.
+func resourceFieldPath(d *schema.ResourceData, kind string, index int, segments []fieldSegment, es []*models.ElasticsearchPayload) cty.Path {
+	path := cty.GetAttrPath(kind).IndexInt(0)
+	if len(segments) == 0 {
+		return path
+	}
+
+	switch name := joinSegments(segments); {
+	case name == "ref_id" || name == "region":
+		return path.GetAttr(name)
+	case name == "plan.deployment_template.id":
+		return cty.GetAttrPath("deployment_template_id")
+	case name == "plan.autoscaling_enabled" && kind == "elasticsearch":
+		return path.GetAttr("autoscale")
+	case name == "plan."+kind+".version":
+		return cty.GetAttrPath("version")
+	}
+
+	if len(segments) < 2 || segments[0].name != "plan" {
+		return path
+	}
+
+	switch segments[1].name {
+	case "cluster_topology":
+		return topologyFieldPath(d, kind, path, esTopologyID(es, index, segments[1].index), segments[2:])
+	case kind:
+		return configFieldPath(kind, path.GetAttr("config").IndexInt(0), segments[2:])
+	}
+
+	return path
+}
+
+// topologyFieldPath maps the fields of a resource plan topology element back
+// to the attributes of its "topology" block. The Elasticsearch topology
+// elements are matched by their ID, since the payload has all the template
+// topology elements rather than only the configured ones.
+func topologyFieldPath(d *schema.ResourceData, kind string, path cty.Path, id string, segments []fieldSegment) cty.Path {
+	path = path.GetAttr("topology")
+
+	index := 0
+	if kind == "elasticsearch" {
+		index = -1
+		topology, _ := d.Get("elasticsearch.0.topology").([]interface{})
+		for i, t := range topology {
+			if m, ok := t.(map[string]interface{}); ok && id != "" && m["id"] == id {
+				index = i
+			}
+		}
+		if index < 0 {
+			return path
+		}
+	}
+
+	path = path.IndexInt(index)
+	if len(segments) == 0 {
+		return path
+	}
+
+	name := joinSegments(segments)
+	if attr, ok := topologyFields[name]; ok {
+		if strings.HasPrefix(attr, "node_type_") && kind != "elasticsearch" {
+			return path
+		}
+		return path.GetAttr(attr)
+	}
+
+	if kind != "elasticsearch" {
+		return path
+	}
+
+	if attr, ok := autoscalingFields[name]; ok {
+		return path.GetAttr("autoscaling").IndexInt(0).GetAttr(attr)
+	}
+
+	// The topology element configuration only supports the user settings.
+	if len(segments) > 1 && segments[0].name == kind && strings.HasPrefix(segments[1].name, "user_settings_") {
+		return path.GetAttr("config").IndexInt(0).GetAttr(segments[1].name)
+	}
+
+	return path
+}
+
+// configFieldPath maps the fields of a resource plan configuration back to
+// the attributes of its "config" block.
+func configFieldPath(kind string, path cty.Path, segments []fieldSegment) cty.Path {
+	if len(segments) == 0 {
+		return path
+	}
+
+	name := segments[0].name
+	if slice.HasString(configFields, name) {
+		return path.GetAttr(name)
+	}
+
+	if kind == "elasticsearch" && (name == "enabled_built_in_plugins" || name == "user_plugins") {
+		return path.GetAttr("plugins")
+	}
+
+	return path
+}
+
+// esTopologyID returns the ID of the Elasticsearch payload topology element.
+func esTopologyID(es []*models.ElasticsearchPayload, index, topologyIndex int) string {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(es) || es[index] == nil || es[index].Plan == nil {
+		return ""
+	}
+
+	topology := es[index].Plan.ClusterTopology
+	if topologyIndex < 0 || topologyIndex >= len(topology) || topology[topologyIndex] == nil {
+		return ""
+	}
+
+	return topology[topologyIndex].ID
+}
@@ -0,0 +1,179 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func newPayloadErrorResourceData(t *testing.T) *schema.ResourceData {
+	return util.NewResourceData(t, util.ResDataParams{
+		ID:     mock.ValidClusterID,
+		Schema: newSchema(),
+		State: map[string]interface{}{
+			"elasticsearch": []interface{}{map[string]interface{}{
+				"topology": []interface{}{
+					map[string]interface{}{"id": "hot_content", "size": "4g"},
+					map[string]interface{}{"id": "warm", "size": "4g"},
+				},
+			}},
+		},
+	})
+}
+
+func newPayloadErrorES() []*models.ElasticsearchPayload {
+	return []*models.ElasticsearchPayload{{
+		Plan: &models.ElasticsearchClusterPlan{
+			ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+				{ID: "coordinating"},
+				{ID: "hot_content"},
+				{ID: "warm"},
+			},
+		},
+	}}
+}
+
+func Test_fieldPath(t *testing.T) {
+	d := newPayloadErrorResourceData(t)
+	es := newPayloadErrorES()
+	tests := []struct {
+		field string
+		want  cty.Path
+	}{
+		{field: "name", want: cty.GetAttrPath("name")},
+		{field: "metadata.tags[0].key", want: cty.GetAttrPath("tags")},
+		{field: "settings.traffic_filter_settings.rulesets", want: cty.GetAttrPath("traffic_filter")},
+		{
+			field: "resources.elasticsearch[0].plan.cluster_topology[2].size.value",
+			want:  cty.GetAttrPath("elasticsearch").IndexInt(0).GetAttr("topology").IndexInt(1).GetAttr("size"),
+		},
+		{
+			field: "resources.elasticsearch[0].plan.cluster_topology[1].autoscaling_max.resource",
+			want: cty.GetAttrPath("elasticsearch").IndexInt(0).GetAttr("topology").IndexInt(0).
+				GetAttr("autoscaling").IndexInt(0).GetAttr("max_size_resource"),
+		},
+		{
+			field: "resources.elasticsearch[0].plan.cluster_topology[1].elasticsearch.user_settings_yaml",
+			want: cty.GetAttrPath("elasticsearch").IndexInt(0).GetAttr("topology").IndexInt(0).
+				GetAttr("config").IndexInt(0).GetAttr("user_settings_yaml"),
+		},
+		{
+			// The topology element isn't part of the configuration.
+			field: "resources.elasticsearch[0].plan.cluster_topology[0].size",
+			want:  cty.GetAttrPath("elasticsearch").IndexInt(0).GetAttr("topology"),
+		},
+		{
+			field: "resources.elasticsearch[0].plan.elasticsearch.user_settings_json",
+			want:  cty.GetAttrPath("elasticsearch").IndexInt(0).GetAttr("config").IndexInt(0).GetAttr("user_settings_json"),
+		},
+		{
+			field: "resources.elasticsearch[0].plan.elasticsearch.version",
+			want:  cty.GetAttrPath("version"),
+		},
+		{
+			field: "resources.elasticsearch[0].plan.deployment_template.id",
+			want:  cty.GetAttrPath("deployment_template_id"),
+		},
+		{
+			field: "resources.kibana[0].plan.cluster_topology[0].zone_count",
+			want:  cty.GetAttrPath("kibana").IndexInt(0).GetAttr("topology").IndexInt(0).GetAttr("zone_count"),
+		},
+		{
+			field: "resources.kibana[0].plan.kibana.docker_image",
+			want:  cty.GetAttrPath("kibana").IndexInt(0).GetAttr("config").IndexInt(0).GetAttr("docker_image"),
+		},
+		{
+			field: "resources.apm[0].settings.metadata",
+			want:  cty.GetAttrPath("apm").IndexInt(0),
+		},
+		{field: "resources.appsearch[0].plan"},
+		{field: "unknown.field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			assert.Equal(t, tt.want, fieldPath(d, tt.field, es))
+		})
+	}
+}
+
+func Test_payloadDiagnostics(t *testing.T) {
+	d := newPayloadErrorResourceData(t)
+	es := newPayloadErrorES()
+	newErr := func(elems ...*models.BasicFailedReplyElement) error {
+		return apierror.Wrap(&deployments.CreateDeploymentBadRequest{
+			Payload: &models.BasicFailedReply{Errors: elems},
+		})
+	}
+
+	t.Run("error without payload", func(t *testing.T) {
+		assert.Nil(t, payloadDiagnostics(d, "failed creating deployment", errors.New("boom"), es))
+	})
+
+	t.Run("error without mapped fields", func(t *testing.T) {
+		err := newErr(&models.BasicFailedReplyElement{
+			Code:    ec.String("deployments.invalid"),
+			Message: ec.String("invalid request"),
+			Fields:  []string{"unknown"},
+		})
+		assert.Nil(t, payloadDiagnostics(d, "failed creating deployment", err, es))
+	})
+
+	t.Run("error with mapped fields", func(t *testing.T) {
+		err := newErr(
+			&models.BasicFailedReplyElement{
+				Code:    ec.String("deployments.invalid_size"),
+				Message: ec.String("invalid size"),
+				Fields:  []string{"resources.elasticsearch[0].plan.cluster_topology[2].size"},
+			},
+			&models.BasicFailedReplyElement{
+				Code:    ec.String("deployments.other"),
+				Message: ec.String("other error"),
+			},
+		)
+		assert.Equal(t, diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "failed creating deployment",
+				Detail:        "deployments.invalid_size: invalid size (resources.elasticsearch[0].plan.cluster_topology[2].size)",
+				AttributePath: cty.GetAttrPath("elasticsearch").IndexInt(0).GetAttr("topology").IndexInt(1).GetAttr("size"),
+			},
+			{
+				Severity: diag.Error,
+				Summary:  "failed creating deployment",
+				Detail:   "deployments.other: other error",
+			},
+		}, payloadDiagnostics(d, "failed creating deployment", err, es))
+
+		perr := newPayloadError(d, "failed updating deployment", err, es)
+		assert.Len(t, errorDiagnostics(perr), 2)
+		assert.Contains(t, perr.Error(), "failed updating deployment: 2 errors occurred")
+	})
+}
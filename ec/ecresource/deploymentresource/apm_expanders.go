@@ -81,6 +81,13 @@ func expandApmResource(raw interface{}, res *models.ApmPayload) (*models.ApmPayl
 		res.Plan.ClusterTopology = defaultApmTopology(res.Plan.ClusterTopology)
 	}
 
+	if strategy := expandPlanStrategy(apm["strategy"]); strategy != nil {
+		if res.Plan.Transient == nil {
+			res.Plan.Transient = &models.TransientApmPlanConfiguration{}
+		}
+		res.Plan.Transient.Strategy = strategy
+	}
+
 	return res, nil
 }
 
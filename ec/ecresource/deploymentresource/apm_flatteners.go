@@ -31,7 +31,11 @@ func flattenApmResources(in []*models.ApmResourceInfo, name string) []interface{
 	var result = make([]interface{}, 0, len(in))
 	for _, res := range in {
 		var m = make(map[string]interface{})
-		if util.IsCurrentApmPlanEmpty(res) || isApmResourceStopped(res) {
+		if util.IsCurrentApmPlanEmpty(res) {
+			continue
+		}
+
+		if isApmResourceStopped(res) && !isDisabledApmPlan(res.Info.PlanInfo.Current.Plan) {
 			continue
 		}
 
@@ -47,8 +51,13 @@ func flattenApmResources(in []*models.ApmResourceInfo, name string) []interface{
 			m["region"] = *res.Region
 		}
 
+		if inMaintenanceMode(res.Info.Topology) {
+			m["maintenance_mode"] = true
+		}
+
 		plan := res.Info.PlanInfo.Current.Plan
 		if topology := flattenApmTopology(plan); len(topology) > 0 {
+			util.FlattenTopologyInstances(topology, res.Info.Topology)
 			m["topology"] = topology
 		}
 
@@ -72,9 +81,10 @@ func flattenApmResources(in []*models.ApmResourceInfo, name string) []interface{
 
 func flattenApmTopology(plan *models.ApmPlan) []interface{} {
 	var result = make([]interface{}, 0, len(plan.ClusterTopology))
+	disabled := isDisabledApmPlan(plan)
 	for _, topology := range plan.ClusterTopology {
 		var m = make(map[string]interface{})
-		if topology.Size == nil || topology.Size.Value == nil || *topology.Size.Value == 0 {
+		if !disabled && (topology.Size == nil || topology.Size.Value == nil || *topology.Size.Value == 0) {
 			continue
 		}
 
@@ -82,8 +92,11 @@ func flattenApmTopology(plan *models.ApmPlan) []interface{} {
 			m["instance_configuration_id"] = topology.InstanceConfigurationID
 		}
 
-		if topology.Size != nil {
+		if topology.Size != nil && topology.Size.Value != nil {
 			m["size"] = util.MemoryToState(*topology.Size.Value)
+		}
+
+		if topology.Size != nil && topology.Size.Resource != nil {
 			m["size_resource"] = *topology.Size.Resource
 		}
 
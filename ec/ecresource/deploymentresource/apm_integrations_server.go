@@ -0,0 +1,84 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+
+	semver "github.com/blang/semver/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// validateApmIntegrationsServerDiff fails the plan when both the apm and
+// integrations_server resources are set, or when integrations_server isn't
+// supported by the stack version.
+func validateApmIntegrationsServerDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.HasChanges("apm", "integrations_server", "version") {
+		return nil
+	}
+
+	var version string
+	if d.NewValueKnown("version") {
+		version, _ = d.Get("version").(string)
+	}
+
+	return validateApmIntegrationsServer(
+		hasResourceBlock(d, "apm"), hasResourceBlock(d, "integrations_server"), version,
+	)
+}
+
+// validateApmIntegrationsServer validates that at most one of the apm and
+// integrations_server resources is set, and that integrations_server is only
+// set on versions which support it. Deployments upgraded to 8.0.0 or above
+// may keep their apm resource, so apm isn't rejected on those versions. The
+// version checks are skipped when the version is unknown.
+func validateApmIntegrationsServer(apm, integrationsServer bool, version string) error {
+	min := stackFeatureVersions["supports_integrations_server"]
+	v, err := semver.Parse(version)
+	knownVersion := err == nil
+
+	switch {
+	case apm && integrationsServer && knownVersion && v.GE(min):
+		return fmt.Errorf(
+			"apm and integrations_server can't both be set: version %s supports integrations_server, remove the apm block", v,
+		)
+	case apm && integrationsServer && knownVersion:
+		return fmt.Errorf(
+			"apm and integrations_server can't both be set: version %s only supports apm, remove the integrations_server block", v,
+		)
+	case apm && integrationsServer:
+		return fmt.Errorf(
+			"apm and integrations_server can't both be set: use integrations_server for versions %s and above, or apm for earlier versions", min,
+		)
+	case !knownVersion:
+		return nil
+	case integrationsServer && v.LT(min):
+		return fmt.Errorf(
+			"integrations_server requires version %s or above, use the apm block for version %s", min, v,
+		)
+	}
+
+	return nil
+}
+
+// hasResourceBlock returns true when the resource kind block is set.
+func hasResourceBlock(d resourceGetter, kind string) bool {
+	raw, ok := d.Get(kind).([]interface{})
+	return ok && len(raw) > 0
+}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateApmIntegrationsServer(t *testing.T) {
+	type args struct {
+		apm                bool
+		integrationsServer bool
+		version            string
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "succeeds without apm nor integrations_server",
+			args: args{version: "8.4.0"},
+		},
+		{
+			name: "succeeds with apm on a 7.x version",
+			args: args{apm: true, version: "7.17.5"},
+		},
+		{
+			name: "succeeds with integrations_server on an 8.x version",
+			args: args{integrationsServer: true, version: "8.0.0"},
+		},
+		{
+			name: "succeeds with apm on an 8.x version",
+			args: args{apm: true, version: "8.4.0"},
+		},
+		{
+			name: "succeeds with integrations_server when the version is unknown",
+			args: args{integrationsServer: true},
+		},
+		{
+			name: "fails with both set on an 8.x version",
+			args: args{apm: true, integrationsServer: true, version: "8.4.0"},
+			err:  errors.New("apm and integrations_server can't both be set: version 8.4.0 supports integrations_server, remove the apm block"),
+		},
+		{
+			name: "fails with both set on a 7.x version",
+			args: args{apm: true, integrationsServer: true, version: "7.17.5"},
+			err:  errors.New("apm and integrations_server can't both be set: version 7.17.5 only supports apm, remove the integrations_server block"),
+		},
+		{
+			name: "fails with both set when the version is unknown",
+			args: args{apm: true, integrationsServer: true},
+			err:  errors.New("apm and integrations_server can't both be set: use integrations_server for versions 8.0.0 and above, or apm for earlier versions"),
+		},
+		{
+			name: "fails with integrations_server on a 7.x version",
+			args: args{integrationsServer: true, version: "7.17.5"},
+			err:  errors.New("integrations_server requires version 8.0.0 or above, use the apm block for version 7.17.5"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateApmIntegrationsServer(
+				tt.args.apm, tt.args.integrationsServer, tt.args.version,
+			)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
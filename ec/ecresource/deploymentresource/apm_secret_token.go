@@ -0,0 +1,81 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const rotateSecretTokenKey = "apm.0.rotate_secret_token"
+
+// rotateSecretTokenDiff marks the apm_secret_token as computed when the
+// "rotate_secret_token" trigger of an existing apm resource changes.
+func rotateSecretTokenDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange(rotateSecretTokenKey) || !hasResourceBlock(d, "apm") {
+		return nil
+	}
+	return d.SetNewComputed("apm_secret_token")
+}
+
+// handleSecretTokenRotation resets the APM secret token when the apm
+// "rotate_secret_token" trigger changes, storing the new token in the state
+// unless the credentials aren't exposed. Setting the trigger when the apm
+// resource is created has no effect.
+func handleSecretTokenRotation(d *schema.ResourceData, client *api.API) error {
+	if d.IsNewResource() || !d.HasChange(rotateSecretTokenKey) || !hasResourceBlock(d, "apm") {
+		return nil
+	}
+
+	res, err := client.V1API.Deployments.DeploymentApmResetSecretToken(
+		deployments.NewDeploymentApmResetSecretTokenParams().
+			WithDeploymentID(d.Id()).
+			WithRefID(resourceRefID(d, "apm")),
+		client.AuthWriter,
+	)
+	if err != nil {
+		return multierror.NewPrefixed("failed rotating the apm secret token", apierror.Wrap(err))
+	}
+
+	if !d.Get("expose_credentials").(bool) || res.Payload == nil || res.Payload.SecretToken == nil {
+		return nil
+	}
+
+	return d.Set("apm_secret_token", *res.Payload.SecretToken)
+}
+
+// flattenRotateSecretToken keeps the apm "rotate_secret_token" trigger in
+// the state, since it isn't returned by the API.
+func flattenRotateSecretToken(d *schema.ResourceData, trigger string) error {
+	if trigger == "" {
+		return nil
+	}
+
+	apm, ok := d.Get("apm").([]interface{})
+	if !ok || len(apm) == 0 || apm[0] == nil {
+		return nil
+	}
+
+	apm[0].(map[string]interface{})["rotate_secret_token"] = trigger
+	return d.Set("apm", apm)
+}
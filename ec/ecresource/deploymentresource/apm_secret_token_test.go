@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_handleSecretTokenRotation(t *testing.T) {
+	newRaw := func(trigger string, expose bool) map[string]interface{} {
+		raw := newSampleLegacyDeployment()
+		raw["expose_credentials"] = expose
+		raw["apm_secret_token"] = "old-token"
+		apm := newApmSample()
+		apm["rotate_secret_token"] = trigger
+		raw["apm"] = []interface{}{apm}
+		return raw
+	}
+	newRD := func(prev, next string, expose bool) *schema.ResourceData {
+		return util.NewResourceData(t, util.ResDataParams{
+			ID:     mock.ValidClusterID,
+			Schema: newSchema(),
+			State:  newRaw(prev, expose),
+			Change: newRaw(next, expose),
+		})
+	}
+
+	resetResponse := mock.New202Response(mock.NewStructBody(models.ApmCrudResponse{
+		ApmID:       "some-apm-id",
+		SecretToken: ec.String("new-token"),
+	}))
+
+	tests := []struct {
+		name      string
+		d         *schema.ResourceData
+		client    *api.API
+		wantToken string
+		err       error
+	}{
+		{
+			name: "does nothing when the trigger is unchanged",
+			d:    newRD("1", "1", true),
+			// Any call would fail with an empty mock.
+			client:    api.NewMock(),
+			wantToken: "old-token",
+		},
+		{
+			name:      "stores the new secret token when the trigger changes",
+			d:         newRD("1", "2", true),
+			client:    api.NewMock(resetResponse),
+			wantToken: "new-token",
+		},
+		{
+			name:      "doesn't store the new secret token when the credentials aren't exposed",
+			d:         newRD("1", "2", false),
+			client:    api.NewMock(resetResponse),
+			wantToken: "old-token",
+		},
+		{
+			name: "returns the error resetting the secret token",
+			d:    newRD("", "1", true),
+			client: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				Code: "some", Message: "message",
+			})),
+			wantToken: "old-token",
+			err:       errors.New("failed rotating the apm secret token: 1 error occurred:\n\t* api error: some: message\n\n"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handleSecretTokenRotation(tt.d, tt.client)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantToken, tt.d.Get("apm_secret_token"))
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+)
+
+// autoscalingBounds are the autoscaling attributes of a topology element
+// which default to the values set by the deployment template.
+var autoscalingBounds = []string{
+	"max_size", "max_size_resource", "min_size", "min_size_resource",
+}
+
+// configuredAutoscaling returns the autoscaling bounds which are explicitly
+// set in the configuration, keyed by topology ID. It returns nil when the
+// configuration isn't available.
+func configuredAutoscaling(config cty.Value) map[string]map[string]bool {
+	if !config.IsKnown() || config.IsNull() {
+		return nil
+	}
+
+	result := make(map[string]map[string]bool)
+	es, ok := firstBlock(getAttr(config, "elasticsearch"))
+	if !ok {
+		return result
+	}
+
+	if topology := getAttr(es, "topology"); isKnownList(topology) {
+		for it := topology.ElementIterator(); it.Next(); {
+			_, elem := it.Element()
+			id := getAttr(elem, "id")
+			if !id.IsKnown() || id.IsNull() {
+				continue
+			}
+			result[id.AsString()] = configuredBounds(elem)
+		}
+	}
+
+	for _, id := range esTierIDs {
+		if tier, ok := firstBlock(getAttr(es, id)); ok {
+			result[id] = configuredBounds(tier)
+		}
+	}
+
+	return result
+}
+
+// configuredBounds returns the autoscaling bounds set in the configuration of
+// a topology element.
+func configuredBounds(elem cty.Value) map[string]bool {
+	result := make(map[string]bool)
+	autoscaling, ok := firstBlock(getAttr(elem, "autoscaling"))
+	if !ok {
+		return result
+	}
+
+	for _, bound := range autoscalingBounds {
+		if v := getAttr(autoscaling, bound); !v.IsNull() {
+			result[bound] = true
+		}
+	}
+
+	return result
+}
+
+// inheritTemplateAutoscaling unsets the autoscaling bounds of the flattened
+// Elasticsearch topology elements which aren't explicitly configured, so the
+// values set by the deployment template are used instead of the ones stored
+// in the state. Nothing is unset when the configured bounds are unknown.
+func inheritTemplateAutoscaling(ess []interface{}, configured map[string]map[string]bool) {
+	if configured == nil {
+		return
+	}
+
+	for _, raw := range ess {
+		es, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if topology, ok := es["topology"].([]interface{}); ok {
+			for _, rawElem := range topology {
+				if elem, ok := rawElem.(map[string]interface{}); ok {
+					id, _ := elem["id"].(string)
+					unsetAutoscalingBounds(elem, configured[id])
+				}
+			}
+		}
+
+		for _, id := range esTierIDs {
+			if tier, ok := es[id].([]interface{}); ok && len(tier) > 0 {
+				if elem, ok := tier[0].(map[string]interface{}); ok {
+					unsetAutoscalingBounds(elem, configured[id])
+				}
+			}
+		}
+	}
+}
+
+func unsetAutoscalingBounds(elem map[string]interface{}, configured map[string]bool) {
+	autoscaling, ok := elem["autoscaling"].([]interface{})
+	if !ok || len(autoscaling) == 0 {
+		return
+	}
+
+	bounds, ok := autoscaling[0].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for _, bound := range autoscalingBounds {
+		if !configured[bound] {
+			delete(bounds, bound)
+		}
+	}
+}
+
+// getAttr returns the named attribute of an object value, or a null value
+// when the value isn't a known object with that attribute.
+func getAttr(v cty.Value, name string) cty.Value {
+	if !v.IsKnown() || v.IsNull() || !v.Type().IsObjectType() || !v.Type().HasAttribute(name) {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	return v.GetAttr(name)
+}
+
+// firstBlock returns the first element of a known list of blocks.
+func firstBlock(v cty.Value) (cty.Value, bool) {
+	if !isKnownList(v) || v.LengthInt() == 0 {
+		return cty.NilVal, false
+	}
+	return v.Index(cty.NumberIntVal(0)), true
+}
+
+func isKnownList(v cty.Value) bool {
+	return v.IsKnown() && !v.IsNull() && (v.Type().IsListType() || v.Type().IsTupleType())
+}
@@ -0,0 +1,155 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_configuredAutoscaling(t *testing.T) {
+	autoscalingType := cty.Object(map[string]cty.Type{
+		"max_size":          cty.String,
+		"max_size_resource": cty.String,
+		"min_size":          cty.String,
+		"min_size_resource": cty.String,
+	})
+	autoscaling := func(maxSize cty.Value) cty.Value {
+		return cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"max_size":          maxSize,
+			"max_size_resource": cty.NullVal(cty.String),
+			"min_size":          cty.NullVal(cty.String),
+			"min_size_resource": cty.NullVal(cty.String),
+		})})
+	}
+
+	tests := []struct {
+		name   string
+		config cty.Value
+		want   map[string]map[string]bool
+	}{
+		{
+			name:   "returns nil when the configuration isn't available",
+			config: cty.NullVal(cty.DynamicPseudoType),
+		},
+		{
+			name: "returns the bounds set in the topology elements",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"elasticsearch": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"topology": cty.ListVal([]cty.Value{
+						cty.ObjectVal(map[string]cty.Value{
+							"id":          cty.StringVal("hot_content"),
+							"autoscaling": autoscaling(cty.StringVal("64g")),
+						}),
+						cty.ObjectVal(map[string]cty.Value{
+							"id":          cty.StringVal("warm"),
+							"autoscaling": cty.ListValEmpty(autoscalingType),
+						}),
+					}),
+				})}),
+			}),
+			want: map[string]map[string]bool{
+				"hot_content": {"max_size": true},
+				"warm":        {},
+			},
+		},
+		{
+			name: "returns the bounds set in the tier blocks",
+			config: cty.ObjectVal(map[string]cty.Value{
+				"elasticsearch": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"hot_content": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+						"autoscaling": autoscaling(cty.NullVal(cty.String)),
+					})}),
+				})}),
+			}),
+			want: map[string]map[string]bool{
+				"hot_content": {},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, configuredAutoscaling(tt.config))
+		})
+	}
+}
+
+func Test_inheritTemplateAutoscaling(t *testing.T) {
+	tplPath := "testdata/template-aws-io-optimized-v2.json"
+	tpl := func() *models.ElasticsearchPayload {
+		return enrichElasticsearchTemplate(
+			esResource(parseDeploymentTemplate(t, tplPath)),
+			"aws-io-optimized-v2",
+			"7.11.0",
+			true,
+		)
+	}
+	ess := func() []interface{} {
+		return []interface{}{map[string]interface{}{
+			"autoscale": "true",
+			"hot_content": []interface{}{map[string]interface{}{
+				"size":          "8g",
+				"size_resource": "memory",
+				"zone_count":    2,
+				"autoscaling": []interface{}{map[string]interface{}{
+					"max_size":          "64g",
+					"max_size_resource": "memory",
+				}},
+			}},
+		}}
+	}
+
+	tests := []struct {
+		name       string
+		configured map[string]map[string]bool
+		want       *models.TopologySize
+	}{
+		{
+			name: "keeps the state values when the configuration isn't available",
+			want: &models.TopologySize{Value: ec.Int32(65536), Resource: ec.String("memory")},
+		},
+		{
+			name:       "keeps the explicitly configured bounds",
+			configured: map[string]map[string]bool{"hot_content": {"max_size": true}},
+			want:       &models.TopologySize{Value: ec.Int32(65536), Resource: ec.String("memory")},
+		},
+		{
+			name:       "uses the template bounds when they aren't configured",
+			configured: map[string]map[string]bool{"hot_content": {}},
+			want:       &models.TopologySize{Value: ec.Int32(118784), Resource: ec.String("memory")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := ess()
+			inheritTemplateAutoscaling(raw, tt.configured)
+
+			got, err := expandEsResources(raw, tpl())
+			assert.NoError(t, err)
+			if assert.Len(t, got, 1) {
+				hot, err := matchEsTopologyID("hot_content", got[0].Plan.ClusterTopology)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, hot.AutoscalingMax)
+			}
+		})
+	}
+}
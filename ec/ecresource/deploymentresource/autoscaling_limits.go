@@ -0,0 +1,193 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deploymentsize"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// validateAutoscalingLimitsDiff fails the plan when autoscaling is enabled
+// and any of the Elasticsearch topology autoscaling sizes are outside of the
+// autoscaling range of the deployment template topology element. The
+// template is only obtained when the elasticsearch block has changed.
+func validateAutoscalingLimitsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, k := range []string{"deployment_template_id", "region", "elasticsearch"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	if !d.HasChange("elasticsearch") {
+		return nil
+	}
+
+	if autoscale, _ := strconv.ParseBool(d.Get("elasticsearch.0.autoscale").(string)); !autoscale {
+		return nil
+	}
+
+	raw, _ := d.Get("elasticsearch").([]interface{})
+	if !hasAutoscalingSizes(raw) {
+		return nil
+	}
+
+	template, err := getDiffTemplate(ctx, meta.(*util.ProviderMeta).API,
+		d.Get("deployment_template_id").(string), d.Get("region").(string),
+	)
+	if err != nil {
+		return multierror.NewPrefixed(
+			"failed obtaining the deployment template autoscaling limits", err,
+		)
+	}
+
+	return validateAutoscalingLimits(raw, template)
+}
+
+// hasAutoscalingSizes returns true when any of the topology elements has an
+// autoscaling max_size or min_size set.
+func hasAutoscalingSizes(raw []interface{}) bool {
+	for _, topology := range rawTopologies("elasticsearch", raw) {
+		autoscaling := topologyAutoscaling(topology)
+		for _, k := range []string{"max_size", "min_size"} {
+			if size, _ := autoscaling[k].(string); size != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateAutoscalingLimits validates the Elasticsearch topology autoscaling
+// sizes against the autoscaling range of the template topology elements:
+// max_size can't be larger than the template maximum, min_size can only be
+// set on the topology elements which scale down, such as ml, and can't be
+// smaller than the template minimum, and min_size can't be larger than
+// max_size. Sizes in a different resource than the template ones are left
+// for the API to validate.
+func validateAutoscalingLimits(raw []interface{}, template *models.DeploymentTemplateInfoV2) error {
+	var tplTopology = make(map[string]*models.ElasticsearchClusterTopologyElement)
+	if res := esResource(template); res != nil && res.Plan != nil {
+		for _, t := range res.Plan.ClusterTopology {
+			tplTopology[t.ID] = t
+		}
+	}
+
+	merr := multierror.NewPrefixed("invalid autoscaling size")
+	for i, topology := range rawTopologies("elasticsearch", raw) {
+		id, _ := topology["id"].(string)
+		tpl, ok := tplTopology[id]
+		if !ok {
+			continue
+		}
+
+		autoscaling := topologyAutoscaling(topology)
+		name := topologyName(topology, i)
+
+		maxSize, err := parseAutoscalingSize(autoscaling, "max")
+		if err != nil {
+			continue
+		}
+		minSize, err := parseAutoscalingSize(autoscaling, "min")
+		if err != nil {
+			continue
+		}
+
+		if maxSize != nil && exceedsLimit(maxSize, tpl.AutoscalingMax, true) {
+			merr = merr.Append(fmt.Errorf(
+				`elasticsearch topology %s: autoscaling max_size "%s" is larger than the deployment template maximum of "%s"`,
+				name, util.MemoryToState(*maxSize.Value), util.MemoryToState(*tpl.AutoscalingMax.Value),
+			))
+		}
+
+		if minSize != nil && *minSize.Value > 0 && tpl.AutoscalingMin == nil {
+			merr = merr.Append(fmt.Errorf(
+				`elasticsearch topology %s: autoscaling min_size can only be set on the topology elements which scale down, such as "ml"`,
+				name,
+			))
+		} else if minSize != nil && exceedsLimit(minSize, tpl.AutoscalingMin, false) {
+			merr = merr.Append(fmt.Errorf(
+				`elasticsearch topology %s: autoscaling min_size "%s" is smaller than the deployment template minimum of "%s"`,
+				name, util.MemoryToState(*minSize.Value), util.MemoryToState(*tpl.AutoscalingMin.Value),
+			))
+		}
+
+		if minSize != nil && maxSize != nil && *minSize.Resource == *maxSize.Resource &&
+			*minSize.Value > *maxSize.Value {
+			merr = merr.Append(fmt.Errorf(
+				`elasticsearch topology %s: autoscaling min_size "%s" is larger than max_size "%s"`,
+				name, util.MemoryToState(*minSize.Value), util.MemoryToState(*maxSize.Value),
+			))
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// topologyAutoscaling returns the flattened autoscaling settings of a
+// topology element.
+func topologyAutoscaling(topology map[string]interface{}) map[string]interface{} {
+	raw, _ := topology["autoscaling"].([]interface{})
+	if len(raw) == 0 {
+		return nil
+	}
+	autoscaling, _ := raw[0].(map[string]interface{})
+	return autoscaling
+}
+
+// parseAutoscalingSize parses the "max" or "min" autoscaling size, returning
+// nil when it's not set.
+func parseAutoscalingSize(autoscaling map[string]interface{}, dimension string) (*models.TopologySize, error) {
+	size, _ := autoscaling[dimension+"_size"].(string)
+	if size == "" {
+		return nil, nil
+	}
+
+	value, err := deploymentsize.ParseGb(size)
+	if err != nil {
+		return nil, err
+	}
+
+	var resource = memorySizeResource
+	if r, ok := autoscaling[dimension+"_size_resource"].(string); ok && r != "" {
+		resource = r
+	}
+
+	return &models.TopologySize{Value: &value, Resource: &resource}, nil
+}
+
+// exceedsLimit returns true when the size is larger than the limit when it's
+// a maximum, or smaller than the limit otherwise. Sizes and limits expressed
+// in different resources aren't compared.
+func exceedsLimit(size, limit *models.TopologySize, maximum bool) bool {
+	if limit == nil || limit.Value == nil || limit.Resource == nil || *limit.Resource != *size.Resource {
+		return false
+	}
+
+	if maximum {
+		return *size.Value > *limit.Value
+	}
+	return *size.Value < *limit.Value
+}
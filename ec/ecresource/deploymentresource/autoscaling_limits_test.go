@@ -0,0 +1,129 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateAutoscalingLimits(t *testing.T) {
+	template := &models.DeploymentTemplateInfoV2{
+		DeploymentTemplate: &models.DeploymentCreateRequest{
+			Resources: &models.DeploymentCreateResources{
+				Elasticsearch: []*models.ElasticsearchPayload{{
+					Plan: &models.ElasticsearchClusterPlan{
+						ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+							{
+								ID: "hot_content",
+								AutoscalingMax: &models.TopologySize{
+									Value: ec.Int32(118784), Resource: ec.String("memory"),
+								},
+							},
+							{
+								ID: "ml",
+								AutoscalingMax: &models.TopologySize{
+									Value: ec.Int32(61440), Resource: ec.String("memory"),
+								},
+								AutoscalingMin: &models.TopologySize{
+									Value: ec.Int32(0), Resource: ec.String("memory"),
+								},
+							},
+						},
+					},
+				}},
+			},
+		},
+	}
+
+	newAutoscaling := func(maxSize, minSize string) []interface{} {
+		return []interface{}{map[string]interface{}{
+			"max_size": maxSize, "min_size": minSize,
+		}}
+	}
+
+	tests := []struct {
+		name string
+		raw  []interface{}
+		err  error
+	}{
+		{
+			name: "succeeds when the sizes are within the template range",
+			raw: []interface{}{map[string]interface{}{
+				"hot_content": []interface{}{map[string]interface{}{
+					"autoscaling": newAutoscaling("116g", ""),
+				}},
+				"ml": []interface{}{map[string]interface{}{
+					"autoscaling": newAutoscaling("60g", "1g"),
+				}},
+			}},
+		},
+		{
+			name: "ignores the sizes in a different resource than the template",
+			raw: []interface{}{map[string]interface{}{
+				"hot_content": []interface{}{map[string]interface{}{
+					"autoscaling": []interface{}{map[string]interface{}{
+						"max_size": "3840g", "max_size_resource": "storage",
+					}},
+				}},
+			}},
+		},
+		{
+			name: "fails when the sizes are outside of the template range",
+			raw: []interface{}{map[string]interface{}{
+				"hot_content": []interface{}{map[string]interface{}{
+					"autoscaling": newAutoscaling("128g", "8g"),
+				}},
+				"ml": []interface{}{map[string]interface{}{
+					"autoscaling": newAutoscaling("64g", ""),
+				}},
+			}},
+			err: multierror.NewPrefixed("invalid autoscaling size",
+				errors.New(`elasticsearch topology "hot_content": autoscaling max_size "128g" is larger than the deployment template maximum of "116g"`),
+				errors.New(`elasticsearch topology "hot_content": autoscaling min_size can only be set on the topology elements which scale down, such as "ml"`),
+				errors.New(`elasticsearch topology "ml": autoscaling max_size "64g" is larger than the deployment template maximum of "60g"`),
+			),
+		},
+		{
+			name: "fails when min_size is larger than max_size",
+			raw: []interface{}{map[string]interface{}{
+				"ml": []interface{}{map[string]interface{}{
+					"autoscaling": newAutoscaling("4g", "8g"),
+				}},
+			}},
+			err: multierror.NewPrefixed("invalid autoscaling size",
+				errors.New(`elasticsearch topology "ml": autoscaling min_size "8g" is larger than max_size "4g"`),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAutoscalingLimits(tt.raw, template)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
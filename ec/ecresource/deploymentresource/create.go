@@ -21,22 +21,25 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
 )
 
 // createResource will createResource a new deployment from the specified settings.
 func createResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api.API)
+	m := meta.(*util.ProviderMeta)
+	client := m.API
 	reqID := deploymentapi.RequestID(d.Get("request_id").(string))
 
-	req, err := createResourceToModel(d, client)
+	req, err := createResourceToModel(ctx, d, m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	addRealms := stripOIDCRealms(req)
 
 	res, err := deploymentapi.Create(deploymentapi.CreateParams{
 		API:       client,
@@ -49,11 +52,14 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		},
 	})
 	if err != nil {
+		if diags := payloadDiagnostics(d, "failed creating deployment", err, req.Resources.Elasticsearch); diags != nil {
+			return append(diags, diag.FromErr(newCreationError(reqID))...)
+		}
 		merr := multierror.NewPrefixed("failed creating deployment", err)
 		return diag.FromErr(merr.Append(newCreationError(reqID)))
 	}
 
-	if err := WaitForPlanCompletion(client, *res.ID); err != nil {
+	if err := waitForPlanCompletion(ctx, client, *res.ID, planTimeouts(d)); err != nil {
 		merr := multierror.NewPrefixed("failed tracking create progress", err)
 		return diag.FromErr(merr.Append(newCreationError(reqID)))
 	}
@@ -68,6 +74,20 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		diags = append(diags, diag.FromErr(err)...)
 	}
 
+	if err := handleMaintenanceMode(d, client); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	if err := handleKeystoreContents(d, client); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	if addRealms {
+		if err := addOIDCRealms(ctx, d, m); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
 	if diag := readResource(ctx, d, meta); diag != nil {
 		diags = append(diags, diags...)
 	}
@@ -76,6 +96,12 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		diags = append(diags, diag.FromErr(err)...)
 	}
 
+	if err := flattenCredentials(d); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	diags = append(diags, deprecatedInstanceConfigurationsWarning(d)...)
+
 	return diags
 }
 
@@ -0,0 +1,45 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// flattenCredentials groups the Elasticsearch cloud_id, the Fleet URL and the
+// credentials stored in the state in the "credentials" attribute, so they can
+// be exposed as a single Terraform output.
+func flattenCredentials(d *schema.ResourceData) error {
+	var cloudID string
+	if v, ok := d.Get("elasticsearch.0.cloud_id").(string); ok {
+		cloudID = v
+	}
+
+	var fleetURL string
+	if v, ok := d.Get("integrations_server.0.fleet_https_endpoint").(string); ok {
+		fleetURL = v
+	}
+
+	return d.Set("credentials", []interface{}{map[string]interface{}{
+		"cloud_id":               cloudID,
+		"elasticsearch_username": d.Get("elasticsearch_username").(string),
+		"elasticsearch_password": d.Get("elasticsearch_password").(string),
+		"apm_secret_token":       d.Get("apm_secret_token").(string),
+		"fleet_url":              fleetURL,
+	}})
+}
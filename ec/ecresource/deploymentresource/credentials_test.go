@@ -0,0 +1,55 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_flattenCredentials(t *testing.T) {
+	rawData := newSampleLegacyDeployment()
+	rawData["elasticsearch_username"] = "elastic"
+	rawData["elasticsearch_password"] = "my-password"
+	rawData["apm_secret_token"] = "some-secret-token"
+	rawData["elasticsearch"] = []interface{}{map[string]interface{}{
+		"cloud_id": "my-deployment:someCloudID",
+	}}
+	rawData["integrations_server"] = []interface{}{map[string]interface{}{
+		"fleet_https_endpoint": "https://my-deployment.fleet.us-east-1.aws.found.io",
+	}}
+
+	d := util.NewResourceData(t, util.ResDataParams{
+		ID:     mock.ValidClusterID,
+		State:  rawData,
+		Schema: newSchema(),
+	})
+
+	assert.NoError(t, flattenCredentials(d))
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"cloud_id":               "my-deployment:someCloudID",
+		"elasticsearch_username": "elastic",
+		"elasticsearch_password": "my-password",
+		"apm_secret_token":       "some-secret-token",
+		"fleet_url":              "https://my-deployment.fleet.us-east-1.aws.found.io",
+	}}, d.Get("credentials"))
+}
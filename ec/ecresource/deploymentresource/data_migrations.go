@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// planDataMigrationsDiff sets "expected_data_migrations" to a summary of the
+// Elasticsearch topology changes which move data between instances, so the
+// data migration can be reviewed and scheduled from the plan output.
+func planDataMigrationsDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	var migrations []string
+	if d.Id() != "" && d.HasChange("elasticsearch") && d.NewValueKnown("elasticsearch") {
+		oldES, newES := d.GetChange("elasticsearch")
+		migrations = dataMigrations(oldES.([]interface{}), newES.([]interface{}))
+	}
+
+	if len(migrations) == 0 {
+		if old, _ := d.Get("expected_data_migrations").([]interface{}); len(old) == 0 {
+			return nil
+		}
+	}
+
+	return d.SetNew("expected_data_migrations", migrations)
+}
+
+// dataMigrations compares the old and new Elasticsearch topology elements by
+// ID and returns the changes which move the data of an element to different
+// instances, which are changes in the instance configuration, the size, or
+// the number of zones.
+func dataMigrations(oldES, newES []interface{}) []string {
+	oldTopology := topologyByID(oldES)
+	var result []string
+	for _, raw := range firstTopology(newES) {
+		elem, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		id, _ := elem["id"].(string)
+		old, ok := oldTopology[id]
+		if !ok {
+			continue
+		}
+
+		var changes []string
+		for _, k := range []string{"instance_configuration_id", "size", "zone_count"} {
+			oldV, newV := fmt.Sprint(old[k]), fmt.Sprint(elem[k])
+			if isUnsetValue(oldV) || isUnsetValue(newV) || oldV == newV {
+				continue
+			}
+			changes = append(changes, fmt.Sprintf(`%s "%s" -> "%s"`, k, oldV, newV))
+		}
+
+		if len(changes) > 0 {
+			result = append(result, fmt.Sprintf(
+				`elasticsearch topology "%s" data will be migrated to new instances: %s`,
+				id, strings.Join(changes, ", "),
+			))
+		}
+	}
+
+	return result
+}
+
+// dataMigrationsWarning returns a warning listing the planned data
+// migrations, or nil when there are none.
+func dataMigrationsWarning(d *schema.ResourceData) diag.Diagnostics {
+	raw, _ := d.Get("expected_data_migrations").([]interface{})
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var migrations []string
+	for _, m := range raw {
+		if s, ok := m.(string); ok {
+			migrations = append(migrations, s)
+		}
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Elasticsearch data has been migrated between instances",
+		Detail:   strings.Join(migrations, "\n"),
+	}}
+}
+
+func firstTopology(es []interface{}) []interface{} {
+	if len(es) == 0 {
+		return nil
+	}
+	m, ok := es[0].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return esTopology(m)
+}
+
+func topologyByID(es []interface{}) map[string]map[string]interface{} {
+	var result = make(map[string]map[string]interface{})
+	for _, raw := range firstTopology(es) {
+		if elem, ok := raw.(map[string]interface{}); ok {
+			id, _ := elem["id"].(string)
+			result[id] = elem
+		}
+	}
+	return result
+}
+
+func isUnsetValue(v string) bool {
+	return v == "" || v == "0" || v == "<nil>"
+}
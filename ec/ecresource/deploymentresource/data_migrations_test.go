@@ -0,0 +1,87 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_dataMigrations(t *testing.T) {
+	newES := func(topology ...interface{}) []interface{} {
+		return []interface{}{map[string]interface{}{"topology": topology}}
+	}
+	hot := func(ic, size string, zones int) map[string]interface{} {
+		return map[string]interface{}{
+			"id":                        "hot_content",
+			"instance_configuration_id": ic,
+			"size":                      size,
+			"zone_count":                zones,
+		}
+	}
+
+	tests := []struct {
+		name  string
+		oldES []interface{}
+		newES []interface{}
+		want  []string
+	}{
+		{
+			name:  "returns nothing when the topology doesn't change",
+			oldES: newES(hot("aws.data.highio.i3", "8g", 2)),
+			newES: newES(hot("aws.data.highio.i3", "8g", 2)),
+		},
+		{
+			name:  "returns the instance configuration and size changes",
+			oldES: newES(hot("aws.data.highio.i3", "8g", 2)),
+			newES: newES(hot("aws.data.highstorage.d3", "16g", 2)),
+			want: []string{
+				`elasticsearch topology "hot_content" data will be migrated to new instances: instance_configuration_id "aws.data.highio.i3" -> "aws.data.highstorage.d3", size "8g" -> "16g"`,
+			},
+		},
+		{
+			name:  "returns the zone count changes",
+			oldES: newES(hot("aws.data.highio.i3", "8g", 2)),
+			newES: newES(hot("aws.data.highio.i3", "8g", 3)),
+			want: []string{
+				`elasticsearch topology "hot_content" data will be migrated to new instances: zone_count "2" -> "3"`,
+			},
+		},
+		{
+			name:  "ignores values which aren't set in the new topology",
+			oldES: newES(hot("aws.data.highio.i3", "8g", 2)),
+			newES: newES(hot("", "", 0)),
+		},
+		{
+			name:  "ignores new topology elements",
+			oldES: newES(hot("aws.data.highio.i3", "8g", 2)),
+			newES: newES(hot("aws.data.highio.i3", "8g", 2), map[string]interface{}{
+				"id":                        "warm",
+				"instance_configuration_id": "aws.data.highstorage.d2",
+				"size":                      "4g",
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dataMigrations(tt.oldES, tt.newES)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
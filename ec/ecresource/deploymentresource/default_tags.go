@@ -0,0 +1,84 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// defaultTagsDiff plans "tags_all" as the provider "default_tags" merged with
+// the resource "tags", so the tags which will be set in the deployment are
+// shown in the plan.
+func defaultTagsDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	var defaults map[string]string
+	if m, ok := meta.(*util.ProviderMeta); ok {
+		defaults = m.DefaultTags
+	}
+	tags, _ := d.Get("tags").(map[string]interface{})
+	merged := mergeTags(defaults, tags)
+
+	if old, _ := d.Get("tags_all").(map[string]interface{}); reflect.DeepEqual(old, merged) {
+		return nil
+	}
+
+	return d.SetNew("tags_all", merged)
+}
+
+// mergeTags merges the default tags with the resource tags, which take
+// precedence over the default tags.
+func mergeTags(defaults map[string]string, tags map[string]interface{}) map[string]interface{} {
+	var result = make(map[string]interface{}, len(defaults)+len(tags))
+	for k, v := range defaults {
+		result[k] = v
+	}
+	for k, v := range tags {
+		result[k] = v
+	}
+	return result
+}
+
+// flattenDefaultTags sets "tags_all" to all the deployment tags and removes
+// the tags which match the default tags from "tags", unless they're set in
+// the resource tags, so the default tags don't show as drift.
+func flattenDefaultTags(d *schema.ResourceData, configured map[string]interface{}, defaults map[string]string) error {
+	remote, _ := d.Get("tags").(map[string]interface{})
+	if err := d.Set("tags_all", remote); err != nil {
+		return err
+	}
+
+	var tags = make(map[string]interface{}, len(remote))
+	for k, v := range remote {
+		if _, ok := configured[k]; !ok {
+			if dv, ok := defaults[k]; ok && dv == v {
+				continue
+			}
+		}
+		tags[k] = v
+	}
+
+	return d.Set("tags", tags)
+}
@@ -0,0 +1,84 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_mergeTags(t *testing.T) {
+	got := mergeTags(
+		map[string]string{"cost-center": "engineering", "owner": "platform"},
+		map[string]interface{}{"owner": "search", "env": "production"},
+	)
+	assert.Equal(t, map[string]interface{}{
+		"cost-center": "engineering",
+		"owner":       "search",
+		"env":         "production",
+	}, got)
+}
+
+func Test_flattenDefaultTags(t *testing.T) {
+	defaults := map[string]string{"cost-center": "engineering", "owner": "platform"}
+	tests := []struct {
+		name       string
+		remote     map[string]interface{}
+		configured map[string]interface{}
+		wantTags   map[string]interface{}
+	}{
+		{
+			name: "removes the default tags from the resource tags",
+			remote: map[string]interface{}{
+				"cost-center": "engineering", "owner": "platform", "env": "production",
+			},
+			configured: map[string]interface{}{"env": "production"},
+			wantTags:   map[string]interface{}{"env": "production"},
+		},
+		{
+			name: "keeps the default tags which are overridden or set in the resource tags",
+			remote: map[string]interface{}{
+				"cost-center": "engineering", "owner": "search",
+			},
+			configured: map[string]interface{}{"cost-center": "engineering"},
+			wantTags:   map[string]interface{}{"cost-center": "engineering", "owner": "search"},
+		},
+		{
+			name:   "unsets the resource tags when all of them are default tags",
+			remote: map[string]interface{}{"cost-center": "engineering"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, newSchema(), nil)
+			_ = d.Set("tags", tt.remote)
+
+			err := flattenDefaultTags(d, tt.configured, defaults)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.remote, d.Get("tags_all"))
+			if tt.wantTags == nil {
+				assert.Empty(t, d.Get("tags"))
+			} else {
+				assert.Equal(t, tt.wantTags, d.Get("tags"))
+			}
+		})
+	}
+}
@@ -22,13 +22,14 @@ import (
 	"errors"
 	"strings"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
 	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
 )
 
 // Delete shuts down and deletes the remote deployment retrying up to 3 times
@@ -39,7 +40,22 @@ func deleteResource(ctx context.Context, d *schema.ResourceData, meta interface{
 	const maxRetries = 3
 	var retries int
 	timeout := d.Timeout(schema.TimeoutDelete)
-	client := meta.(*api.API)
+	client := meta.(*util.ProviderMeta).API
+
+	// Terminated deployments can still be restored, leave them in place.
+	if d.Get("skip_destroy_if_terminated").(bool) && d.Get("terminated").(bool) {
+		d.SetId("")
+		return nil
+	}
+
+	destroyMode := d.Get("destroy_mode").(string)
+	if destroyMode == hibernateDestroyMode {
+		if err := hibernate(d, client); err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId("")
+		return nil
+	}
 
 	return diag.FromErr(resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		if _, err := deploymentapi.Shutdown(deploymentapi.ShutdownParams{
@@ -54,7 +70,7 @@ func deleteResource(ctx context.Context, d *schema.ResourceData, meta interface{
 			))
 		}
 
-		if err := WaitForPlanCompletion(client, d.Id()); err != nil {
+		if err := waitForPlanCompletion(ctx, client, d.Id(), planTimeouts(d)); err != nil {
 			if shouldRetryShutdown(err, retries, maxRetries) {
 				retries++
 				return resource.RetryableError(err)
@@ -66,6 +82,12 @@ func deleteResource(ctx context.Context, d *schema.ResourceData, meta interface{
 			return resource.NonRetryableError(err)
 		}
 
+		// The shut down deployment is kept so it can be restored.
+		if destroyMode == shutdownKeepDestroyMode {
+			d.SetId("")
+			return nil
+		}
+
 		// We don't particularly care if delete succeeds or not. It's better to
 		// remove it, but it might fail on ESS. For example, when user's aren't
 		// allowed to delete deployments, or on ECE when the cluster is "still
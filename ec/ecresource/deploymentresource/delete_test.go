@@ -22,7 +22,6 @@ import (
 	"errors"
 	"testing"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -56,6 +55,21 @@ func Test_deleteResource(t *testing.T) {
 	})
 	wantTC404.SetId("")
 
+	terminatedState := newSampleLegacyDeployment()
+	terminatedState["skip_destroy_if_terminated"] = true
+	terminatedState["terminated"] = true
+	tcTerminated := util.NewResourceData(t, util.ResDataParams{
+		ID:     mock.ValidClusterID,
+		State:  terminatedState,
+		Schema: newSchema(),
+	})
+	wantTCTerminated := util.NewResourceData(t, util.ResDataParams{
+		ID:     mock.ValidClusterID,
+		State:  terminatedState,
+		Schema: newSchema(),
+	})
+	wantTCTerminated.SetId("")
+
 	type args struct {
 		d    *schema.ResourceData
 		meta interface{}
@@ -70,7 +84,7 @@ func Test_deleteResource(t *testing.T) {
 			name: "returns an error when it receives a 500",
 			args: args{
 				d: tc500Err,
-				meta: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				meta: util.NewMockMeta(mock.NewErrorResponse(500, mock.APIError{
 					Code: "some", Message: "message",
 				})),
 			},
@@ -86,13 +100,24 @@ func Test_deleteResource(t *testing.T) {
 			name: "returns nil and unsets the state when the error is known",
 			args: args{
 				d: tc404Err,
-				meta: api.NewMock(mock.NewErrorResponse(404, mock.APIError{
+				meta: util.NewMockMeta(mock.NewErrorResponse(404, mock.APIError{
 					Code: "some", Message: "message",
 				})),
 			},
 			want:   nil,
 			wantRD: wantTC404,
 		},
+		{
+			name: "unsets the state without shutting down a terminated deployment when skip_destroy_if_terminated is set",
+			args: args{
+				d: tcTerminated,
+				meta: util.NewMockMeta(mock.NewErrorResponse(500, mock.APIError{
+					Code: "some", Message: "message",
+				})),
+			},
+			want:   nil,
+			wantRD: wantTCTerminated,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
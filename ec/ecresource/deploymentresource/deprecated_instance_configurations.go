@@ -0,0 +1,215 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/instanceconfigapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// replacementMetadataPrefix prefixes the deployment template metadata keys
+// which hold the suggested replacement of a deprecated instance
+// configuration, i.e. "instance_configuration_replacement:aws.data.highio.i3".
+const replacementMetadataPrefix = "instance_configuration_replacement:"
+
+// deprecatedInstanceConfigurationsDiff sets "deprecated_instance_configurations"
+// to the topology elements which use an instance configuration deprecated in
+// the deployment region, so the deprecation is visible in the plan output.
+// The instance configurations are only obtained when the deployment template,
+// the region or any of the resources change. Since it's only informational,
+// any failure obtaining them is logged and the previous value is kept.
+func deprecatedInstanceConfigurationsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChanges(append([]string{"deployment_template_id", "region"}, resourceKinds...)...) {
+		return nil
+	}
+
+	for _, k := range []string{"deployment_template_id", "region"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	var resources = make(map[string][]interface{})
+	for _, kind := range resourceKinds {
+		if !d.NewValueKnown(kind) {
+			return nil
+		}
+		if raw, _ := d.Get(kind).([]interface{}); len(raw) > 0 {
+			resources[kind] = raw
+		}
+	}
+
+	var deprecations []string
+	if len(resources) > 0 {
+		var err error
+		if deprecations, err = getDeprecatedInstanceConfigurations(
+			ctx, meta.(*util.ProviderMeta).API, d.Get("deployment_template_id").(string),
+			d.Get("region").(string), resources,
+		); err != nil {
+			tflog.Warn(ctx, "failed checking for deprecated instance configurations", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return nil
+		}
+	}
+
+	if len(deprecations) == 0 {
+		if old, _ := d.Get("deprecated_instance_configurations").([]interface{}); len(old) == 0 {
+			return nil
+		}
+	}
+
+	return d.SetNew("deprecated_instance_configurations", deprecations)
+}
+
+// getDeprecatedInstanceConfigurations obtains the deployment template and the
+// instance configurations used by the resources, and returns the topology
+// elements which use a deprecated one.
+func getDeprecatedInstanceConfigurations(ctx context.Context, client *api.API, templateID, region string, resources map[string][]interface{}) ([]string, error) {
+	template, err := getDiffTemplate(ctx, client, templateID, region)
+	if err != nil {
+		return nil, multierror.NewPrefixed(
+			"failed obtaining the deployment template instance configurations", err,
+		)
+	}
+
+	var deprecated = make(map[string]bool)
+	for _, id := range usedInstanceConfigurations(resources, template) {
+		ic, err := instanceconfigapi.Get(instanceconfigapi.GetParams{
+			API:    client,
+			ID:     id,
+			Region: region,
+		})
+		if err != nil {
+			return nil, multierror.NewPrefixed(
+				fmt.Sprintf(`failed obtaining the "%s" instance configuration`, id), err,
+			)
+		}
+		deprecated[id] = isDeprecatedInstanceConfiguration(ic)
+	}
+
+	return deprecatedInstanceConfigurations(resources, template, deprecated), nil
+}
+
+// usedInstanceConfigurations returns the distinct instance configuration IDs
+// of the resources topology elements, in order of appearance.
+func usedInstanceConfigurations(resources map[string][]interface{}, template *models.DeploymentTemplateInfoV2) []string {
+	var result []string
+	var seen = make(map[string]bool)
+	for _, kind := range resourceKinds {
+		tplICs := templateInstanceConfigurations(kind, template)
+		for i, topology := range rawTopologies(kind, resources[kind]) {
+			icID := topologyInstanceConfiguration(topology, i, tplICs)
+			if icID == "" || seen[icID] {
+				continue
+			}
+			seen[icID] = true
+			result = append(result, icID)
+		}
+	}
+	return result
+}
+
+// isDeprecatedInstanceConfiguration returns true when the instance
+// configuration has been deleted, or its metadata marks it as deprecated.
+func isDeprecatedInstanceConfiguration(ic *models.InstanceConfiguration) bool {
+	if ic == nil {
+		return false
+	}
+	if ic.DeletedOn != nil {
+		return true
+	}
+	if meta, ok := ic.Metadata.(map[string]interface{}); ok {
+		deprecated, _ := meta["deprecated"].(bool)
+		return deprecated
+	}
+	return false
+}
+
+// deprecatedInstanceConfigurations returns a message for each of the topology
+// elements which use a deprecated instance configuration, including the
+// replacement suggested by the template metadata when there's one.
+func deprecatedInstanceConfigurations(resources map[string][]interface{}, template *models.DeploymentTemplateInfoV2, deprecated map[string]bool) []string {
+	var replacements = make(map[string]string)
+	if template != nil {
+		for _, item := range template.Metadata {
+			if item == nil || item.Key == nil || item.Value == nil {
+				continue
+			}
+			if id := strings.TrimPrefix(*item.Key, replacementMetadataPrefix); id != *item.Key {
+				replacements[id] = *item.Value
+			}
+		}
+	}
+
+	var result []string
+	for _, kind := range resourceKinds {
+		tplICs := templateInstanceConfigurations(kind, template)
+		for i, topology := range rawTopologies(kind, resources[kind]) {
+			icID := topologyInstanceConfiguration(topology, i, tplICs)
+			if !deprecated[icID] {
+				continue
+			}
+
+			msg := fmt.Sprintf(
+				`%s topology %s: instance configuration "%s" is deprecated`,
+				kind, topologyName(topology, i), icID,
+			)
+			if replacement := replacements[icID]; replacement != "" {
+				msg += fmt.Sprintf(`, use "%s" instead`, replacement)
+			}
+			result = append(result, msg)
+		}
+	}
+
+	return result
+}
+
+// deprecatedInstanceConfigurationsWarning returns a warning listing the
+// topology elements which use a deprecated instance configuration, or nil
+// when there are none.
+func deprecatedInstanceConfigurationsWarning(d *schema.ResourceData) diag.Diagnostics {
+	raw, _ := d.Get("deprecated_instance_configurations").([]interface{})
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var deprecations []string
+	for _, m := range raw {
+		if s, ok := m.(string); ok {
+			deprecations = append(deprecations, s)
+		}
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "The deployment uses deprecated instance configurations",
+		Detail:   strings.Join(deprecations, "\n"),
+	}}
+}
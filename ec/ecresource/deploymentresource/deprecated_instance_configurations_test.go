@@ -0,0 +1,172 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_deprecatedInstanceConfigurations(t *testing.T) {
+	template := &models.DeploymentTemplateInfoV2{
+		DeploymentTemplate: &models.DeploymentCreateRequest{
+			Resources: &models.DeploymentCreateResources{
+				Elasticsearch: []*models.ElasticsearchPayload{{
+					Plan: &models.ElasticsearchClusterPlan{
+						ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+							{ID: "hot_content", InstanceConfigurationID: "aws.data.highio.i3"},
+							{ID: "ml", InstanceConfigurationID: "aws.ml.m5d"},
+						},
+					},
+				}},
+				Kibana: []*models.KibanaPayload{{
+					Plan: &models.KibanaClusterPlan{
+						ClusterTopology: []*models.KibanaClusterTopologyElement{
+							{InstanceConfigurationID: "aws.kibana.r5d"},
+						},
+					},
+				}},
+			},
+		},
+		Metadata: []*models.MetadataItem{
+			{Key: ec.String("instance_configuration_replacement:aws.data.highio.i3"), Value: ec.String("aws.es.datahot.c6gd")},
+			{Key: ec.String("parent_solution"), Value: ec.String("stack")},
+		},
+	}
+
+	resources := map[string][]interface{}{
+		"elasticsearch": {map[string]interface{}{
+			"topology": []interface{}{
+				map[string]interface{}{"id": "hot_content", "size": "4g"},
+				map[string]interface{}{"id": "ml", "size": "1g"},
+			},
+		}},
+		"kibana": {map[string]interface{}{
+			"topology": []interface{}{
+				map[string]interface{}{"size": "1g"},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		deprecated map[string]bool
+		want       []string
+	}{
+		{
+			name: "returns nothing when no instance configuration is deprecated",
+			deprecated: map[string]bool{
+				"aws.data.highio.i3": false, "aws.ml.m5d": false, "aws.kibana.r5d": false,
+			},
+		},
+		{
+			name: "returns the deprecated instance configurations with their replacement",
+			deprecated: map[string]bool{
+				"aws.data.highio.i3": true, "aws.ml.m5d": false, "aws.kibana.r5d": true,
+			},
+			want: []string{
+				`elasticsearch topology "hot_content": instance configuration "aws.data.highio.i3" is deprecated, use "aws.es.datahot.c6gd" instead`,
+				`kibana topology [0]: instance configuration "aws.kibana.r5d" is deprecated`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := deprecatedInstanceConfigurations(resources, template, tt.deprecated)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("reuses the deployment template obtained in the same plan", func(t *testing.T) {
+		client := api.NewMock(
+			mock.New200StructResponse(template),
+			mock.New200StructResponse(models.InstanceConfiguration{ID: "aws.data.highio.i3"}),
+			mock.New200StructResponse(models.InstanceConfiguration{ID: "aws.ml.m5d"}),
+			mock.New200StructResponse(models.InstanceConfiguration{
+				ID:       "aws.kibana.r5d",
+				Metadata: map[string]interface{}{"deprecated": true},
+			}),
+		)
+
+		fn := func(ctx context.Context, _ *schema.ResourceDiff, meta interface{}) error {
+			_, err := getDiffTemplate(ctx, meta.(*util.ProviderMeta).API, "aws-io-optimized-v2", "us-east-1")
+			assert.NoError(t, err)
+
+			got, err := getDeprecatedInstanceConfigurations(
+				ctx, meta.(*util.ProviderMeta).API, "aws-io-optimized-v2", "us-east-1", resources,
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{
+				`kibana topology [0]: instance configuration "aws.kibana.r5d" is deprecated`,
+			}, got)
+			return err
+		}
+
+		assert.NoError(t, withDiffLookups(fn)(context.Background(), nil, &util.ProviderMeta{API: client}))
+	})
+
+	t.Run("returns the distinct used instance configurations", func(t *testing.T) {
+		assert.Equal(t,
+			[]string{"aws.data.highio.i3", "aws.ml.m5d", "aws.kibana.r5d"},
+			usedInstanceConfigurations(resources, template),
+		)
+	})
+}
+
+func Test_isDeprecatedInstanceConfiguration(t *testing.T) {
+	deletedOn := strfmt.DateTime{}
+	tests := []struct {
+		name string
+		ic   *models.InstanceConfiguration
+		want bool
+	}{
+		{name: "nil instance configuration"},
+		{
+			name: "instance configuration without metadata",
+			ic:   &models.InstanceConfiguration{ID: "aws.data.highio.i3"},
+		},
+		{
+			name: "deleted instance configuration",
+			ic:   &models.InstanceConfiguration{ID: "aws.data.highio.i3", DeletedOn: &deletedOn},
+			want: true,
+		},
+		{
+			name: "instance configuration marked as deprecated",
+			ic: &models.InstanceConfiguration{
+				ID:       "aws.data.highio.i3",
+				Metadata: map[string]interface{}{"deprecated": true},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isDeprecatedInstanceConfiguration(tt.ic))
+		})
+	}
+}
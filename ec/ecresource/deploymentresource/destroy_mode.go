@@ -0,0 +1,67 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/depresourceapi"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// terminateDestroyMode shuts down the deployment and deletes it.
+	terminateDestroyMode = "terminate"
+	// shutdownKeepDestroyMode shuts down the deployment without deleting it,
+	// so it can be restored afterwards.
+	shutdownKeepDestroyMode = "shutdown_keep"
+	// hibernateDestroyMode stops the instances of the deployment resources,
+	// keeping their data in place so they can be started again.
+	hibernateDestroyMode = "hibernate"
+)
+
+var destroyModes = []string{
+	terminateDestroyMode, shutdownKeepDestroyMode, hibernateDestroyMode,
+}
+
+// hibernate stops all the instances of each of the deployment resources.
+func hibernate(d *schema.ResourceData, client *api.API) error {
+	merr := multierror.NewPrefixed("failed hibernating the deployment")
+	for _, kind := range resourceKinds {
+		refID := resourceRefID(d, kind)
+		if refID == "" {
+			continue
+		}
+
+		if _, err := depresourceapi.Stop(depresourceapi.StopParams{
+			Params: depresourceapi.Params{
+				API:          client,
+				DeploymentID: d.Id(),
+				Kind:         kind,
+				RefID:        refID,
+			},
+			All: true,
+		}); err != nil {
+			merr = merr.Append(fmt.Errorf("%s: %w", kind, err))
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
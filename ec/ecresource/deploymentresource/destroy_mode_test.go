@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_hibernate(t *testing.T) {
+	newRD := func() *schema.ResourceData {
+		return util.NewResourceData(t, util.ResDataParams{
+			ID:     mock.ValidClusterID,
+			Schema: newSchema(),
+			State: map[string]interface{}{
+				"destroy_mode": "hibernate",
+				"elasticsearch": []interface{}{map[string]interface{}{
+					"ref_id": "main-elasticsearch",
+				}},
+				"kibana": []interface{}{map[string]interface{}{
+					"ref_id": "main-kibana",
+				}},
+			},
+		})
+	}
+
+	tests := []struct {
+		name   string
+		client *api.API
+		err    error
+	}{
+		{
+			name: "stops the instances of each resource",
+			client: api.NewMock(
+				mock.New202Response(mock.NewStringBody("{}")),
+				mock.New202Response(mock.NewStringBody("{}")),
+			),
+		},
+		{
+			name: "returns the API errors",
+			client: api.NewMock(
+				mock.New202Response(mock.NewStringBody("{}")),
+				mock.NewErrorResponse(500, mock.APIError{
+					Code: "some", Message: "message",
+				}),
+			),
+			err: errors.New("failed hibernating the deployment: 1 error occurred:\n\t* kibana: api error: 1 error occurred:\n\t* some: message\n\n\n\n"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := hibernate(newRD(), tt.client)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("destroying the resource hibernates the deployment", func(t *testing.T) {
+		d := newRD()
+		diags := deleteResource(context.Background(), d, util.NewMockMeta(
+			mock.New202Response(mock.NewStringBody("{}")),
+			mock.New202Response(mock.NewStringBody("{}")),
+		))
+		assert.Nil(t, diags)
+		assert.Empty(t, d.Id())
+	})
+}
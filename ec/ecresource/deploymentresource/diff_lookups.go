@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"sync"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deptemplateapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/stackapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type diffLookupsKey struct{}
+
+// diffLookups holds the deployment templates and stack versions obtained
+// while a plan is computed, so the CustomizeDiff functions which validate the
+// configuration against them share a single API call. Failed lookups are kept
+// as well, so they aren't retried by each of the functions.
+type diffLookups struct {
+	mu        sync.Mutex
+	templates map[[2]string]templateLookup
+	stacks    map[[2]string]stackLookup
+}
+
+type templateLookup struct {
+	template *models.DeploymentTemplateInfoV2
+	err      error
+}
+
+type stackLookup struct {
+	stack *models.StackVersionConfig
+	err   error
+}
+
+// withDiffLookups returns a CustomizeDiffFunc which calls fn with a context
+// holding new diff lookups, shared by any of the functions it's composed of.
+func withDiffLookups(fn schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		lookups := &diffLookups{
+			templates: make(map[[2]string]templateLookup),
+			stacks:    make(map[[2]string]stackLookup),
+		}
+		return fn(context.WithValue(ctx, diffLookupsKey{}, lookups), d, meta)
+	}
+}
+
+// getDiffTemplate obtains the deployment template, including its instance
+// configurations. It's only obtained once per plan when the context holds
+// diff lookups.
+func getDiffTemplate(ctx context.Context, client *api.API, templateID, region string) (*models.DeploymentTemplateInfoV2, error) {
+	get := func() (*models.DeploymentTemplateInfoV2, error) {
+		return deptemplateapi.Get(deptemplateapi.GetParams{
+			API:        client,
+			TemplateID: templateID,
+			Region:     region,
+		})
+	}
+
+	lookups, ok := ctx.Value(diffLookupsKey{}).(*diffLookups)
+	if !ok {
+		return get()
+	}
+
+	lookups.mu.Lock()
+	defer lookups.mu.Unlock()
+
+	key := [2]string{templateID, region}
+	if res, ok := lookups.templates[key]; ok {
+		return res.template, res.err
+	}
+
+	template, err := get()
+	lookups.templates[key] = templateLookup{template: template, err: err}
+	return template, err
+}
+
+// getDiffStack obtains the stack version. It's only obtained once per plan
+// when the context holds diff lookups.
+func getDiffStack(ctx context.Context, client *api.API, version, region string) (*models.StackVersionConfig, error) {
+	get := func() (*models.StackVersionConfig, error) {
+		return stackapi.Get(stackapi.GetParams{
+			API:     client,
+			Region:  region,
+			Version: version,
+		})
+	}
+
+	lookups, ok := ctx.Value(diffLookupsKey{}).(*diffLookups)
+	if !ok {
+		return get()
+	}
+
+	lookups.mu.Lock()
+	defer lookups.mu.Unlock()
+
+	key := [2]string{version, region}
+	if res, ok := lookups.stacks[key]; ok {
+		return res.stack, res.err
+	}
+
+	stack, err := get()
+	lookups.stacks[key] = stackLookup{stack: stack, err: err}
+	return stack, err
+}
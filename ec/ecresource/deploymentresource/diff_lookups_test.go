@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_withDiffLookups(t *testing.T) {
+	// Only one response of each is mocked, any further API call fails.
+	client := api.NewMock(
+		mock.New200StructResponse(models.DeploymentTemplateInfoV2{
+			ID: ec.String("aws-io-optimized-v2"),
+		}),
+		mock.New200StructResponse(models.StackVersionConfig{
+			Version: "8.4.3",
+		}),
+	)
+
+	fn := func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		for i := 0; i < 2; i++ {
+			template, err := getDiffTemplate(ctx, meta.(*util.ProviderMeta).API, "aws-io-optimized-v2", "us-east-1")
+			if !assert.NoError(t, err) {
+				return err
+			}
+			assert.Equal(t, "aws-io-optimized-v2", *template.ID)
+		}
+
+		for i := 0; i < 2; i++ {
+			stack, err := getDiffStack(ctx, meta.(*util.ProviderMeta).API, "8.4.3", "us-east-1")
+			if !assert.NoError(t, err) {
+				return err
+			}
+			assert.Equal(t, "8.4.3", stack.Version)
+		}
+		return nil
+	}
+
+	assert.NoError(t, withDiffLookups(fn)(context.Background(), nil, &util.ProviderMeta{API: client}))
+}
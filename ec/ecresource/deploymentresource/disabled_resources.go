@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+// A Kibana, APM, Integrations Server or Enterprise Search resource whose
+// topology elements are all sized "0g" is provisioned but disabled. Such
+// resources are stopped by the API, but they're kept in the state with their
+// zero sized topology, so they can be disabled and enabled again without
+// adding and removing their block.
+
+// isZeroSized returns true when none of the sizes are greater than zero.
+func isZeroSized(sizes ...*models.TopologySize) bool {
+	for _, size := range sizes {
+		if size != nil && size.Value != nil && *size.Value > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func isDisabledKibanaPlan(plan *models.KibanaClusterPlan) bool {
+	if plan == nil || len(plan.ClusterTopology) == 0 {
+		return false
+	}
+
+	var sizes []*models.TopologySize
+	for _, t := range plan.ClusterTopology {
+		sizes = append(sizes, t.Size)
+	}
+	return isZeroSized(sizes...)
+}
+
+func isDisabledApmPlan(plan *models.ApmPlan) bool {
+	if plan == nil || len(plan.ClusterTopology) == 0 {
+		return false
+	}
+
+	var sizes []*models.TopologySize
+	for _, t := range plan.ClusterTopology {
+		sizes = append(sizes, t.Size)
+	}
+	return isZeroSized(sizes...)
+}
+
+func isDisabledIntegrationsServerPlan(plan *models.IntegrationsServerPlan) bool {
+	if plan == nil || len(plan.ClusterTopology) == 0 {
+		return false
+	}
+
+	var sizes []*models.TopologySize
+	for _, t := range plan.ClusterTopology {
+		sizes = append(sizes, t.Size)
+	}
+	return isZeroSized(sizes...)
+}
+
+func isDisabledEssPlan(plan *models.EnterpriseSearchPlan) bool {
+	if plan == nil || len(plan.ClusterTopology) == 0 {
+		return false
+	}
+
+	var sizes []*models.TopologySize
+	for _, t := range plan.ClusterTopology {
+		sizes = append(sizes, t.Size)
+	}
+	return isZeroSized(sizes...)
+}
@@ -0,0 +1,362 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+const (
+	defaultDockerRegistry = "registry-1.docker.io"
+	defaultDockerTag      = "latest"
+
+	// dockerRegistryTimeout is the timeout of the container registry requests.
+	dockerRegistryTimeout = 30 * time.Second
+)
+
+var (
+	// dockerImageKeys are all the attributes which override the docker image
+	// of a deployment resource.
+	dockerImageKeys = []string{
+		"elasticsearch.0.config.0.docker_image",
+		"kibana.0.config.0.docker_image",
+		"apm.0.config.0.docker_image",
+		"integrations_server.0.config.0.docker_image",
+		"enterprise_search.0.config.0.docker_image",
+	}
+
+	// manifestMediaTypes are the accepted image manifest media types.
+	manifestMediaTypes = []string{
+		"application/vnd.docker.distribution.manifest.v2+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+		"application/vnd.oci.image.manifest.v1+json",
+		"application/vnd.oci.image.index.v1+json",
+	}
+)
+
+// verifyDockerImagesDiff fails the plan when "verify_docker_images" is set
+// and any of the changed docker_image overrides can't be found in its
+// container registry, preventing plans from failing after the cluster has
+// been built.
+func verifyDockerImagesDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("verify_docker_images").(bool) {
+		return nil
+	}
+
+	// The registries are reached with the provider proxy and TLS settings.
+	m, _ := meta.(*util.ProviderMeta)
+	verifier := dockerImageVerifier{
+		client: m.HTTPClient(dockerRegistryTimeout),
+		auths:  expandDockerRegistryAuths(d.Get("docker_registry_auth").([]interface{})),
+	}
+
+	merr := multierror.NewPrefixed("invalid docker_image override")
+	for _, key := range dockerImageKeys {
+		if !d.HasChange(key) || !d.NewValueKnown(key) {
+			continue
+		}
+
+		image, ok := d.Get(key).(string)
+		if !ok || image == "" {
+			continue
+		}
+
+		if err := verifier.verify(ctx, image); err != nil {
+			merr = merr.Append(fmt.Errorf("%s: %w", key, err))
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// dockerRegistryAuth are the credentials of a container registry.
+type dockerRegistryAuth struct {
+	username string
+	password string
+}
+
+// expandDockerRegistryAuths returns the "docker_registry_auth" credentials by
+// registry address.
+func expandDockerRegistryAuths(raw []interface{}) map[string]dockerRegistryAuth {
+	auths := make(map[string]dockerRegistryAuth, len(raw))
+	for _, r := range raw {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		address, _ := m["address"].(string)
+		username, _ := m["username"].(string)
+		password, _ := m["password"].(string)
+		auths[address] = dockerRegistryAuth{username: username, password: password}
+	}
+	return auths
+}
+
+// dockerImageVerifier verifies that the docker images exist in their
+// container registry.
+type dockerImageVerifier struct {
+	client *http.Client
+	// auths are the credentials by registry address. The registries without
+	// credentials are queried anonymously.
+	auths map[string]dockerRegistryAuth
+}
+
+// dockerImageRef is a parsed docker image reference.
+type dockerImageRef struct {
+	registry   string
+	repository string
+	reference  string
+}
+
+// parseDockerImage parses a docker image in the
+// "[registry[:port]/]repository[:tag|@digest]" notation.
+func parseDockerImage(image string) (dockerImageRef, error) {
+	var ref = dockerImageRef{registry: defaultDockerRegistry}
+	if image == "" || strings.ContainsAny(image, " \t\n") {
+		return ref, fmt.Errorf(`invalid docker image "%s"`, image)
+	}
+
+	name := image
+	if parts := strings.SplitN(image, "/", 2); len(parts) == 2 {
+		// The first component is a registry when it contains a "." or ":"
+		// or is "localhost", otherwise it's part of the repository.
+		if strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost" {
+			ref.registry = parts[0]
+			name = parts[1]
+		}
+	}
+
+	if i := strings.Index(name, "@"); i > 0 {
+		ref.repository, ref.reference = name[:i], name[i+1:]
+	} else if i := strings.LastIndex(name, ":"); i > 0 {
+		ref.repository, ref.reference = name[:i], name[i+1:]
+	} else {
+		ref.repository, ref.reference = name, defaultDockerTag
+	}
+
+	if ref.repository == "" || ref.reference == "" {
+		return ref, fmt.Errorf(`invalid docker image "%s"`, image)
+	}
+
+	// Official Docker Hub images live under the "library" namespace.
+	if ref.registry == defaultDockerRegistry && !strings.Contains(ref.repository, "/") {
+		ref.repository = "library/" + ref.repository
+	}
+
+	return ref, nil
+}
+
+// verify checks that the image manifest exists in the registry by sending a
+// HEAD request to the Docker Registry HTTP API. When the registry requires
+// authentication, the request is retried with the registry credentials, or
+// with a bearer token obtained with them, which is anonymous when there are
+// no credentials for the registry.
+func (v dockerImageVerifier) verify(ctx context.Context, image string) error {
+	ref, err := parseDockerImage(image)
+	if err != nil {
+		return err
+	}
+
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s",
+		ref.registry, ref.repository, ref.reference,
+	)
+
+	res, err := v.headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode == http.StatusUnauthorized {
+		authorization, err := v.authorize(ctx, ref.registry, res.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return fmt.Errorf(`failed authenticating to registry "%s": %w`, ref.registry, err)
+		}
+		if res, err = v.headManifest(ctx, manifestURL, authorization); err != nil {
+			return err
+		}
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf(`failed verifying docker image "%s": not authorized by registry "%s", set its credentials in docker_registry_auth`,
+			image, ref.registry,
+		)
+	case http.StatusNotFound:
+		return fmt.Errorf(`docker image "%s" not found in registry "%s"`, image, ref.registry)
+	default:
+		return fmt.Errorf(`failed verifying docker image "%s": registry "%s" returned status %d`,
+			image, ref.registry, res.StatusCode,
+		)
+	}
+}
+
+func (v dockerImageVerifier) headManifest(ctx context.Context, manifestURL, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	res, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying the container registry: %w", err)
+	}
+	res.Body.Close()
+
+	return res, nil
+}
+
+// authorize returns the Authorization header answering the registry
+// authentication challenge. A "Basic" challenge is answered with the registry
+// credentials, while a "Bearer" one is answered with a token obtained from the
+// advertised token service.
+func (v dockerImageVerifier) authorize(ctx context.Context, registry, challenge string) (string, error) {
+	auth, hasAuth := v.auths[registry]
+	switch {
+	case strings.HasPrefix(challenge, "Basic "):
+		if !hasAuth {
+			return "", errors.New("the registry requires credentials, set them in docker_registry_auth")
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString(
+			[]byte(auth.username+":"+auth.password),
+		), nil
+	case strings.HasPrefix(challenge, "Bearer "):
+		var creds *dockerRegistryAuth
+		if hasAuth {
+			creds = &auth
+		}
+		token, err := v.token(ctx, challenge, creds)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	default:
+		return "", fmt.Errorf(`unsupported authentication challenge "%s"`, challenge)
+	}
+}
+
+// token obtains a pull token from the token service advertised in a
+// 'Bearer realm="...",service="...",scope="..."' challenge, authenticating
+// with the credentials when set, or anonymously otherwise.
+func (v dockerImageVerifier) token(ctx context.Context, challenge string, creds *dockerRegistryAuth) (string, error) {
+	params := parseChallengeParams(strings.TrimPrefix(challenge, "Bearer "))
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf(`invalid authentication realm "%s"`, params["realm"])
+	}
+
+	query := realm.Query()
+	for _, k := range []string{"service", "scope"} {
+		if v := params[k]; v != "" {
+			query.Set(k, v)
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if creds != nil {
+		req.SetBasicAuth(creds.username, creds.password)
+	}
+
+	res, err := v.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token service returned status %d", res.StatusCode)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", err
+	}
+
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// parseChallengeParams parses the comma separated key=value parameters of an
+// authentication challenge. The values may be quoted strings which contain
+// commas, such as 'scope="repository:some/image:pull,push"'.
+func parseChallengeParams(s string) map[string]string {
+	params := make(map[string]string)
+	for {
+		s = strings.TrimLeft(s, " ,")
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			return params
+		}
+
+		key := strings.ToLower(strings.TrimSpace(s[:eq]))
+		s = strings.TrimLeft(s[eq+1:], " ")
+
+		if !strings.HasPrefix(s, `"`) {
+			end := strings.IndexByte(s, ',')
+			if end < 0 {
+				end = len(s)
+			}
+			params[key] = strings.TrimSpace(s[:end])
+			s = s[end:]
+			continue
+		}
+
+		var value strings.Builder
+		i := 1
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+			}
+			value.WriteByte(s[i])
+		}
+		if i < len(s) {
+			i++
+		}
+		params[key] = value.String()
+		s = s[i:]
+	}
+}
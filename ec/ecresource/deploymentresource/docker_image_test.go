@@ -0,0 +1,238 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseDockerImage(t *testing.T) {
+	tests := []struct {
+		name  string
+		image string
+		want  dockerImageRef
+		err   error
+	}{
+		{
+			name:  "parses an image with a registry and a tag",
+			image: "docker.elastic.co/cloud-assets/elasticsearch:8.4.0",
+			want: dockerImageRef{
+				registry:   "docker.elastic.co",
+				repository: "cloud-assets/elasticsearch",
+				reference:  "8.4.0",
+			},
+		},
+		{
+			name:  "parses an image with a registry port and a digest",
+			image: "localhost:5000/elasticsearch@sha256:abcdef",
+			want: dockerImageRef{
+				registry:   "localhost:5000",
+				repository: "elasticsearch",
+				reference:  "sha256:abcdef",
+			},
+		},
+		{
+			name:  "parses a Docker Hub image without tag",
+			image: "elasticsearch",
+			want: dockerImageRef{
+				registry:   "registry-1.docker.io",
+				repository: "library/elasticsearch",
+				reference:  "latest",
+			},
+		},
+		{
+			name:  "parses a Docker Hub image with a namespace",
+			image: "elastic/elasticsearch:8.4.0",
+			want: dockerImageRef{
+				registry:   "registry-1.docker.io",
+				repository: "elastic/elasticsearch",
+				reference:  "8.4.0",
+			},
+		},
+		{
+			name:  "fails on an image with whitespace",
+			image: "elastic/elasticsearch: 8.4.0",
+			err:   errors.New(`invalid docker image "elastic/elasticsearch: 8.4.0"`),
+		},
+		{
+			name:  "fails on an image with an empty tag",
+			image: "elastic/elasticsearch:",
+			err:   errors.New(`invalid docker image "elastic/elasticsearch:"`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDockerImage(tt.image)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_dockerImageVerifier_verify(t *testing.T) {
+	var registry *httptest.Server
+	registry = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, hasAuth := r.BasicAuth()
+		switch {
+		case r.URL.Path == "/token" && hasAuth:
+			if username != "some-user" || password != "some-password" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"token":"private-token"}`)
+		case r.URL.Path == "/token":
+			fmt.Fprint(w, `{"token":"some-token"}`)
+		case strings.HasPrefix(r.URL.Path, "/v2/private/") && r.Header.Get("Authorization") != "Bearer private-token",
+			!strings.HasPrefix(r.URL.Path, "/v2/private/") && r.Header.Get("Authorization") != "Bearer some-token":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(
+				`Bearer realm="%s/token",service="registry",scope="repository:cloud/elasticsearch:pull"`,
+				registry.URL,
+			))
+			w.WriteHeader(http.StatusUnauthorized)
+		case strings.HasSuffix(r.URL.Path, "/elasticsearch/manifests/8.4.0"):
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer registry.Close()
+
+	host := strings.TrimPrefix(registry.URL, "https://")
+	tests := []struct {
+		name  string
+		auths map[string]dockerRegistryAuth
+		image string
+		err   error
+	}{
+		{
+			name:  "succeeds when the image exists",
+			image: host + "/cloud/elasticsearch:8.4.0",
+		},
+		{
+			name:  "fails when the image doesn't exist",
+			image: host + "/cloud/elasticsearch:8.4.1",
+			err:   fmt.Errorf(`docker image "%s/cloud/elasticsearch:8.4.1" not found in registry "%s"`, host, host),
+		},
+		{
+			name: "succeeds with the registry credentials when the image is private",
+			auths: map[string]dockerRegistryAuth{
+				host: {username: "some-user", password: "some-password"},
+			},
+			image: host + "/private/elasticsearch:8.4.0",
+		},
+		{
+			name:  "fails without the registry credentials when the image is private",
+			image: host + "/private/elasticsearch:8.4.0",
+			err: fmt.Errorf(`failed verifying docker image "%s/private/elasticsearch:8.4.0": not authorized by registry "%s", set its credentials in docker_registry_auth`,
+				host, host,
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := dockerImageVerifier{client: registry.Client(), auths: tt.auths}
+			err := v.verify(context.Background(), tt.image)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_dockerImageVerifier_authorize(t *testing.T) {
+	v := dockerImageVerifier{auths: map[string]dockerRegistryAuth{
+		"registry.example.com": {username: "some-user", password: "some-password"},
+	}}
+
+	got, err := v.authorize(context.Background(), "registry.example.com", `Basic realm="registry"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "Basic c29tZS11c2VyOnNvbWUtcGFzc3dvcmQ=", got)
+
+	_, err = v.authorize(context.Background(), "other.example.com", `Basic realm="registry"`)
+	assert.EqualError(t, err, "the registry requires credentials, set them in docker_registry_auth")
+
+	_, err = v.authorize(context.Background(), "registry.example.com", `Digest realm="registry"`)
+	assert.EqualError(t, err, `unsupported authentication challenge "Digest realm="registry""`)
+}
+
+func Test_expandDockerRegistryAuths(t *testing.T) {
+	got := expandDockerRegistryAuths([]interface{}{
+		map[string]interface{}{
+			"address":  "registry.example.com",
+			"username": "some-user",
+			"password": "some-password",
+		},
+	})
+	assert.Equal(t, map[string]dockerRegistryAuth{
+		"registry.example.com": {username: "some-user", password: "some-password"},
+	}, got)
+}
+
+func Test_parseChallengeParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		challenge string
+		want      map[string]string
+	}{
+		{
+			name:      "parses the quoted parameters",
+			challenge: `realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/ubuntu:pull"`,
+			want: map[string]string{
+				"realm":   "https://auth.docker.io/token",
+				"service": "registry.docker.io",
+				"scope":   "repository:library/ubuntu:pull",
+			},
+		},
+		{
+			name:      "keeps the commas of the quoted values",
+			challenge: `realm="https://auth.docker.io/token", scope="repository:library/ubuntu:pull,push", service="registry.docker.io"`,
+			want: map[string]string{
+				"realm":   "https://auth.docker.io/token",
+				"service": "registry.docker.io",
+				"scope":   "repository:library/ubuntu:pull,push",
+			},
+		},
+		{
+			name:      "parses the unquoted and escaped values",
+			challenge: `Realm=https://registry.example.com/token,error="insufficient \"scope\""`,
+			want: map[string]string{
+				"realm": "https://registry.example.com/token",
+				"error": `insufficient "scope"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseChallengeParams(tt.challenge))
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deploymentsize"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/elastic/terraform-provider-ec/ec/internal/util"
@@ -38,15 +39,21 @@ const (
 	dataTierRolePrefix   = "data_"
 	ingestDataTierRole   = "ingest"
 	masterDataTierRole   = "master"
+	mlRole               = "ml"
 	autodetect           = "autodetect"
 	growAndShrink        = "grow_and_shrink"
 	rollingGrowAndShrink = "rolling_grow_and_shrink"
 	rollingAll           = "rolling_all"
+	rolling              = "rolling"
 )
 
+// defaultDataRoles are the roles given to a topology element which had the
+// legacy node_type_data set to true but no data roles in its template.
+var defaultDataRoles = []string{"data_hot", "data_content"}
+
 // List of update strategies availables.
 var strategiesList = []string{
-	autodetect, growAndShrink, rollingGrowAndShrink, rollingAll,
+	autodetect, growAndShrink, rollingGrowAndShrink, rollingAll, rolling,
 }
 
 // expandEsResources expands Elasticsearch resources
@@ -83,7 +90,7 @@ func expandEsResource(raw interface{}, res *models.ElasticsearchPayload) (*model
 	// >= 6.6.0 which is when ILM is introduced in Elasticsearch.
 	unsetElasticsearchCuration(res)
 
-	if rt, ok := es["topology"].([]interface{}); ok && len(rt) > 0 {
+	if rt := esTopology(es); len(rt) > 0 {
 		topology, err := expandEsTopology(rt, res.Plan.ClusterTopology)
 		if err != nil {
 			return nil, err
@@ -101,6 +108,10 @@ func expandEsResource(raw interface{}, res *models.ElasticsearchPayload) (*model
 		}
 	}
 
+	if err := expandSecurityRealms(es, res.Plan.Elasticsearch); err != nil {
+		return nil, err
+	}
+
 	if snap, ok := es["snapshot_source"].([]interface{}); ok && len(snap) > 0 {
 		res.Plan.Transient = &models.TransientElasticsearchPlanConfiguration{
 			RestoreSnapshot: &models.RestoreSnapshotConfiguration{},
@@ -134,18 +145,54 @@ func expandEsResource(raw interface{}, res *models.ElasticsearchPayload) (*model
 		expandExternalTrust(trust.List(), res.Settings)
 	}
 
-	if strategy, ok := es["strategy"].([]interface{}); ok && len(strategy) > 0 {
+	if repo, ok := es["snapshot_repository"].(string); ok && repo != "" {
+		if res.Settings == nil {
+			res.Settings = &models.ElasticsearchClusterSettings{}
+		}
+		expandSnapshotRepository(repo, res.Settings)
+	}
+
+	if snapshot, ok := es["snapshot"].([]interface{}); ok && len(snapshot) > 0 {
+		if res.Settings == nil {
+			res.Settings = &models.ElasticsearchClusterSettings{}
+		}
+		expandSnapshotSettings(snapshot, res.Settings)
+	}
+
+	if threshold, ok := es["dedicated_masters_threshold"].(int); ok && threshold > 0 {
+		if res.Settings == nil {
+			res.Settings = &models.ElasticsearchClusterSettings{}
+		}
+		res.Settings.DedicatedMastersThreshold = int32(threshold)
+	}
+
+	if strategy := expandPlanStrategy(es["strategy"]); strategy != nil {
 		if res.Plan.Transient == nil {
-			res.Plan.Transient = &models.TransientElasticsearchPlanConfiguration{
-				Strategy: &models.PlanStrategy{},
-			}
+			res.Plan.Transient = &models.TransientElasticsearchPlanConfiguration{}
 		}
-		expandStrategy(strategy, res.Plan.Transient.Strategy)
+		res.Plan.Transient.Strategy = strategy
 	}
 
 	return res, nil
 }
 
+// expandPlanStrategy returns the plan strategy set in a resource kind's
+// strategy block, or nil when no strategy is set.
+func expandPlanStrategy(raw interface{}) *models.PlanStrategy {
+	rawStrategy, ok := raw.([]interface{})
+	if !ok || len(rawStrategy) == 0 {
+		return nil
+	}
+
+	var strategy models.PlanStrategy
+	expandStrategy(rawStrategy, &strategy)
+	if strategy == (models.PlanStrategy{}) {
+		return nil
+	}
+
+	return &strategy
+}
+
 // expandStrategy expands the Configuration Strategy.
 func expandStrategy(raw []interface{}, strategy *models.PlanStrategy) {
 	for _, rawStrategy := range raw {
@@ -169,6 +216,10 @@ func expandStrategy(raw []interface{}, strategy *models.PlanStrategy) {
 			strategy.Rolling = &models.RollingStrategyConfig{
 				GroupBy: "__all__",
 			}
+		} else if rawValue == rolling {
+			strategy.Rolling = &models.RollingStrategyConfig{
+				GroupBy: "__zone__",
+			}
 		}
 	}
 }
@@ -212,6 +263,14 @@ func expandEsTopology(rawTopologies []interface{}, topologies []*models.Elastics
 		if nrSet, ok := topology["node_roles"].(*schema.Set); ok && nrSet.Len() > 0 {
 			elem.NodeRoles = util.ItemsToString(nrSet.List())
 			elem.NodeType = nil
+		} else if elem.NodeType == nil && len(elem.NodeRoles) > 0 {
+			// The topology element is being migrated to node_roles, any
+			// legacy node_type_* settings are converted to their roles.
+			roles, err := legacyNodeTypeToRoles(topology, elem.NodeRoles)
+			if err != nil {
+				return nil, err
+			}
+			elem.NodeRoles = roles
 		}
 
 		if autoscalingRaw, ok := topology["autoscaling"].([]interface{}); ok && len(autoscalingRaw) > 0 {
@@ -351,6 +410,10 @@ func expandSnapshotSource(raw []interface{}, restore *models.RestoreSnapshotConf
 			restore.SourceClusterID = clusterID
 		}
 
+		if repository, ok := rs["repository_name"].(string); ok {
+			restore.RepositoryName = repository
+		}
+
 		if snapshotName, ok := rs["snapshot_name"].(string); ok {
 			restore.SnapshotName = ec.String(snapshotName)
 		}
@@ -451,6 +514,71 @@ func parseLegacyNodeType(topology map[string]interface{}, nodeType *models.Elast
 	return nil
 }
 
+// legacyNodeTypeToRoles converts the legacy node_type_* settings into the
+// equivalent node roles, using the template's node roles as the base. Only
+// the node types which are explicitly set modify the resulting roles, which
+// keep the template order.
+func legacyNodeTypeToRoles(topology map[string]interface{}, roles []string) ([]string, error) {
+	result := make([]string, len(roles))
+	copy(result, roles)
+
+	for _, nt := range []struct{ key, role string }{
+		{key: "node_type_master", role: masterDataTierRole},
+		{key: "node_type_ingest", role: ingestDataTierRole},
+		{key: "node_type_ml", role: mlRole},
+	} {
+		enabled, ok, err := parseNodeTypeValue(topology, nt.key)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		if !enabled {
+			result = removeItemFromSlice(result, nt.role)
+		} else if !slice.HasString(result, nt.role) {
+			result = append(result, nt.role)
+		}
+	}
+
+	enabled, ok, err := parseNodeTypeValue(topology, "node_type_data")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		var dataRoles []string
+		for _, role := range result {
+			if strings.HasPrefix(role, dataTierRolePrefix) {
+				dataRoles = append(dataRoles, role)
+			}
+		}
+		if !enabled {
+			for _, role := range dataRoles {
+				result = removeItemFromSlice(result, role)
+			}
+		} else if len(dataRoles) == 0 {
+			result = append(result, defaultDataRoles...)
+		}
+	}
+
+	return result, nil
+}
+
+func parseNodeTypeValue(topology map[string]interface{}, key string) (value, ok bool, err error) {
+	raw, ok := topology[key].(string)
+	if !ok || raw == "" {
+		return false, false, nil
+	}
+
+	value, err = strconv.ParseBool(raw)
+	if err != nil {
+		return false, false, fmt.Errorf("failed parsing %s value: %w", key, err)
+	}
+
+	return value, true, nil
+}
+
 func updateNodeRolesOnDedicatedTiers(topologies []*models.ElasticsearchClusterTopologyElement) {
 	dataTier, hasMasterTier, hasIngestTier := dedicatedTopoogies(topologies)
 	// This case is not very likely since all deployments will have a data tier.
@@ -895,7 +895,7 @@ func Test_expandEsResource(t *testing.T) {
 			}),
 		},
 		{
-			name: "migrates old node_type state to new node_roles payload when the cold tier is set",
+			name: "migrates old node_type state to the equivalent node_roles payload when the cold tier is set",
 			args: args{
 				dt: hotWarm7111Tpl(),
 				ess: []interface{}{map[string]interface{}{
@@ -952,12 +952,9 @@ func Test_expandEsResource(t *testing.T) {
 								Value:    ec.Int32(4096),
 							},
 							NodeRoles: []string{
-								"master",
-								"ingest",
 								"remote_cluster_client",
-								"data_hot",
 								"transform",
-								"data_content",
+								"ml",
 							},
 							TopologyElementControl: &models.TopologyElementControl{
 								Min: &models.TopologySize{
@@ -986,6 +983,7 @@ func Test_expandEsResource(t *testing.T) {
 							NodeRoles: []string{
 								"data_warm",
 								"remote_cluster_client",
+								"master",
 							},
 							TopologyElementControl: &models.TopologyElementControl{
 								Min: &models.TopologySize{
@@ -1772,6 +1770,70 @@ func Test_expandEsResource(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "parses an ES resource with a dedicated_masters_threshold override",
+			args: args{
+				dt: tp770(),
+				ess: []interface{}{
+					map[string]interface{}{
+						"ref_id":                      "main-elasticsearch",
+						"resource_id":                 mock.ValidClusterID,
+						"region":                      "some-region",
+						"dedicated_masters_threshold": 3,
+						"topology": []interface{}{map[string]interface{}{
+							"id":         "hot_content",
+							"size":       "2g",
+							"zone_count": 1,
+						}},
+					},
+				},
+			},
+			want: enrichWithEmptyTopologies(tp770(), &models.ElasticsearchPayload{
+				Region: ec.String("some-region"),
+				RefID:  ec.String("main-elasticsearch"),
+				Settings: &models.ElasticsearchClusterSettings{
+					DedicatedMastersThreshold: 3,
+				},
+				Plan: &models.ElasticsearchClusterPlan{
+					AutoscalingEnabled: ec.Bool(false),
+					Elasticsearch: &models.ElasticsearchConfiguration{
+						Version: "7.7.0",
+					},
+					DeploymentTemplate: &models.DeploymentTemplateReference{
+						ID: ec.String("aws-io-optimized-v2"),
+					},
+					ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+						{
+							ID:                      "hot_content",
+							ZoneCount:               1,
+							InstanceConfigurationID: "aws.data.highio.i3",
+							Size: &models.TopologySize{
+								Resource: ec.String("memory"),
+								Value:    ec.Int32(2048),
+							},
+							NodeType: &models.ElasticsearchNodeType{
+								Data:   ec.Bool(true),
+								Ingest: ec.Bool(true),
+								Master: ec.Bool(true),
+							},
+							Elasticsearch: &models.ElasticsearchConfiguration{
+								NodeAttributes: map[string]string{"data": "hot"},
+							},
+							TopologyElementControl: &models.TopologyElementControl{
+								Min: &models.TopologySize{
+									Resource: ec.String("memory"),
+									Value:    ec.Int32(1024),
+								},
+							},
+							AutoscalingMax: &models.TopologySize{
+								Value:    ec.Int32(118784),
+								Resource: ec.String("memory"),
+							},
+						},
+					},
+				},
+			}),
+		},
 		{
 			name: "parse autodetect configuration strategy",
 			args: args{
@@ -2090,3 +2152,151 @@ func Test_expandEsResource(t *testing.T) {
 		})
 	}
 }
+
+func Test_legacyNodeTypeToRoles(t *testing.T) {
+	hotRoles := []string{
+		"master", "ingest", "remote_cluster_client", "data_hot",
+		"transform", "data_content",
+	}
+	type args struct {
+		topology map[string]interface{}
+		roles    []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+		err  error
+	}{
+		{
+			name: "keeps the template roles when no node types are set",
+			args: args{
+				topology: map[string]interface{}{"id": "hot_content"},
+				roles:    hotRoles,
+			},
+			want: hotRoles,
+		},
+		{
+			name: "keeps the template order when the node types are enabled",
+			args: args{
+				topology: map[string]interface{}{
+					"node_type_data":   "true",
+					"node_type_master": "true",
+					"node_type_ingest": "true",
+				},
+				roles: hotRoles,
+			},
+			want: hotRoles,
+		},
+		{
+			name: "removes the master and ingest roles when disabled",
+			args: args{
+				topology: map[string]interface{}{
+					"node_type_master": "false",
+					"node_type_ingest": "false",
+				},
+				roles: hotRoles,
+			},
+			want: []string{
+				"remote_cluster_client", "data_hot", "transform", "data_content",
+			},
+		},
+		{
+			name: "adds the ml role when enabled",
+			args: args{
+				topology: map[string]interface{}{"node_type_ml": "true"},
+				roles:    []string{"master"},
+			},
+			want: []string{"master", "ml"},
+		},
+		{
+			name: "converts a dedicated master tier",
+			args: args{
+				topology: map[string]interface{}{
+					"node_type_data":   "false",
+					"node_type_master": "true",
+					"node_type_ingest": "false",
+					"node_type_ml":     "false",
+				},
+				roles: []string{"master"},
+			},
+			want: []string{"master"},
+		},
+		{
+			name: "removes all the data roles when node_type_data is false",
+			args: args{
+				topology: map[string]interface{}{"node_type_data": "false"},
+				roles:    hotRoles,
+			},
+			want: []string{
+				"master", "ingest", "remote_cluster_client", "transform",
+			},
+		},
+		{
+			name: "adds the default data roles when node_type_data is true",
+			args: args{
+				topology: map[string]interface{}{"node_type_data": "true"},
+				roles:    []string{"ingest", "remote_cluster_client"},
+			},
+			want: []string{
+				"ingest", "remote_cluster_client", "data_hot", "data_content",
+			},
+		},
+		{
+			name: "returns an error on an invalid node type value",
+			args: args{
+				topology: map[string]interface{}{"node_type_ml": "nope"},
+				roles:    hotRoles,
+			},
+			err: errors.New(`failed parsing node_type_ml value: strconv.ParseBool: parsing "nope": invalid syntax`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := legacyNodeTypeToRoles(tt.args.topology, tt.args.roles)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_expandAutoscalingDimension(t *testing.T) {
+	tests := []struct {
+		name      string
+		autoscale map[string]interface{}
+		dimension string
+		want      *models.TopologySize
+	}{
+		{
+			name:      "expands a zero min_size",
+			autoscale: map[string]interface{}{"min_size": "0g"},
+			dimension: "min",
+			want:      &models.TopologySize{Value: ec.Int32(0), Resource: ec.String("memory")},
+		},
+		{
+			name:      "expands a zero min_size with its resource",
+			autoscale: map[string]interface{}{"min_size": "0g", "min_size_resource": "memory"},
+			dimension: "min",
+			want:      &models.TopologySize{Value: ec.Int32(0), Resource: ec.String("memory")},
+		},
+		{
+			name:      "leaves an unset dimension empty",
+			autoscale: map[string]interface{}{"min_size": "0g"},
+			dimension: "max",
+			want:      &models.TopologySize{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(models.TopologySize)
+			err := expandAutoscalingDimension(tt.autoscale, got, tt.dimension)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
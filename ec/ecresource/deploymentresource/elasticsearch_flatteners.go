@@ -52,6 +52,10 @@ func flattenEsResources(in []*models.ElasticsearchResourceInfo, name string, rem
 			m["region"] = *res.Region
 		}
 
+		if inMaintenanceMode(res.Info.Topology) {
+			m["maintenance_mode"] = true
+		}
+
 		plan := res.Info.PlanInfo.Current.Plan
 		topology, err := flattenEsTopology(plan)
 		if err != nil {
@@ -100,6 +104,18 @@ func flattenEsResources(in []*models.ElasticsearchResourceInfo, name string, rem
 			if trust := flattenExternalTrust(settings.Trust); trust != nil {
 				m["trust_external"] = trust
 			}
+
+			if repo := flattenSnapshotRepository(settings.Snapshot); repo != "" {
+				m["snapshot_repository"] = repo
+			}
+
+			if snapshot := flattenSnapshotSettings(settings.Snapshot); len(snapshot) > 0 {
+				m["snapshot"] = snapshot
+			}
+
+			if settings.DedicatedMastersThreshold > 0 {
+				m["dedicated_masters_threshold"] = int(settings.DedicatedMastersThreshold)
+			}
 		}
 
 		result = append(result, m)
@@ -135,8 +151,11 @@ func flattenEsTopology(plan *models.ElasticsearchClusterPlan) ([]interface{}, er
 		// 	m["size"] = strconv.Itoa(int(topology.MemoryPerNode))
 		// }
 
-		if topology.Size != nil {
+		if topology.Size != nil && topology.Size.Value != nil {
 			m["size"] = util.MemoryToState(*topology.Size.Value)
+		}
+
+		if topology.Size != nil && topology.Size.Resource != nil {
 			m["size_resource"] = *topology.Size.Resource
 		}
 
@@ -168,13 +187,27 @@ func flattenEsTopology(plan *models.ElasticsearchClusterPlan) ([]interface{}, er
 
 		autoscaling := make(map[string]interface{})
 		if ascale := topology.AutoscalingMax; ascale != nil {
-			autoscaling["max_size_resource"] = *ascale.Resource
-			autoscaling["max_size"] = util.MemoryToState(*ascale.Value)
+			if ascale.Resource != nil {
+				autoscaling["max_size_resource"] = *ascale.Resource
+			}
+			if ascale.Value != nil {
+				autoscaling["max_size"] = util.MemoryToState(*ascale.Value)
+			}
 		}
 
+		// A minimum size without a value is a zero floor, such as the one of
+		// the ML tier which scales down to zero. It's kept as "0g" rather than
+		// being unset, so a configured zero minimum size doesn't drift.
 		if ascale := topology.AutoscalingMin; ascale != nil {
-			autoscaling["min_size_resource"] = *ascale.Resource
-			autoscaling["min_size"] = util.MemoryToState(*ascale.Value)
+			if ascale.Resource != nil {
+				autoscaling["min_size_resource"] = *ascale.Resource
+			}
+
+			var minSize int32
+			if ascale.Value != nil {
+				minSize = *ascale.Value
+			}
+			autoscaling["min_size"] = util.MemoryToState(minSize)
 		}
 
 		if topology.AutoscalingPolicyOverrideJSON != nil {
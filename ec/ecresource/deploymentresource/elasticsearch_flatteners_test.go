@@ -67,6 +67,9 @@ func Test_flattenEsResource(t *testing.T) {
 						ClusterID: &mock.ValidClusterID,
 						Region:    "some-region",
 						Status:    ec.String("started"),
+						Settings: &models.ElasticsearchClusterSettings{
+							DedicatedMastersThreshold: 6,
+						},
 						Metadata: &models.ClusterMetadataInfo{
 							CloudID:  "some CLOUD ID",
 							Endpoint: "somecluster.cloud.elastic.co",
@@ -149,13 +152,14 @@ func Test_flattenEsResource(t *testing.T) {
 			}},
 			want: []interface{}{
 				map[string]interface{}{
-					"ref_id":         "main-elasticsearch",
-					"resource_id":    mock.ValidClusterID,
-					"region":         "some-region",
-					"cloud_id":       "some CLOUD ID",
-					"http_endpoint":  "http://somecluster.cloud.elastic.co:9200",
-					"https_endpoint": "https://somecluster.cloud.elastic.co:9243",
-					"config":         func() []interface{} { return nil }(),
+					"ref_id":                      "main-elasticsearch",
+					"resource_id":                 mock.ValidClusterID,
+					"region":                      "some-region",
+					"cloud_id":                    "some CLOUD ID",
+					"http_endpoint":               "http://somecluster.cloud.elastic.co:9200",
+					"https_endpoint":              "https://somecluster.cloud.elastic.co:9243",
+					"dedicated_masters_threshold": 6,
+					"config":                      func() []interface{} { return nil }(),
 					"topology": []interface{}{
 						map[string]interface{}{
 							"config":                    func() []interface{} { return nil }(),
@@ -377,6 +381,46 @@ func Test_flattenEsTopology(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "keeps a zero autoscaling min_size without a value",
+			args: args{plan: &models.ElasticsearchClusterPlan{
+				AutoscalingEnabled: ec.Bool(true),
+				ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+					{
+						ID:                      "ml",
+						ZoneCount:               1,
+						InstanceConfigurationID: "aws.ml.m5",
+						Size: &models.TopologySize{
+							Value: ec.Int32(0), Resource: ec.String("memory"),
+						},
+						AutoscalingMax: &models.TopologySize{
+							Value: ec.Int32(8192), Resource: ec.String("memory"),
+						},
+						AutoscalingMin: &models.TopologySize{
+							Resource: ec.String("memory"),
+						},
+					},
+				},
+			}},
+			want: []interface{}{
+				map[string]interface{}{
+					"config":                    func() []interface{} { return nil }(),
+					"id":                        "ml",
+					"instance_configuration_id": "aws.ml.m5",
+					"size":                      "0g",
+					"size_resource":             "memory",
+					"zone_count":                int32(1),
+					"autoscaling": []interface{}{
+						map[string]interface{}{
+							"max_size":          "8g",
+							"max_size_resource": "memory",
+							"min_size":          "0g",
+							"min_size_resource": "memory",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -37,7 +37,7 @@ func handleRemoteClusters(d *schema.ResourceData, client *api.API) error {
 	return esremoteclustersapi.Update(esremoteclustersapi.UpdateParams{
 		API:             client,
 		DeploymentID:    d.Id(),
-		RefID:           d.Get("elasticsearch.0.ref_id").(string),
+		RefID:           resourceRefID(d, "elasticsearch"),
 		RemoteResources: remoteResources,
 	})
 }
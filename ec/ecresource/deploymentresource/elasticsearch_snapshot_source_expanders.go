@@ -0,0 +1,118 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+// resolveSnapshotSource populates the "source_elasticsearch_cluster_id" of the
+// "snapshot_source" blocks which reference a "source_deployment_id" with the
+// source deployment's Elasticsearch cluster ID. Since the default snapshot
+// repository is regional, restoring a snapshot taken in a different region
+// requires "repository_name" to be set to a repository readable from both.
+func resolveSnapshotSource(client *api.API, ess []interface{}, region string) error {
+	for _, rawEs := range ess {
+		es, ok := rawEs.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		snapshots, ok := es["snapshot_source"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, rawSnap := range snapshots {
+			snap, ok := rawSnap.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			depID, ok := snap["source_deployment_id"].(string)
+			if !ok || depID == "" {
+				continue
+			}
+
+			res, err := deploymentapi.Get(deploymentapi.GetParams{
+				API: client, DeploymentID: depID,
+			})
+			if err != nil {
+				return multierror.NewPrefixed(
+					"failed obtaining the snapshot_source deployment", err,
+				)
+			}
+
+			if res.Resources == nil || len(res.Resources.Elasticsearch) == 0 ||
+				res.Resources.Elasticsearch[0].ID == nil {
+				return fmt.Errorf(
+					`snapshot_source: deployment "%s" has no elasticsearch resources`, depID,
+				)
+			}
+
+			source := res.Resources.Elasticsearch[0]
+			if source.Region != nil && *source.Region != region {
+				if repo, _ := snap["repository_name"].(string); repo == "" {
+					return fmt.Errorf(
+						`snapshot_source: deployment "%s" is in region "%s", "repository_name" must be set to restore snapshots from a different region`,
+						depID, *source.Region,
+					)
+				}
+			}
+
+			snap["source_elasticsearch_cluster_id"] = *source.ID
+		}
+	}
+
+	return nil
+}
+
+// skipUnresolvedSnapshotSource removes the "snapshot_source" blocks which only
+// reference a "source_deployment_id", since it's only resolved to its
+// Elasticsearch cluster ID when the deployment is created.
+func skipUnresolvedSnapshotSource(ess []interface{}) {
+	for _, rawEs := range ess {
+		es, ok := rawEs.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		snapshots, ok := es["snapshot_source"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		var resolved = make([]interface{}, 0, len(snapshots))
+		for _, rawSnap := range snapshots {
+			snap, ok := rawSnap.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if clusterID, _ := snap["source_elasticsearch_cluster_id"].(string); clusterID == "" {
+				continue
+			}
+			resolved = append(resolved, snap)
+		}
+		es["snapshot_source"] = resolved
+	}
+}
@@ -0,0 +1,168 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_resolveSnapshotSource(t *testing.T) {
+	sourceDeployment := func(region string) models.DeploymentGetResponse {
+		return models.DeploymentGetResponse{
+			ID: ec.String("0a592ab2c5baf0fa95c77ac62135782e"),
+			Resources: &models.DeploymentResources{
+				Elasticsearch: []*models.ElasticsearchResourceInfo{{
+					ID:     ec.String("1238f19957874af69306787dca662154"),
+					Region: ec.String(region),
+				}},
+			},
+		}
+	}
+	newEs := func(snap map[string]interface{}) []interface{} {
+		return []interface{}{map[string]interface{}{
+			"snapshot_source": []interface{}{snap},
+		}}
+	}
+
+	type args struct {
+		client *api.API
+		ess    []interface{}
+		region string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []interface{}
+		err  error
+	}{
+		{
+			name: "leaves the snapshot source untouched when no source_deployment_id is set",
+			args: args{
+				client: api.NewMock(),
+				region: "us-east-1",
+				ess: newEs(map[string]interface{}{
+					"source_elasticsearch_cluster_id": "1238f19957874af69306787dca662154",
+					"snapshot_name":                   "__latest_success__",
+				}),
+			},
+			want: newEs(map[string]interface{}{
+				"source_elasticsearch_cluster_id": "1238f19957874af69306787dca662154",
+				"snapshot_name":                   "__latest_success__",
+			}),
+		},
+		{
+			name: "resolves the source cluster ID from a deployment in the same region",
+			args: args{
+				client: api.NewMock(mock.New200StructResponse(sourceDeployment("us-east-1"))),
+				region: "us-east-1",
+				ess: newEs(map[string]interface{}{
+					"source_deployment_id": "0a592ab2c5baf0fa95c77ac62135782e",
+					"snapshot_name":        "my-snapshot",
+				}),
+			},
+			want: newEs(map[string]interface{}{
+				"source_deployment_id":            "0a592ab2c5baf0fa95c77ac62135782e",
+				"source_elasticsearch_cluster_id": "1238f19957874af69306787dca662154",
+				"snapshot_name":                   "my-snapshot",
+			}),
+		},
+		{
+			name: "resolves the source cluster ID from a deployment in a different region with a repository",
+			args: args{
+				client: api.NewMock(mock.New200StructResponse(sourceDeployment("eu-west-1"))),
+				region: "us-east-1",
+				ess: newEs(map[string]interface{}{
+					"source_deployment_id": "0a592ab2c5baf0fa95c77ac62135782e",
+					"repository_name":      "my-s3-repository",
+					"snapshot_name":        "my-snapshot",
+				}),
+			},
+			want: newEs(map[string]interface{}{
+				"source_deployment_id":            "0a592ab2c5baf0fa95c77ac62135782e",
+				"source_elasticsearch_cluster_id": "1238f19957874af69306787dca662154",
+				"repository_name":                 "my-s3-repository",
+				"snapshot_name":                   "my-snapshot",
+			}),
+		},
+		{
+			name: "fails when the source deployment is in a different region and no repository is set",
+			args: args{
+				client: api.NewMock(mock.New200StructResponse(sourceDeployment("eu-west-1"))),
+				region: "us-east-1",
+				ess: newEs(map[string]interface{}{
+					"source_deployment_id": "0a592ab2c5baf0fa95c77ac62135782e",
+					"snapshot_name":        "my-snapshot",
+				}),
+			},
+			err: errors.New(`snapshot_source: deployment "0a592ab2c5baf0fa95c77ac62135782e" is in region "eu-west-1", "repository_name" must be set to restore snapshots from a different region`),
+		},
+		{
+			name: "fails when the source deployment can't be obtained",
+			args: args{
+				client: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+					Code: "some", Message: "message",
+				})),
+				region: "us-east-1",
+				ess: newEs(map[string]interface{}{
+					"source_deployment_id": "0a592ab2c5baf0fa95c77ac62135782e",
+				}),
+			},
+			err: errors.New("failed obtaining the snapshot_source deployment: 1 error occurred:\n\t* api error: some: message\n\n"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := resolveSnapshotSource(tt.args.client, tt.args.ess, tt.args.region)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, tt.args.ess)
+		})
+	}
+}
+
+func Test_skipUnresolvedSnapshotSource(t *testing.T) {
+	ess := []interface{}{map[string]interface{}{
+		"snapshot_source": []interface{}{
+			map[string]interface{}{
+				"source_deployment_id": "0a592ab2c5baf0fa95c77ac62135782e",
+			},
+			map[string]interface{}{
+				"source_elasticsearch_cluster_id": "1238f19957874af69306787dca662154",
+			},
+		},
+	}}
+
+	skipUnresolvedSnapshotSource(ess)
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"snapshot_source": []interface{}{
+			map[string]interface{}{
+				"source_elasticsearch_cluster_id": "1238f19957874af69306787dca662154",
+			},
+		},
+	}}, ess)
+}
@@ -0,0 +1,143 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// esTierIDs are the topology IDs which can be set as keyed blocks in the
+// elasticsearch resource instead of "topology" elements, in the order they
+// are sent to the API.
+var esTierIDs = []string{
+	"hot_content",
+	"warm",
+	"cold",
+	"frozen",
+	"master",
+	"ml",
+	"coordinating",
+}
+
+// elasticsearchTierSchema returns the schema of a keyed topology block, which
+// is the same as a "topology" element with its ID being the block name.
+func elasticsearchTierSchema(id string) *schema.Schema {
+	elem := elasticsearchTopologySchema().Elem.(*schema.Resource)
+	delete(elem.Schema, "id")
+
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		Computed:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"elasticsearch.0.topology"},
+		Description:   `Optional "` + id + `" topology element, mutually exclusive with the "topology" elements`,
+		Elem:          elem,
+	}
+}
+
+// esTopology returns the raw topology elements of a flattened Elasticsearch
+// resource, either from its keyed tier blocks, or from its "topology" list
+// when none of the tier blocks are set.
+func esTopology(es map[string]interface{}) []interface{} {
+	if tiers := tiersToTopology(es); len(tiers) > 0 {
+		return tiers
+	}
+
+	if rt, ok := es["topology"].([]interface{}); ok {
+		return rt
+	}
+
+	return nil
+}
+
+// tiersToTopology converts the keyed tier blocks to "topology" elements.
+func tiersToTopology(es map[string]interface{}) []interface{} {
+	var result []interface{}
+	for _, id := range esTierIDs {
+		raw, ok := es[id].([]interface{})
+		if !ok || len(raw) == 0 {
+			continue
+		}
+
+		tier, ok := raw[0].(map[string]interface{})
+		if !ok {
+			tier = make(map[string]interface{})
+		}
+
+		elem := make(map[string]interface{}, len(tier)+1)
+		for k, v := range tier {
+			elem[k] = v
+		}
+		elem["id"] = id
+
+		result = append(result, elem)
+	}
+
+	return result
+}
+
+// topologyToTiers moves the flattened "topology" elements of an Elasticsearch
+// resource to their keyed tier blocks. Elements whose ID doesn't have a tier
+// block are kept in the "topology" list.
+func topologyToTiers(es map[string]interface{}) {
+	topology, ok := es["topology"].([]interface{})
+	if !ok {
+		return
+	}
+
+	var remaining []interface{}
+	for _, raw := range topology {
+		elem, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		id, _ := elem["id"].(string)
+		if !slice.HasString(esTierIDs, id) {
+			remaining = append(remaining, elem)
+			continue
+		}
+
+		tier := make(map[string]interface{}, len(elem)-1)
+		for k, v := range elem {
+			if k != "id" {
+				tier[k] = v
+			}
+		}
+		es[id] = []interface{}{tier}
+	}
+
+	if len(remaining) > 0 {
+		es["topology"] = remaining
+	} else {
+		delete(es, "topology")
+	}
+}
+
+// usesEsTiers returns true when the Elasticsearch resource in the state or
+// configuration has any of its keyed tier blocks set.
+func usesEsTiers(d *schema.ResourceData) bool {
+	for _, id := range esTierIDs {
+		if raw, ok := d.Get("elasticsearch.0." + id).([]interface{}); ok && len(raw) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_esTopology(t *testing.T) {
+	tests := []struct {
+		name string
+		es   map[string]interface{}
+		want []interface{}
+	}{
+		{
+			name: "returns the topology elements when no tiers are set",
+			es: map[string]interface{}{
+				"topology": []interface{}{map[string]interface{}{
+					"id": "hot_content", "size": "8g",
+				}},
+			},
+			want: []interface{}{map[string]interface{}{
+				"id": "hot_content", "size": "8g",
+			}},
+		},
+		{
+			name: "converts the tiers to topology elements in the tier order",
+			es: map[string]interface{}{
+				"warm":        []interface{}{map[string]interface{}{"size": "4g"}},
+				"hot_content": []interface{}{map[string]interface{}{"size": "8g"}},
+				"cold":        []interface{}{},
+			},
+			want: []interface{}{
+				map[string]interface{}{"id": "hot_content", "size": "8g"},
+				map[string]interface{}{"id": "warm", "size": "4g"},
+			},
+		},
+		{
+			name: "returns nil when there's no topology",
+			es:   map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, esTopology(tt.es))
+		})
+	}
+}
+
+func Test_topologyToTiers(t *testing.T) {
+	tests := []struct {
+		name string
+		es   map[string]interface{}
+		want map[string]interface{}
+	}{
+		{
+			name: "moves the topology elements to their tiers",
+			es: map[string]interface{}{
+				"ref_id": "main-elasticsearch",
+				"topology": []interface{}{
+					map[string]interface{}{"id": "hot_content", "size": "8g"},
+					map[string]interface{}{"id": "ml", "size": "1g"},
+				},
+			},
+			want: map[string]interface{}{
+				"ref_id":      "main-elasticsearch",
+				"hot_content": []interface{}{map[string]interface{}{"size": "8g"}},
+				"ml":          []interface{}{map[string]interface{}{"size": "1g"}},
+			},
+		},
+		{
+			name: "keeps the topology elements without a tier",
+			es: map[string]interface{}{
+				"topology": []interface{}{
+					map[string]interface{}{"id": "hot_content", "size": "8g"},
+					map[string]interface{}{"id": "custom", "size": "1g"},
+				},
+			},
+			want: map[string]interface{}{
+				"hot_content": []interface{}{map[string]interface{}{"size": "8g"}},
+				"topology": []interface{}{
+					map[string]interface{}{"id": "custom", "size": "1g"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topologyToTiers(tt.es)
+			assert.Equal(t, tt.want, tt.es)
+		})
+	}
+}
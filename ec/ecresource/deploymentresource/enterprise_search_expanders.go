@@ -85,6 +85,13 @@ func expandEssResource(raw interface{}, res *models.EnterpriseSearchPayload) (*m
 		res.Plan.ClusterTopology = defaultEssTopology(res.Plan.ClusterTopology)
 	}
 
+	if strategy := expandPlanStrategy(ess["strategy"]); strategy != nil {
+		if res.Plan.Transient == nil {
+			res.Plan.Transient = &models.TransientEnterpriseSearchPlanConfiguration{}
+		}
+		res.Plan.Transient.Strategy = strategy
+	}
+
 	return res, nil
 }
 
@@ -134,6 +141,10 @@ func expandEssTopology(rawTopologies []interface{}, topologies []*models.Enterpr
 			elem.ZoneCount = int32(zones)
 		}
 
+		if nodeTypes := expandEssNodeTypes(topology); nodeTypes != nil {
+			elem.NodeType = nodeTypes
+		}
+
 		res = append(res, elem)
 	}
 
@@ -31,7 +31,11 @@ func flattenEssResources(in []*models.EnterpriseSearchResourceInfo, name string)
 	result := make([]interface{}, 0, len(in))
 	for _, res := range in {
 		m := make(map[string]interface{})
-		if util.IsCurrentEssPlanEmpty(res) || isEssResourceStopped(res) {
+		if util.IsCurrentEssPlanEmpty(res) {
+			continue
+		}
+
+		if isEssResourceStopped(res) && !isDisabledEssPlan(res.Info.PlanInfo.Current.Plan) {
 			continue
 		}
 
@@ -47,8 +51,13 @@ func flattenEssResources(in []*models.EnterpriseSearchResourceInfo, name string)
 			m["region"] = *res.Region
 		}
 
+		if inMaintenanceMode(res.Info.Topology) {
+			m["maintenance_mode"] = true
+		}
+
 		plan := res.Info.PlanInfo.Current.Plan
 		if topology := flattenEssTopology(plan); len(topology) > 0 {
+			util.FlattenTopologyInstances(topology, res.Info.Topology)
 			m["topology"] = topology
 		}
 
@@ -74,9 +83,10 @@ func flattenEssResources(in []*models.EnterpriseSearchResourceInfo, name string)
 
 func flattenEssTopology(plan *models.EnterpriseSearchPlan) []interface{} {
 	var result = make([]interface{}, 0, len(plan.ClusterTopology))
+	disabled := isDisabledEssPlan(plan)
 	for _, topology := range plan.ClusterTopology {
 		var m = make(map[string]interface{})
-		if topology.Size == nil || topology.Size.Value == nil || *topology.Size.Value == 0 {
+		if !disabled && (topology.Size == nil || topology.Size.Value == nil || *topology.Size.Value == 0) {
 			continue
 		}
 
@@ -84,8 +94,11 @@ func flattenEssTopology(plan *models.EnterpriseSearchPlan) []interface{} {
 			m["instance_configuration_id"] = topology.InstanceConfigurationID
 		}
 
-		if topology.Size != nil {
+		if topology.Size != nil && topology.Size.Value != nil {
 			m["size"] = util.MemoryToState(*topology.Size.Value)
+		}
+
+		if topology.Size != nil && topology.Size.Resource != nil {
 			m["size_resource"] = *topology.Size.Resource
 		}
 
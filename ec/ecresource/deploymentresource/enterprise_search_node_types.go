@@ -0,0 +1,167 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+const essTopologyKey = "enterprise_search.0.topology.0"
+
+// essNodeTypes are the Enterprise Search node types, which are set with the
+// "node_type_<name>" topology attributes.
+var essNodeTypes = []string{"appserver", "connector", "worker"}
+
+// enabledEssNodeTypes returns the Enterprise Search node types which are
+// enabled in the topology element.
+func enabledEssNodeTypes(topology map[string]interface{}) []string {
+	var enabled []string
+	for _, name := range essNodeTypes {
+		if v, ok := topology["node_type_"+name].(bool); ok && v {
+			enabled = append(enabled, name)
+		}
+	}
+	return enabled
+}
+
+// expandEssNodeTypes returns the node types of the topology element when any
+// of them is enabled, disabling the ones which aren't. When none is enabled,
+// nil is returned so the deployment template node types are kept.
+func expandEssNodeTypes(topology map[string]interface{}) *models.EnterpriseSearchNodeTypes {
+	enabled := enabledEssNodeTypes(topology)
+	if len(enabled) == 0 {
+		return nil
+	}
+
+	return &models.EnterpriseSearchNodeTypes{
+		Appserver: ec.Bool(slice.HasString(enabled, "appserver")),
+		Connector: ec.Bool(slice.HasString(enabled, "connector")),
+		Worker:    ec.Bool(slice.HasString(enabled, "worker")),
+	}
+}
+
+// validateEssNodeTypesDiff fails the plan when the enabled Enterprise Search
+// node types aren't supported by the stack version of the deployment, or
+// can't be combined, since the API only rejects them once the plan runs.
+func validateEssNodeTypesDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	keys := []string{"version"}
+	for _, name := range essNodeTypes {
+		keys = append(keys, essTopologyKey+".node_type_"+name)
+	}
+	if !d.HasChanges(keys...) {
+		return nil
+	}
+
+	for _, k := range []string{"version", "region"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	topology, _ := d.Get(essTopologyKey).(map[string]interface{})
+	enabled := enabledEssNodeTypes(topology)
+	if len(enabled) == 0 {
+		return nil
+	}
+
+	version := d.Get("version").(string)
+	res, err := getDiffStack(ctx, meta.(*util.ProviderMeta).API, version, d.Get("region").(string))
+	if err != nil {
+		return multierror.NewPrefixed(
+			"failed obtaining the supported enterprise_search node types", err,
+		)
+	}
+
+	if res.EnterpriseSearch == nil {
+		return nil
+	}
+
+	return validateEssNodeTypes(version, enabled, res.EnterpriseSearch.NodeTypes)
+}
+
+// validateEssNodeTypes returns an error when any of the enabled node types
+// isn't supported by the stack version, isn't compatible with the rest of
+// the enabled node types, or when a mandatory node type isn't enabled.
+func validateEssNodeTypes(version string, enabled []string, supported []*models.StackVersionNodeType) error {
+	if len(supported) == 0 {
+		return nil
+	}
+
+	var byName = make(map[string]*models.StackVersionNodeType, len(supported))
+	for _, nt := range supported {
+		if nt != nil && nt.NodeType != nil {
+			byName[*nt.NodeType] = nt
+		}
+	}
+
+	merr := multierror.NewPrefixed("enterprise_search topology")
+	for i, name := range enabled {
+		nt, ok := byName[name]
+		if !ok {
+			merr = merr.Append(fmt.Errorf(
+				"node_type_%s is not supported by version %s", name, version,
+			))
+			continue
+		}
+
+		for _, other := range enabled[i+1:] {
+			if !compatibleNodeTypes(nt, other) || !compatibleNodeTypes(byName[other], name) {
+				merr = merr.Append(fmt.Errorf(
+					"node_type_%s can't be combined with node_type_%s in version %s",
+					name, other, version,
+				))
+			}
+		}
+	}
+
+	var names = make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		nt := byName[name]
+		if nt.Mandatory != nil && *nt.Mandatory && !slice.HasString(enabled, name) {
+			merr = merr.Append(fmt.Errorf(
+				"node_type_%s must be enabled in version %s", name, version,
+			))
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// compatibleNodeTypes returns false when the node type lists the node types
+// it's compatible with and the other node type isn't one of them.
+func compatibleNodeTypes(nt *models.StackVersionNodeType, other string) bool {
+	if nt == nil || len(nt.CompatibleNodeTypes) == 0 {
+		return true
+	}
+	return slice.HasString(nt.CompatibleNodeTypes, other)
+}
@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandEssNodeTypes(t *testing.T) {
+	assert.Nil(t, expandEssNodeTypes(map[string]interface{}{
+		"node_type_appserver": false,
+		"node_type_connector": false,
+		"node_type_worker":    false,
+	}))
+
+	assert.Equal(t, &models.EnterpriseSearchNodeTypes{
+		Appserver: ec.Bool(true),
+		Connector: ec.Bool(false),
+		Worker:    ec.Bool(true),
+	}, expandEssNodeTypes(map[string]interface{}{
+		"node_type_appserver": true,
+		"node_type_worker":    true,
+	}))
+}
+
+func Test_validateEssNodeTypes(t *testing.T) {
+	supported := []*models.StackVersionNodeType{
+		{NodeType: ec.String("appserver"), Mandatory: ec.Bool(true)},
+		{NodeType: ec.String("worker"), CompatibleNodeTypes: []string{"appserver"}},
+	}
+	tests := []struct {
+		name    string
+		enabled []string
+		err     string
+	}{
+		{
+			name:    "supported node types",
+			enabled: []string{"appserver", "worker"},
+		},
+		{
+			name:    "unsupported node type",
+			enabled: []string{"appserver", "connector"},
+			err:     "enterprise_search topology: 1 error occurred:\n\t* node_type_connector is not supported by version 7.7.0\n\n",
+		},
+		{
+			name:    "mandatory node type disabled",
+			enabled: []string{"worker"},
+			err:     "enterprise_search topology: 1 error occurred:\n\t* node_type_appserver must be enabled in version 7.7.0\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEssNodeTypes("7.7.0", tt.enabled, supported)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+
+	t.Run("incompatible node types", func(t *testing.T) {
+		err := validateEssNodeTypes("8.0.0", []string{"connector", "worker"}, []*models.StackVersionNodeType{
+			{NodeType: ec.String("connector")},
+			{NodeType: ec.String("worker"), CompatibleNodeTypes: []string{"appserver"}},
+		})
+		assert.EqualError(t, err, "enterprise_search topology: 1 error occurred:\n\t* node_type_connector can't be combined with node_type_worker in version 8.0.0\n\n")
+	})
+
+	t.Run("no supported node types", func(t *testing.T) {
+		assert.NoError(t, validateEssNodeTypes("7.7.0", []string{"connector"}, nil))
+	})
+}
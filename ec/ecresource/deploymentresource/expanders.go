@@ -18,11 +18,11 @@
 package deploymentresource
 
 import (
+	"context"
 	"fmt"
 	"sort"
 
 	semver "github.com/blang/semver/v4"
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deptemplateapi"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
@@ -36,7 +36,10 @@ var (
 	dataTiersVersion = semver.MustParse("7.10.0")
 )
 
-func createResourceToModel(d *schema.ResourceData, client *api.API) (*models.DeploymentCreateRequest, error) {
+func createResourceToModel(ctx context.Context, d *schema.ResourceData, meta *util.ProviderMeta) (*models.DeploymentCreateRequest, error) {
+	// The API calls are aborted when the context is done.
+	client := util.ContextClient(ctx, meta.API)
+
 	var result = models.DeploymentCreateRequest{
 		Name:      d.Get("name").(string),
 		Alias:     d.Get("alias").(string),
@@ -62,9 +65,14 @@ func createResourceToModel(d *schema.ResourceData, client *api.API) (*models.Dep
 		return nil, err
 	}
 
+	es := d.Get("elasticsearch").([]interface{})
+	if err := resolveSnapshotSource(client, es, d.Get("region").(string)); err != nil {
+		return nil, err
+	}
+
 	merr := multierror.NewPrefixed("invalid configuration")
 	esRes, err := expandEsResources(
-		d.Get("elasticsearch").([]interface{}),
+		es,
 		enrichElasticsearchTemplate(
 			esResource(template), dtID, version, useNodeRoles,
 		),
@@ -110,6 +118,9 @@ func createResourceToModel(d *schema.ResourceData, client *api.API) (*models.Dep
 		return nil, err
 	}
 
+	generated, _ := d.Get("generated_ref_ids").(map[string]interface{})
+	applyGeneratedRefIDs(generated, result.Resources)
+
 	expandTrafficFilterCreate(d.Get("traffic_filter").(*schema.Set), &result)
 
 	observability, err := expandObservability(d.Get("observability").([]interface{}), client)
@@ -118,12 +129,17 @@ func createResourceToModel(d *schema.ResourceData, client *api.API) (*models.Dep
 	}
 	result.Settings.Observability = observability
 
-	result.Metadata.Tags = expandTags(d.Get("tags").(map[string]interface{}))
+	result.Metadata.Tags = expandTags(mergeTags(
+		meta.DefaultTags, d.Get("tags").(map[string]interface{}),
+	))
 
 	return &result, nil
 }
 
-func updateResourceToModel(d *schema.ResourceData, client *api.API) (*models.DeploymentUpdateRequest, error) {
+func updateResourceToModel(ctx context.Context, d *schema.ResourceData, meta *util.ProviderMeta) (*models.DeploymentUpdateRequest, error) {
+	// The API calls are aborted when the context is done.
+	client := util.ContextClient(ctx, meta.API)
+
 	var result = models.DeploymentUpdateRequest{
 		Name:         d.Get("name").(string),
 		Alias:        d.Get("alias").(string),
@@ -165,6 +181,10 @@ func updateResourceToModel(d *schema.ResourceData, client *api.API) (*models.Dep
 		unsetTopology(es)
 	}
 
+	// Autoscaling bounds which aren't explicitly configured default to the
+	// deployment template values rather than the ones stored in the state.
+	inheritTemplateAutoscaling(es, configuredAutoscaling(d.GetRawConfig()))
+
 	useNodeRoles, err := compatibleWithNodeRoles(version)
 	if err != nil {
 		return nil, err
@@ -175,6 +195,10 @@ func updateResourceToModel(d *schema.ResourceData, client *api.API) (*models.Dep
 	}
 	useNodeRoles = useNodeRoles && convertLegacy
 
+	// The snapshot_source deployments are only resolved when the deployment
+	// is created, rather than obtaining them on every update.
+	skipUnresolvedSnapshotSource(es)
+
 	merr := multierror.NewPrefixed("invalid configuration")
 	esRes, err := expandEsResources(
 		es, enrichElasticsearchTemplate(
@@ -232,7 +256,9 @@ func updateResourceToModel(d *schema.ResourceData, client *api.API) (*models.Dep
 		result.Settings.Observability = &models.DeploymentObservabilitySettings{}
 	}
 
-	result.Metadata.Tags = expandTags(d.Get("tags").(map[string]interface{}))
+	result.Metadata.Tags = expandTags(mergeTags(
+		meta.DefaultTags, d.Get("tags").(map[string]interface{}),
+	))
 
 	return &result, nil
 }
@@ -341,7 +367,8 @@ func legacyToNodeRoles(d *schema.ResourceData) (bool, error) {
 	// we'd be changing the version AND migrating over `node_role`s
 	// which is not permitted by the API.
 	var hasNodeTypeSet bool
-	for _, t := range d.Get("elasticsearch.0.topology").([]interface{}) {
+	es, _ := d.Get("elasticsearch.0").(map[string]interface{})
+	for _, t := range esTopology(es) {
 		top := t.(map[string]interface{})
 		if nt, ok := top["node_type_data"]; ok {
 			if nt.(string) != "" {
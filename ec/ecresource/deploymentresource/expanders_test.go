@@ -19,6 +19,7 @@ package deploymentresource
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"os"
@@ -2906,7 +2907,7 @@ func Test_createResourceToModel(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := createResourceToModel(tt.args.d, tt.args.client)
+			got, err := createResourceToModel(context.Background(), tt.args.d, &util.ProviderMeta{API: tt.args.client})
 			if tt.err != nil {
 				assert.EqualError(t, err, tt.err.Error())
 			} else {
@@ -5044,7 +5045,7 @@ func Test_updateResourceToModel(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := updateResourceToModel(tt.args.d, tt.args.client)
+			got, err := updateResourceToModel(context.Background(), tt.args.d, &util.ProviderMeta{API: tt.args.client})
 			if tt.err != nil {
 				assert.EqualError(t, err, tt.err.Error())
 			} else {
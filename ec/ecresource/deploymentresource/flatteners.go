@@ -45,6 +45,20 @@ func modelToState(d *schema.ResourceData, res *models.DeploymentGetResponse, rem
 		}
 	}
 
+	// Ensures any credentials which were stored before the credentials were
+	// hidden are removed from the state.
+	if !d.Get("expose_credentials").(bool) {
+		if err := hideCredentials(d); err != nil {
+			return err
+		}
+	}
+
+	if !d.Get("store_elasticsearch_password").(bool) {
+		if err := d.Set("elasticsearch_password", ""); err != nil {
+			return err
+		}
+	}
+
 	if res.Resources != nil {
 		dt, err := getDeploymentTemplateID(res.Resources)
 		if err != nil {
@@ -55,6 +69,10 @@ func modelToState(d *schema.ResourceData, res *models.DeploymentGetResponse, rem
 			return err
 		}
 
+		if err := d.Set("resolved_template_id", dt); err != nil {
+			return err
+		}
+
 		if err := d.Set("region", getRegion(res.Resources)); err != nil {
 			return err
 		}
@@ -74,37 +92,50 @@ func modelToState(d *schema.ResourceData, res *models.DeploymentGetResponse, rem
 			return err
 		}
 
+		if err := d.Set("resolved_version", version); err != nil {
+			return err
+		}
+
+		if err := d.Set("stack_features", flattenStackFeatures(version)); err != nil {
+			return err
+		}
+
 		esFlattened, err := flattenEsResources(res.Resources.Elasticsearch, *res.Name, remotes)
 		if err != nil {
 			return err
 		}
+		if usesEsTiers(d) {
+			for _, es := range esFlattened {
+				topologyToTiers(es.(map[string]interface{}))
+			}
+		}
 		if err := d.Set("elasticsearch", esFlattened); err != nil {
 			return err
 		}
 
 		kibanaFlattened := flattenKibanaResources(res.Resources.Kibana, *res.Name)
-		if len(kibanaFlattened) > 0 {
+		if len(kibanaFlattened) > 0 && managesResourceKind(d, "kibana") {
 			if err := d.Set("kibana", kibanaFlattened); err != nil {
 				return err
 			}
 		}
 
 		apmFlattened := flattenApmResources(res.Resources.Apm, *res.Name)
-		if len(apmFlattened) > 0 {
+		if len(apmFlattened) > 0 && managesResourceKind(d, "apm") {
 			if err := d.Set("apm", apmFlattened); err != nil {
 				return err
 			}
 		}
 
 		integrationsServerFlattened := flattenIntegrationsServerResources(res.Resources.IntegrationsServer, *res.Name)
-		if len(integrationsServerFlattened) > 0 {
+		if len(integrationsServerFlattened) > 0 && managesResourceKind(d, "integrations_server") {
 			if err := d.Set("integrations_server", integrationsServerFlattened); err != nil {
 				return err
 			}
 		}
 
 		enterpriseSearchFlattened := flattenEssResources(res.Resources.EnterpriseSearch, *res.Name)
-		if len(enterpriseSearchFlattened) > 0 {
+		if len(enterpriseSearchFlattened) > 0 && managesResourceKind(d, "enterprise_search") {
 			if err := d.Set("enterprise_search", enterpriseSearchFlattened); err != nil {
 				return err
 			}
@@ -116,10 +147,8 @@ func modelToState(d *schema.ResourceData, res *models.DeploymentGetResponse, rem
 			}
 		}
 
-		if observability := flattenObservability(res.Settings); len(observability) > 0 {
-			if err := d.Set("observability", observability); err != nil {
-				return err
-			}
+		if err := setObservability(d, res.Settings); err != nil {
+			return err
 		}
 	}
 
@@ -165,7 +194,15 @@ func getDeploymentTemplateID(res *models.DeploymentResources) (string, error) {
 // credential settings in the Terraform state if the keys are found, currently
 // populates the following credentials in plain text:
 // * Elasticsearch username and Password
+// * APM secret token
+// When "expose_credentials" is false, any stored credentials are removed, and
+// when "store_elasticsearch_password" is false, the password isn't stored.
 func parseCredentials(d *schema.ResourceData, resources []*models.DeploymentResource) error {
+	if !d.Get("expose_credentials").(bool) {
+		return hideCredentials(d)
+	}
+
+	var storePassword = d.Get("store_elasticsearch_password").(bool)
 	var merr = multierror.NewPrefixed("failed parsing credentials")
 	for _, res := range resources {
 		// Parse ES credentials
@@ -176,7 +213,7 @@ func parseCredentials(d *schema.ResourceData, resources []*models.DeploymentReso
 				}
 			}
 
-			if creds.Password != nil && *creds.Password != "" {
+			if creds.Password != nil && *creds.Password != "" && storePassword {
 				if err := d.Set("elasticsearch_password", *creds.Password); err != nil {
 					merr = merr.Append(err)
 				}
@@ -194,6 +231,20 @@ func parseCredentials(d *schema.ResourceData, resources []*models.DeploymentReso
 	return merr.ErrorOrNil()
 }
 
+// hideCredentials removes the credentials from the Terraform state.
+func hideCredentials(d *schema.ResourceData) error {
+	var merr = multierror.NewPrefixed("failed removing credentials")
+	for _, k := range []string{
+		"elasticsearch_username", "elasticsearch_password", "apm_secret_token",
+	} {
+		if err := d.Set(k, ""); err != nil {
+			merr = merr.Append(err)
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
 func getRegion(res *models.DeploymentResources) (region string) {
 	for _, r := range res.Elasticsearch {
 		if r.Region != nil && *r.Region != "" {
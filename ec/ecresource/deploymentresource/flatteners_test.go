@@ -51,10 +51,17 @@ func Test_modelToState(t *testing.T) {
 		State: map[string]interface{}{
 			"alias":                  "my-deployment",
 			"deployment_template_id": "azure-io-optimized",
+			"resolved_template_id":   "azure-io-optimized",
 			"id":                     "123b7b540dfc967a7a649c18e2fce4ed",
 			"name":                   "up2d",
 			"region":                 "azure-eastus2",
 			"version":                "7.9.2",
+			"resolved_version":       "7.9.2",
+			"stack_features": map[string]interface{}{
+				"supports_node_roles":          false,
+				"supports_autoscaling":         false,
+				"supports_integrations_server": false,
+			},
 			"apm": []interface{}{map[string]interface{}{
 				"elasticsearch_cluster_ref_id": "main-elasticsearch",
 				"ref_id":                       "main-apm",
@@ -117,10 +124,17 @@ func Test_modelToState(t *testing.T) {
 		State: map[string]interface{}{
 			"alias":                  "my-deployment",
 			"deployment_template_id": "aws-io-optimized-v2",
+			"resolved_template_id":   "aws-io-optimized-v2",
 			"id":                     "123b7b540dfc967a7a649c18e2fce4ed",
 			"name":                   "up2d",
 			"region":                 "aws-eu-central-1",
 			"version":                "7.9.2",
+			"resolved_version":       "7.9.2",
+			"stack_features": map[string]interface{}{
+				"supports_node_roles":          false,
+				"supports_autoscaling":         false,
+				"supports_integrations_server": false,
+			},
 			"apm": []interface{}{map[string]interface{}{
 				"elasticsearch_cluster_ref_id": "main-elasticsearch",
 				"ref_id":                       "main-apm",
@@ -186,6 +200,7 @@ func Test_modelToState(t *testing.T) {
 		State: map[string]interface{}{
 			"alias":                  "my-deployment",
 			"deployment_template_id": "aws-io-optimized-v2",
+			"resolved_template_id":   "aws-io-optimized-v2",
 			"id":                     "123b7b540dfc967a7a649c18e2fce4ed",
 			"name":                   "up2d",
 			"region":                 "aws-eu-central-1",
@@ -194,7 +209,13 @@ func Test_modelToState(t *testing.T) {
 				"cost":  "rnd",
 				"owner": "elastic",
 			},
-			"version": "7.9.2",
+			"version":          "7.9.2",
+			"resolved_version": "7.9.2",
+			"stack_features": map[string]interface{}{
+				"supports_node_roles":          false,
+				"supports_autoscaling":         false,
+				"supports_integrations_server": false,
+			},
 			"apm": []interface{}{map[string]interface{}{
 				"elasticsearch_cluster_ref_id": "main-elasticsearch",
 				"ref_id":                       "main-apm",
@@ -257,10 +278,17 @@ func Test_modelToState(t *testing.T) {
 		State: map[string]interface{}{
 			"alias":                  "my-deployment",
 			"deployment_template_id": "gcp-io-optimized",
+			"resolved_template_id":   "gcp-io-optimized",
 			"id":                     "123b7b540dfc967a7a649c18e2fce4ed",
 			"name":                   "up2d",
 			"region":                 "gcp-asia-east1",
 			"version":                "7.9.2",
+			"resolved_version":       "7.9.2",
+			"stack_features": map[string]interface{}{
+				"supports_node_roles":          false,
+				"supports_autoscaling":         false,
+				"supports_integrations_server": false,
+			},
 			"apm": []interface{}{map[string]interface{}{
 				"elasticsearch_cluster_ref_id": "main-elasticsearch",
 				"ref_id":                       "main-apm",
@@ -326,10 +354,17 @@ func Test_modelToState(t *testing.T) {
 		ID: mock.ValidClusterID,
 		State: map[string]interface{}{
 			"deployment_template_id": "gcp-hot-warm",
+			"resolved_template_id":   "gcp-hot-warm",
 			"id":                     "123b7b540dfc967a7a649c18e2fce4ed",
 			"name":                   "up2d-hot-warm",
 			"region":                 "gcp-us-central1",
 			"version":                "7.9.2",
+			"resolved_version":       "7.9.2",
+			"stack_features": map[string]interface{}{
+				"supports_node_roles":          false,
+				"supports_autoscaling":         false,
+				"supports_integrations_server": false,
+			},
 			"apm": []interface{}{map[string]interface{}{
 				"elasticsearch_cluster_ref_id": "main-elasticsearch",
 				"ref_id":                       "main-apm",
@@ -403,10 +438,17 @@ func Test_modelToState(t *testing.T) {
 		State: map[string]interface{}{
 			"alias":                  "",
 			"deployment_template_id": "gcp-io-optimized",
+			"resolved_template_id":   "gcp-io-optimized",
 			"id":                     "123b7b540dfc967a7a649c18e2fce4ed",
 			"name":                   "up2d",
 			"region":                 "gcp-asia-east1",
 			"version":                "7.9.2",
+			"resolved_version":       "7.9.2",
+			"stack_features": map[string]interface{}{
+				"supports_node_roles":          false,
+				"supports_autoscaling":         false,
+				"supports_integrations_server": false,
+			},
 			"apm": []interface{}{map[string]interface{}{
 				"elasticsearch_cluster_ref_id": "main-elasticsearch",
 				"ref_id":                       "main-apm",
@@ -494,10 +536,17 @@ func Test_modelToState(t *testing.T) {
 		ID: mock.ValidClusterID,
 		State: map[string]interface{}{
 			"deployment_template_id": "gcp-hot-warm",
+			"resolved_template_id":   "gcp-hot-warm",
 			"id":                     "123b7b540dfc967a7a649c18e2fce4ed",
 			"name":                   "up2d-hot-warm",
 			"region":                 "gcp-us-central1",
 			"version":                "7.11.0",
+			"resolved_version":       "7.11.0",
+			"stack_features": map[string]interface{}{
+				"supports_node_roles":          true,
+				"supports_autoscaling":         true,
+				"supports_integrations_server": false,
+			},
 			"apm": []interface{}{map[string]interface{}{
 				"elasticsearch_cluster_ref_id": "main-elasticsearch",
 				"ref_id":                       "main-apm",
@@ -577,10 +626,17 @@ func Test_modelToState(t *testing.T) {
 		ID: mock.ValidClusterID,
 		State: map[string]interface{}{
 			"deployment_template_id": "aws-cross-cluster-search-v2",
+			"resolved_template_id":   "aws-cross-cluster-search-v2",
 			"id":                     "123b7b540dfc967a7a649c18e2fce4ed",
 			"name":                   "ccs",
 			"region":                 "eu-west-1",
 			"version":                "7.9.2",
+			"resolved_version":       "7.9.2",
+			"stack_features": map[string]interface{}{
+				"supports_node_roles":          false,
+				"supports_autoscaling":         false,
+				"supports_integrations_server": false,
+			},
 			"elasticsearch": []interface{}{map[string]interface{}{
 				"autoscale":      "false",
 				"cloud_id":       "ccs:someCloudID",
@@ -961,8 +1017,15 @@ func Test_modelToState(t *testing.T) {
 					"alias":                  "my-deployment",
 					"name":                   "my_deployment_name",
 					"deployment_template_id": "aws-io-optimized-v2",
+					"resolved_template_id":   "aws-io-optimized-v2",
 					"region":                 "us-east-1",
 					"version":                "7.6.2",
+					"resolved_version":       "7.6.2",
+					"stack_features": map[string]interface{}{
+						"supports_node_roles":          false,
+						"supports_autoscaling":         false,
+						"supports_integrations_server": false,
+					},
 					"elasticsearch": []interface{}{map[string]interface{}{
 						"ref_id":      "main-elasticsearch",
 						"resource_id": mock.ValidClusterID,
@@ -1023,10 +1086,17 @@ func Test_modelToState(t *testing.T) {
 				State: map[string]interface{}{
 					"alias":                  "my-deployment",
 					"deployment_template_id": "aws-io-optimized-v2",
+					"resolved_template_id":   "aws-io-optimized-v2",
 					"id":                     "123b7b540dfc967a7a649c18e2fce4ed",
 					"name":                   "up2d",
 					"region":                 "aws-eu-central-1",
 					"version":                "7.9.2",
+					"resolved_version":       "7.9.2",
+					"stack_features": map[string]interface{}{
+						"supports_node_roles":          false,
+						"supports_autoscaling":         false,
+						"supports_integrations_server": false,
+					},
 					"apm": []interface{}{map[string]interface{}{
 						"elasticsearch_cluster_ref_id": "main-elasticsearch",
 						"ref_id":                       "main-apm",
@@ -1180,10 +1250,17 @@ func Test_modelToState(t *testing.T) {
 				State: map[string]interface{}{
 					"alias":                  "OH",
 					"deployment_template_id": "aws-io-optimized-v2",
+					"resolved_template_id":   "aws-io-optimized-v2",
 					"id":                     "123b7b540dfc967a7a649c18e2fce4ed",
 					"name":                   "up2d",
 					"region":                 "aws-eu-central-1",
 					"version":                "7.13.1",
+					"resolved_version":       "7.13.1",
+					"stack_features": map[string]interface{}{
+						"supports_node_roles":          true,
+						"supports_autoscaling":         true,
+						"supports_integrations_server": false,
+					},
 					"elasticsearch": []interface{}{map[string]interface{}{
 						"region": "aws-eu-central-1",
 						"ref_id": "main-elasticsearch",
@@ -1270,10 +1347,17 @@ func Test_modelToState(t *testing.T) {
 				State: map[string]interface{}{
 					"alias":                  "OH",
 					"deployment_template_id": "aws-io-optimized-v2",
+					"resolved_template_id":   "aws-io-optimized-v2",
 					"id":                     "123b7b540dfc967a7a649c18e2fce4ed",
 					"name":                   "up2d",
 					"region":                 "aws-eu-central-1",
 					"version":                "7.13.1",
+					"resolved_version":       "7.13.1",
+					"stack_features": map[string]interface{}{
+						"supports_node_roles":          true,
+						"supports_autoscaling":         true,
+						"supports_integrations_server": false,
+					},
 					"elasticsearch": []interface{}{map[string]interface{}{
 						"region": "aws-eu-central-1",
 						"ref_id": "main-elasticsearch",
@@ -1419,10 +1503,17 @@ func Test_modelToState(t *testing.T) {
 				State: map[string]interface{}{
 					"alias":                  "OH",
 					"deployment_template_id": "aws-io-optimized-v2",
+					"resolved_template_id":   "aws-io-optimized-v2",
 					"id":                     "123b7b540dfc967a7a649c18e2fce4ed",
 					"name":                   "up2d",
 					"region":                 "aws-eu-central-1",
 					"version":                "7.14.1",
+					"resolved_version":       "7.14.1",
+					"stack_features": map[string]interface{}{
+						"supports_node_roles":          true,
+						"supports_autoscaling":         true,
+						"supports_integrations_server": false,
+					},
 					"elasticsearch": []interface{}{map[string]interface{}{
 						"region": "aws-eu-central-1",
 						"ref_id": "main-elasticsearch",
@@ -1712,6 +1803,57 @@ func Test_parseCredentials(t *testing.T) {
 	}
 }
 
+func Test_parseCredentials_hidden(t *testing.T) {
+	rawData := newSampleLegacyDeployment()
+	rawData["expose_credentials"] = false
+	rawData["elasticsearch_username"] = "my-username"
+	rawData["elasticsearch_password"] = "my-password"
+	rawData["apm_secret_token"] = "some-secret-token"
+
+	d := util.NewResourceData(t, util.ResDataParams{
+		ID:     mock.ValidClusterID,
+		State:  rawData,
+		Schema: newSchema(),
+	})
+
+	err := parseCredentials(d, []*models.DeploymentResource{{
+		Credentials: &models.ClusterCredentials{
+			Username: ec.String("my-username"),
+			Password: ec.String("my-password"),
+		},
+		SecretToken: "some-secret-token",
+	}})
+	assert.NoError(t, err)
+
+	assert.Empty(t, d.Get("elasticsearch_username"))
+	assert.Empty(t, d.Get("elasticsearch_password"))
+	assert.Empty(t, d.Get("apm_secret_token"))
+}
+
+func Test_parseCredentials_withoutPassword(t *testing.T) {
+	rawData := newSampleLegacyDeployment()
+	rawData["store_elasticsearch_password"] = false
+
+	d := util.NewResourceData(t, util.ResDataParams{
+		ID:     mock.ValidClusterID,
+		State:  rawData,
+		Schema: newSchema(),
+	})
+
+	err := parseCredentials(d, []*models.DeploymentResource{{
+		Credentials: &models.ClusterCredentials{
+			Username: ec.String("my-username"),
+			Password: ec.String("my-password"),
+		},
+		SecretToken: "some-secret-token",
+	}})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "my-username", d.Get("elasticsearch_username"))
+	assert.Empty(t, d.Get("elasticsearch_password"))
+	assert.Equal(t, "some-secret-token", d.Get("apm_secret_token"))
+}
+
 func Test_hasRunningResources(t *testing.T) {
 	type args struct {
 		res *models.DeploymentGetResponse
@@ -35,9 +35,10 @@ import (
 var ilmVersion = semver.MustParse("6.6.0")
 
 // imports a deployment limitting the allowed version to 6.6.0 or higher.
-// TODO: It might be desired to provide the ability to import a deployment
-// specifying key:value pairs of secrets to populate as part of the
-// import with an implementation of schema.StateContextFunc.
+// The traffic filters, observability settings and tags are populated by the
+// read which follows the import. The Elasticsearch credentials can't be
+// imported, but they can be reset on the first apply after the import when
+// "reset_elasticsearch_password_on_import" is set.
 func importFunc(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	client := m.(*api.API)
 	res, err := deploymentapi.Get(deploymentapi.GetParams{
@@ -22,7 +22,6 @@ import (
 	"errors"
 	"testing"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -80,7 +79,7 @@ func Test_importFunc(t *testing.T) {
 			name: "succeeds with an importable version",
 			args: args{
 				d: deploymentWithImportableVersion,
-				m: api.NewMock(mock.New200Response(mock.NewStructBody(models.DeploymentGetResponse{
+				m: util.NewMockMeta(mock.New200Response(mock.NewStructBody(models.DeploymentGetResponse{
 					Resources: &models.DeploymentResources{Elasticsearch: []*models.ElasticsearchResourceInfo{
 						{
 							Info: &models.ElasticsearchClusterInfo{
@@ -101,34 +100,52 @@ func Test_importFunc(t *testing.T) {
 			want: map[string]string{
 				"id": "320b7b540dfc967a7a649c18e2fce4ed",
 
-				"name":                   "my_deployment_name",
-				"region":                 "us-east-1",
-				"version":                "7.9.2",
-				"deployment_template_id": "aws-cross-cluster-search-v2",
+				"name":                         "my_deployment_name",
+				"region":                       "us-east-1",
+				"version":                      "7.9.2",
+				"deployment_template_id":       "aws-cross-cluster-search-v2",
+				"destroy_mode":                 "terminate",
+				"update_strategy":              "partial",
+				"store_elasticsearch_password": "true",
+				"expose_credentials":           "true",
 
-				"elasticsearch.#":                   "1",
-				"elasticsearch.0.autoscale":         "",
-				"elasticsearch.0.cloud_id":          "",
-				"elasticsearch.0.snapshot_source.#": "0",
-				"elasticsearch.0.config.#":          "0",
-				"elasticsearch.0.extension.#":       "0",
-				"elasticsearch.0.http_endpoint":     "",
-				"elasticsearch.0.https_endpoint":    "",
-				"elasticsearch.0.ref_id":            "main-elasticsearch",
-				"elasticsearch.0.region":            "",
-				"elasticsearch.0.remote_cluster.#":  "0",
-				"elasticsearch.0.resource_id":       "",
-				"elasticsearch.0.topology.#":        "0",
-				"elasticsearch.0.trust_account.#":   "0",
-				"elasticsearch.0.trust_external.#":  "0",
-				"elasticsearch.0.strategy.#":        "0",
+				"elasticsearch.#":                             "1",
+				"elasticsearch.0.autoscale":                   "",
+				"elasticsearch.0.cloud_id":                    "",
+				"elasticsearch.0.snapshot_source.#":           "0",
+				"elasticsearch.0.config.#":                    "0",
+				"elasticsearch.0.extension.#":                 "0",
+				"elasticsearch.0.http_endpoint":               "",
+				"elasticsearch.0.https_endpoint":              "",
+				"elasticsearch.0.maintenance_mode":            "false",
+				"elasticsearch.0.ref_id":                      "main-elasticsearch",
+				"elasticsearch.0.region":                      "",
+				"elasticsearch.0.remote_cluster.#":            "0",
+				"elasticsearch.0.resource_id":                 "",
+				"elasticsearch.0.topology.#":                  "0",
+				"elasticsearch.0.trust_account.#":             "0",
+				"elasticsearch.0.trust_external.#":            "0",
+				"elasticsearch.0.strategy.#":                  "0",
+				"elasticsearch.0.keystore_contents.%":         "0",
+				"elasticsearch.0.snapshot.#":                  "0",
+				"elasticsearch.0.dedicated_masters_threshold": "0",
+				"elasticsearch.0.oidc.#":                      "0",
+				"elasticsearch.0.saml.#":                      "0",
+				"elasticsearch.0.snapshot_repository":         "",
+				"elasticsearch.0.cold.#":                      "0",
+				"elasticsearch.0.coordinating.#":              "0",
+				"elasticsearch.0.frozen.#":                    "0",
+				"elasticsearch.0.hot_content.#":               "0",
+				"elasticsearch.0.master.#":                    "0",
+				"elasticsearch.0.ml.#":                        "0",
+				"elasticsearch.0.warm.#":                      "0",
 			},
 		},
 		{
 			name: "fails with a non importable version (5.6.1)",
 			args: args{
 				d: deploymentWithNonImportableVersion,
-				m: api.NewMock(mock.New200Response(mock.NewStructBody(models.DeploymentGetResponse{
+				m: util.NewMockMeta(mock.New200Response(mock.NewStructBody(models.DeploymentGetResponse{
 					Resources: &models.DeploymentResources{Elasticsearch: []*models.ElasticsearchResourceInfo{
 						{
 							Info: &models.ElasticsearchClusterInfo{
@@ -150,34 +167,52 @@ func Test_importFunc(t *testing.T) {
 			want: map[string]string{
 				"id": "320b7b540dfc967a7a649c18e2fce4ed",
 
-				"name":                   "my_deployment_name",
-				"region":                 "us-east-1",
-				"version":                "5.6.1",
-				"deployment_template_id": "aws-cross-cluster-search-v2",
+				"name":                         "my_deployment_name",
+				"region":                       "us-east-1",
+				"version":                      "5.6.1",
+				"deployment_template_id":       "aws-cross-cluster-search-v2",
+				"destroy_mode":                 "terminate",
+				"update_strategy":              "partial",
+				"store_elasticsearch_password": "true",
+				"expose_credentials":           "true",
 
-				"elasticsearch.#":                   "1",
-				"elasticsearch.0.autoscale":         "",
-				"elasticsearch.0.cloud_id":          "",
-				"elasticsearch.0.snapshot_source.#": "0",
-				"elasticsearch.0.config.#":          "0",
-				"elasticsearch.0.extension.#":       "0",
-				"elasticsearch.0.http_endpoint":     "",
-				"elasticsearch.0.https_endpoint":    "",
-				"elasticsearch.0.ref_id":            "main-elasticsearch",
-				"elasticsearch.0.region":            "",
-				"elasticsearch.0.remote_cluster.#":  "0",
-				"elasticsearch.0.resource_id":       "",
-				"elasticsearch.0.topology.#":        "0",
-				"elasticsearch.0.trust_account.#":   "0",
-				"elasticsearch.0.trust_external.#":  "0",
-				"elasticsearch.0.strategy.#":        "0",
+				"elasticsearch.#":                             "1",
+				"elasticsearch.0.autoscale":                   "",
+				"elasticsearch.0.cloud_id":                    "",
+				"elasticsearch.0.snapshot_source.#":           "0",
+				"elasticsearch.0.config.#":                    "0",
+				"elasticsearch.0.extension.#":                 "0",
+				"elasticsearch.0.http_endpoint":               "",
+				"elasticsearch.0.https_endpoint":              "",
+				"elasticsearch.0.maintenance_mode":            "false",
+				"elasticsearch.0.ref_id":                      "main-elasticsearch",
+				"elasticsearch.0.region":                      "",
+				"elasticsearch.0.remote_cluster.#":            "0",
+				"elasticsearch.0.resource_id":                 "",
+				"elasticsearch.0.topology.#":                  "0",
+				"elasticsearch.0.trust_account.#":             "0",
+				"elasticsearch.0.trust_external.#":            "0",
+				"elasticsearch.0.strategy.#":                  "0",
+				"elasticsearch.0.keystore_contents.%":         "0",
+				"elasticsearch.0.snapshot.#":                  "0",
+				"elasticsearch.0.dedicated_masters_threshold": "0",
+				"elasticsearch.0.oidc.#":                      "0",
+				"elasticsearch.0.saml.#":                      "0",
+				"elasticsearch.0.snapshot_repository":         "",
+				"elasticsearch.0.cold.#":                      "0",
+				"elasticsearch.0.coordinating.#":              "0",
+				"elasticsearch.0.frozen.#":                    "0",
+				"elasticsearch.0.hot_content.#":               "0",
+				"elasticsearch.0.master.#":                    "0",
+				"elasticsearch.0.ml.#":                        "0",
+				"elasticsearch.0.warm.#":                      "0",
 			},
 		},
 		{
 			name: "fails with a non importable version (6.5.1)",
 			args: args{
 				d: deploymentWithNonImportableVersionSix,
-				m: api.NewMock(mock.New200Response(mock.NewStructBody(models.DeploymentGetResponse{
+				m: util.NewMockMeta(mock.New200Response(mock.NewStructBody(models.DeploymentGetResponse{
 					Resources: &models.DeploymentResources{Elasticsearch: []*models.ElasticsearchResourceInfo{
 						{
 							Info: &models.ElasticsearchClusterInfo{
@@ -199,27 +234,45 @@ func Test_importFunc(t *testing.T) {
 			want: map[string]string{
 				"id": "320b7b540dfc967a7a649c18e2fce4ed",
 
-				"name":                   "my_deployment_name",
-				"region":                 "us-east-1",
-				"version":                "6.5.1",
-				"deployment_template_id": "aws-cross-cluster-search-v2",
+				"name":                         "my_deployment_name",
+				"region":                       "us-east-1",
+				"version":                      "6.5.1",
+				"deployment_template_id":       "aws-cross-cluster-search-v2",
+				"destroy_mode":                 "terminate",
+				"update_strategy":              "partial",
+				"store_elasticsearch_password": "true",
+				"expose_credentials":           "true",
 
-				"elasticsearch.#":                   "1",
-				"elasticsearch.0.autoscale":         "",
-				"elasticsearch.0.cloud_id":          "",
-				"elasticsearch.0.snapshot_source.#": "0",
-				"elasticsearch.0.config.#":          "0",
-				"elasticsearch.0.extension.#":       "0",
-				"elasticsearch.0.http_endpoint":     "",
-				"elasticsearch.0.https_endpoint":    "",
-				"elasticsearch.0.ref_id":            "main-elasticsearch",
-				"elasticsearch.0.region":            "",
-				"elasticsearch.0.remote_cluster.#":  "0",
-				"elasticsearch.0.resource_id":       "",
-				"elasticsearch.0.topology.#":        "0",
-				"elasticsearch.0.trust_account.#":   "0",
-				"elasticsearch.0.trust_external.#":  "0",
-				"elasticsearch.0.strategy.#":        "0",
+				"elasticsearch.#":                             "1",
+				"elasticsearch.0.autoscale":                   "",
+				"elasticsearch.0.cloud_id":                    "",
+				"elasticsearch.0.snapshot_source.#":           "0",
+				"elasticsearch.0.config.#":                    "0",
+				"elasticsearch.0.extension.#":                 "0",
+				"elasticsearch.0.http_endpoint":               "",
+				"elasticsearch.0.https_endpoint":              "",
+				"elasticsearch.0.maintenance_mode":            "false",
+				"elasticsearch.0.ref_id":                      "main-elasticsearch",
+				"elasticsearch.0.region":                      "",
+				"elasticsearch.0.remote_cluster.#":            "0",
+				"elasticsearch.0.resource_id":                 "",
+				"elasticsearch.0.topology.#":                  "0",
+				"elasticsearch.0.trust_account.#":             "0",
+				"elasticsearch.0.trust_external.#":            "0",
+				"elasticsearch.0.strategy.#":                  "0",
+				"elasticsearch.0.keystore_contents.%":         "0",
+				"elasticsearch.0.snapshot.#":                  "0",
+				"elasticsearch.0.dedicated_masters_threshold": "0",
+				"elasticsearch.0.oidc.#":                      "0",
+				"elasticsearch.0.saml.#":                      "0",
+				"elasticsearch.0.snapshot_repository":         "",
+				"elasticsearch.0.cold.#":                      "0",
+				"elasticsearch.0.coordinating.#":              "0",
+				"elasticsearch.0.frozen.#":                    "0",
+				"elasticsearch.0.hot_content.#":               "0",
+				"elasticsearch.0.master.#":                    "0",
+				"elasticsearch.0.ml.#":                        "0",
+				"elasticsearch.0.warm.#":                      "0",
 			},
 		},
 	}
@@ -81,6 +81,13 @@ func expandIntegrationsServerResource(raw interface{}, res *models.IntegrationsS
 		res.Plan.ClusterTopology = defaultIntegrationsServerTopology(res.Plan.ClusterTopology)
 	}
 
+	if strategy := expandPlanStrategy(integrationsServer["strategy"]); strategy != nil {
+		if res.Plan.Transient == nil {
+			res.Plan.Transient = &models.TransientIntegrationsServerPlanConfiguration{}
+		}
+		res.Plan.Transient.Strategy = strategy
+	}
+
 	return res, nil
 }
 
@@ -161,6 +168,10 @@ func expandIntegrationsServerConfig(raw []interface{}, res *models.IntegrationsS
 		if v, ok := cfg["docker_image"].(string); ok {
 			res.DockerImage = v
 		}
+
+		if v, ok := cfg["mode"].(string); ok && v != "" {
+			res.Mode = v
+		}
 	}
 
 	return nil
@@ -32,7 +32,11 @@ func flattenIntegrationsServerResources(in []*models.IntegrationsServerResourceI
 	var result = make([]interface{}, 0, len(in))
 	for _, res := range in {
 		var m = make(map[string]interface{})
-		if util.IsCurrentIntegrationsServerPlanEmpty(res) || isIntegrationsServerResourceStopped(res) {
+		if util.IsCurrentIntegrationsServerPlanEmpty(res) {
+			continue
+		}
+
+		if isIntegrationsServerResourceStopped(res) && !isDisabledIntegrationsServerPlan(res.Info.PlanInfo.Current.Plan) {
 			continue
 		}
 
@@ -48,6 +52,10 @@ func flattenIntegrationsServerResources(in []*models.IntegrationsServerResourceI
 			m["region"] = *res.Region
 		}
 
+		if inMaintenanceMode(res.Info.Topology) {
+			m["maintenance_mode"] = true
+		}
+
 		plan := res.Info.PlanInfo.Current.Plan
 		if topology := flattenIntegrationsServerTopology(plan); len(topology) > 0 {
 			m["topology"] = topology
@@ -77,9 +85,10 @@ func flattenIntegrationsServerResources(in []*models.IntegrationsServerResourceI
 
 func flattenIntegrationsServerTopology(plan *models.IntegrationsServerPlan) []interface{} {
 	var result = make([]interface{}, 0, len(plan.ClusterTopology))
+	disabled := isDisabledIntegrationsServerPlan(plan)
 	for _, topology := range plan.ClusterTopology {
 		var m = make(map[string]interface{})
-		if topology.Size == nil || topology.Size.Value == nil || *topology.Size.Value == 0 {
+		if !disabled && (topology.Size == nil || topology.Size.Value == nil || *topology.Size.Value == 0) {
 			continue
 		}
 
@@ -87,8 +96,11 @@ func flattenIntegrationsServerTopology(plan *models.IntegrationsServerPlan) []in
 			m["instance_configuration_id"] = topology.InstanceConfigurationID
 		}
 
-		if topology.Size != nil {
+		if topology.Size != nil && topology.Size.Value != nil {
 			m["size"] = util.MemoryToState(*topology.Size.Value)
+		}
+
+		if topology.Size != nil && topology.Size.Resource != nil {
 			m["size_resource"] = *topology.Size.Resource
 		}
 
@@ -130,6 +142,10 @@ func flattenIntegrationsServerConfig(cfg *models.IntegrationsServerConfiguration
 		m["docker_image"] = cfg.DockerImage
 	}
 
+	if cfg.Mode != "" {
+		m["mode"] = cfg.Mode
+	}
+
 	for k, v := range flattenIntegrationsServerSystemConfig(cfg.SystemSettings) {
 		m[k] = v
 	}
@@ -300,6 +300,7 @@ func Test_flattenIntegrationsServerResource(t *testing.T) {
 									UserSettingsOverrideJSON: map[string]interface{}{
 										"some.setting": "value2",
 									},
+									Mode: "managed",
 									SystemSettings: &models.IntegrationsServerSystemSettings{
 										DebugEnabled: ec.Bool(true),
 									},
@@ -340,6 +341,7 @@ func Test_flattenIntegrationsServerResource(t *testing.T) {
 					"user_settings_json":          "{\"some.setting\":\"value\"}",
 					"user_settings_override_json": "{\"some.setting\":\"value2\"}",
 
+					"mode":          "managed",
 					"debug_enabled": true,
 				}},
 			}},
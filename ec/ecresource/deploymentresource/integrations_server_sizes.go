@@ -0,0 +1,151 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// validateIntegrationsServerSizeDiff fails the plan when any integrations_server
+// topology size isn't one of the discrete sizes offered by its instance
+// configuration in the selected deployment template. The template is only
+// obtained when the integrations_server resource has changed.
+func validateIntegrationsServerSizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("integrations_server") {
+		return nil
+	}
+
+	// When any of the values are interpolated from other resources, they
+	// won't be known until apply time, so the check can't be performed.
+	for _, k := range []string{"integrations_server", "deployment_template_id", "region"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	raw := d.Get("integrations_server").([]interface{})
+	if len(raw) == 0 {
+		return nil
+	}
+
+	template, err := getDiffTemplate(ctx, meta.(*util.ProviderMeta).API,
+		d.Get("deployment_template_id").(string), d.Get("region").(string),
+	)
+	if err != nil {
+		return multierror.NewPrefixed(
+			"failed obtaining the deployment template instance configurations", err,
+		)
+	}
+
+	return validateIntegrationsServerSizes(raw, template)
+}
+
+// validateIntegrationsServerSizes validates that the integrations_server
+// topology sizes are allowed by their instance configurations, returning an
+// error which lists the allowed sizes otherwise.
+func validateIntegrationsServerSizes(raw []interface{}, template *models.DeploymentTemplateInfoV2) error {
+	tpl := integrationsServerResource(template)
+	if tpl == nil || tpl.Plan == nil {
+		return nil
+	}
+
+	merr := multierror.NewPrefixed("invalid integrations_server size")
+	for _, rawRes := range raw {
+		res, ok := rawRes.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		rawTopologies, _ := res["topology"].([]interface{})
+		for i, rawTop := range rawTopologies {
+			topology, ok := rawTop.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			size, err := util.ParseTopologySize(topology)
+			if err != nil {
+				merr = merr.Append(err)
+				continue
+			}
+			// A zero size disables the resource, it's always allowed.
+			if size == nil || (size.Value != nil && *size.Value == 0) {
+				continue
+			}
+
+			icID, _ := topology["instance_configuration_id"].(string)
+			if t := tpl.Plan.ClusterTopology; icID == "" && len(t) > i {
+				icID = t[i].InstanceConfigurationID
+			}
+
+			sizes := discreteSizes(template.InstanceConfigurations, icID, *size.Resource)
+			if len(sizes) == 0 || containsSize(sizes, *size.Value) {
+				continue
+			}
+
+			merr = merr.Append(fmt.Errorf(
+				`size "%s" is not supported by the "%s" instance configuration, allowed sizes: %s`,
+				util.MemoryToState(*size.Value), icID, formatSizes(sizes),
+			))
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// discreteSizes returns the allowed sizes of the specified instance
+// configuration, only when the sizes are expressed in the same resource.
+func discreteSizes(ics []*models.InstanceConfigurationInfo, id, resource string) []int32 {
+	for _, ic := range ics {
+		if ic == nil || ic.ID != id || ic.DiscreteSizes == nil {
+			continue
+		}
+
+		if r := ic.DiscreteSizes.Resource; r != nil && *r != resource {
+			return nil
+		}
+		return ic.DiscreteSizes.Sizes
+	}
+
+	return nil
+}
+
+func containsSize(sizes []int32, size int32) bool {
+	for _, s := range sizes {
+		if s == size {
+			return true
+		}
+	}
+	return false
+}
+
+func formatSizes(sizes []int32) string {
+	formatted := make([]string, 0, len(sizes))
+	for _, s := range sizes {
+		formatted = append(formatted, util.MemoryToState(s))
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,121 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateIntegrationsServerSizes(t *testing.T) {
+	template := &models.DeploymentTemplateInfoV2{
+		DeploymentTemplate: &models.DeploymentCreateRequest{
+			Resources: &models.DeploymentCreateResources{
+				IntegrationsServer: []*models.IntegrationsServerPayload{{
+					Plan: &models.IntegrationsServerPlan{
+						ClusterTopology: []*models.IntegrationsServerTopologyElement{{
+							InstanceConfigurationID: "aws.integrationsserver.r5d",
+						}},
+					},
+				}},
+			},
+		},
+		InstanceConfigurations: []*models.InstanceConfigurationInfo{{
+			ID: "aws.integrationsserver.r5d",
+			DiscreteSizes: &models.DiscreteSizes{
+				Resource:    ec.String("memory"),
+				DefaultSize: ec.Int32(1024),
+				Sizes:       []int32{512, 1024, 2048, 4096, 8192},
+			},
+		}},
+	}
+
+	type args struct {
+		raw      []interface{}
+		template *models.DeploymentTemplateInfoV2
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "succeeds when no topology is set",
+			args: args{template: template, raw: []interface{}{
+				map[string]interface{}{"ref_id": "main-integrations_server"},
+			}},
+		},
+		{
+			name: "succeeds with an allowed size",
+			args: args{template: template, raw: []interface{}{
+				map[string]interface{}{"topology": []interface{}{
+					map[string]interface{}{"size": "2g"},
+				}},
+			}},
+		},
+		{
+			name: "succeeds when the template has no integrations_server",
+			args: args{
+				template: &models.DeploymentTemplateInfoV2{
+					DeploymentTemplate: &models.DeploymentCreateRequest{
+						Resources: &models.DeploymentCreateResources{},
+					},
+				},
+				raw: []interface{}{
+					map[string]interface{}{"topology": []interface{}{
+						map[string]interface{}{"size": "3g"},
+					}},
+				},
+			},
+		},
+		{
+			name: "succeeds when the size resource doesn't match the discrete sizes",
+			args: args{template: template, raw: []interface{}{
+				map[string]interface{}{"topology": []interface{}{
+					map[string]interface{}{"size": "3g", "size_resource": "storage"},
+				}},
+			}},
+		},
+		{
+			name: "fails with a size which isn't allowed",
+			args: args{template: template, raw: []interface{}{
+				map[string]interface{}{"topology": []interface{}{
+					map[string]interface{}{
+						"size":                      "3g",
+						"instance_configuration_id": "aws.integrationsserver.r5d",
+					},
+				}},
+			}},
+			err: errors.New("invalid integrations_server size: 1 error occurred:\n\t* size \"3g\" is not supported by the \"aws.integrationsserver.r5d\" instance configuration, allowed sizes: 0.5g, 1g, 2g, 4g, 8g\n\n"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateIntegrationsServerSizes(tt.args.raw, tt.args.template)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"encoding/json"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/eskeystoreapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const keystoreContentsKey = "elasticsearch.0.keystore_contents"
+
+// handleKeystoreContents sets the Elasticsearch keystore secure settings of
+// "keystore_contents" which are added or changed, and removes the ones which
+// are no longer set. Settings which are managed elsewhere, such as through
+// the ec_deployment_elasticsearch_keystore resource, are left untouched.
+func handleKeystoreContents(d *schema.ResourceData, client *api.API) error {
+	if !d.HasChange(keystoreContentsKey) {
+		return nil
+	}
+
+	prev, next := d.GetChange(keystoreContentsKey)
+	contents := expandKeystoreContents(prev, next)
+	if contents == nil {
+		return nil
+	}
+
+	if _, err := eskeystoreapi.Update(eskeystoreapi.UpdateParams{
+		API:          client,
+		DeploymentID: d.Id(),
+		Contents:     contents,
+		RefID:        resourceRefID(d, "elasticsearch"),
+	}); err != nil {
+		return multierror.NewPrefixed("failed updating the elasticsearch keystore", err)
+	}
+
+	return nil
+}
+
+// expandKeystoreContents returns the keystore contents which set the changed
+// secure settings and unset the removed ones, or nil when nothing changed.
+func expandKeystoreContents(prev, next interface{}) *models.KeystoreContents {
+	oldSettings, _ := prev.(map[string]interface{})
+	newSettings, _ := next.(map[string]interface{})
+
+	var secrets = make(map[string]models.KeystoreSecret)
+	for name, raw := range newSettings {
+		value, _ := raw.(string)
+		if old, ok := oldSettings[name]; ok && old == raw {
+			continue
+		}
+		secrets[name] = models.KeystoreSecret{Value: keystoreValue(value)}
+	}
+
+	// Since the Update API patches the keystore, the removed settings are
+	// unset by sending them without a value.
+	for name := range oldSettings {
+		if _, ok := newSettings[name]; !ok {
+			secrets[name] = models.KeystoreSecret{}
+		}
+	}
+
+	if len(secrets) == 0 {
+		return nil
+	}
+
+	return &models.KeystoreContents{Secrets: secrets}
+}
+
+// keystoreValue unmarshals JSON values, such as service account credentials,
+// returning the value as is when it isn't JSON.
+func keystoreValue(value string) interface{} {
+	var v interface{}
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		return value
+	}
+	return v
+}
+
+// flattenKeystoreContents keeps the configured "keystore_contents" in the
+// state, since the API doesn't return the secure settings values.
+func flattenKeystoreContents(d *schema.ResourceData, contents map[string]interface{}) error {
+	if len(contents) == 0 {
+		return nil
+	}
+
+	es, ok := d.Get("elasticsearch").([]interface{})
+	if !ok || len(es) == 0 || es[0] == nil {
+		return nil
+	}
+
+	es[0].(map[string]interface{})["keystore_contents"] = contents
+	return d.Set("elasticsearch", es)
+}
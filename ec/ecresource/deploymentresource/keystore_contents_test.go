@@ -0,0 +1,140 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_expandKeystoreContents(t *testing.T) {
+	tests := []struct {
+		name string
+		prev interface{}
+		next interface{}
+		want *models.KeystoreContents
+	}{
+		{
+			name: "returns nil when nothing changed",
+			prev: map[string]interface{}{"xpack.notification.slack.account.hook": "https://hooks"},
+			next: map[string]interface{}{"xpack.notification.slack.account.hook": "https://hooks"},
+		},
+		{
+			name: "sets the added and changed settings",
+			prev: map[string]interface{}{
+				"xpack.notification.slack.account.hook": "https://hooks",
+				"s3.client.default.access_key":          "old-key",
+			},
+			next: map[string]interface{}{
+				"xpack.notification.slack.account.hook": "https://hooks",
+				"s3.client.default.access_key":          "new-key",
+				"gcs.client.default.credentials_file":   `{"type":"service_account"}`,
+			},
+			want: &models.KeystoreContents{Secrets: map[string]models.KeystoreSecret{
+				"s3.client.default.access_key": {Value: "new-key"},
+				"gcs.client.default.credentials_file": {Value: map[string]interface{}{
+					"type": "service_account",
+				}},
+			}},
+		},
+		{
+			name: "unsets the removed settings",
+			prev: map[string]interface{}{"s3.client.default.access_key": "some-key"},
+			next: map[string]interface{}{},
+			want: &models.KeystoreContents{Secrets: map[string]models.KeystoreSecret{
+				"s3.client.default.access_key": {},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, expandKeystoreContents(tt.prev, tt.next))
+		})
+	}
+}
+
+func Test_handleKeystoreContents(t *testing.T) {
+	newRaw := func(contents map[string]interface{}) map[string]interface{} {
+		raw := newSampleLegacyDeployment()
+		es := raw["elasticsearch"].([]interface{})[0].(map[string]interface{})
+		es["keystore_contents"] = contents
+		return raw
+	}
+	newRD := func(prev, next map[string]interface{}) *schema.ResourceData {
+		return util.NewResourceData(t, util.ResDataParams{
+			ID:     mock.ValidClusterID,
+			Schema: newSchema(),
+			State:  newRaw(prev),
+			Change: newRaw(next),
+		})
+	}
+
+	tests := []struct {
+		name   string
+		d      *schema.ResourceData
+		client *api.API
+		err    error
+	}{
+		{
+			name: "does nothing when the keystore contents are unchanged",
+			d: newRD(
+				map[string]interface{}{"s3.client.default.access_key": "some-key"},
+				map[string]interface{}{"s3.client.default.access_key": "some-key"},
+			),
+			// Any call would fail with an empty mock.
+			client: api.NewMock(),
+		},
+		{
+			name: "updates the keystore when the keystore contents change",
+			d: newRD(
+				map[string]interface{}{"s3.client.default.access_key": "some-key"},
+				map[string]interface{}{"s3.client.default.access_key": "new-key"},
+			),
+			client: api.NewMock(mock.New200Response(mock.NewStructBody(models.KeystoreContents{}))),
+		},
+		{
+			name: "returns the error updating the keystore",
+			d: newRD(
+				nil,
+				map[string]interface{}{"s3.client.default.access_key": "some-key"},
+			),
+			client: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				Code: "some", Message: "message",
+			})),
+			err: errors.New("failed updating the elasticsearch keystore: 1 error occurred:\n\t* api error: some: message\n\n"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handleKeystoreContents(tt.d, tt.client)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
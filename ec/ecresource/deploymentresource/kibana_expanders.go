@@ -81,6 +81,13 @@ func expandKibanaResource(raw interface{}, res *models.KibanaPayload) (*models.K
 		res.Plan.ClusterTopology = defaultKibanaTopology(res.Plan.ClusterTopology)
 	}
 
+	if strategy := expandPlanStrategy(kibana["strategy"]); strategy != nil {
+		if res.Plan.Transient == nil {
+			res.Plan.Transient = &models.TransientKibanaPlanConfiguration{}
+		}
+		res.Plan.Transient.Strategy = strategy
+	}
+
 	return res, nil
 }
 
@@ -151,6 +158,12 @@ func expandKibanaConfig(raw interface{}, res *models.KibanaConfiguration) error
 		if v, ok := cfg["docker_image"].(string); ok && v != "" {
 			res.DockerImage = v
 		}
+
+		if v, ok := cfg["solution"].(string); ok && v != "" {
+			if err := expandKibanaSolution(v, res); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
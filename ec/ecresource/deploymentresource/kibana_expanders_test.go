@@ -288,6 +288,49 @@ func Test_expandKibanaResources(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "parses a kibana resource with a grow_and_shrink strategy",
+			args: args{
+				tpl: tpl(),
+				ess: []interface{}{map[string]interface{}{
+					"ref_id":                       "main-kibana",
+					"elasticsearch_cluster_ref_id": "somerefid",
+					"resource_id":                  mock.ValidClusterID,
+					"region":                       "some-region",
+					"topology": []interface{}{map[string]interface{}{
+						"instance_configuration_id": "aws.kibana.r5d",
+						"size":                      "2g",
+						"zone_count":                1,
+					}},
+					"strategy": []interface{}{map[string]interface{}{
+						"type": "grow_and_shrink",
+					}},
+				}},
+			},
+			want: []*models.KibanaPayload{
+				{
+					ElasticsearchClusterRefID: ec.String("somerefid"),
+					Region:                    ec.String("some-region"),
+					RefID:                     ec.String("main-kibana"),
+					Plan: &models.KibanaClusterPlan{
+						Kibana: &models.KibanaConfiguration{},
+						ClusterTopology: []*models.KibanaClusterTopologyElement{{
+							ZoneCount:               1,
+							InstanceConfigurationID: "aws.kibana.r5d",
+							Size: &models.TopologySize{
+								Resource: ec.String("memory"),
+								Value:    ec.Int32(2048),
+							},
+						}},
+						Transient: &models.TransientKibanaPlanConfiguration{
+							Strategy: &models.PlanStrategy{
+								GrowAndShrink: new(models.GrowShrinkStrategyConfig),
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "tries to parse an kibana resource when the template doesn't have a kibana instance set.",
 			args: args{
@@ -31,7 +31,11 @@ func flattenKibanaResources(in []*models.KibanaResourceInfo, name string) []inte
 	result := make([]interface{}, 0, len(in))
 	for _, res := range in {
 		m := make(map[string]interface{})
-		if util.IsCurrentKibanaPlanEmpty(res) || isKibanaResourceStopped(res) {
+		if util.IsCurrentKibanaPlanEmpty(res) {
+			continue
+		}
+
+		if isKibanaResourceStopped(res) && !isDisabledKibanaPlan(res.Info.PlanInfo.Current.Plan) {
 			continue
 		}
 
@@ -47,8 +51,13 @@ func flattenKibanaResources(in []*models.KibanaResourceInfo, name string) []inte
 			m["region"] = *res.Region
 		}
 
+		if inMaintenanceMode(res.Info.Topology) {
+			m["maintenance_mode"] = true
+		}
+
 		plan := res.Info.PlanInfo.Current.Plan
 		if topology := flattenKibanaTopology(plan); len(topology) > 0 {
+			util.FlattenTopologyInstances(topology, res.Info.Topology)
 			m["topology"] = topology
 		}
 
@@ -72,9 +81,10 @@ func flattenKibanaResources(in []*models.KibanaResourceInfo, name string) []inte
 
 func flattenKibanaTopology(plan *models.KibanaClusterPlan) []interface{} {
 	var result = make([]interface{}, 0, len(plan.ClusterTopology))
+	disabled := isDisabledKibanaPlan(plan)
 	for _, topology := range plan.ClusterTopology {
 		var m = make(map[string]interface{})
-		if topology.Size == nil || topology.Size.Value == nil || *topology.Size.Value == 0 {
+		if !disabled && (topology.Size == nil || topology.Size.Value == nil || *topology.Size.Value == 0) {
 			continue
 		}
 
@@ -82,10 +92,12 @@ func flattenKibanaTopology(plan *models.KibanaClusterPlan) []interface{} {
 			m["instance_configuration_id"] = topology.InstanceConfigurationID
 		}
 
-		if topology.Size != nil {
+		if topology.Size != nil && topology.Size.Value != nil {
 			m["size"] = util.MemoryToState(*topology.Size.Value)
-			m["size_resource"] = *topology.Size.Resource
+		}
 
+		if topology.Size != nil && topology.Size.Resource != nil {
+			m["size_resource"] = *topology.Size.Resource
 		}
 
 		m["zone_count"] = topology.ZoneCount
@@ -110,7 +122,12 @@ func flattenKibanaConfig(cfg *models.KibanaConfiguration) []interface{} {
 		m["user_settings_override_yaml"] = cfg.UserSettingsOverrideYaml
 	}
 
-	if o := cfg.UserSettingsJSON; o != nil {
+	solution, userSettings := flattenKibanaSolution(cfg.UserSettingsJSON)
+	if solution != "" {
+		m["solution"] = solution
+	}
+
+	if o := userSettings; o != nil {
 		if b, _ := json.Marshal(o); len(b) > 0 && !bytes.Equal([]byte("{}"), b) {
 			m["user_settings_json"] = string(b)
 		}
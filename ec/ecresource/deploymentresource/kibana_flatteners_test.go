@@ -214,6 +214,57 @@ func Test_flattenKibanaResources(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "keeps a stopped kibana resource which has been disabled with a zero size",
+			args: args{in: []*models.KibanaResourceInfo{
+				{
+					Region:                    ec.String("some-region"),
+					RefID:                     ec.String("main-kibana"),
+					ElasticsearchClusterRefID: ec.String("main-elasticsearch"),
+					Info: &models.KibanaClusterInfo{
+						ClusterID:   &mock.ValidClusterID,
+						ClusterName: ec.String("some-kibana-name"),
+						Region:      "some-region",
+						Status:      ec.String("stopped"),
+						PlanInfo: &models.KibanaClusterPlansInfo{
+							Current: &models.KibanaClusterPlanInfo{
+								Plan: &models.KibanaClusterPlan{
+									Kibana: &models.KibanaConfiguration{
+										Version: "7.7.0",
+									},
+									ClusterTopology: []*models.KibanaClusterTopologyElement{
+										{
+											ZoneCount:               1,
+											InstanceConfigurationID: "aws.kibana.r4",
+											Size: &models.TopologySize{
+												Resource: ec.String("memory"),
+												Value:    ec.Int32(0),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}},
+			want: []interface{}{
+				map[string]interface{}{
+					"elasticsearch_cluster_ref_id": "main-elasticsearch",
+					"ref_id":                       "main-kibana",
+					"resource_id":                  mock.ValidClusterID,
+					"region":                       "some-region",
+					"topology": []interface{}{
+						map[string]interface{}{
+							"instance_configuration_id": "aws.kibana.r4",
+							"size":                      "0g",
+							"size_resource":             "memory",
+							"zone_count":                int32(1),
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,113 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+// kibanaSolutionSetting is the Kibana setting which sets the solution view of
+// the default space when Kibana starts for the first time.
+const kibanaSolutionSetting = "xpack.cloud.onboarding.default_solution"
+
+// kibanaSolutions maps the solution names accepted by the provider to the
+// values of the Kibana setting.
+var kibanaSolutions = map[string]string{
+	"search":        "es",
+	"observability": "oblt",
+	"security":      "security",
+}
+
+func kibanaSolutionNames() []string {
+	names := make([]string, 0, len(kibanaSolutions))
+	for name := range kibanaSolutions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// expandKibanaSolution sets the solution view setting in the Kibana user
+// settings JSON, along with any other user settings.
+func expandKibanaSolution(solution string, res *models.KibanaConfiguration) error {
+	value, ok := kibanaSolutions[solution]
+	if !ok {
+		return fmt.Errorf(`invalid kibana solution "%s"`, solution)
+	}
+
+	if res.UserSettingsYaml != "" {
+		return errors.New(
+			"kibana solution can't be combined with user_settings_yaml, set " +
+				kibanaSolutionSetting + " in user_settings_yaml instead",
+		)
+	}
+
+	var settings = make(map[string]interface{})
+	if res.UserSettingsJSON != nil {
+		m, ok := res.UserSettingsJSON.(map[string]interface{})
+		if !ok {
+			return errors.New("kibana solution requires user_settings_json to be a JSON object")
+		}
+		for k, v := range m {
+			settings[k] = v
+		}
+	}
+
+	settings[kibanaSolutionSetting] = value
+	res.UserSettingsJSON = settings
+	return nil
+}
+
+// flattenKibanaSolution returns the solution name set in the Kibana user
+// settings JSON, along with the rest of the user settings without it.
+func flattenKibanaSolution(userSettings interface{}) (string, interface{}) {
+	m, ok := userSettings.(map[string]interface{})
+	if !ok {
+		return "", userSettings
+	}
+
+	value, ok := m[kibanaSolutionSetting].(string)
+	if !ok {
+		return "", userSettings
+	}
+
+	var solution string
+	for name, v := range kibanaSolutions {
+		if v == value {
+			solution = name
+		}
+	}
+
+	// Unknown values are kept in the user settings.
+	if solution == "" {
+		return "", userSettings
+	}
+
+	var settings = make(map[string]interface{}, len(m)-1)
+	for k, v := range m {
+		if k != kibanaSolutionSetting {
+			settings[k] = v
+		}
+	}
+
+	return solution, settings
+}
@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandKibanaSolution(t *testing.T) {
+	type args struct {
+		solution string
+		res      *models.KibanaConfiguration
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.KibanaConfiguration
+		err  error
+	}{
+		{
+			name: "sets the solution in empty user settings",
+			args: args{solution: "observability", res: &models.KibanaConfiguration{}},
+			want: &models.KibanaConfiguration{UserSettingsJSON: map[string]interface{}{
+				"xpack.cloud.onboarding.default_solution": "oblt",
+			}},
+		},
+		{
+			name: "merges the solution with the user settings json",
+			args: args{solution: "search", res: &models.KibanaConfiguration{
+				UserSettingsJSON: map[string]interface{}{"xpack.fleet.enabled": true},
+			}},
+			want: &models.KibanaConfiguration{UserSettingsJSON: map[string]interface{}{
+				"xpack.fleet.enabled":                     true,
+				"xpack.cloud.onboarding.default_solution": "es",
+			}},
+		},
+		{
+			name: "fails when combined with user settings yaml",
+			args: args{solution: "security", res: &models.KibanaConfiguration{
+				UserSettingsYaml: "xpack.fleet.enabled: true",
+			}},
+			want: &models.KibanaConfiguration{UserSettingsYaml: "xpack.fleet.enabled: true"},
+			err:  errors.New("kibana solution can't be combined with user_settings_yaml, set xpack.cloud.onboarding.default_solution in user_settings_yaml instead"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := expandKibanaSolution(tt.args.solution, tt.args.res)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, tt.args.res)
+		})
+	}
+}
+
+func Test_flattenKibanaSolution(t *testing.T) {
+	tests := []struct {
+		name         string
+		userSettings interface{}
+		wantSolution string
+		wantSettings interface{}
+	}{
+		{
+			name: "returns nothing without user settings",
+		},
+		{
+			name:         "extracts the solution from the user settings",
+			userSettings: map[string]interface{}{"xpack.cloud.onboarding.default_solution": "security", "xpack.fleet.enabled": true},
+			wantSolution: "security",
+			wantSettings: map[string]interface{}{"xpack.fleet.enabled": true},
+		},
+		{
+			name:         "keeps an unknown solution in the user settings",
+			userSettings: map[string]interface{}{"xpack.cloud.onboarding.default_solution": "classic"},
+			wantSettings: map[string]interface{}{"xpack.cloud.onboarding.default_solution": "classic"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solution, settings := flattenKibanaSolution(tt.userSettings)
+			assert.Equal(t, tt.wantSolution, solution)
+			assert.Equal(t, tt.wantSettings, settings)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/depresourceapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func maintenanceModeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Optional flag which puts all of the resource instances in maintenance mode, so they stop receiving traffic through the proxies. Defaults to false",
+		Optional:    true,
+		Default:     false,
+	}
+}
+
+// inMaintenanceMode returns true when all of the resource instances are in
+// maintenance mode.
+func inMaintenanceMode(topology *models.ClusterTopologyInfo) bool {
+	if topology == nil || len(topology.Instances) == 0 {
+		return false
+	}
+
+	for _, instance := range topology.Instances {
+		if instance.MaintenanceMode == nil || !*instance.MaintenanceMode {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isMaintenanceModeAttribute returns true for the "maintenance_mode" resource
+// attributes, which are applied through their own API and don't require the
+// deployment to be updated.
+func isMaintenanceModeAttribute(attr string) bool {
+	for _, kind := range resourceKinds {
+		if attr == kind+".0.maintenance_mode" {
+			return true
+		}
+	}
+	return false
+}
+
+// handleMaintenanceMode starts or stops the maintenance mode of each resource
+// kind whose "maintenance_mode" has changed. On creation, it's only started
+// for the resource kinds which have it enabled.
+func handleMaintenanceMode(d *schema.ResourceData, client *api.API) error {
+	merr := multierror.NewPrefixed("failed changing the maintenance mode")
+	for _, kind := range resourceKinds {
+		key := kind + ".0.maintenance_mode"
+		enabled, _ := d.Get(key).(bool)
+
+		changed := d.HasChange(key)
+		if d.IsNewResource() {
+			changed = enabled
+		}
+		if !changed {
+			continue
+		}
+
+		refID := resourceRefID(d, kind)
+		if refID == "" {
+			continue
+		}
+
+		params := depresourceapi.Params{
+			API:          client,
+			DeploymentID: d.Id(),
+			Kind:         kind,
+			RefID:        refID,
+		}
+
+		var err error
+		if enabled {
+			_, err = depresourceapi.StartMaintenanceMode(depresourceapi.StartParams{
+				Params: params, All: true,
+			})
+		} else {
+			_, err = depresourceapi.StopMaintenanceMode(depresourceapi.StopParams{
+				Params: params, All: true,
+			})
+		}
+		if err != nil {
+			merr = merr.Append(fmt.Errorf("%s: %w", kind, err))
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
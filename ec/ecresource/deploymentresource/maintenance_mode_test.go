@@ -0,0 +1,137 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_inMaintenanceMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		topology *models.ClusterTopologyInfo
+		want     bool
+	}{
+		{
+			name: "no topology",
+		},
+		{
+			name:     "no instances",
+			topology: &models.ClusterTopologyInfo{},
+		},
+		{
+			name: "some instances in maintenance mode",
+			topology: &models.ClusterTopologyInfo{Instances: []*models.ClusterInstanceInfo{
+				{MaintenanceMode: ec.Bool(true)},
+				{MaintenanceMode: ec.Bool(false)},
+			}},
+		},
+		{
+			name: "all instances in maintenance mode",
+			topology: &models.ClusterTopologyInfo{Instances: []*models.ClusterInstanceInfo{
+				{MaintenanceMode: ec.Bool(true)},
+				{MaintenanceMode: ec.Bool(true)},
+			}},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, inMaintenanceMode(tt.topology))
+		})
+	}
+}
+
+func Test_isMaintenanceModeAttribute(t *testing.T) {
+	assert.True(t, isMaintenanceModeAttribute("kibana.0.maintenance_mode"))
+	assert.True(t, isMaintenanceModeAttribute("elasticsearch.0.maintenance_mode"))
+	assert.False(t, isMaintenanceModeAttribute("kibana.0.ref_id"))
+	assert.False(t, isMaintenanceModeAttribute("maintenance_mode"))
+}
+
+func Test_handleMaintenanceMode(t *testing.T) {
+	newKibana := func(maintenance bool) map[string]interface{} {
+		return map[string]interface{}{
+			"kibana": []interface{}{map[string]interface{}{
+				"ref_id":           "main-kibana",
+				"maintenance_mode": maintenance,
+			}},
+		}
+	}
+	newRD := func() *schema.ResourceData {
+		return util.NewResourceData(t, util.ResDataParams{
+			ID:     mock.ValidClusterID,
+			Schema: newSchema(),
+			State:  newKibana(false),
+			Change: newKibana(true),
+		})
+	}
+	unchanged := util.NewResourceData(t, util.ResDataParams{
+		ID:     mock.ValidClusterID,
+		Schema: newSchema(),
+		State:  newKibana(true),
+		Change: newKibana(true),
+	})
+
+	tests := []struct {
+		name   string
+		d      *schema.ResourceData
+		client *api.API
+		err    error
+	}{
+		{
+			name:   "starts the maintenance mode when enabled",
+			d:      newRD(),
+			client: api.NewMock(mock.New202Response(mock.NewStringBody("{}"))),
+		},
+		{
+			name: "does nothing when unchanged",
+			d:    unchanged,
+			// Any call would fail with an empty mock.
+			client: api.NewMock(),
+		},
+		{
+			name: "returns the API error",
+			d:    newRD(),
+			client: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				Code: "some", Message: "message",
+			})),
+			err: errors.New("failed changing the maintenance mode: 1 error occurred:\n\t* kibana: api error: 1 error occurred:\n\t* some: message\n\n\n\n"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handleMaintenanceMode(tt.d, tt.client)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
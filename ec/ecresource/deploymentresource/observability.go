@@ -18,13 +18,16 @@
 package deploymentresource
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/util"
 	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // flattenObservability parses a deployment's observability settings.
@@ -56,6 +59,37 @@ func flattenObservability(settings *models.DeploymentSettings) []interface{} {
 	return []interface{}{m}
 }
 
+// setObservability sets the deployment observability settings in the state,
+// unless they've been adopted from the deployment template, in which case
+// they're left out of the state so they aren't removed on the next apply.
+func setObservability(d *schema.ResourceData, settings *models.DeploymentSettings) error {
+	if adopted, _ := d.Get("observability_adopted").(bool); adopted {
+		return nil
+	}
+
+	if observability := flattenObservability(settings); len(observability) > 0 {
+		return d.Set("observability", observability)
+	}
+
+	return nil
+}
+
+// adoptObservabilityDiff adopts the observability settings provided by the
+// deployment template when the deployment is created without the
+// "observability" block. The adoption ends as soon as the block is set.
+func adoptObservabilityDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	configured := len(d.Get("observability").([]interface{})) > 0
+	if d.Id() == "" {
+		return d.SetNew("observability_adopted", !configured)
+	}
+
+	if adopted, _ := d.Get("observability_adopted").(bool); adopted && configured {
+		return d.SetNew("observability_adopted", false)
+	}
+
+	return nil
+}
+
 func expandObservability(raw []interface{}, client *api.API) (*models.DeploymentObservabilitySettings, error) {
 	if len(raw) == 0 {
 		return nil, nil
@@ -115,3 +149,23 @@ func expandObservability(raw []interface{}, client *api.API) (*models.Deployment
 
 	return &req, nil
 }
+
+// validateObservabilityDeploymentID rejects observability destinations which
+// aren't a deployment ID or "self". The API only ships logs and metrics to
+// deployments managed by the same environment, so clusters outside of it
+// can't be targeted by their endpoint.
+func validateObservabilityDeploymentID(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("%s: expected a string", k)}
+	}
+
+	if strings.ContainsAny(value, ":/@") {
+		return nil, []error{fmt.Errorf(
+			`%s: "%s" is not a deployment ID, the observability destination must be "self" or the ID of a deployment in the same Elastic Cloud environment, shipping to external clusters by endpoint isn't supported`,
+			k, value,
+		)}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,162 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	semver "github.com/blang/semver/v4"
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// observabilityTarget is the Elasticsearch resource of an observability
+// destination deployment.
+type observabilityTarget struct {
+	region  string
+	version string
+}
+
+// observabilityTargets caches the observability destination deployments, so
+// every deployment shipping to the same destination doesn't obtain it again
+// on each plan.
+var observabilityTargets = struct {
+	sync.Mutex
+	targets map[string]observabilityTarget
+}{targets: make(map[string]observabilityTarget)}
+
+// getObservabilityTarget returns the Elasticsearch resource of the
+// destination deployment, which is cached per API client.
+func getObservabilityTarget(client *api.API, id string) (observabilityTarget, error) {
+	key := fmt.Sprintf("%p/%s", client, id)
+
+	observabilityTargets.Lock()
+	defer observabilityTargets.Unlock()
+	if target, ok := observabilityTargets.targets[key]; ok {
+		return target, nil
+	}
+
+	res, err := deploymentapi.Get(deploymentapi.GetParams{
+		API:          client,
+		DeploymentID: id,
+		QueryParams:  deputil.QueryParams{ShowPlans: true},
+	})
+	if err != nil {
+		return observabilityTarget{}, multierror.NewPrefixed(
+			"failed obtaining the observability deployment", err,
+		)
+	}
+
+	if res.Resources == nil || len(res.Resources.Elasticsearch) == 0 {
+		return observabilityTarget{}, fmt.Errorf(
+			`observability: deployment "%s" has no elasticsearch resources`, id,
+		)
+	}
+
+	var target observabilityTarget
+	es := res.Resources.Elasticsearch[0]
+	if es.Region != nil {
+		target.region = *es.Region
+	}
+	if plan := currentEsPlan(es); plan != nil && plan.Elasticsearch != nil {
+		target.version = plan.Elasticsearch.Version
+	}
+
+	observabilityTargets.targets[key] = target
+	return target, nil
+}
+
+// validateObservabilityTargetDiff fails the plan when the observability
+// destination deployment doesn't exist, is in a different region or runs an
+// older major version than the deployment, since the API only rejects the
+// destination after the rest of the changes have been applied.
+func validateObservabilityTargetDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChanges("observability", "region", "version") {
+		return nil
+	}
+
+	for _, k := range []string{"observability.0.deployment_id", "region", "version"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	id, _ := d.Get("observability.0.deployment_id").(string)
+	if id == "" || id == "self" || id == d.Id() {
+		return nil
+	}
+
+	m, ok := meta.(*util.ProviderMeta)
+	if !ok {
+		return nil
+	}
+	client := m.API
+
+	target, err := getObservabilityTarget(client, id)
+	if err != nil {
+		return err
+	}
+
+	return validateObservabilityTarget(id, target, d.Get("region").(string), d.Get("version").(string))
+}
+
+// validateObservabilityTarget returns an error when the destination isn't in
+// the deployment region, or runs an older major version than the deployment.
+func validateObservabilityTarget(id string, target observabilityTarget, region, version string) error {
+	if region != "" && target.region != "" && target.region != region {
+		return fmt.Errorf(
+			`observability: deployment "%s" is in region "%s", logs and metrics can only be shipped to a deployment in the same region "%s"`,
+			id, target.region, region,
+		)
+	}
+
+	targetVersion, err := semver.Parse(target.version)
+	if err != nil {
+		return nil
+	}
+
+	v, err := semver.Parse(version)
+	if err != nil {
+		return nil
+	}
+
+	if targetVersion.Major < v.Major {
+		return fmt.Errorf(
+			`observability: deployment "%s" runs version %s, logs and metrics can only be shipped to a deployment running the same major version as %s or a later one`,
+			id, target.version, version,
+		)
+	}
+
+	return nil
+}
+
+// currentEsPlan returns the current plan of the Elasticsearch resource.
+func currentEsPlan(es *models.ElasticsearchResourceInfo) *models.ElasticsearchClusterPlan {
+	if es.Info == nil || es.Info.PlanInfo == nil || es.Info.PlanInfo.Current == nil {
+		return nil
+	}
+	return es.Info.PlanInfo.Current.Plan
+}
@@ -0,0 +1,102 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getObservabilityTarget(t *testing.T) {
+	// A single response is mocked, so the second call must be cached.
+	client := api.NewMock(mock.New200Response(mock.NewStructBody(models.DeploymentGetResponse{
+		ID: ec.String(mock.ValidClusterID),
+		Resources: &models.DeploymentResources{
+			Elasticsearch: []*models.ElasticsearchResourceInfo{{
+				Region: ec.String("us-east-1"),
+				RefID:  ec.String("main-elasticsearch"),
+				Info: &models.ElasticsearchClusterInfo{
+					PlanInfo: &models.ElasticsearchClusterPlansInfo{
+						Current: &models.ElasticsearchClusterPlanInfo{
+							Plan: &models.ElasticsearchClusterPlan{
+								Elasticsearch: &models.ElasticsearchConfiguration{
+									Version: "8.4.0",
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+	})))
+
+	want := observabilityTarget{region: "us-east-1", version: "8.4.0"}
+	for i := 0; i < 2; i++ {
+		got, err := getObservabilityTarget(client, mock.ValidClusterID)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func Test_validateObservabilityTarget(t *testing.T) {
+	target := observabilityTarget{region: "us-east-1", version: "7.17.0"}
+	tests := []struct {
+		name    string
+		region  string
+		version string
+		err     string
+	}{
+		{
+			name:    "same region and version",
+			region:  "us-east-1",
+			version: "7.17.0",
+		},
+		{
+			name:    "older minor version",
+			region:  "us-east-1",
+			version: "7.10.0",
+		},
+		{
+			name:    "different region",
+			region:  "eu-west-1",
+			version: "7.17.0",
+			err:     `observability: deployment "some-id" is in region "us-east-1", logs and metrics can only be shipped to a deployment in the same region "eu-west-1"`,
+		},
+		{
+			name:    "newer major version",
+			region:  "us-east-1",
+			version: "8.4.0",
+			err:     `observability: deployment "some-id" runs version 7.17.0, logs and metrics can only be shipped to a deployment running the same major version as 8.4.0 or a later one`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateObservabilityTarget("some-id", target, tt.region, tt.version)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
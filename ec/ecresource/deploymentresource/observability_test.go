@@ -25,6 +25,8 @@ import (
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
 )
 
 func TestFlattenObservability(t *testing.T) {
@@ -344,3 +346,68 @@ func TestExpandObservability(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateObservabilityDeploymentID(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		err   bool
+	}{
+		{name: "accepts a deployment ID", value: "320b7b540dfc967a7a649c18e2fce4ed"},
+		{name: "accepts self", value: "self"},
+		{name: "rejects an endpoint", value: "https://monitoring.example.com:9243", err: true},
+		{name: "rejects credentials", value: "elastic@monitoring", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := validateObservabilityDeploymentID(tt.value, "deployment_id")
+			assert.Equal(t, tt.err, len(errs) > 0)
+		})
+	}
+}
+
+func Test_setObservability(t *testing.T) {
+	settings := &models.DeploymentSettings{
+		Observability: &models.DeploymentObservabilitySettings{
+			Logging: &models.DeploymentLoggingSettings{
+				Destination: &models.ObservabilityAbsoluteDeployment{
+					DeploymentID: ec.String(mock.ValidClusterID),
+					RefID:        "main-elasticsearch",
+				},
+			},
+			Metrics: &models.DeploymentMetricsSettings{
+				Destination: &models.ObservabilityAbsoluteDeployment{
+					DeploymentID: ec.String(mock.ValidClusterID),
+					RefID:        "main-elasticsearch",
+				},
+			},
+		},
+	}
+
+	t.Run("sets the observability settings", func(t *testing.T) {
+		d := util.NewResourceData(t, util.ResDataParams{
+			ID:     mock.ValidClusterID,
+			State:  newSampleDeploymentEmptyRD(),
+			Schema: newSchema(),
+		})
+		assert.Nil(t, setObservability(d, settings))
+		assert.Equal(t, []interface{}{map[string]interface{}{
+			"deployment_id": mock.ValidClusterID,
+			"ref_id":        "main-elasticsearch",
+			"metrics":       true,
+			"logs":          true,
+		}}, d.Get("observability"))
+	})
+
+	t.Run("leaves out the observability settings adopted from the template", func(t *testing.T) {
+		state := newSampleDeploymentEmptyRD()
+		state["observability_adopted"] = true
+		d := util.NewResourceData(t, util.ResDataParams{
+			ID:     mock.ValidClusterID,
+			State:  state,
+			Schema: newSchema(),
+		})
+		assert.Nil(t, setObservability(d, settings))
+		assert.Empty(t, d.Get("observability"))
+	})
+}
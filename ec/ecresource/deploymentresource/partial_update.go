@@ -0,0 +1,183 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// fullUpdateStrategy sends all the resource kinds on every update and
+	// removes the ones which aren't part of the configuration.
+	fullUpdateStrategy = "full"
+
+	// partialUpdateStrategy only sends the changed resource kinds, removing
+	// the resource kinds which are removed from the configuration.
+	partialUpdateStrategy = "partial"
+
+	// settingsOnlyUpdateStrategy only sends the changed resource kinds and
+	// never removes any resource, leaving the resource kinds which aren't
+	// part of the configuration untouched.
+	settingsOnlyUpdateStrategy = "settings_only"
+)
+
+var updateStrategies = []string{
+	fullUpdateStrategy, partialUpdateStrategy, settingsOnlyUpdateStrategy,
+}
+
+// partialUpdate sets the update request "prune_orphans" flag and removes the
+// unchanged resource kinds from it, so the resources which haven't changed
+// are left untouched rather than having a no-op plan applied to them.
+//
+// With the "full" update strategy, the full payload is sent with the flag set,
+// so any resource which isn't part of the configuration is removed. With the
+// "partial" one, orphans are only pruned when a resource kind has been removed
+// from the configuration, since that's the only way to remove it. With the
+// "settings_only" one, orphans are never pruned. The full payload is also sent
+// when the version or the deployment template are changed, since they apply
+// to all the resources.
+func partialUpdate(d *schema.ResourceData, req *models.DeploymentUpdateRequest) {
+	strategy := updateStrategy(d)
+	if strategy == fullUpdateStrategy {
+		req.PruneOrphans = ec.Bool(true)
+		return
+	}
+
+	var removed bool
+	for _, kind := range resourceKinds {
+		if resourceKindRemoved(d, kind) {
+			removed = true
+		}
+	}
+
+	if strategy == settingsOnlyUpdateStrategy {
+		removed = false
+	}
+
+	req.PruneOrphans = ec.Bool(removed)
+	if removed || d.HasChange("version") || d.HasChange("deployment_template_id") || req.Resources == nil {
+		return
+	}
+
+	if !d.HasChange("elasticsearch") {
+		req.Resources.Elasticsearch = nil
+	}
+	if !d.HasChange("kibana") {
+		req.Resources.Kibana = nil
+	}
+	if !d.HasChange("apm") {
+		req.Resources.Apm = nil
+	}
+	if !d.HasChange("integrations_server") {
+		req.Resources.IntegrationsServer = nil
+	}
+	if !d.HasChange("enterprise_search") {
+		req.Resources.EnterpriseSearch = nil
+	}
+}
+
+// resourceKindRemoved returns true when the resource kind was set and has
+// been removed from the configuration.
+func resourceKindRemoved(d *schema.ResourceData, kind string) bool {
+	oldRaw, newRaw := d.GetChange(kind)
+	oldList, _ := oldRaw.([]interface{})
+	newList, _ := newRaw.([]interface{})
+	return len(oldList) > 0 && len(newList) == 0
+}
+
+// updateStrategy returns the configured update strategy. The "prune_orphans"
+// flag maps to the "full" update strategy when true. When false, it maps to
+// the "partial" one, which is the "update_strategy" default since both
+// attributes can't be set at once.
+func updateStrategy(d *schema.ResourceData) string {
+	if prune, _ := d.Get("prune_orphans").(bool); prune {
+		return fullUpdateStrategy
+	}
+
+	if strategy, _ := d.Get("update_strategy").(string); strategy != "" {
+		return strategy
+	}
+
+	return partialUpdateStrategy
+}
+
+// managesResourceKind returns false when the resource kind isn't managed by
+// Terraform, which is the case with the "settings_only" update strategy for
+// the resource kinds which aren't part of the configuration. Those resource
+// kinds aren't read into the state, so they don't show up as removed in the
+// following plans.
+func managesResourceKind(d *schema.ResourceData, kind string) bool {
+	if updateStrategy(d) != settingsOnlyUpdateStrategy {
+		return true
+	}
+
+	current, _ := d.Get(kind).([]interface{})
+	return len(current) > 0
+}
+
+// validateUpdateStrategy validates the update strategy, warning on every plan
+// when it's "full", since any deployment resource which isn't part of the
+// configuration is removed.
+func validateUpdateStrategy(v interface{}, path cty.Path) diag.Diagnostics {
+	strategy, _ := v.(string)
+	for _, s := range updateStrategies {
+		if s != strategy {
+			continue
+		}
+
+		if strategy != fullUpdateStrategy {
+			return nil
+		}
+
+		return diag.Diagnostics{{
+			Severity:      diag.Warning,
+			Summary:       `update_strategy is "full"`,
+			Detail:        "Any deployment resource which isn't part of the configuration, including resources added outside of Terraform, will be removed from the deployment on the next update.",
+			AttributePath: path,
+		}}
+	}
+
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       "invalid update_strategy",
+		Detail:        fmt.Sprintf(`update_strategy must be one of %s, got "%s"`, strings.Join(updateStrategies, ", "), strategy),
+		AttributePath: path,
+	}}
+}
+
+// warnPruneOrphans warns on every plan when "prune_orphans" is enabled, since
+// any deployment resource which isn't part of the configuration is removed.
+func warnPruneOrphans(v interface{}, path cty.Path) diag.Diagnostics {
+	if enabled, _ := v.(bool); !enabled {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity:      diag.Warning,
+		Summary:       "prune_orphans is enabled",
+		Detail:        "Any deployment resource which isn't part of the configuration, including resources added outside of Terraform, will be removed from the deployment on the next update.",
+		AttributePath: path,
+	}}
+}
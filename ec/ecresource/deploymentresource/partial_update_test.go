@@ -0,0 +1,209 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_partialUpdate(t *testing.T) {
+	newState := func(version, kibanaSize string, withApm bool) map[string]interface{} {
+		state := map[string]interface{}{
+			"name":                   "my_deployment_name",
+			"deployment_template_id": "aws-io-optimized-v2",
+			"region":                 "us-east-1",
+			"version":                version,
+			"elasticsearch": []interface{}{map[string]interface{}{
+				"topology": []interface{}{map[string]interface{}{
+					"id":   "hot_content",
+					"size": "8g",
+				}},
+			}},
+			"kibana": []interface{}{map[string]interface{}{
+				"topology": []interface{}{map[string]interface{}{
+					"size": kibanaSize,
+				}},
+			}},
+		}
+		if withApm {
+			state["apm"] = []interface{}{map[string]interface{}{
+				"topology": []interface{}{map[string]interface{}{
+					"size": "0.5g",
+				}},
+			}}
+		}
+		return state
+	}
+
+	newRequest := func() *models.DeploymentUpdateRequest {
+		return &models.DeploymentUpdateRequest{
+			PruneOrphans: ec.Bool(true),
+			Resources: &models.DeploymentUpdateResources{
+				Elasticsearch: []*models.ElasticsearchPayload{{RefID: ec.String("main-elasticsearch")}},
+				Kibana:        []*models.KibanaPayload{{RefID: ec.String("main-kibana")}},
+				Apm:           []*models.ApmPayload{{RefID: ec.String("main-apm")}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		state map[string]interface{}
+		plan  map[string]interface{}
+		want  *models.DeploymentUpdateRequest
+	}{
+		{
+			name:  "only sends the changed resource kinds",
+			state: newState("7.17.0", "1g", true),
+			plan:  newState("7.17.0", "2g", true),
+			want: &models.DeploymentUpdateRequest{
+				PruneOrphans: ec.Bool(false),
+				Resources: &models.DeploymentUpdateResources{
+					Kibana: []*models.KibanaPayload{{RefID: ec.String("main-kibana")}},
+				},
+			},
+		},
+		{
+			name:  "sends all the resource kinds without pruning orphans when the version changes",
+			state: newState("7.17.0", "1g", true),
+			plan:  newState("8.4.3", "1g", true),
+			want: func() *models.DeploymentUpdateRequest {
+				req := newRequest()
+				req.PruneOrphans = ec.Bool(false)
+				return req
+			}(),
+		},
+		{
+			name:  "sends all the resource kinds pruning orphans when a resource kind is removed",
+			state: newState("7.17.0", "1g", true),
+			plan:  newState("7.17.0", "1g", false),
+			want:  newRequest(),
+		},
+		{
+			name:  "sends all the resource kinds pruning orphans when prune_orphans is true",
+			state: newState("7.17.0", "1g", true),
+			plan: func() map[string]interface{} {
+				plan := newState("7.17.0", "2g", true)
+				plan["prune_orphans"] = true
+				return plan
+			}(),
+			want: newRequest(),
+		},
+		{
+			name:  "only sends the changed resource kinds when prune_orphans is false",
+			state: newState("7.17.0", "1g", true),
+			plan: func() map[string]interface{} {
+				plan := newState("7.17.0", "2g", true)
+				plan["prune_orphans"] = false
+				return plan
+			}(),
+			want: &models.DeploymentUpdateRequest{
+				PruneOrphans: ec.Bool(false),
+				Resources: &models.DeploymentUpdateResources{
+					Kibana: []*models.KibanaPayload{{RefID: ec.String("main-kibana")}},
+				},
+			},
+		},
+		{
+			name:  "sends all the resource kinds pruning orphans when update_strategy is full",
+			state: newState("7.17.0", "1g", true),
+			plan: func() map[string]interface{} {
+				plan := newState("7.17.0", "2g", true)
+				plan["update_strategy"] = "full"
+				return plan
+			}(),
+			want: newRequest(),
+		},
+		{
+			name:  "only sends the changed resource kinds when update_strategy is settings_only",
+			state: newState("7.17.0", "1g", true),
+			plan: func() map[string]interface{} {
+				plan := newState("7.17.0", "2g", true)
+				plan["update_strategy"] = "settings_only"
+				return plan
+			}(),
+			want: &models.DeploymentUpdateRequest{
+				PruneOrphans: ec.Bool(false),
+				Resources: &models.DeploymentUpdateResources{
+					Kibana: []*models.KibanaPayload{{RefID: ec.String("main-kibana")}},
+				},
+			},
+		},
+		{
+			name:  "doesn't prune orphans when a resource kind is removed and update_strategy is settings_only",
+			state: newState("7.17.0", "1g", true),
+			plan: func() map[string]interface{} {
+				plan := newState("7.17.0", "1g", false)
+				plan["update_strategy"] = "settings_only"
+				return plan
+			}(),
+			want: &models.DeploymentUpdateRequest{
+				PruneOrphans: ec.Bool(false),
+				Resources: &models.DeploymentUpdateResources{
+					Apm: []*models.ApmPayload{{RefID: ec.String("main-apm")}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := util.NewResourceData(t, util.ResDataParams{
+				ID:     mock.ValidClusterID,
+				State:  tt.state,
+				Change: tt.plan,
+				Schema: newSchema(),
+			})
+
+			got := newRequest()
+			partialUpdate(d, got)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_warnPruneOrphans(t *testing.T) {
+	assert.Nil(t, warnPruneOrphans(false, cty.GetAttrPath("prune_orphans")))
+
+	got := warnPruneOrphans(true, cty.GetAttrPath("prune_orphans"))
+	assert.Len(t, got, 1)
+	assert.Equal(t, diag.Warning, got[0].Severity)
+}
+
+func Test_validateUpdateStrategy(t *testing.T) {
+	path := cty.GetAttrPath("update_strategy")
+	assert.Nil(t, validateUpdateStrategy("partial", path))
+	assert.Nil(t, validateUpdateStrategy("settings_only", path))
+
+	got := validateUpdateStrategy("full", path)
+	assert.Len(t, got, 1)
+	assert.Equal(t, diag.Warning, got[0].Severity)
+
+	got = validateUpdateStrategy("none", path)
+	assert.Len(t, got, 1)
+	assert.Equal(t, diag.Error, got[0].Severity)
+	assert.Equal(t, `update_strategy must be one of full, partial, settings_only, got "none"`, got[0].Detail)
+}
@@ -0,0 +1,63 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/depresourceapi"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resetPasswordOnImport resets the Elasticsearch "elastic" user password when
+// "reset_elasticsearch_password_on_import" is set and the password isn't in
+// the state, which is the case for imported deployments since the password
+// is only returned when the deployment is created.
+func resetPasswordOnImport(d *schema.ResourceData, client *api.API) error {
+	if !d.Get("reset_elasticsearch_password_on_import").(bool) ||
+		!d.Get("expose_credentials").(bool) ||
+		!d.Get("store_elasticsearch_password").(bool) ||
+		d.Get("elasticsearch_password").(string) != "" {
+		return nil
+	}
+
+	refID := resourceRefID(d, "elasticsearch")
+
+	res, err := depresourceapi.ResetElasticsearchPassword(
+		depresourceapi.ResetElasticsearchPasswordParams{
+			API: client, ID: d.Id(), RefID: refID,
+		},
+	)
+	if err != nil {
+		return multierror.NewPrefixed("failed resetting the elasticsearch password", err)
+	}
+
+	if res.Username != nil {
+		if err := d.Set("elasticsearch_username", *res.Username); err != nil {
+			return err
+		}
+	}
+
+	if res.Password != nil {
+		if err := d.Set("elasticsearch_password", *res.Password); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_resetPasswordOnImport(t *testing.T) {
+	resetRaw := newSampleLegacyDeployment()
+	resetRaw["reset_elasticsearch_password_on_import"] = true
+
+	withPasswordRaw := newSampleLegacyDeployment()
+	withPasswordRaw["reset_elasticsearch_password_on_import"] = true
+	withPasswordRaw["elasticsearch_username"] = "elastic"
+	withPasswordRaw["elasticsearch_password"] = "existing-password"
+
+	hiddenRaw := newSampleLegacyDeployment()
+	hiddenRaw["reset_elasticsearch_password_on_import"] = true
+	hiddenRaw["expose_credentials"] = false
+
+	resetResponse := mock.New200StructResponse(
+		models.ElasticsearchElasticUserPasswordResetResponse{
+			Username: ec.String("elastic"),
+			Password: ec.String("new-password"),
+		},
+	)
+
+	tests := []struct {
+		name         string
+		raw          map[string]interface{}
+		client       *api.API
+		wantUsername string
+		wantPassword string
+		err          string
+	}{
+		{
+			name:   "does nothing when the flag isn't set",
+			raw:    newSampleLegacyDeployment(),
+			client: api.NewMock(),
+		},
+		{
+			name:         "does nothing when the password is already in the state",
+			raw:          withPasswordRaw,
+			client:       api.NewMock(),
+			wantUsername: "elastic",
+			wantPassword: "existing-password",
+		},
+		{
+			name:   "does nothing when the credentials aren't exposed",
+			raw:    hiddenRaw,
+			client: api.NewMock(),
+		},
+		{
+			name:         "resets the password when it's not in the state",
+			raw:          resetRaw,
+			client:       api.NewMock(resetResponse),
+			wantUsername: "elastic",
+			wantPassword: "new-password",
+		},
+		{
+			name: "returns the error when the reset fails",
+			raw:  resetRaw,
+			client: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				Code: "some", Message: "message",
+			})),
+			err: "failed resetting the elasticsearch password: 1 error occurred:\n\t* api error: some: message\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := util.NewResourceData(t, util.ResDataParams{
+				ID:     mock.ValidClusterID,
+				State:  tt.raw,
+				Schema: newSchema(),
+			})
+
+			err := resetPasswordOnImport(d, tt.client)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.wantUsername, d.Get("elasticsearch_username"))
+			assert.Equal(t, tt.wantPassword, d.Get("elasticsearch_password"))
+		})
+	}
+}
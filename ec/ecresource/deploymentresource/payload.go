@@ -0,0 +1,76 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// Schema returns the schema of the "ec_deployment" resource, so the resources
+// which render its API payloads can be configured like a deployment.
+func Schema() map[string]*schema.Schema {
+	return newSchema()
+}
+
+// CreatePayload returns the request the deployment resource sends to create
+// the configured deployment, built with the same expanders and overrides.
+// The OIDC realms are left out, since they're added once the deployment has
+// been created.
+func CreatePayload(ctx context.Context, d *schema.ResourceData, meta *util.ProviderMeta) (*models.DeploymentCreateRequest, error) {
+	req, err := createResourceToModel(ctx, d, meta)
+	if err != nil {
+		return nil, err
+	}
+	stripOIDCRealms(req)
+
+	if err := deploymentapi.OverrideCreateOrUpdateRequest(req, &deploymentapi.PayloadOverrides{
+		Name:    d.Get("name").(string),
+		Version: d.Get("version").(string),
+		Region:  d.Get("region").(string),
+	}); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// UpdatePayload returns the request the deployment resource sends to update
+// a deployment to the configured one, built with the same expanders and
+// overrides, and following its update strategy.
+func UpdatePayload(ctx context.Context, d *schema.ResourceData, meta *util.ProviderMeta) (*models.DeploymentUpdateRequest, error) {
+	req, err := updateResourceToModel(ctx, d, meta)
+	if err != nil {
+		return nil, err
+	}
+	partialUpdate(d, req)
+
+	if err := deploymentapi.OverrideCreateOrUpdateRequest(req, &deploymentapi.PayloadOverrides{
+		Version: d.Get("version").(string),
+		Region:  d.Get("region").(string),
+	}); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
@@ -0,0 +1,56 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// planStrategies returns the configured "strategy" block of each resource
+// kind, which isn't returned by the API, keyed by resource kind.
+func planStrategies(d *schema.ResourceData) map[string][]interface{} {
+	result := make(map[string][]interface{})
+	for _, kind := range resourceKinds {
+		if strategy, ok := d.Get(kind + ".0.strategy").([]interface{}); ok && len(strategy) > 0 {
+			result[kind] = strategy
+		}
+	}
+	return result
+}
+
+// flattenPlanStrategies sets the strategies obtained through planStrategies
+// back on the resource kinds which are still part of the state.
+func flattenPlanStrategies(d *schema.ResourceData, strategies map[string][]interface{}) error {
+	for _, kind := range resourceKinds {
+		strategy, ok := strategies[kind]
+		if !ok {
+			continue
+		}
+
+		resources, ok := d.Get(kind).([]interface{})
+		if !ok || len(resources) == 0 || resources[0] == nil {
+			continue
+		}
+
+		resources[0].(map[string]interface{})["strategy"] = strategy
+		if err := d.Set(kind, resources); err != nil {
+			return err
+		}
+	}
+	return nil
+}
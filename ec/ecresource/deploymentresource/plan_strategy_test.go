@@ -0,0 +1,101 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_expandPlanStrategy(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  interface{}
+		want *models.PlanStrategy
+	}{
+		{
+			name: "returns nil when no strategy is set",
+		},
+		{
+			name: "returns nil when the strategy block is empty",
+			raw:  []interface{}{map[string]interface{}{}},
+		},
+		{
+			name: "expands the autodetect strategy",
+			raw:  []interface{}{map[string]interface{}{"type": "autodetect"}},
+			want: &models.PlanStrategy{
+				Autodetect: new(models.AutodetectStrategyConfig),
+			},
+		},
+		{
+			name: "expands the rolling_grow_and_shrink strategy",
+			raw:  []interface{}{map[string]interface{}{"type": "rolling_grow_and_shrink"}},
+			want: &models.PlanStrategy{
+				RollingGrowAndShrink: new(models.RollingGrowShrinkStrategyConfig),
+			},
+		},
+		{
+			name: "expands the rolling strategy grouped by zone",
+			raw:  []interface{}{map[string]interface{}{"type": "rolling"}},
+			want: &models.PlanStrategy{
+				Rolling: &models.RollingStrategyConfig{GroupBy: "__zone__"},
+			},
+		},
+		{
+			name: "expands the rolling_all strategy",
+			raw:  []interface{}{map[string]interface{}{"type": "rolling_all"}},
+			want: &models.PlanStrategy{
+				Rolling: &models.RollingStrategyConfig{GroupBy: "__all__"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, expandPlanStrategy(tt.raw))
+		})
+	}
+}
+
+func Test_flattenPlanStrategies(t *testing.T) {
+	raw := newSampleLegacyDeployment()
+	strategy := []interface{}{map[string]interface{}{"type": "grow_and_shrink"}}
+	raw["kibana"].([]interface{})[0].(map[string]interface{})["strategy"] = strategy
+	d := util.NewResourceData(t, util.ResDataParams{
+		ID:     mock.ValidClusterID,
+		Schema: newSchema(),
+		State:  raw,
+	})
+
+	strategies := planStrategies(d)
+	assert.Equal(t, map[string][]interface{}{"kibana": strategy}, strategies)
+
+	// Simulates the strategy being dropped when the state is refreshed.
+	kibana := d.Get("kibana").([]interface{})
+	kibana[0].(map[string]interface{})["strategy"] = []interface{}{}
+	assert.NoError(t, d.Set("kibana", kibana))
+	assert.Empty(t, d.Get("kibana.0.strategy"))
+
+	assert.NoError(t, flattenPlanStrategies(d, strategies))
+	assert.Equal(t, strategy, d.Get("kibana.0.strategy"))
+	assert.Empty(t, d.Get("elasticsearch.0.strategy"))
+}
@@ -0,0 +1,89 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// planTimeoutsSchema returns the "plan_timeouts" block, which sets how long
+// the plans of each resource kind are tracked for.
+func planTimeoutsSchema() *schema.Schema {
+	var attrs = make(map[string]*schema.Schema, len(resourceKinds))
+	for _, kind := range resourceKinds {
+		attrs[kind] = &schema.Schema{
+			Type:         schema.TypeString,
+			Description:  fmt.Sprintf(`Optional duration, such as "30m" or "6h", after which the %s plan stops being tracked`, kind),
+			Optional:     true,
+			ValidateFunc: validatePlanTimeout,
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: `Optional per resource kind plan timeouts, which fail the operation sooner than its "timeouts" when the plan of the resource kind takes longer`,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: attrs,
+		},
+	}
+}
+
+// validatePlanTimeout validates that the plan timeout is a positive duration.
+func validatePlanTimeout(v interface{}, k string) ([]string, []error) {
+	s, _ := v.(string)
+	if s == "" {
+		return nil, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: failed parsing the duration: %w", k, err)}
+	}
+
+	if d <= 0 {
+		return nil, []error{fmt.Errorf("%s: the duration must be positive", k)}
+	}
+
+	return nil, nil
+}
+
+// expandPlanTimeouts returns the plan timeouts set for each resource kind.
+func expandPlanTimeouts(raw []interface{}) map[string]time.Duration {
+	var timeouts = make(map[string]time.Duration)
+	for _, r := range raw {
+		m, _ := r.(map[string]interface{})
+		for _, kind := range resourceKinds {
+			s, _ := m[kind].(string)
+			if d, err := time.ParseDuration(s); err == nil && d > 0 {
+				timeouts[kind] = d
+			}
+		}
+	}
+	return timeouts
+}
+
+// planTimeouts returns the plan timeouts of the deployment resource.
+func planTimeouts(d *schema.ResourceData) map[string]time.Duration {
+	raw, _ := d.Get("plan_timeouts").([]interface{})
+	return expandPlanTimeouts(raw)
+}
@@ -0,0 +1,76 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandPlanTimeouts(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  []interface{}
+		want map[string]time.Duration
+	}{
+		{name: "no plan_timeouts", want: map[string]time.Duration{}},
+		{
+			name: "plan_timeouts",
+			raw: []interface{}{map[string]interface{}{
+				"elasticsearch": "6h",
+				"kibana":        "15m",
+				"apm":           "",
+			}},
+			want: map[string]time.Duration{
+				"elasticsearch": 6 * time.Hour,
+				"kibana":        15 * time.Minute,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, expandPlanTimeouts(tt.raw))
+		})
+	}
+}
+
+func Test_validatePlanTimeout(t *testing.T) {
+	tests := []struct {
+		value string
+		err   string
+	}{
+		{value: "30m"},
+		{value: ""},
+		{value: "-1m", err: "kibana: the duration must be positive"},
+		{value: "soon", err: `kibana: failed parsing the duration: time: invalid duration "soon"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			_, errs := validatePlanTimeout(tt.value, "kibana")
+			if tt.err == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			if assert.Len(t, errs, 1) {
+				assert.EqualError(t, errs[0], tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+const esPluginsKey = "elasticsearch.0.config.0.plugins"
+
+// validatePluginsDiff fails the plan when any of the Elasticsearch plugins,
+// which are sent as the plan "enabled_built_in_plugins", isn't supported by
+// the stack version of the deployment.
+func validatePluginsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange(esPluginsKey) {
+		return nil
+	}
+
+	for _, k := range []string{esPluginsKey, "version", "region"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	set, ok := d.Get(esPluginsKey).(*schema.Set)
+	if !ok || set.Len() == 0 {
+		return nil
+	}
+
+	version := d.Get("version").(string)
+	res, err := getDiffStack(ctx, meta.(*util.ProviderMeta).API, version, d.Get("region").(string))
+	if err != nil {
+		return multierror.NewPrefixed(
+			"failed obtaining the supported elasticsearch plugins", err,
+		)
+	}
+
+	if res.Elasticsearch == nil {
+		return nil
+	}
+
+	return validatePlugins(version, util.ItemsToString(set.List()), res.Elasticsearch.Plugins)
+}
+
+// validatePlugins returns an error listing the plugins which aren't part of
+// the supported plugins.
+func validatePlugins(version string, plugins, supported []string) error {
+	var unsupported []string
+	for _, p := range plugins {
+		if !slice.HasString(supported, p) {
+			unsupported = append(unsupported, p)
+		}
+	}
+
+	if len(unsupported) == 0 {
+		return nil
+	}
+
+	sort.Strings(unsupported)
+	sorted := append([]string(nil), supported...)
+	sort.Strings(sorted)
+
+	return fmt.Errorf(
+		`elasticsearch.config.plugins: "%s" not supported by version %s, supported plugins are: %s`,
+		strings.Join(unsupported, `", "`), version, strings.Join(sorted, ", "),
+	)
+}
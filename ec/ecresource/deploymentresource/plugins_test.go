@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validatePlugins(t *testing.T) {
+	supported := []string{"repository-s3", "analysis-icu", "analysis-kuromoji"}
+	tests := []struct {
+		name    string
+		plugins []string
+		err     error
+	}{
+		{
+			name:    "accepts the supported plugins",
+			plugins: []string{"analysis-icu", "repository-s3"},
+		},
+		{
+			name:    "fails on the unsupported plugins",
+			plugins: []string{"analysis-icu", "repositroy-s3", "analysis-kuromogi"},
+			err:     errors.New(`elasticsearch.config.plugins: "analysis-kuromogi", "repositroy-s3" not supported by version 7.17.0, supported plugins are: analysis-icu, analysis-kuromoji, repository-s3`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePlugins("7.17.0", tt.plugins, supported)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
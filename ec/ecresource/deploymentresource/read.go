@@ -20,49 +20,52 @@ package deploymentresource
 import (
 	"context"
 	"errors"
+	"fmt"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
-	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
-	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/esremoteclustersapi"
 	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
 )
 
 // Read queries the remote deployment state and updates the local state.
 func readResource(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api.API)
+	m := meta.(*util.ProviderMeta)
+	client := m.API
+	id := d.Id()
 
-	res, err := deploymentapi.Get(deploymentapi.GetParams{
-		API: client, DeploymentID: d.Id(),
-		QueryParams: deputil.QueryParams{
-			ShowSettings:     true,
-			ShowPlans:        true,
-			ShowMetadata:     true,
-			ShowPlanDefaults: true,
-		},
-	})
+	res, err := util.GetDeployment(client, id)
 	if err != nil {
 		if deploymentNotFound(err) {
 			d.SetId("")
-			return nil
+			return removedDeploymentWarning(id, "deleted")
 		}
 		return diag.FromErr(multierror.NewPrefixed("failed reading deployment", err))
 	}
 
 	if !hasRunningResources(res) {
+		// A terminated deployment can be restored, keep the deployment in
+		// the state so the restore can be planned.
+		if d.Get("restore_if_terminated").(bool) {
+			return diag.FromErr(d.Set("terminated", true))
+		}
 		d.SetId("")
-		return nil
+		return removedDeploymentWarning(id, "terminated")
+	}
+
+	if err := d.Set("terminated", false); err != nil {
+		return diag.FromErr(err)
 	}
 
 	var diags diag.Diagnostics
 	remotes, err := esremoteclustersapi.Get(esremoteclustersapi.GetParams{
 		API: client, DeploymentID: d.Id(),
-		RefID: d.Get("elasticsearch.0.ref_id").(string),
+		RefID: resourceRefID(d, "elasticsearch"),
 	})
 	if err != nil {
 		diags = append(diags, diag.FromErr(
@@ -74,13 +77,52 @@ func readResource(_ context.Context, d *schema.ResourceData, meta interface{}) d
 		remotes = &models.RemoteResources{}
 	}
 
+	configuredTags, _ := d.Get("tags").(map[string]interface{})
+	realms := newManagedRealms(d)
+	rotateTrigger, _ := d.Get(rotateSecretTokenKey).(string)
+	keystoreContents, _ := d.Get(keystoreContentsKey).(map[string]interface{})
+	strategies := planStrategies(d)
 	if err := modelToState(d, res, *remotes); err != nil {
 		diags = append(diags, diag.FromErr(err)...)
 	}
 
+	if err := flattenRotateSecretToken(d, rotateTrigger); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	if err := flattenKeystoreContents(d, keystoreContents); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	if err := flattenPlanStrategies(d, strategies); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	if err := flattenManagedRealms(d, realms); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	if err := flattenDefaultTags(d, configuredTags, m.DefaultTags); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	if err := flattenCredentials(d); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
 	return diags
 }
 
+// removedDeploymentWarning warns that the deployment is removed from the
+// state since it has been deleted or terminated outside of Terraform.
+func removedDeploymentWarning(id, reason string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Deployment %s has been %s outside of Terraform", id, reason),
+		Detail:   "The deployment has been removed from the state, a new deployment is created on the next apply.",
+	}}
+}
+
 func deploymentNotFound(err error) bool {
 	// We're using the As() call since we do not care about the error value
 	// but do care about the error's contents type since it's an implicit 404.
@@ -21,7 +21,6 @@ import (
 	"context"
 	"testing"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
 	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
 	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
@@ -73,6 +72,19 @@ func Test_readResource(t *testing.T) {
 	})
 	wantTC200Stopped.SetId("")
 
+	tc200StoppedRestore := util.NewResourceData(t, util.ResDataParams{
+		ID:     mock.ValidClusterID,
+		State:  newSampleRestoreIfTerminatedDeployment(),
+		Schema: newSchema(),
+	})
+
+	wantTC200StoppedRestore := util.NewResourceData(t, util.ResDataParams{
+		ID:     mock.ValidClusterID,
+		State:  newSampleRestoreIfTerminatedDeployment(),
+		Schema: newSchema(),
+	})
+	_ = wantTC200StoppedRestore.Set("terminated", true)
+
 	type args struct {
 		ctx  context.Context
 		d    *schema.ResourceData
@@ -88,7 +100,7 @@ func Test_readResource(t *testing.T) {
 			name: "returns an error when it receives a 500",
 			args: args{
 				d: tc500Err,
-				meta: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				meta: util.NewMockMeta(mock.NewErrorResponse(500, mock.APIError{
 					Code: "some", Message: "message",
 				})),
 			},
@@ -101,21 +113,25 @@ func Test_readResource(t *testing.T) {
 			wantRD: wantTC500,
 		},
 		{
-			name: "returns nil and unsets the state when the error is known",
+			name: "returns a warning and unsets the state when the error is known",
 			args: args{
 				d: tc404Err,
-				meta: api.NewMock(mock.NewErrorResponse(404, mock.APIError{
+				meta: util.NewMockMeta(mock.NewErrorResponse(404, mock.APIError{
 					Code: "some", Message: "message",
 				})),
 			},
-			want:   nil,
+			want: diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Deployment " + mock.ValidClusterID + " has been deleted outside of Terraform",
+				Detail:   "The deployment has been removed from the state, a new deployment is created on the next apply.",
+			}},
 			wantRD: wantTC404,
 		},
 		{
-			name: "returns nil and unsets the state when none of the deployment resources are running",
+			name: "returns a warning and unsets the state when none of the deployment resources are running",
 			args: args{
 				d: tc200Stopped,
-				meta: api.NewMock(mock.New200StructResponse(models.DeploymentGetResponse{
+				meta: util.NewMockMeta(mock.New200StructResponse(models.DeploymentGetResponse{
 					Resources: &models.DeploymentResources{
 						Elasticsearch: []*models.ElasticsearchResourceInfo{{
 							Info: &models.ElasticsearchClusterInfo{Status: ec.String("stopped")},
@@ -123,9 +139,28 @@ func Test_readResource(t *testing.T) {
 					},
 				})),
 			},
-			want:   nil,
+			want: diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Deployment " + mock.ValidClusterID + " has been terminated outside of Terraform",
+				Detail:   "The deployment has been removed from the state, a new deployment is created on the next apply.",
+			}},
 			wantRD: wantTC200Stopped,
 		},
+		{
+			name: "returns nil and flags the deployment as terminated when it can be restored",
+			args: args{
+				d: tc200StoppedRestore,
+				meta: util.NewMockMeta(mock.New200StructResponse(models.DeploymentGetResponse{
+					Resources: &models.DeploymentResources{
+						Elasticsearch: []*models.ElasticsearchResourceInfo{{
+							Info: &models.ElasticsearchClusterInfo{Status: ec.String("stopped")},
+						}},
+					},
+				})),
+			},
+			want:   nil,
+			wantRD: wantTC200StoppedRestore,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -148,6 +183,12 @@ func Test_readResource(t *testing.T) {
 	}
 }
 
+func newSampleRestoreIfTerminatedDeployment() map[string]interface{} {
+	state := newSampleLegacyDeployment()
+	state["restore_if_terminated"] = true
+	return state
+}
+
 func Test_deploymentNotFound(t *testing.T) {
 	type args struct {
 		err error
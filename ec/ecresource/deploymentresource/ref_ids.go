@@ -0,0 +1,242 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// resourceGetter is implemented by both *schema.ResourceData and
+// *schema.ResourceDiff.
+type resourceGetter interface {
+	Get(key string) interface{}
+}
+
+// defaultRefID returns the ref_id the resource kind defaults to.
+func defaultRefID(kind string) string {
+	return "main-" + kind
+}
+
+// newRefIDSuffix returns the random suffix of the generated ref_ids.
+var newRefIDSuffix = func() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// generateRefIDsDiff plans "generated_ref_ids" when the deployment is created
+// with "generate_ref_ids" set, either in the resource or in the provider. The
+// ref_id is generated for all the resource kinds which don't set it, so the
+// generated ref_ids are known at plan time.
+func generateRefIDsDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" {
+		return nil
+	}
+
+	generate, _ := d.Get("generate_ref_ids").(bool)
+	if m, ok := meta.(*util.ProviderMeta); ok && m.GenerateRefIDs {
+		generate = true
+	}
+	if !generate {
+		return nil
+	}
+
+	suffix, err := newRefIDSuffix()
+	if err != nil {
+		return fmt.Errorf("failed generating the ref_ids: %w", err)
+	}
+
+	generated := generateRefIDs(d, suffix)
+	if len(generated) == 0 {
+		return nil
+	}
+
+	return d.SetNew("generated_ref_ids", generated)
+}
+
+// generateRefIDs returns the "<kind>-<suffix>" ref_ids of the resource kinds
+// which are set with the default ref_id.
+func generateRefIDs(d resourceGetter, suffix string) map[string]interface{} {
+	var generated = make(map[string]interface{})
+	for _, kind := range resourceKinds {
+		if raw, _ := d.Get(kind).([]interface{}); len(raw) == 0 {
+			continue
+		}
+
+		if refID, _ := d.Get(kind + ".0.ref_id").(string); refID == defaultRefID(kind) {
+			generated[kind] = fmt.Sprintf("%s-%s", kind, suffix)
+		}
+	}
+	return generated
+}
+
+// suppressGeneratedRefID suppresses the diff between the default ref_id of
+// the resource kind and the ref_id which was generated for it, so resources
+// which don't set their ref_id don't show a diff once it's been generated.
+func suppressGeneratedRefID(kind string) schema.SchemaDiffSuppressFunc {
+	return func(_, old, new string, d *schema.ResourceData) bool {
+		if old == "" || new != defaultRefID(kind) {
+			return false
+		}
+
+		generated, _ := d.Get("generated_ref_ids").(map[string]interface{})
+		return generated[kind] == old
+	}
+}
+
+// resourceRefID returns the ref_id of the resource kind, which is the one
+// generated for it when it's set with the default ref_id.
+func resourceRefID(d resourceGetter, kind string) string {
+	refID, _ := d.Get(kind + ".0.ref_id").(string)
+	if refID != defaultRefID(kind) {
+		return refID
+	}
+
+	generated, _ := d.Get("generated_ref_ids").(map[string]interface{})
+	if v, ok := generated[kind].(string); ok && v != "" {
+		return v
+	}
+	return refID
+}
+
+// applyGeneratedRefIDs sets the generated ref_ids in the resources of the
+// create request, as well as the generated Elasticsearch ref_id in the
+// resources which refer to the default one.
+func applyGeneratedRefIDs(generated map[string]interface{}, res *models.DeploymentCreateResources) {
+	if len(generated) == 0 || res == nil {
+		return
+	}
+
+	refID := func(kind string, current *string) *string {
+		if v, ok := generated[kind].(string); ok && v != "" {
+			return ec.String(v)
+		}
+		return current
+	}
+	esRefID := func(current *string) *string {
+		if current == nil || *current == defaultRefID("elasticsearch") {
+			return refID("elasticsearch", current)
+		}
+		return current
+	}
+
+	for _, r := range res.Elasticsearch {
+		r.RefID = refID("elasticsearch", r.RefID)
+	}
+	for _, r := range res.Kibana {
+		r.RefID = refID("kibana", r.RefID)
+		r.ElasticsearchClusterRefID = esRefID(r.ElasticsearchClusterRefID)
+	}
+	for _, r := range res.Apm {
+		r.RefID = refID("apm", r.RefID)
+		r.ElasticsearchClusterRefID = esRefID(r.ElasticsearchClusterRefID)
+	}
+	for _, r := range res.IntegrationsServer {
+		r.RefID = refID("integrations_server", r.RefID)
+		r.ElasticsearchClusterRefID = esRefID(r.ElasticsearchClusterRefID)
+	}
+	for _, r := range res.EnterpriseSearch {
+		r.RefID = refID("enterprise_search", r.RefID)
+		r.ElasticsearchClusterRefID = esRefID(r.ElasticsearchClusterRefID)
+	}
+}
+
+// validateRefIDsDiff fails the plan when more than one resource kind has the
+// same ref_id, or when a resource refers to an Elasticsearch ref_id which
+// isn't the deployment's one, since the API only rejects them once the plan
+// runs.
+func validateRefIDsDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.HasChanges(resourceKinds...) {
+		return nil
+	}
+
+	for _, kind := range resourceKinds {
+		if !d.NewValueKnown(kind) {
+			return nil
+		}
+	}
+
+	return validateRefIDs(d)
+}
+
+// validateRefIDs returns an error for each ref_id which is set in more than
+// one resource kind, and for each elasticsearch_cluster_ref_id which isn't
+// the Elasticsearch ref_id.
+func validateRefIDs(d resourceGetter) error {
+	var kinds = make(map[string][]string)
+	var refIDs []string
+	for _, kind := range resourceKinds {
+		if raw, _ := d.Get(kind).([]interface{}); len(raw) == 0 {
+			continue
+		}
+
+		refID := resourceRefID(d, kind)
+		if refID == "" {
+			continue
+		}
+		if _, ok := kinds[refID]; !ok {
+			refIDs = append(refIDs, refID)
+		}
+		kinds[refID] = append(kinds[refID], kind)
+	}
+	sort.Strings(refIDs)
+
+	merr := multierror.NewPrefixed("invalid ref_id")
+	for _, refID := range refIDs {
+		if len(kinds[refID]) > 1 {
+			merr = merr.Append(fmt.Errorf(
+				`"%s" is set in more than one resource: %v`, refID, kinds[refID],
+			))
+		}
+	}
+
+	// The resources which don't set elasticsearch_cluster_ref_id refer to
+	// the default Elasticsearch ref_id, or to the one generated for it.
+	esRefID := resourceRefID(d, "elasticsearch")
+	esConfigured, _ := d.Get("elasticsearch.0.ref_id").(string)
+	esDefault := esConfigured == defaultRefID("elasticsearch")
+	for _, kind := range resourceKinds[1:] {
+		if raw, _ := d.Get(kind).([]interface{}); len(raw) == 0 {
+			continue
+		}
+
+		v, _ := d.Get(kind + ".0.elasticsearch_cluster_ref_id").(string)
+		if v == "" || v == esRefID || (esDefault && v == defaultRefID("elasticsearch")) {
+			continue
+		}
+		merr = merr.Append(fmt.Errorf(
+			`%s elasticsearch_cluster_ref_id "%s" doesn't match the elasticsearch ref_id "%s"`,
+			kind, v, esRefID,
+		))
+	}
+
+	return merr.ErrorOrNil()
+}
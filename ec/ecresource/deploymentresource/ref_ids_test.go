@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func newRefIDsResourceData(t *testing.T, state map[string]interface{}) *schema.ResourceData {
+	return util.NewResourceData(t, util.ResDataParams{
+		ID:     mock.ValidClusterID,
+		Schema: newSchema(),
+		State:  state,
+	})
+}
+
+func Test_generateRefIDs(t *testing.T) {
+	d := newRefIDsResourceData(t, map[string]interface{}{
+		"elasticsearch": []interface{}{map[string]interface{}{}},
+		"kibana":        []interface{}{map[string]interface{}{"ref_id": "my-kibana"}},
+		"apm":           []interface{}{map[string]interface{}{}},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"elasticsearch": "elasticsearch-1a2b3c4d",
+		"apm":           "apm-1a2b3c4d",
+	}, generateRefIDs(d, "1a2b3c4d"))
+}
+
+func Test_suppressGeneratedRefID(t *testing.T) {
+	d := newRefIDsResourceData(t, map[string]interface{}{
+		"generated_ref_ids": map[string]interface{}{"kibana": "kibana-1a2b3c4d"},
+	})
+
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want bool
+	}{
+		{name: "generated ref_id", old: "kibana-1a2b3c4d", new: "main-kibana", want: true},
+		{name: "create", old: "", new: "main-kibana"},
+		{name: "configured ref_id", old: "kibana-1a2b3c4d", new: "my-kibana"},
+		{name: "not generated", old: "other-kibana", new: "main-kibana"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, suppressGeneratedRefID("kibana")("", tt.old, tt.new, d))
+		})
+	}
+}
+
+func Test_applyGeneratedRefIDs(t *testing.T) {
+	res := &models.DeploymentCreateResources{
+		Elasticsearch: []*models.ElasticsearchPayload{{RefID: ec.String("main-elasticsearch")}},
+		Kibana: []*models.KibanaPayload{{
+			RefID:                     ec.String("main-kibana"),
+			ElasticsearchClusterRefID: ec.String("main-elasticsearch"),
+		}},
+		Apm: []*models.ApmPayload{{
+			RefID:                     ec.String("my-apm"),
+			ElasticsearchClusterRefID: ec.String("other-elasticsearch"),
+		}},
+	}
+
+	applyGeneratedRefIDs(map[string]interface{}{
+		"elasticsearch": "elasticsearch-1a2b3c4d",
+		"kibana":        "kibana-1a2b3c4d",
+	}, res)
+
+	assert.Equal(t, &models.DeploymentCreateResources{
+		Elasticsearch: []*models.ElasticsearchPayload{{RefID: ec.String("elasticsearch-1a2b3c4d")}},
+		Kibana: []*models.KibanaPayload{{
+			RefID:                     ec.String("kibana-1a2b3c4d"),
+			ElasticsearchClusterRefID: ec.String("elasticsearch-1a2b3c4d"),
+		}},
+		Apm: []*models.ApmPayload{{
+			RefID:                     ec.String("my-apm"),
+			ElasticsearchClusterRefID: ec.String("other-elasticsearch"),
+		}},
+	}, res)
+}
+
+func Test_validateRefIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		state map[string]interface{}
+		err   string
+	}{
+		{
+			name: "default ref_ids",
+			state: map[string]interface{}{
+				"elasticsearch": []interface{}{map[string]interface{}{}},
+				"kibana":        []interface{}{map[string]interface{}{}},
+			},
+		},
+		{
+			name: "generated ref_ids",
+			state: map[string]interface{}{
+				"generated_ref_ids": map[string]interface{}{
+					"elasticsearch": "elasticsearch-1a2b3c4d",
+				},
+				"elasticsearch": []interface{}{map[string]interface{}{}},
+				"kibana":        []interface{}{map[string]interface{}{}},
+			},
+		},
+		{
+			name: "duplicate ref_id",
+			state: map[string]interface{}{
+				"elasticsearch": []interface{}{map[string]interface{}{}},
+				"kibana":        []interface{}{map[string]interface{}{"ref_id": "main-elasticsearch"}},
+			},
+			err: "invalid ref_id: 1 error occurred:\n\t* \"main-elasticsearch\" is set in more than one resource: [elasticsearch kibana]\n\n",
+		},
+		{
+			name: "elasticsearch_cluster_ref_id mismatch",
+			state: map[string]interface{}{
+				"elasticsearch": []interface{}{map[string]interface{}{"ref_id": "my-elasticsearch"}},
+				"kibana":        []interface{}{map[string]interface{}{}},
+			},
+			err: "invalid ref_id: 1 error occurred:\n\t* kibana elasticsearch_cluster_ref_id \"main-elasticsearch\" doesn't match the elasticsearch ref_id \"my-elasticsearch\"\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRefIDs(newRefIDsResourceData(t, tt.state))
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
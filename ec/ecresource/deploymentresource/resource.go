@@ -18,8 +18,10 @@
 package deploymentresource
 
 import (
+	"context"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -30,6 +32,33 @@ func Resource() *schema.Resource {
 		ReadContext:   readResource,
 		UpdateContext: updateResource,
 		DeleteContext: deleteResource,
+		// The deployment template and stack version are obtained at most once
+		// per plan, and shared by the functions which validate against them.
+		CustomizeDiff: withDiffLookups(customdiff.All(
+			checkUniqueNameDiff,
+			verifyDockerImagesDiff,
+			validateApmIntegrationsServerDiff,
+			validateIntegrationsServerSizeDiff,
+			checkAliasConflictsDiff,
+			restoreTerminatedDiff,
+			validateSnapshotRepositoryDiff,
+			validateSizeResourceDiff,
+			validateTopologySizeDiff,
+			validateAutoscalingLimitsDiff,
+			validatePluginsDiff,
+			validateEssNodeTypesDiff,
+			validateObservabilityTargetDiff,
+			generateRefIDsDiff,
+			validateRefIDsDiff,
+			planDataMigrationsDiff,
+			deprecatedInstanceConfigurationsDiff,
+			defaultTagsDiff,
+			adoptObservabilityDiff,
+			stackFeaturesDiff,
+			rotateSecretTokenDiff,
+			customdiff.ComputedIf("resolved_version", versionChanged),
+			customdiff.ComputedIf("resolved_template_id", templateChanged),
+		)),
 
 		Schema: newSchema(),
 
@@ -40,6 +69,7 @@ func Resource() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(40 * time.Minute),
+			Create:  schema.DefaultTimeout(40 * time.Minute),
 			Update:  schema.DefaultTimeout(60 * time.Minute),
 			Delete:  schema.DefaultTimeout(60 * time.Minute),
 		},
@@ -54,3 +84,15 @@ func Resource() *schema.Resource {
 		},
 	}
 }
+
+// versionChanged marks the resolved_version as unknown when the version is
+// changed, so preconditions don't evaluate against the previous version.
+func versionChanged(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+	return d.HasChange("version")
+}
+
+// templateChanged marks the resolved_template_id as unknown when the
+// deployment_template_id is changed.
+func templateChanged(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+	return d.HasChange("deployment_template_id")
+}
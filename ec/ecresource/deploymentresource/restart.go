@@ -0,0 +1,121 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/planutil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// handleRestartTrigger re-applies the deployment current plans with a rolling
+// strategy when "restart_trigger" changes, which restarts all the instances
+// one at a time, picking up changes such as instance configuration or trust
+// updates which don't cause a plan change.
+func handleRestartTrigger(ctx context.Context, d *schema.ResourceData, client *api.API) error {
+	if d.IsNewResource() || !d.HasChange("restart_trigger") {
+		return nil
+	}
+
+	merr := multierror.NewPrefixed("failed restarting the deployment")
+	req, err := getRollbackRequest(client, d.Id())
+	if err != nil {
+		return merr.Append(err)
+	}
+
+	if _, err := deploymentapi.Update(deploymentapi.UpdateParams{
+		API:          client,
+		DeploymentID: d.Id(),
+		Request:      newRestartRequest(req),
+	}); err != nil {
+		return merr.Append(err)
+	}
+
+	if err := waitForPlanCompletion(ctx, client, d.Id(), planTimeouts(d)); err != nil {
+		return merr.Append(err)
+	}
+
+	return nil
+}
+
+// newRestartRequest sets the rolling strategy on all the plans of the request
+// which re-applies the current plans.
+func newRestartRequest(req *models.DeploymentUpdateRequest) *models.DeploymentUpdateRequest {
+	if req == nil || req.Resources == nil {
+		return req
+	}
+
+	// The resources which aren't sent are left untouched.
+	req.PruneOrphans = new(bool)
+	for _, r := range req.Resources.Elasticsearch {
+		if r.Plan == nil {
+			continue
+		}
+		if r.Plan.Transient == nil {
+			r.Plan.Transient = &models.TransientElasticsearchPlanConfiguration{}
+		}
+		r.Plan.Transient.Strategy = planutil.RollingByNameStrategy
+	}
+
+	for _, r := range req.Resources.Kibana {
+		if r.Plan == nil {
+			continue
+		}
+		if r.Plan.Transient == nil {
+			r.Plan.Transient = &models.TransientKibanaPlanConfiguration{}
+		}
+		r.Plan.Transient.Strategy = planutil.RollingByNameStrategy
+	}
+
+	for _, r := range req.Resources.Apm {
+		if r.Plan == nil {
+			continue
+		}
+		if r.Plan.Transient == nil {
+			r.Plan.Transient = &models.TransientApmPlanConfiguration{}
+		}
+		r.Plan.Transient.Strategy = planutil.RollingByNameStrategy
+	}
+
+	for _, r := range req.Resources.IntegrationsServer {
+		if r.Plan == nil {
+			continue
+		}
+		if r.Plan.Transient == nil {
+			r.Plan.Transient = &models.TransientIntegrationsServerPlanConfiguration{}
+		}
+		r.Plan.Transient.Strategy = planutil.RollingByNameStrategy
+	}
+
+	for _, r := range req.Resources.EnterpriseSearch {
+		if r.Plan == nil {
+			continue
+		}
+		if r.Plan.Transient == nil {
+			r.Plan.Transient = &models.TransientEnterpriseSearchPlanConfiguration{}
+		}
+		r.Plan.Transient.Strategy = planutil.RollingByNameStrategy
+	}
+
+	return req
+}
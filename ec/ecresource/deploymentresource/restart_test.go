@@ -0,0 +1,112 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/planutil"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_newRestartRequest(t *testing.T) {
+	req := newRestartRequest(&models.DeploymentUpdateRequest{
+		Resources: &models.DeploymentUpdateResources{
+			Elasticsearch: []*models.ElasticsearchPayload{{
+				RefID: ec.String("main-elasticsearch"),
+				Plan: &models.ElasticsearchClusterPlan{
+					Transient: &models.TransientElasticsearchPlanConfiguration{
+						PlanConfiguration: &models.ElasticsearchPlanControlConfiguration{
+							Timeout: 4096,
+						},
+					},
+				},
+			}},
+			Kibana: []*models.KibanaPayload{{
+				RefID: ec.String("main-kibana"),
+				Plan:  &models.KibanaClusterPlan{},
+			}},
+			Apm: []*models.ApmPayload{{RefID: ec.String("main-apm")}},
+		},
+	})
+
+	assert.Equal(t, ec.Bool(false), req.PruneOrphans)
+
+	es := req.Resources.Elasticsearch[0].Plan.Transient
+	assert.Equal(t, planutil.RollingByNameStrategy, es.Strategy)
+	assert.Equal(t, int64(4096), es.PlanConfiguration.Timeout)
+
+	assert.Equal(t, planutil.RollingByNameStrategy, req.Resources.Kibana[0].Plan.Transient.Strategy)
+
+	// Resources without a plan are left as is.
+	assert.Nil(t, req.Resources.Apm[0].Plan)
+
+	assert.Nil(t, newRestartRequest(nil))
+}
+
+func Test_handleRestartTrigger(t *testing.T) {
+	newRD := func(prev, next string) *schema.ResourceData {
+		return util.NewResourceData(t, util.ResDataParams{
+			ID:     mock.ValidClusterID,
+			Schema: newSchema(),
+			State:  map[string]interface{}{"restart_trigger": prev},
+			Change: map[string]interface{}{"restart_trigger": next},
+		})
+	}
+
+	tests := []struct {
+		name   string
+		d      *schema.ResourceData
+		client *api.API
+		err    error
+	}{
+		{
+			name: "does nothing when the trigger is unchanged",
+			d:    newRD("1", "1"),
+			// Any call would fail with an empty mock.
+			client: api.NewMock(),
+		},
+		{
+			name: "returns the error obtaining the current plans",
+			d:    newRD("1", "2"),
+			client: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				Code: "some", Message: "message",
+			})),
+			err: errors.New("failed restarting the deployment: 1 error occurred:\n\t* api error: some: message\n\n"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handleRestartTrigger(context.Background(), tt.d, tt.client)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// rollbackTimeout bounds the rollback of a failed update plan, in addition to
+// the update operation timeout.
+const rollbackTimeout = 40 * time.Minute
+
+var errRolledBack = errors.New(
+	"the deployment has been rolled back to its last successful plan",
+)
+
+// getRollbackRequest obtains the deployment's current plans, which are the
+// last successfully applied ones, and builds an update request out of them
+// so they can be re-applied if the next plan fails.
+func getRollbackRequest(client *api.API, id string) (*models.DeploymentUpdateRequest, error) {
+	res, err := deploymentapi.Get(deploymentapi.GetParams{
+		API: client, DeploymentID: id,
+		QueryParams: deputil.QueryParams{
+			ShowSettings: true,
+			ShowPlans:    true,
+			ShowMetadata: true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newRollbackRequest(res), nil
+}
+
+// newRollbackRequest generates a DeploymentUpdateRequest from the deployment
+// current plans. deploymentapi.NewUpdateRequest doesn't parse the integrations
+// server resources, so they're added here.
+func newRollbackRequest(res *models.DeploymentGetResponse) *models.DeploymentUpdateRequest {
+	if res == nil || res.Resources == nil {
+		return nil
+	}
+
+	req := deploymentapi.NewUpdateRequest(res)
+
+	var esRefID string
+	for _, r := range req.Resources.Elasticsearch {
+		if r.RefID != nil {
+			esRefID = *r.RefID
+		}
+	}
+
+	for _, r := range res.Resources.IntegrationsServer {
+		if payload := integrationsServerRollbackPayload(r, esRefID); payload != nil {
+			req.Resources.IntegrationsServer = append(
+				req.Resources.IntegrationsServer, payload,
+			)
+		}
+	}
+
+	return req
+}
+
+func integrationsServerRollbackPayload(r *models.IntegrationsServerResourceInfo, esRefID string) *models.IntegrationsServerPayload {
+	if r.Info == nil || r.Info.PlanInfo == nil || r.Info.PlanInfo.Current == nil {
+		return nil
+	}
+
+	current := r.Info.PlanInfo.Current
+	if current.Plan == nil {
+		return nil
+	}
+
+	var name string
+	if r.Info.Name != nil {
+		name = *r.Info.Name
+	}
+
+	return &models.IntegrationsServerPayload{
+		ElasticsearchClusterRefID: &esRefID,
+		DisplayName:               name,
+		RefID:                     r.RefID,
+		Region:                    r.Region,
+		Plan:                      current.Plan,
+		Settings:                  r.Info.Settings,
+	}
+}
+
+// rollbackDeployment re-applies the specified rollback request and waits for
+// the plan to finish.
+func rollbackDeployment(ctx context.Context, client *api.API, id string, req *models.DeploymentUpdateRequest) error {
+	var merr = multierror.NewPrefixed("failed rolling back to the last successful plan")
+	if _, err := deploymentapi.Update(deploymentapi.UpdateParams{
+		API:          util.ContextClient(ctx, client),
+		DeploymentID: id,
+		Request:      req,
+	}); err != nil {
+		return merr.Append(err)
+	}
+
+	if err := WaitForPlanCompletion(ctx, client, id); err != nil {
+		return merr.Append(err)
+	}
+
+	return errRolledBack
+}
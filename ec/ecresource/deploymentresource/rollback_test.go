@@ -0,0 +1,116 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newRollbackRequest(t *testing.T) {
+	esPlan := &models.ElasticsearchClusterPlan{
+		Elasticsearch: &models.ElasticsearchConfiguration{Version: "7.16.2"},
+		ClusterTopology: []*models.ElasticsearchClusterTopologyElement{{
+			ID:   "hot_content",
+			Size: &models.TopologySize{Resource: ec.String("memory"), Value: ec.Int32(4096)},
+		}},
+	}
+	integrationsServerPlan := &models.IntegrationsServerPlan{
+		IntegrationsServer: &models.IntegrationsServerConfiguration{Version: "7.16.2"},
+	}
+
+	type args struct {
+		res *models.DeploymentGetResponse
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.DeploymentUpdateRequest
+	}{
+		{
+			name: "returns nil when there are no resources",
+			args: args{res: &models.DeploymentGetResponse{Name: ec.String("my_deployment")}},
+		},
+		{
+			name: "generates the request from the current plans",
+			args: args{res: &models.DeploymentGetResponse{
+				Name: ec.String("my_deployment"),
+				Resources: &models.DeploymentResources{
+					Elasticsearch: []*models.ElasticsearchResourceInfo{{
+						RefID:  ec.String("main-elasticsearch"),
+						Region: ec.String("us-east-1"),
+						Info: &models.ElasticsearchClusterInfo{
+							ClusterName: ec.String("my_deployment"),
+							PlanInfo: &models.ElasticsearchClusterPlansInfo{
+								Current: &models.ElasticsearchClusterPlanInfo{Plan: esPlan},
+							},
+						},
+					}},
+					IntegrationsServer: []*models.IntegrationsServerResourceInfo{
+						{
+							RefID:  ec.String("main-integrations_server"),
+							Region: ec.String("us-east-1"),
+							Info: &models.IntegrationsServerInfo{
+								Name: ec.String("my_deployment"),
+								PlanInfo: &models.IntegrationsServerPlansInfo{
+									Current: &models.IntegrationsServerPlanInfo{Plan: integrationsServerPlan},
+								},
+							},
+						},
+						{
+							RefID:  ec.String("secondary-integrations_server"),
+							Region: ec.String("us-east-1"),
+							Info: &models.IntegrationsServerInfo{
+								Name:     ec.String("my_deployment"),
+								PlanInfo: &models.IntegrationsServerPlansInfo{},
+							},
+						},
+					},
+				},
+			}},
+			want: &models.DeploymentUpdateRequest{
+				Name:         "my_deployment",
+				PruneOrphans: ec.Bool(false),
+				Resources: &models.DeploymentUpdateResources{
+					Elasticsearch: []*models.ElasticsearchPayload{{
+						DisplayName: "my_deployment",
+						RefID:       ec.String("main-elasticsearch"),
+						Region:      ec.String("us-east-1"),
+						Plan:        esPlan,
+					}},
+					IntegrationsServer: []*models.IntegrationsServerPayload{{
+						DisplayName:               "my_deployment",
+						ElasticsearchClusterRefID: ec.String("main-elasticsearch"),
+						RefID:                     ec.String("main-integrations_server"),
+						Region:                    ec.String("us-east-1"),
+						Plan:                      integrationsServerPlan,
+					}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newRollbackRequest(tt.args.res)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -18,7 +18,10 @@
 package deploymentresource
 
 import (
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -65,6 +68,122 @@ func newSchema() map[string]*schema.Schema {
 			Description: "Optional request_id to set on the create operation, only use when previous create attempts return with an error and a request_id is returned as part of the error",
 			Optional:    true,
 		},
+		"enforce_unique_name": {
+			Type:        schema.TypeBool,
+			Description: "Optional flag which fails the plan when a deployment with the same name already exists. Only checked when the deployment is created",
+			Optional:    true,
+		},
+		"verify_docker_images": {
+			Type:        schema.TypeBool,
+			Description: "Optional flag which verifies that the docker_image overrides exist in their container registry at plan time",
+			Optional:    true,
+		},
+		"docker_registry_auth": {
+			Type:        schema.TypeList,
+			Description: "Optional container registry credentials used to verify the docker_image overrides when verify_docker_images is set",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"address": {
+						Type:        schema.TypeString,
+						Description: `Required address of the container registry, such as "docker.elastic.co"`,
+						Required:    true,
+					},
+					"username": {
+						Type:        schema.TypeString,
+						Description: "Required username of the container registry",
+						Required:    true,
+					},
+					"password": {
+						Type:        schema.TypeString,
+						Description: "Required password or access token of the container registry",
+						Required:    true,
+						Sensitive:   true,
+					},
+				},
+			},
+		},
+		"rollback_on_failure": {
+			Type:        schema.TypeBool,
+			Description: "Optional flag which re-applies the last successful plan when an update plan fails",
+			Optional:    true,
+		},
+		"reset_elasticsearch_password_on_import": {
+			Type:        schema.TypeBool,
+			Description: "Optional flag which resets the Elasticsearch \"elastic\" user password on the first apply after the deployment has been imported, storing the new credentials in the state",
+			Optional:    true,
+		},
+		"prune_orphans": {
+			Type:             schema.TypeBool,
+			Description:      "Optional flag which removes any deployment resource which isn't part of the configuration on every update, such as resources added outside of Terraform. Equivalent to update_strategy = \"full\" when true. Defaults to false",
+			Optional:         true,
+			ConflictsWith:    []string{"update_strategy"},
+			ValidateDiagFunc: warnPruneOrphans,
+		},
+		"update_strategy": {
+			Type:             schema.TypeString,
+			Description:      `Optional strategy which governs how the resource kinds omitted from the configuration are handled on update: "full" removes them, "partial" only removes the resource kinds which are removed from the configuration, and "settings_only" never removes them, leaving them untouched. Defaults to "partial"`,
+			Optional:         true,
+			Default:          partialUpdateStrategy,
+			ValidateDiagFunc: validateUpdateStrategy,
+		},
+		"plan_timeouts": planTimeoutsSchema(),
+		"restart_trigger": {
+			Type:        schema.TypeString,
+			Description: "Optional value which, when changed, re-applies the current plan of all the deployment resources with a rolling strategy, restarting their instances one at a time. Useful to force instance configuration refreshes or pick up trust changes",
+			Optional:    true,
+		},
+		"restore_if_terminated": {
+			Type:        schema.TypeBool,
+			Description: "Optional flag which restores the deployment when it has been terminated but not deleted, rather than creating a new deployment",
+			Optional:    true,
+		},
+		"destroy_mode": {
+			Type:         schema.TypeString,
+			Description:  `Optional mode used to destroy the deployment: "terminate" shuts it down and deletes it, "shutdown_keep" shuts it down without deleting it, so it can still be restored, and "hibernate" stops the instances of its resources, keeping their data in place so they can be started again. Defaults to "terminate"`,
+			Optional:     true,
+			Default:      terminateDestroyMode,
+			ValidateFunc: validation.StringInSlice(destroyModes, false),
+		},
+		"skip_destroy_if_terminated": {
+			Type:         schema.TypeBool,
+			Description:  "Optional flag which, when the deployment has been terminated but not deleted, only removes it from the state on destroy, leaving it in place so it can still be restored. Requires restore_if_terminated, since terminated deployments are only kept in the state when it's set",
+			Optional:     true,
+			RequiredWith: []string{"restore_if_terminated"},
+		},
+		"terminated": {
+			Type:        schema.TypeBool,
+			Description: "Computed flag which is set when all of the deployment resources have been terminated",
+			Computed:    true,
+		},
+		"expected_data_migrations": {
+			Type:        schema.TypeList,
+			Description: "Computed list of the Elasticsearch topology changes in the plan which migrate data to new instances",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"deprecated_instance_configurations": {
+			Type:        schema.TypeList,
+			Description: "Computed list of the topology elements which use an instance configuration deprecated in the deployment region",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"expose_credentials": {
+			Type:        schema.TypeBool,
+			Description: "Optional flag which, when set to false, prevents the elasticsearch_username, elasticsearch_password and apm_secret_token from being stored in the Terraform state",
+			Optional:    true,
+			Default:     true,
+		},
+		"store_elasticsearch_password": {
+			Type:        schema.TypeBool,
+			Description: "Optional flag which, when set to false, prevents the elasticsearch_password from being stored in the Terraform state, while the rest of the credentials are still stored",
+			Optional:    true,
+			Default:     true,
+		},
 
 		// Computed ES Creds
 		"elasticsearch_username": {
@@ -85,6 +204,68 @@ func newSchema() map[string]*schema.Schema {
 			Computed:  true,
 			Sensitive: true,
 		},
+		"credentials": {
+			Type:        schema.TypeList,
+			Description: "Computed connection details and credentials of the deployment, grouped so they can be exposed as a single output",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"cloud_id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"elasticsearch_username": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"elasticsearch_password": {
+						Type:      schema.TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+					"apm_secret_token": {
+						Type:      schema.TypeString,
+						Computed:  true,
+						Sensitive: true,
+					},
+					"fleet_url": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+		"resolved_version": {
+			Type:        schema.TypeString,
+			Description: "Computed lowest Elastic Stack version running on any of the deployment resources. Useful in precondition and postcondition checks",
+			Computed:    true,
+		},
+		"resolved_template_id": {
+			Type:        schema.TypeString,
+			Description: "Computed Deployment Template identifier the deployment is running on. Useful in precondition and postcondition checks",
+			Computed:    true,
+		},
+		"generate_ref_ids": {
+			Type:        schema.TypeBool,
+			Description: "Optional flag to generate the ref_id of the resources which don't set it when the deployment is created, rather than defaulting to \"main-<kind>\". Also enabled by the provider generate_ref_ids setting",
+			Optional:    true,
+		},
+		"generated_ref_ids": {
+			Type:        schema.TypeMap,
+			Description: "Computed ref_ids which were generated for the resources when the deployment was created, keyed by resource kind",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"stack_features": {
+			Type:        schema.TypeMap,
+			Description: "Computed features supported by the deployment version: supports_node_roles, supports_autoscaling and supports_integrations_server",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeBool,
+			},
+		},
 
 		// Resources
 		"elasticsearch": {
@@ -145,6 +326,11 @@ func newSchema() map[string]*schema.Schema {
 			MaxItems:    1,
 			Elem:        newObservabilitySettings(),
 		},
+		"observability_adopted": {
+			Type:        schema.TypeBool,
+			Description: "Computed flag set when the deployment was created without observability settings, in which case the ones provided by the deployment template are left untouched until the observability block is set",
+			Computed:    true,
+		},
 
 		"tags": {
 			Description: "Optional map of deployment tags",
@@ -154,6 +340,14 @@ func newSchema() map[string]*schema.Schema {
 				Type: schema.TypeString,
 			},
 		},
+		"tags_all": {
+			Description: "Computed map of all the deployment tags, including the provider default_tags",
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
 	}
 }
 
@@ -161,8 +355,9 @@ func newObservabilitySettings() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"deployment_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateObservabilityDeploymentID,
 				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
 					// The terraform config can contain 'self' as a deployment target
 					// However the API will return the actual deployment-id.
@@ -202,6 +397,15 @@ func mapSelfToDeploymentID(value string, deploymentID string) string {
 	return value
 }
 
+// userSettingsAttributes are the user settings attributes of the resource
+// "config" blocks.
+var userSettingsAttributes = []string{
+	"user_settings_json",
+	"user_settings_override_json",
+	"user_settings_yaml",
+	"user_settings_override_yaml",
+}
+
 // suppressMissingOptionalConfigurationBlock handles configuration block attributes in the following scenario:
 //   - The resource schema includes an optional configuration block with defaults
 //   - The API response includes those defaults to refresh into the Terraform state
@@ -209,3 +413,37 @@ func mapSelfToDeploymentID(value string, deploymentID string) string {
 func suppressMissingOptionalConfigurationBlock(k, old, new string, d *schema.ResourceData) bool {
 	return old == "1" && new == "0"
 }
+
+// suppressMissingUserSettingsBlock behaves like
+// suppressMissingOptionalConfigurationBlock, unless the configuration block
+// in the state holds user settings. Since the deployment templates don't set
+// Kibana user settings, the ones in the state have been set outside of
+// Terraform, such as in the Cloud UI, so they're shown as drift and removed
+// on apply.
+func suppressMissingUserSettingsBlock(k, old, new string, d *schema.ResourceData) bool {
+	if !suppressMissingOptionalConfigurationBlock(k, old, new, d) {
+		return false
+	}
+
+	prior, _ := d.GetChange(strings.TrimSuffix(k, ".#"))
+	blocks, _ := prior.([]interface{})
+	for _, b := range blocks {
+		cfg, _ := b.(map[string]interface{})
+		for _, attr := range userSettingsAttributes {
+			if v, _ := cfg[attr].(string); v != "" {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// suppressLegacyNodeTypeDiff suppresses any node_type_* differences once the
+// topology element has been migrated to node_roles, since the API no longer
+// returns the legacy node types and they have been converted to roles.
+func suppressLegacyNodeTypeDiff(k, old, new string, d *schema.ResourceData) bool {
+	prefix := k[:strings.LastIndex(k, ".")+1]
+	roles, ok := d.Get(prefix + "node_roles").(*schema.Set)
+	return ok && roles.Len() > 0
+}
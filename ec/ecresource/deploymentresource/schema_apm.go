@@ -19,20 +19,30 @@ package deploymentresource
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func newApmResource() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"elasticsearch_cluster_ref_id": {
-				Type:     schema.TypeString,
-				Default:  "main-elasticsearch",
-				Optional: true,
+				Type:             schema.TypeString,
+				Default:          "main-elasticsearch",
+				Optional:         true,
+				DiffSuppressFunc: suppressGeneratedRefID("elasticsearch"),
 			},
 			"ref_id": {
-				Type:     schema.TypeString,
-				Default:  "main-apm",
-				Optional: true,
+				Type:             schema.TypeString,
+				Default:          "main-apm",
+				Optional:         true,
+				DiffSuppressFunc: suppressGeneratedRefID("apm"),
+			},
+			"maintenance_mode": maintenanceModeSchema(),
+			"rotate_secret_token": {
+				Type:        schema.TypeString,
+				Description: "Optional value which, when changed, resets the APM secret token, storing the new token in apm_secret_token",
+				Optional:    true,
 			},
 			"resource_id": {
 				Type:     schema.TypeString,
@@ -52,7 +62,8 @@ func newApmResource() *schema.Resource {
 			},
 			"topology": apmTopologySchema(),
 
-			"config": apmConfig(),
+			"config":   apmConfig(),
+			"strategy": newStrategySchema(),
 
 			// TODO: Implement settings field.
 			// "settings": interface{}
@@ -79,16 +90,30 @@ func apmTopologySchema() *schema.Schema {
 					Optional: true,
 				},
 				"size_resource": {
-					Type:        schema.TypeString,
-					Description: `Optional size type, defaults to "memory".`,
-					Default:     "memory",
-					Optional:    true,
+					Type:         schema.TypeString,
+					Description:  `Optional size type, either "memory" or "storage", defaults to "memory".`,
+					Default:      "memory",
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice(sizeResources, false),
 				},
 				"zone_count": {
 					Type:     schema.TypeInt,
 					Computed: true,
 					Optional: true,
 				},
+				"instance_configuration_name": {
+					Type:        schema.TypeString,
+					Description: "Name of the instance configuration the running instances use",
+					Computed:    true,
+				},
+				"zones": {
+					Type:        schema.TypeMap,
+					Description: "Number of running instances in each zone",
+					Computed:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeInt,
+					},
+				},
 			},
 		},
 	}
@@ -117,24 +142,32 @@ func apmConfig() *schema.Schema {
 				},
 
 				"user_settings_json": {
-					Type:        schema.TypeString,
-					Description: `An arbitrary JSON object allowing (non-admin) cluster owners to set their parameters (only one of this and 'user_settings_yaml' is allowed), provided they are on the whitelist ('user_settings_whitelist') and not on the blacklist ('user_settings_blacklist'). (This field together with 'user_settings_override*' and 'system_settings' defines the total set of resource settings)`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `An arbitrary JSON object allowing (non-admin) cluster owners to set their parameters (only one of this and 'user_settings_yaml' is allowed), provided they are on the whitelist ('user_settings_whitelist') and not on the blacklist ('user_settings_blacklist'). (This field together with 'user_settings_override*' and 'system_settings' defines the total set of resource settings)`,
+					Optional:         true,
+					ValidateFunc:     validation.All(validateUserSettingsSize, validateUserSettingsJSON),
+					DiffSuppressFunc: structure.SuppressJsonDiff,
 				},
 				"user_settings_override_json": {
-					Type:        schema.TypeString,
-					Description: `An arbitrary JSON object allowing ECE admins owners to set clusters' parameters (only one of this and 'user_settings_override_yaml' is allowed), ie in addition to the documented 'system_settings'. (This field together with 'system_settings' and 'user_settings*' defines the total set of resource settings)`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `An arbitrary JSON object allowing ECE admins owners to set clusters' parameters (only one of this and 'user_settings_override_yaml' is allowed), ie in addition to the documented 'system_settings'. (This field together with 'system_settings' and 'user_settings*' defines the total set of resource settings)`,
+					Optional:         true,
+					ValidateFunc:     validation.All(validateUserSettingsSize, validateUserSettingsJSON),
+					DiffSuppressFunc: structure.SuppressJsonDiff,
 				},
 				"user_settings_yaml": {
-					Type:        schema.TypeString,
-					Description: `An arbitrary YAML object allowing ECE admins owners to set clusters' parameters (only one of this and 'user_settings_override_json' is allowed), ie in addition to the documented 'system_settings'. (This field together with 'system_settings' and 'user_settings*' defines the total set of resource settings)`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `An arbitrary YAML object allowing ECE admins owners to set clusters' parameters (only one of this and 'user_settings_override_json' is allowed), ie in addition to the documented 'system_settings'. (This field together with 'system_settings' and 'user_settings*' defines the total set of resource settings)`,
+					Optional:         true,
+					ValidateFunc:     validateUserSettingsSize,
+					DiffSuppressFunc: suppressYAMLDiff,
 				},
 				"user_settings_override_yaml": {
-					Type:        schema.TypeString,
-					Description: `An arbitrary YAML object allowing (non-admin) cluster owners to set their parameters (only one of this and 'user_settings_json' is allowed), provided they are on the whitelist ('user_settings_whitelist') and not on the blacklist ('user_settings_blacklist'). (These field together with 'user_settings_override*' and 'system_settings' defines the total set of resource settings)`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `An arbitrary YAML object allowing (non-admin) cluster owners to set their parameters (only one of this and 'user_settings_json' is allowed), provided they are on the whitelist ('user_settings_whitelist') and not on the blacklist ('user_settings_blacklist'). (These field together with 'user_settings_override*' and 'system_settings' defines the total set of resource settings)`,
+					Optional:         true,
+					ValidateFunc:     validateUserSettingsSize,
+					DiffSuppressFunc: suppressYAMLDiff,
 				},
 			},
 		},
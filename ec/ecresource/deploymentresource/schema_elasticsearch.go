@@ -20,83 +20,120 @@ package deploymentresource
 import (
 	"bytes"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func newElasticsearchResource() *schema.Resource {
-	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			"autoscale": {
-				Type:        schema.TypeString,
-				Description: `Enable or disable autoscaling. Defaults to the setting coming from the deployment template. Accepted values are "true" or "false".`,
-				Computed:    true,
-				Optional:    true,
-				ValidateFunc: func(i interface{}, s string) ([]string, []error) {
-					if _, err := strconv.ParseBool(i.(string)); err != nil {
-						return nil, []error{
-							fmt.Errorf("failed parsing autoscale value: %w", err),
-						}
+	var s = map[string]*schema.Schema{
+		"autoscale": {
+			Type:        schema.TypeString,
+			Description: `Enable or disable autoscaling. Defaults to the setting coming from the deployment template. Accepted values are "true" or "false".`,
+			Computed:    true,
+			Optional:    true,
+			ValidateFunc: func(i interface{}, s string) ([]string, []error) {
+				if _, err := strconv.ParseBool(i.(string)); err != nil {
+					return nil, []error{
+						fmt.Errorf("failed parsing autoscale value: %w", err),
 					}
-					return nil, nil
-				},
+				}
+				return nil, nil
 			},
+		},
 
-			"ref_id": {
-				Type:        schema.TypeString,
-				Description: "Optional ref_id to set on the Elasticsearch resource",
-				Default:     "main-elasticsearch",
-				Optional:    true,
-			},
+		"ref_id": {
+			Type:             schema.TypeString,
+			Description:      "Optional ref_id to set on the Elasticsearch resource",
+			Default:          "main-elasticsearch",
+			Optional:         true,
+			DiffSuppressFunc: suppressGeneratedRefID("elasticsearch"),
+		},
+		"maintenance_mode": maintenanceModeSchema(),
 
-			// Computed attributes
-			"resource_id": {
-				Type:        schema.TypeString,
-				Description: "The Elasticsearch resource unique identifier",
-				Computed:    true,
-			},
-			"region": {
-				Type:        schema.TypeString,
-				Description: "The Elasticsearch resource region",
-				Computed:    true,
-			},
-			"cloud_id": {
-				Type:        schema.TypeString,
-				Description: "The encoded Elasticsearch credentials to use in Beats or Logstash",
-				Computed:    true,
-			},
-			"http_endpoint": {
-				Type:        schema.TypeString,
-				Description: "The Elasticsearch resource HTTP endpoint",
-				Computed:    true,
-			},
-			"https_endpoint": {
-				Type:        schema.TypeString,
-				Description: "The Elasticsearch resource HTTPs endpoint",
-				Computed:    true,
-			},
+		// Computed attributes
+		"resource_id": {
+			Type:        schema.TypeString,
+			Description: "The Elasticsearch resource unique identifier",
+			Computed:    true,
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Description: "The Elasticsearch resource region",
+			Computed:    true,
+		},
+		"cloud_id": {
+			Type:        schema.TypeString,
+			Description: "The encoded Elasticsearch credentials to use in Beats or Logstash",
+			Computed:    true,
+		},
+		"http_endpoint": {
+			Type:        schema.TypeString,
+			Description: "The Elasticsearch resource HTTP endpoint",
+			Computed:    true,
+		},
+		"https_endpoint": {
+			Type:        schema.TypeString,
+			Description: "The Elasticsearch resource HTTPs endpoint",
+			Computed:    true,
+		},
 
-			// Sub-objects
-			"topology": elasticsearchTopologySchema(),
+		// Sub-objects
+		"topology": elasticsearchTopologySchema(),
 
-			"config": elasticsearchConfig(),
+		"config": elasticsearchConfig(),
 
-			"remote_cluster": elasticsearchRemoteCluster(),
+		"remote_cluster": elasticsearchRemoteCluster(),
 
-			"snapshot_source": newSnapshotSourceSettings(),
+		"snapshot_source": newSnapshotSourceSettings(),
 
-			"extension": newExtensionSchema(),
+		"snapshot_repository": {
+			Type:        schema.TypeString,
+			Description: "Optional name of the platform snapshot repository the Elasticsearch cluster snapshots are stored in. Only available in ECE installations",
+			Optional:    true,
+		},
 
-			"trust_account":  newTrustAccountSchema(),
-			"trust_external": newTrustExternalSchema(),
+		"snapshot": newSnapshotSettingsSchema(),
 
-			"strategy": newStrategySchema(),
+		"keystore_contents": {
+			Type:        schema.TypeMap,
+			Description: "Optional Elasticsearch keystore secure settings, keyed by setting name. JSON values, such as service account credentials, are stored as JSON objects",
+			Optional:    true,
+			Sensitive:   true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
 		},
+
+		"dedicated_masters_threshold": {
+			Type:         schema.TypeInt,
+			Description:  "Optional number of instances from which dedicated master nodes are added to the Elasticsearch cluster. Defaults to the threshold set by the deployment template",
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+
+		"extension": newExtensionSchema(),
+
+		"trust_account":  newTrustAccountSchema(),
+		"trust_external": newTrustExternalSchema(),
+
+		"saml": newSamlRealmSchema(),
+		"oidc": newOidcRealmSchema(),
+
+		"strategy": newStrategySchema(),
+	}
+
+	for _, id := range esTierIDs {
+		s[id] = elasticsearchTierSchema(id)
 	}
+
+	return &schema.Resource{Schema: s}
 }
 
 func elasticsearchTopologySchema() *schema.Schema {
@@ -125,10 +162,11 @@ func elasticsearchTopologySchema() *schema.Schema {
 					Optional:    true,
 				},
 				"size_resource": {
-					Type:        schema.TypeString,
-					Description: `Optional size type, defaults to "memory".`,
-					Default:     "memory",
-					Optional:    true,
+					Type:         schema.TypeString,
+					Description:  `Optional size type, either "memory" or "storage", defaults to "memory".`,
+					Default:      "memory",
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice(sizeResources, false),
 				},
 				"zone_count": {
 					Type:        schema.TypeInt,
@@ -137,28 +175,32 @@ func elasticsearchTopologySchema() *schema.Schema {
 					Optional:    true,
 				},
 				"node_type_data": {
-					Type:        schema.TypeString,
-					Description: `The node type for the Elasticsearch Topology element (data node)`,
-					Computed:    true,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `The node type for the Elasticsearch Topology element (data node)`,
+					Computed:         true,
+					Optional:         true,
+					DiffSuppressFunc: suppressLegacyNodeTypeDiff,
 				},
 				"node_type_master": {
-					Type:        schema.TypeString,
-					Description: `The node type for the Elasticsearch Topology element (master node)`,
-					Computed:    true,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `The node type for the Elasticsearch Topology element (master node)`,
+					Computed:         true,
+					Optional:         true,
+					DiffSuppressFunc: suppressLegacyNodeTypeDiff,
 				},
 				"node_type_ingest": {
-					Type:        schema.TypeString,
-					Description: `The node type for the Elasticsearch Topology element (ingest node)`,
-					Computed:    true,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `The node type for the Elasticsearch Topology element (ingest node)`,
+					Computed:         true,
+					Optional:         true,
+					DiffSuppressFunc: suppressLegacyNodeTypeDiff,
 				},
 				"node_type_ml": {
-					Type:        schema.TypeString,
-					Description: `The node type for the Elasticsearch Topology element (machine learning node)`,
-					Computed:    true,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `The node type for the Elasticsearch Topology element (machine learning node)`,
+					Computed:         true,
+					Optional:         true,
+					DiffSuppressFunc: suppressLegacyNodeTypeDiff,
 				},
 				"node_roles": {
 					Type:        schema.TypeSet,
@@ -183,6 +225,8 @@ func elasticsearchTopologySchema() *schema.Schema {
 								Type:        schema.TypeString,
 								Optional:    true,
 								Computed:    true,
+
+								ValidateFunc: validation.StringInSlice(sizeResources, false),
 							},
 
 							"max_size": {
@@ -197,6 +241,8 @@ func elasticsearchTopologySchema() *schema.Schema {
 								Type:        schema.TypeString,
 								Optional:    true,
 								Computed:    true,
+
+								ValidateFunc: validation.StringInSlice(sizeResources, false),
 							},
 
 							"min_size": {
@@ -302,24 +348,32 @@ func elasticsearchConfig() *schema.Schema {
 
 				// User settings
 				"user_settings_json": {
-					Type:        schema.TypeString,
-					Description: `JSON-formatted user level "elasticsearch.yml" setting overrides`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `JSON-formatted user level "elasticsearch.yml" setting overrides`,
+					Optional:         true,
+					ValidateFunc:     validation.All(validateUserSettingsSize, validateUserSettingsJSON),
+					DiffSuppressFunc: structure.SuppressJsonDiff,
 				},
 				"user_settings_override_json": {
-					Type:        schema.TypeString,
-					Description: `JSON-formatted admin (ECE) level "elasticsearch.yml" setting overrides`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `JSON-formatted admin (ECE) level "elasticsearch.yml" setting overrides`,
+					Optional:         true,
+					ValidateFunc:     validation.All(validateUserSettingsSize, validateUserSettingsJSON),
+					DiffSuppressFunc: structure.SuppressJsonDiff,
 				},
 				"user_settings_yaml": {
-					Type:        schema.TypeString,
-					Description: `YAML-formatted user level "elasticsearch.yml" setting overrides`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `YAML-formatted user level "elasticsearch.yml" setting overrides`,
+					Optional:         true,
+					ValidateFunc:     validateUserSettingsSize,
+					DiffSuppressFunc: suppressYAMLDiff,
 				},
 				"user_settings_override_yaml": {
-					Type:        schema.TypeString,
-					Description: `YAML-formatted admin (ECE) level "elasticsearch.yml" setting overrides`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `YAML-formatted admin (ECE) level "elasticsearch.yml" setting overrides`,
+					Optional:         true,
+					ValidateFunc:     validateUserSettingsSize,
+					DiffSuppressFunc: suppressYAMLDiff,
 				},
 			},
 		},
@@ -373,7 +427,21 @@ func newSnapshotSourceSettings() *schema.Schema {
 				"source_elasticsearch_cluster_id": {
 					Description: "ID of the Elasticsearch cluster that will be used as the source of the snapshot",
 					Type:        schema.TypeString,
-					Required:    true,
+					Optional:    true,
+					ExactlyOneOf: []string{
+						"elasticsearch.0.snapshot_source.0.source_elasticsearch_cluster_id",
+						"elasticsearch.0.snapshot_source.0.source_deployment_id",
+					},
+				},
+				"source_deployment_id": {
+					Description: "ID of the deployment that will be used as the source of the snapshot, it can be in a different region than the deployment. Only used when the deployment is created",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"repository_name": {
+					Description: "Optional name of the snapshot repository to restore the snapshot from. Required when the source deployment is in a different region. Defaults to the 'found-snapshots' repository.",
+					Type:        schema.TypeString,
+					Optional:    true,
 				},
 				"snapshot_name": {
 					Description: "Name of the snapshot to restore. Use '__latest_success__' to get the most recent successful snapshot.",
@@ -542,3 +610,159 @@ func strategyResource() *schema.Resource {
 		},
 	}
 }
+
+func newSamlRealmSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Optional SAML realms, rendered into the Elasticsearch user settings",
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name":  newRealmNameSchema(),
+				"order": newRealmOrderSchema(),
+				"idp_metadata_path": {
+					Type:        schema.TypeString,
+					Description: "Required path or URL of the SAML identity provider metadata",
+					Required:    true,
+				},
+				"idp_entity_id": {
+					Type:        schema.TypeString,
+					Description: "Required SAML entity ID of the identity provider",
+					Required:    true,
+				},
+				"sp_entity_id": {
+					Type:        schema.TypeString,
+					Description: "Required SAML entity ID of the service provider, usually the Kibana URL",
+					Required:    true,
+				},
+				"sp_acs": {
+					Type:        schema.TypeString,
+					Description: "Required assertion consumer service URL, usually the Kibana \"/api/security/saml/callback\" URL",
+					Required:    true,
+				},
+				"sp_logout": {
+					Type:        schema.TypeString,
+					Description: "Optional single logout URL, usually the Kibana \"/logout\" URL",
+					Optional:    true,
+				},
+				"attributes_principal": {
+					Type:        schema.TypeString,
+					Description: "Required SAML attribute used as the user principal",
+					Required:    true,
+				},
+				"attributes_groups": {
+					Type:        schema.TypeString,
+					Description: "Optional SAML attribute used as the user groups",
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+func newOidcRealmSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Optional OpenID Connect realms, rendered into the Elasticsearch user settings and keystore",
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name":  newRealmNameSchema(),
+				"order": newRealmOrderSchema(),
+				"rp_client_id": {
+					Type:        schema.TypeString,
+					Description: "Required client ID of Elasticsearch in the OpenID Connect provider",
+					Required:    true,
+				},
+				"rp_client_secret": {
+					Type:        schema.TypeString,
+					Description: "Required client secret of Elasticsearch in the OpenID Connect provider, stored in the Elasticsearch keystore",
+					Required:    true,
+					Sensitive:   true,
+				},
+				"rp_response_type": {
+					Type:         schema.TypeString,
+					Description:  `Optional OAuth 2.0 response type, either "code" or "id_token" (Defaults to "code")`,
+					Optional:     true,
+					Default:      "code",
+					ValidateFunc: validation.StringInSlice([]string{"code", "id_token"}, false),
+				},
+				"rp_redirect_uri": {
+					Type:        schema.TypeString,
+					Description: "Required redirect URI, usually the Kibana \"/api/security/oidc/callback\" URL",
+					Required:    true,
+				},
+				"rp_post_logout_redirect_uri": {
+					Type:        schema.TypeString,
+					Description: "Optional URL to redirect to after logging out, usually the Kibana \"/security/logged_out\" URL",
+					Optional:    true,
+				},
+				"rp_requested_scopes": {
+					Type:        schema.TypeList,
+					Description: "Optional scopes requested in addition to \"openid\"",
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"op_issuer": {
+					Type:        schema.TypeString,
+					Description: "Required issuer identifier of the OpenID Connect provider",
+					Required:    true,
+				},
+				"op_authorization_endpoint": {
+					Type:        schema.TypeString,
+					Description: "Required authorization endpoint URL of the OpenID Connect provider",
+					Required:    true,
+				},
+				"op_token_endpoint": {
+					Type:        schema.TypeString,
+					Description: "Optional token endpoint URL of the OpenID Connect provider, required when the response type is \"code\"",
+					Optional:    true,
+				},
+				"op_jwkset_path": {
+					Type:        schema.TypeString,
+					Description: "Required path or URL of the OpenID Connect provider JSON Web Key Set",
+					Required:    true,
+				},
+				"op_userinfo_endpoint": {
+					Type:        schema.TypeString,
+					Description: "Optional user info endpoint URL of the OpenID Connect provider",
+					Optional:    true,
+				},
+				"op_endsession_endpoint": {
+					Type:        schema.TypeString,
+					Description: "Optional end session endpoint URL of the OpenID Connect provider",
+					Optional:    true,
+				},
+				"claims_principal": {
+					Type:        schema.TypeString,
+					Description: "Required claim used as the user principal",
+					Required:    true,
+				},
+				"claims_groups": {
+					Type:        schema.TypeString,
+					Description: "Optional claim used as the user groups",
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+func newRealmNameSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "Required realm name, unique across all the realms of the deployment",
+		Required:     true,
+		ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-zA-Z0-9_-]+$`), "must only contain letters, digits, underscores and hyphens"),
+	}
+}
+
+func newRealmOrderSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: "Required order of the realm in the realm chain",
+		Required:    true,
+	}
+}
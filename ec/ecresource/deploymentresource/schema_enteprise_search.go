@@ -19,21 +19,26 @@ package deploymentresource
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func newEnterpriseSearchResource() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"elasticsearch_cluster_ref_id": {
-				Type:     schema.TypeString,
-				Default:  "main-elasticsearch",
-				Optional: true,
+				Type:             schema.TypeString,
+				Default:          "main-elasticsearch",
+				Optional:         true,
+				DiffSuppressFunc: suppressGeneratedRefID("elasticsearch"),
 			},
 			"ref_id": {
-				Type:     schema.TypeString,
-				Default:  "main-enterprise_search",
-				Optional: true,
+				Type:             schema.TypeString,
+				Default:          "main-enterprise_search",
+				Optional:         true,
+				DiffSuppressFunc: suppressGeneratedRefID("enterprise_search"),
 			},
+			"maintenance_mode": maintenanceModeSchema(),
 			"resource_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -52,7 +57,8 @@ func newEnterpriseSearchResource() *schema.Resource {
 			},
 			"topology": enterpriseSearchTopologySchema(),
 
-			"config": enterpriseSearchConfig(),
+			"config":   enterpriseSearchConfig(),
+			"strategy": newStrategySchema(),
 
 			// TODO: Implement settings field.
 			// "settings": interface{}
@@ -79,30 +85,50 @@ func enterpriseSearchTopologySchema() *schema.Schema {
 					Optional: true,
 				},
 				"size_resource": {
-					Type:        schema.TypeString,
-					Description: `Optional size type, defaults to "memory".`,
-					Default:     "memory",
-					Optional:    true,
+					Type:         schema.TypeString,
+					Description:  `Optional size type, either "memory" or "storage", defaults to "memory".`,
+					Default:      "memory",
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice(sizeResources, false),
 				},
 				"zone_count": {
 					Type:     schema.TypeInt,
 					Computed: true,
 					Optional: true,
 				},
+				"instance_configuration_name": {
+					Type:        schema.TypeString,
+					Description: "Name of the instance configuration the running instances use",
+					Computed:    true,
+				},
+				"zones": {
+					Type:        schema.TypeMap,
+					Description: "Number of running instances in each zone",
+					Computed:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeInt,
+					},
+				},
 
 				// Node types
 
 				"node_type_appserver": {
-					Type:     schema.TypeBool,
-					Computed: true,
+					Type:        schema.TypeBool,
+					Description: "Whether the instances run as application/API server. When any node type is enabled, the ones which aren't are disabled",
+					Optional:    true,
+					Computed:    true,
 				},
 				"node_type_connector": {
-					Type:     schema.TypeBool,
-					Computed: true,
+					Type:        schema.TypeBool,
+					Description: "Whether the instances run as connector. When any node type is enabled, the ones which aren't are disabled",
+					Optional:    true,
+					Computed:    true,
 				},
 				"node_type_worker": {
-					Type:     schema.TypeBool,
-					Computed: true,
+					Type:        schema.TypeBool,
+					Description: "Whether the instances run as background worker. When any node type is enabled, the ones which aren't are disabled",
+					Optional:    true,
+					Computed:    true,
 				},
 			},
 		},
@@ -124,24 +150,32 @@ func enterpriseSearchConfig() *schema.Schema {
 					Optional:    true,
 				},
 				"user_settings_json": {
-					Type:        schema.TypeString,
-					Description: `An arbitrary JSON object allowing (non-admin) cluster owners to set their parameters (only one of this and 'user_settings_yaml' is allowed), provided they are on the whitelist ('user_settings_whitelist') and not on the blacklist ('user_settings_blacklist'). (This field together with 'user_settings_override*' and 'system_settings' defines the total set of resource settings)`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `An arbitrary JSON object allowing (non-admin) cluster owners to set their parameters (only one of this and 'user_settings_yaml' is allowed), provided they are on the whitelist ('user_settings_whitelist') and not on the blacklist ('user_settings_blacklist'). (This field together with 'user_settings_override*' and 'system_settings' defines the total set of resource settings)`,
+					Optional:         true,
+					ValidateFunc:     validation.All(validateUserSettingsSize, validateUserSettingsJSON),
+					DiffSuppressFunc: structure.SuppressJsonDiff,
 				},
 				"user_settings_override_json": {
-					Type:        schema.TypeString,
-					Description: `An arbitrary JSON object allowing ECE admins owners to set clusters' parameters (only one of this and 'user_settings_override_yaml' is allowed), ie in addition to the documented 'system_settings'. (This field together with 'system_settings' and 'user_settings*' defines the total set of resource settings)`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `An arbitrary JSON object allowing ECE admins owners to set clusters' parameters (only one of this and 'user_settings_override_yaml' is allowed), ie in addition to the documented 'system_settings'. (This field together with 'system_settings' and 'user_settings*' defines the total set of resource settings)`,
+					Optional:         true,
+					ValidateFunc:     validation.All(validateUserSettingsSize, validateUserSettingsJSON),
+					DiffSuppressFunc: structure.SuppressJsonDiff,
 				},
 				"user_settings_yaml": {
-					Type:        schema.TypeString,
-					Description: `An arbitrary YAML object allowing ECE admins owners to set clusters' parameters (only one of this and 'user_settings_override_json' is allowed), ie in addition to the documented 'system_settings'. (This field together with 'system_settings' and 'user_settings*' defines the total set of resource settings)`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `An arbitrary YAML object allowing ECE admins owners to set clusters' parameters (only one of this and 'user_settings_override_json' is allowed), ie in addition to the documented 'system_settings'. (This field together with 'system_settings' and 'user_settings*' defines the total set of resource settings)`,
+					Optional:         true,
+					ValidateFunc:     validateUserSettingsSize,
+					DiffSuppressFunc: suppressYAMLDiff,
 				},
 				"user_settings_override_yaml": {
-					Type:        schema.TypeString,
-					Description: `An arbitrary YAML object allowing (non-admin) cluster owners to set their parameters (only one of this and 'user_settings_json' is allowed), provided they are on the whitelist ('user_settings_whitelist') and not on the blacklist ('user_settings_blacklist'). (These field together with 'user_settings_override*' and 'system_settings' defines the total set of resource settings)`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `An arbitrary YAML object allowing (non-admin) cluster owners to set their parameters (only one of this and 'user_settings_json' is allowed), provided they are on the whitelist ('user_settings_whitelist') and not on the blacklist ('user_settings_blacklist'). (These field together with 'user_settings_override*' and 'system_settings' defines the total set of resource settings)`,
+					Optional:         true,
+					ValidateFunc:     validateUserSettingsSize,
+					DiffSuppressFunc: suppressYAMLDiff,
 				},
 			},
 		},
@@ -18,22 +18,28 @@
 package deploymentresource
 
 import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func newIntegrationsServerResource() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"elasticsearch_cluster_ref_id": {
-				Type:     schema.TypeString,
-				Default:  "main-elasticsearch",
-				Optional: true,
+				Type:             schema.TypeString,
+				Default:          "main-elasticsearch",
+				Optional:         true,
+				DiffSuppressFunc: suppressGeneratedRefID("elasticsearch"),
 			},
 			"ref_id": {
-				Type:     schema.TypeString,
-				Default:  "main-integrations_server",
-				Optional: true,
+				Type:             schema.TypeString,
+				Default:          "main-integrations_server",
+				Optional:         true,
+				DiffSuppressFunc: suppressGeneratedRefID("integrations_server"),
 			},
+			"maintenance_mode": maintenanceModeSchema(),
 			"resource_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -60,7 +66,8 @@ func newIntegrationsServerResource() *schema.Resource {
 			},
 			"topology": integrationsServerTopologySchema(),
 
-			"config": integrationsServerConfig(),
+			"config":   integrationsServerConfig(),
+			"strategy": newStrategySchema(),
 		},
 	}
 }
@@ -84,10 +91,11 @@ func integrationsServerTopologySchema() *schema.Schema {
 					Optional: true,
 				},
 				"size_resource": {
-					Type:        schema.TypeString,
-					Description: `Optional size type, defaults to "memory".`,
-					Default:     "memory",
-					Optional:    true,
+					Type:         schema.TypeString,
+					Description:  `Optional size type, either "memory" or "storage", defaults to "memory".`,
+					Default:      "memory",
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice(sizeResources, false),
 				},
 				"zone_count": {
 					Type:     schema.TypeInt,
@@ -113,6 +121,16 @@ func integrationsServerConfig() *schema.Schema {
 					Description: "Optionally override the docker image the IntegrationsServer nodes will use. Note that this field will only work for internal users only.",
 					Optional:    true,
 				},
+				"mode": {
+					Type:        schema.TypeString,
+					Description: `Optionally set the mode the IntegrationsServer operates in, either "managed" for Fleet-managed APM or "standalone". When omitted, the mode chosen by the API is used`,
+					Optional:    true,
+					Computed:    true,
+					ValidateFunc: validation.StringInSlice([]string{
+						models.IntegrationsServerConfigurationModeManaged,
+						models.IntegrationsServerConfigurationModeStandalone,
+					}, false),
+				},
 				// IntegrationsServer System Settings
 				"debug_enabled": {
 					Type:        schema.TypeBool,
@@ -122,24 +140,32 @@ func integrationsServerConfig() *schema.Schema {
 				},
 
 				"user_settings_json": {
-					Type:        schema.TypeString,
-					Description: `An arbitrary JSON object allowing (non-admin) cluster owners to set their parameters (only one of this and 'user_settings_yaml' is allowed), provided they are on the whitelist ('user_settings_whitelist') and not on the blacklist ('user_settings_blacklist'). (This field together with 'user_settings_override*' and 'system_settings' defines the total set of resource settings)`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `An arbitrary JSON object allowing (non-admin) cluster owners to set their parameters (only one of this and 'user_settings_yaml' is allowed), provided they are on the whitelist ('user_settings_whitelist') and not on the blacklist ('user_settings_blacklist'). (This field together with 'user_settings_override*' and 'system_settings' defines the total set of resource settings)`,
+					Optional:         true,
+					ValidateFunc:     validation.All(validateUserSettingsSize, validateUserSettingsJSON),
+					DiffSuppressFunc: structure.SuppressJsonDiff,
 				},
 				"user_settings_override_json": {
-					Type:        schema.TypeString,
-					Description: `An arbitrary JSON object allowing ECE admins owners to set clusters' parameters (only one of this and 'user_settings_override_yaml' is allowed), ie in addition to the documented 'system_settings'. (This field together with 'system_settings' and 'user_settings*' defines the total set of resource settings)`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `An arbitrary JSON object allowing ECE admins owners to set clusters' parameters (only one of this and 'user_settings_override_yaml' is allowed), ie in addition to the documented 'system_settings'. (This field together with 'system_settings' and 'user_settings*' defines the total set of resource settings)`,
+					Optional:         true,
+					ValidateFunc:     validation.All(validateUserSettingsSize, validateUserSettingsJSON),
+					DiffSuppressFunc: structure.SuppressJsonDiff,
 				},
 				"user_settings_yaml": {
-					Type:        schema.TypeString,
-					Description: `An arbitrary YAML object allowing ECE admins owners to set clusters' parameters (only one of this and 'user_settings_override_json' is allowed), ie in addition to the documented 'system_settings'. (This field together with 'system_settings' and 'user_settings*' defines the total set of resource settings)`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `An arbitrary YAML object allowing ECE admins owners to set clusters' parameters (only one of this and 'user_settings_override_json' is allowed), ie in addition to the documented 'system_settings'. (This field together with 'system_settings' and 'user_settings*' defines the total set of resource settings)`,
+					Optional:         true,
+					ValidateFunc:     validateUserSettingsSize,
+					DiffSuppressFunc: suppressYAMLDiff,
 				},
 				"user_settings_override_yaml": {
-					Type:        schema.TypeString,
-					Description: `An arbitrary YAML object allowing (non-admin) cluster owners to set their parameters (only one of this and 'user_settings_json' is allowed), provided they are on the whitelist ('user_settings_whitelist') and not on the blacklist ('user_settings_blacklist'). (These field together with 'user_settings_override*' and 'system_settings' defines the total set of resource settings)`,
-					Optional:    true,
+					Type:             schema.TypeString,
+					Description:      `An arbitrary YAML object allowing (non-admin) cluster owners to set their parameters (only one of this and 'user_settings_json' is allowed), provided they are on the whitelist ('user_settings_whitelist') and not on the blacklist ('user_settings_blacklist'). (These field together with 'user_settings_override*' and 'system_settings' defines the total set of resource settings)`,
+					Optional:         true,
+					ValidateFunc:     validateUserSettingsSize,
+					DiffSuppressFunc: suppressYAMLDiff,
 				},
 			},
 		},
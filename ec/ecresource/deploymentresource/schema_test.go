@@ -0,0 +1,116 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_suppressMissingUserSettingsBlock(t *testing.T) {
+	newKibana := func(config map[string]interface{}) map[string]interface{} {
+		kibana := map[string]interface{}{"ref_id": "main-kibana"}
+		if config != nil {
+			kibana["config"] = []interface{}{config}
+		}
+		return map[string]interface{}{"kibana": []interface{}{kibana}}
+	}
+
+	tests := []struct {
+		name   string
+		state  map[string]interface{}
+		change map[string]interface{}
+		old    string
+		new    string
+		want   bool
+	}{
+		{
+			name:   "config block without user settings",
+			state:  newKibana(map[string]interface{}{"docker_image": "docker.elastic.co/kibana:8.4.0"}),
+			change: newKibana(nil),
+			old:    "1",
+			new:    "0",
+			want:   true,
+		},
+		{
+			name:   "config block with user settings",
+			state:  newKibana(map[string]interface{}{"user_settings_yaml": "some.setting: value"}),
+			change: newKibana(nil),
+			old:    "1",
+			new:    "0",
+		},
+		{
+			name:   "config block with user settings override",
+			state:  newKibana(map[string]interface{}{"user_settings_override_json": `{"some.setting":"value"}`}),
+			change: newKibana(nil),
+			old:    "1",
+			new:    "0",
+		},
+		{
+			name:   "config block added",
+			state:  newKibana(nil),
+			change: newKibana(map[string]interface{}{"user_settings_yaml": "some.setting: value"}),
+			old:    "0",
+			new:    "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := util.NewResourceData(t, util.ResDataParams{
+				ID:     mock.ValidClusterID,
+				Schema: newSchema(),
+				State:  tt.state,
+				Change: tt.change,
+			})
+			assert.Equal(t, tt.want, suppressMissingUserSettingsBlock(
+				"kibana.0.config.#", tt.old, tt.new, d,
+			))
+		})
+	}
+}
+
+func Test_skipDestroyIfTerminatedRequiresRestore(t *testing.T) {
+	hasSkipDestroyError := func(diags diag.Diagnostics) bool {
+		for _, d := range diags {
+			if d.Severity == diag.Error && strings.Contains(d.Detail, `"skip_destroy_if_terminated": all of`) {
+				return true
+			}
+		}
+		return false
+	}
+
+	cfg := map[string]interface{}{
+		"version":                    "8.4.3",
+		"region":                     "us-east-1",
+		"deployment_template_id":     "aws-io-optimized-v2",
+		"skip_destroy_if_terminated": true,
+	}
+	diags := Resource().Validate(terraform.NewResourceConfigRaw(cfg))
+	assert.True(t, hasSkipDestroyError(diags), "skip_destroy_if_terminated can't be set alone")
+
+	cfg["restore_if_terminated"] = true
+	diags = Resource().Validate(terraform.NewResourceConfigRaw(cfg))
+	assert.False(t, hasSkipDestroyError(diags))
+}
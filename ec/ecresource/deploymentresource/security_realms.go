@@ -0,0 +1,419 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/eskeystoreapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+const (
+	samlRealm = "saml"
+	oidcRealm = "oidc"
+
+	realmsPrefix = "xpack.security.authc.realms."
+
+	// oidcClientSecretKey is the OpenID Connect realm secure setting which
+	// is stored in the Elasticsearch keystore rather than in the user
+	// settings.
+	oidcClientSecretKey = "rp.client_secret"
+)
+
+// realmSetting maps a realm block attribute to its Elasticsearch setting,
+// relative to the realm settings prefix.
+type realmSetting struct {
+	attr string
+	key  string
+}
+
+var realmSettings = map[string][]realmSetting{
+	samlRealm: {
+		{attr: "order", key: "order"},
+		{attr: "idp_metadata_path", key: "idp.metadata.path"},
+		{attr: "idp_entity_id", key: "idp.entity_id"},
+		{attr: "sp_entity_id", key: "sp.entity_id"},
+		{attr: "sp_acs", key: "sp.acs"},
+		{attr: "sp_logout", key: "sp.logout"},
+		{attr: "attributes_principal", key: "attributes.principal"},
+		{attr: "attributes_groups", key: "attributes.groups"},
+	},
+	oidcRealm: {
+		{attr: "order", key: "order"},
+		{attr: "rp_client_id", key: "rp.client_id"},
+		{attr: "rp_response_type", key: "rp.response_type"},
+		{attr: "rp_redirect_uri", key: "rp.redirect_uri"},
+		{attr: "rp_post_logout_redirect_uri", key: "rp.post_logout_redirect_uri"},
+		{attr: "rp_requested_scopes", key: "rp.requested_scopes"},
+		{attr: "op_issuer", key: "op.issuer"},
+		{attr: "op_authorization_endpoint", key: "op.authorization_endpoint"},
+		{attr: "op_token_endpoint", key: "op.token_endpoint"},
+		{attr: "op_jwkset_path", key: "op.jwkset_path"},
+		{attr: "op_userinfo_endpoint", key: "op.userinfo_endpoint"},
+		{attr: "op_endsession_endpoint", key: "op.endsession_endpoint"},
+		{attr: "claims_principal", key: "claims.principal"},
+		{attr: "claims_groups", key: "claims.groups"},
+	},
+}
+
+func realmPrefix(kind, name string) string {
+	return realmsPrefix + kind + "." + name + "."
+}
+
+// expandSecurityRealms renders the SAML and OpenID Connect realm blocks into
+// the Elasticsearch user settings JSON, along with any other user settings.
+func expandSecurityRealms(es map[string]interface{}, cfg *models.ElasticsearchConfiguration) error {
+	var settings = make(map[string]interface{})
+	for _, kind := range []string{samlRealm, oidcRealm} {
+		realms, _ := es[kind].([]interface{})
+		for _, raw := range realms {
+			m, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			prefix := realmPrefix(kind, m["name"].(string))
+			for _, s := range realmSettings[kind] {
+				if v, ok := expandRealmValue(m[s.attr]); ok {
+					settings[prefix+s.key] = v
+				}
+			}
+		}
+	}
+
+	if len(settings) == 0 {
+		return nil
+	}
+
+	if cfg.UserSettingsYaml != "" {
+		return errors.New(
+			"elasticsearch saml and oidc realms can't be combined with user_settings_yaml, use user_settings_json instead",
+		)
+	}
+
+	if cfg.UserSettingsJSON != nil {
+		m, ok := cfg.UserSettingsJSON.(map[string]interface{})
+		if !ok {
+			return errors.New("elasticsearch saml and oidc realms require user_settings_json to be a JSON object")
+		}
+		for k, v := range m {
+			if _, ok := settings[k]; !ok {
+				settings[k] = v
+			}
+		}
+	}
+
+	cfg.UserSettingsJSON = settings
+	return nil
+}
+
+// expandRealmValue returns the setting value of a realm attribute, and false
+// when the attribute isn't set.
+func expandRealmValue(v interface{}) (interface{}, bool) {
+	switch value := v.(type) {
+	case string:
+		return value, value != ""
+	case int:
+		return value, true
+	case []interface{}:
+		if len(value) == 0 {
+			return nil, false
+		}
+		return value, true
+	}
+	return nil, false
+}
+
+// flattenSecurityRealms returns the managed SAML and OpenID Connect realms
+// found in the Elasticsearch user settings, along with the rest of the user
+// settings without them. Realm settings which don't map to any of the realm
+// block attributes are kept in the user settings.
+func flattenSecurityRealms(userSettings map[string]interface{}, managed managedRealms) (saml, oidc []interface{}, rest map[string]interface{}) {
+	rest = make(map[string]interface{}, len(userSettings))
+	var realms = map[string]map[string]map[string]interface{}{
+		samlRealm: {}, oidcRealm: {},
+	}
+	for k, v := range userSettings {
+		kind, name, attr, ok := parseRealmSetting(k)
+		if !ok || !managed.has(kind, name) {
+			rest[k] = v
+			continue
+		}
+
+		value, ok := flattenRealmValue(v)
+		if !ok {
+			rest[k] = v
+			continue
+		}
+
+		realm, ok := realms[kind][name]
+		if !ok {
+			realm = map[string]interface{}{"name": name}
+			realms[kind][name] = realm
+		}
+		realm[attr] = value
+	}
+
+	for name, realm := range realms[oidcRealm] {
+		realm["rp_client_secret"] = managed[oidcRealm][name]
+	}
+
+	return sortRealms(realms[samlRealm]), sortRealms(realms[oidcRealm]), rest
+}
+
+// parseRealmSetting returns the realm kind, name and block attribute of an
+// Elasticsearch realm setting, and false when the setting isn't one of the
+// realm block attributes.
+func parseRealmSetting(key string) (kind, name, attr string, ok bool) {
+	for _, kind := range []string{samlRealm, oidcRealm} {
+		prefix := realmsPrefix + kind + "."
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		parts := strings.SplitN(strings.TrimPrefix(key, prefix), ".", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return "", "", "", false
+		}
+
+		for _, s := range realmSettings[kind] {
+			if s.key == parts[1] {
+				return kind, parts[0], s.attr, true
+			}
+		}
+	}
+
+	return "", "", "", false
+}
+
+func flattenRealmValue(v interface{}) (interface{}, bool) {
+	switch value := v.(type) {
+	case string:
+		return value, true
+	case float64:
+		return int(value), true
+	case int:
+		return value, true
+	case []interface{}:
+		return value, true
+	}
+	return nil, false
+}
+
+// sortRealms returns the realms sorted by their order and name.
+func sortRealms(realms map[string]map[string]interface{}) []interface{} {
+	if len(realms) == 0 {
+		return nil
+	}
+
+	var result = make([]interface{}, 0, len(realms))
+	for _, realm := range realms {
+		result = append(result, realm)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i].(map[string]interface{}), result[j].(map[string]interface{})
+		ao, _ := a["order"].(int)
+		bo, _ := b["order"].(int)
+		if ao != bo {
+			return ao < bo
+		}
+		return a["name"].(string) < b["name"].(string)
+	})
+
+	return result
+}
+
+// stripOIDCRealms removes the OpenID Connect realm settings from the create
+// request, and returns whether any were removed. Elasticsearch doesn't start
+// with an OpenID Connect realm whose client secret isn't in the keystore, so
+// the realms can only be added once the deployment exists and its keystore
+// has been updated.
+func stripOIDCRealms(req *models.DeploymentCreateRequest) bool {
+	if req.Resources == nil {
+		return false
+	}
+
+	var stripped bool
+	for _, es := range req.Resources.Elasticsearch {
+		if es.Plan == nil || es.Plan.Elasticsearch == nil {
+			continue
+		}
+
+		m, ok := es.Plan.Elasticsearch.UserSettingsJSON.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for k := range m {
+			if strings.HasPrefix(k, realmsPrefix+oidcRealm+".") {
+				delete(m, k)
+				stripped = true
+			}
+		}
+	}
+
+	return stripped
+}
+
+// managedRealms are the names of the realms configured through the realm
+// blocks by kind, along with the OpenID Connect realm client secrets.
+type managedRealms map[string]map[string]string
+
+func (m managedRealms) has(kind, name string) bool {
+	_, ok := m[kind][name]
+	return ok
+}
+
+// newManagedRealms returns the realms configured in the realm blocks.
+func newManagedRealms(d *schema.ResourceData) managedRealms {
+	var result = make(managedRealms)
+	for _, kind := range []string{samlRealm, oidcRealm} {
+		result[kind] = oidcClientSecrets(d.Get("elasticsearch.0." + kind))
+	}
+	return result
+}
+
+// oidcClientSecrets returns the realm client secrets by realm name. Realms
+// without a client secret, such as the SAML ones, map to an empty string.
+func oidcClientSecrets(realms interface{}) map[string]string {
+	var secrets = make(map[string]string)
+	raw, _ := realms.([]interface{})
+	for _, r := range raw {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name, _ := m["name"].(string)
+		secret, _ := m["rp_client_secret"].(string)
+		if name != "" {
+			secrets[name] = secret
+		}
+	}
+	return secrets
+}
+
+// flattenManagedRealms moves the settings of the realms managed through the
+// realm blocks from the Elasticsearch user_settings_json to the realm blocks.
+// Realms which are only set in user_settings_json, such as the ones of an
+// imported deployment, are kept there.
+func flattenManagedRealms(d *schema.ResourceData, managed managedRealms) error {
+	if len(managed[samlRealm]) == 0 && len(managed[oidcRealm]) == 0 {
+		return nil
+	}
+
+	es, ok := d.Get("elasticsearch").([]interface{})
+	if !ok || len(es) == 0 || es[0] == nil {
+		return nil
+	}
+	m := es[0].(map[string]interface{})
+
+	cfgs, _ := m["config"].([]interface{})
+	if len(cfgs) == 0 || cfgs[0] == nil {
+		return nil
+	}
+	cfg := cfgs[0].(map[string]interface{})
+
+	raw, _ := cfg["user_settings_json"].(string)
+	if raw == "" {
+		return nil
+	}
+
+	var settings map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &settings); err != nil {
+		return nil
+	}
+
+	saml, oidc, rest := flattenSecurityRealms(settings, managed)
+	m[samlRealm], m[oidcRealm] = saml, oidc
+
+	cfg["user_settings_json"] = ""
+	if len(rest) > 0 {
+		b, err := json.Marshal(rest)
+		if err != nil {
+			return err
+		}
+		cfg["user_settings_json"] = string(b)
+	}
+
+	return d.Set("elasticsearch", es)
+}
+
+// updateOIDCClientSecrets stores the client secrets of the configured OpenID
+// Connect realms in the Elasticsearch keystore. When removed is set, the
+// secrets of the realms which are no longer configured are removed instead.
+func updateOIDCClientSecrets(client *api.API, d *schema.ResourceData, removed bool) error {
+	if !d.HasChange("elasticsearch.0." + oidcRealm) {
+		return nil
+	}
+
+	oldRealms, newRealms := d.GetChange("elasticsearch.0." + oidcRealm)
+	current := oidcClientSecrets(newRealms)
+
+	var secrets = make(map[string]models.KeystoreSecret)
+	if removed {
+		for name := range oidcClientSecrets(oldRealms) {
+			if _, ok := current[name]; !ok {
+				secrets[realmPrefix(oidcRealm, name)+oidcClientSecretKey] = models.KeystoreSecret{}
+			}
+		}
+	} else {
+		for name, secret := range current {
+			secrets[realmPrefix(oidcRealm, name)+oidcClientSecretKey] = models.KeystoreSecret{
+				Value: secret,
+			}
+		}
+	}
+
+	if len(secrets) == 0 {
+		return nil
+	}
+
+	if _, err := eskeystoreapi.Update(eskeystoreapi.UpdateParams{
+		API:          client,
+		DeploymentID: d.Id(),
+		RefID:        resourceRefID(d, "elasticsearch"),
+		Contents:     &models.KeystoreContents{Secrets: secrets},
+	}); err != nil {
+		return fmt.Errorf("failed updating the oidc realm client secrets: %w", err)
+	}
+
+	return nil
+}
+
+// addOIDCRealms adds the OpenID Connect realms which were left out of the
+// create request once the deployment exists, after their client secrets have
+// been stored in the keystore.
+func addOIDCRealms(ctx context.Context, d *schema.ResourceData, meta *util.ProviderMeta) error {
+	if err := updateOIDCClientSecrets(meta.API, d, false); err != nil {
+		return err
+	}
+
+	return updateDeployment(ctx, d, meta)
+}
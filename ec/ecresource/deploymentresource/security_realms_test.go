@@ -0,0 +1,184 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandSecurityRealms(t *testing.T) {
+	saml := map[string]interface{}{
+		"name":                 "saml1",
+		"order":                2,
+		"idp_metadata_path":    "https://idp.example.com/metadata.xml",
+		"idp_entity_id":        "https://idp.example.com",
+		"sp_entity_id":         "https://kibana.example.com",
+		"sp_acs":               "https://kibana.example.com/api/security/saml/callback",
+		"sp_logout":            "",
+		"attributes_principal": "nameid",
+		"attributes_groups":    "groups",
+	}
+	oidc := map[string]interface{}{
+		"name":                      "oidc1",
+		"order":                     3,
+		"rp_client_id":              "elasticsearch",
+		"rp_client_secret":          "secret",
+		"rp_response_type":          "code",
+		"rp_redirect_uri":           "https://kibana.example.com/api/security/oidc/callback",
+		"rp_requested_scopes":       []interface{}{"email"},
+		"op_issuer":                 "https://op.example.com",
+		"op_authorization_endpoint": "https://op.example.com/auth",
+		"op_token_endpoint":         "https://op.example.com/token",
+		"op_jwkset_path":            "https://op.example.com/jwks",
+		"claims_principal":          "sub",
+	}
+	type args struct {
+		es  map[string]interface{}
+		cfg *models.ElasticsearchConfiguration
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.ElasticsearchConfiguration
+		err  error
+	}{
+		{
+			name: "leaves the user settings untouched without realms",
+			args: args{es: map[string]interface{}{}, cfg: &models.ElasticsearchConfiguration{
+				UserSettingsYaml: "action.auto_create_index: true",
+			}},
+			want: &models.ElasticsearchConfiguration{
+				UserSettingsYaml: "action.auto_create_index: true",
+			},
+		},
+		{
+			name: "renders the realms into the user settings json",
+			args: args{
+				es: map[string]interface{}{
+					"saml": []interface{}{saml},
+					"oidc": []interface{}{oidc},
+				},
+				cfg: &models.ElasticsearchConfiguration{
+					UserSettingsJSON: map[string]interface{}{"action.auto_create_index": true},
+				},
+			},
+			want: &models.ElasticsearchConfiguration{UserSettingsJSON: map[string]interface{}{
+				"action.auto_create_index":                                         true,
+				"xpack.security.authc.realms.saml.saml1.order":                     2,
+				"xpack.security.authc.realms.saml.saml1.idp.metadata.path":         "https://idp.example.com/metadata.xml",
+				"xpack.security.authc.realms.saml.saml1.idp.entity_id":             "https://idp.example.com",
+				"xpack.security.authc.realms.saml.saml1.sp.entity_id":              "https://kibana.example.com",
+				"xpack.security.authc.realms.saml.saml1.sp.acs":                    "https://kibana.example.com/api/security/saml/callback",
+				"xpack.security.authc.realms.saml.saml1.attributes.principal":      "nameid",
+				"xpack.security.authc.realms.saml.saml1.attributes.groups":         "groups",
+				"xpack.security.authc.realms.oidc.oidc1.order":                     3,
+				"xpack.security.authc.realms.oidc.oidc1.rp.client_id":              "elasticsearch",
+				"xpack.security.authc.realms.oidc.oidc1.rp.response_type":          "code",
+				"xpack.security.authc.realms.oidc.oidc1.rp.redirect_uri":           "https://kibana.example.com/api/security/oidc/callback",
+				"xpack.security.authc.realms.oidc.oidc1.rp.requested_scopes":       []interface{}{"email"},
+				"xpack.security.authc.realms.oidc.oidc1.op.issuer":                 "https://op.example.com",
+				"xpack.security.authc.realms.oidc.oidc1.op.authorization_endpoint": "https://op.example.com/auth",
+				"xpack.security.authc.realms.oidc.oidc1.op.token_endpoint":         "https://op.example.com/token",
+				"xpack.security.authc.realms.oidc.oidc1.op.jwkset_path":            "https://op.example.com/jwks",
+				"xpack.security.authc.realms.oidc.oidc1.claims.principal":          "sub",
+			}},
+		},
+		{
+			name: "fails when combined with user settings yaml",
+			args: args{
+				es: map[string]interface{}{"saml": []interface{}{saml}},
+				cfg: &models.ElasticsearchConfiguration{
+					UserSettingsYaml: "action.auto_create_index: true",
+				},
+			},
+			want: &models.ElasticsearchConfiguration{
+				UserSettingsYaml: "action.auto_create_index: true",
+			},
+			err: errors.New("elasticsearch saml and oidc realms can't be combined with user_settings_yaml, use user_settings_json instead"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := expandSecurityRealms(tt.args.es, tt.args.cfg)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, tt.args.cfg)
+		})
+	}
+}
+
+func Test_flattenSecurityRealms(t *testing.T) {
+	settings := map[string]interface{}{
+		"action.auto_create_index":                                    true,
+		"xpack.security.authc.realms.saml.saml1.order":                float64(2),
+		"xpack.security.authc.realms.saml.saml1.idp.entity_id":        "https://idp.example.com",
+		"xpack.security.authc.realms.saml.saml1.attributes.principal": "nameid",
+		"xpack.security.authc.realms.saml.saml1.nameid_format":        "persistent",
+		"xpack.security.authc.realms.saml.unmanaged.order":            float64(4),
+		"xpack.security.authc.realms.oidc.oidc1.order":                float64(3),
+		"xpack.security.authc.realms.oidc.oidc1.rp.requested_scopes":  []interface{}{"email"},
+	}
+	managed := managedRealms{
+		samlRealm: {"saml1": ""},
+		oidcRealm: {"oidc1": "secret"},
+	}
+
+	saml, oidc, rest := flattenSecurityRealms(settings, managed)
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"name":                 "saml1",
+		"order":                2,
+		"idp_entity_id":        "https://idp.example.com",
+		"attributes_principal": "nameid",
+	}}, saml)
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"name":                "oidc1",
+		"order":               3,
+		"rp_requested_scopes": []interface{}{"email"},
+		"rp_client_secret":    "secret",
+	}}, oidc)
+	assert.Equal(t, map[string]interface{}{
+		"action.auto_create_index":                             true,
+		"xpack.security.authc.realms.saml.saml1.nameid_format": "persistent",
+		"xpack.security.authc.realms.saml.unmanaged.order":     float64(4),
+	}, rest)
+}
+
+func Test_stripOIDCRealms(t *testing.T) {
+	req := &models.DeploymentCreateRequest{Resources: &models.DeploymentCreateResources{
+		Elasticsearch: []*models.ElasticsearchPayload{{
+			Plan: &models.ElasticsearchClusterPlan{
+				Elasticsearch: &models.ElasticsearchConfiguration{
+					UserSettingsJSON: map[string]interface{}{
+						"xpack.security.authc.realms.saml.saml1.order": 2,
+						"xpack.security.authc.realms.oidc.oidc1.order": 3,
+					},
+				},
+			},
+		}},
+	}}
+
+	assert.True(t, stripOIDCRealms(req))
+	assert.Equal(t, map[string]interface{}{
+		"xpack.security.authc.realms.saml.saml1.order": 2,
+	}, req.Resources.Elasticsearch[0].Plan.Elasticsearch.UserSettingsJSON)
+
+	assert.False(t, stripOIDCRealms(req))
+}
@@ -0,0 +1,234 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+const (
+	memorySizeResource  = "memory"
+	storageSizeResource = "storage"
+)
+
+var (
+	// sizeResources are the accepted size_resource values.
+	sizeResources = []string{memorySizeResource, storageSizeResource}
+
+	// sizedResourceKinds are the resource kinds whose topology elements
+	// accept a size_resource.
+	sizedResourceKinds = []string{
+		"elasticsearch",
+		"kibana",
+		"apm",
+		"integrations_server",
+		"enterprise_search",
+	}
+)
+
+// validateSizeResourceDiff fails the plan when any topology element sets a
+// size_resource which doesn't match the size resource of its instance
+// configuration. Since "memory" is supported by all the instance
+// configurations, the template is only obtained when any of the changed
+// topology elements is sized in "storage".
+func validateSizeResourceDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, k := range []string{"deployment_template_id", "region"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	var resources = make(map[string][]interface{})
+	var hasStorage bool
+	for _, kind := range sizedResourceKinds {
+		if !d.HasChange(kind) || !d.NewValueKnown(kind) {
+			continue
+		}
+
+		raw, _ := d.Get(kind).([]interface{})
+		if len(raw) == 0 {
+			continue
+		}
+
+		resources[kind] = raw
+		for _, topology := range rawTopologies(kind, raw) {
+			if topology["size_resource"] == storageSizeResource {
+				hasStorage = true
+			}
+		}
+	}
+
+	if !hasStorage {
+		return nil
+	}
+
+	template, err := getDiffTemplate(ctx, meta.(*util.ProviderMeta).API,
+		d.Get("deployment_template_id").(string), d.Get("region").(string),
+	)
+	if err != nil {
+		return multierror.NewPrefixed(
+			"failed obtaining the deployment template instance configurations", err,
+		)
+	}
+
+	return validateSizeResources(resources, template)
+}
+
+// validateSizeResources validates that the size_resource of every topology
+// element matches the size resource of its instance configuration.
+func validateSizeResources(resources map[string][]interface{}, template *models.DeploymentTemplateInfoV2) error {
+	merr := multierror.NewPrefixed("invalid size_resource")
+	for _, kind := range sizedResourceKinds {
+		raw, ok := resources[kind]
+		if !ok {
+			continue
+		}
+
+		tplICs := templateInstanceConfigurations(kind, template)
+		for i, topology := range rawTopologies(kind, raw) {
+			resource, _ := topology["size_resource"].(string)
+			if resource == "" {
+				continue
+			}
+
+			icID := topologyInstanceConfiguration(topology, i, tplICs)
+			want := instanceConfigurationSizeResource(template.InstanceConfigurations, icID)
+			if want == "" || want == resource {
+				continue
+			}
+
+			merr = merr.Append(fmt.Errorf(
+				`%s topology %s: size_resource "%s" doesn't match the "%s" instance configuration size resource "%s"`,
+				kind, topologyName(topology, i), resource, icID, want,
+			))
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// rawTopologies returns the flattened topology elements of a resource kind.
+func rawTopologies(kind string, raw []interface{}) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, rawRes := range raw {
+		res, ok := rawRes.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var topologies []interface{}
+		if kind == "elasticsearch" {
+			topologies = esTopology(res)
+		} else {
+			topologies, _ = res["topology"].([]interface{})
+		}
+
+		for _, rawTop := range topologies {
+			if topology, ok := rawTop.(map[string]interface{}); ok {
+				result = append(result, topology)
+			}
+		}
+	}
+	return result
+}
+
+// templateInstanceConfigurations returns the instance configuration IDs of
+// the template topology elements, keyed by topology ID for Elasticsearch and
+// by topology index for the rest of the resource kinds.
+func templateInstanceConfigurations(kind string, template *models.DeploymentTemplateInfoV2) map[string]string {
+	var result = make(map[string]string)
+	if template == nil || template.DeploymentTemplate == nil || template.DeploymentTemplate.Resources == nil {
+		return result
+	}
+
+	switch kind {
+	case "elasticsearch":
+		if res := esResource(template); res != nil && res.Plan != nil {
+			for _, t := range res.Plan.ClusterTopology {
+				result[t.ID] = t.InstanceConfigurationID
+			}
+		}
+	case "kibana":
+		if res := kibanaResource(template); res != nil && res.Plan != nil {
+			for i, t := range res.Plan.ClusterTopology {
+				result[strconv.Itoa(i)] = t.InstanceConfigurationID
+			}
+		}
+	case "apm":
+		if res := apmResource(template); res != nil && res.Plan != nil {
+			for i, t := range res.Plan.ClusterTopology {
+				result[strconv.Itoa(i)] = t.InstanceConfigurationID
+			}
+		}
+	case "integrations_server":
+		if res := integrationsServerResource(template); res != nil && res.Plan != nil {
+			for i, t := range res.Plan.ClusterTopology {
+				result[strconv.Itoa(i)] = t.InstanceConfigurationID
+			}
+		}
+	case "enterprise_search":
+		if res := essResource(template); res != nil && res.Plan != nil {
+			for i, t := range res.Plan.ClusterTopology {
+				result[strconv.Itoa(i)] = t.InstanceConfigurationID
+			}
+		}
+	}
+
+	return result
+}
+
+// topologyInstanceConfiguration returns the instance configuration ID of a
+// topology element, falling back to the template one when it's not set.
+func topologyInstanceConfiguration(topology map[string]interface{}, i int, tplICs map[string]string) string {
+	icID, _ := topology["instance_configuration_id"].(string)
+	if id, ok := topology["id"].(string); ok && id != "" {
+		if tplID, ok := tplICs[id]; ok {
+			icID = tplID
+		}
+	} else if icID == "" {
+		icID = tplICs[strconv.Itoa(i)]
+	}
+	return icID
+}
+
+// instanceConfigurationSizeResource returns the resource the discrete sizes
+// of an instance configuration are expressed in.
+func instanceConfigurationSizeResource(ics []*models.InstanceConfigurationInfo, id string) string {
+	for _, ic := range ics {
+		if ic == nil || ic.ID != id || ic.DiscreteSizes == nil || ic.DiscreteSizes.Resource == nil {
+			continue
+		}
+		return *ic.DiscreteSizes.Resource
+	}
+	return ""
+}
+
+func topologyName(topology map[string]interface{}, i int) string {
+	if id, ok := topology["id"].(string); ok && id != "" {
+		return fmt.Sprintf(`"%s"`, id)
+	}
+	return fmt.Sprintf("[%d]", i)
+}
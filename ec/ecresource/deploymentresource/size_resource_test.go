@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateSizeResources(t *testing.T) {
+	template := &models.DeploymentTemplateInfoV2{
+		DeploymentTemplate: &models.DeploymentCreateRequest{
+			Resources: &models.DeploymentCreateResources{
+				Elasticsearch: []*models.ElasticsearchPayload{{
+					Plan: &models.ElasticsearchClusterPlan{
+						ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+							{ID: "hot_content", InstanceConfigurationID: "ece.data.highstorage"},
+							{ID: "ml", InstanceConfigurationID: "ece.ml"},
+						},
+					},
+				}},
+				Kibana: []*models.KibanaPayload{{
+					Plan: &models.KibanaClusterPlan{
+						ClusterTopology: []*models.KibanaClusterTopologyElement{
+							{InstanceConfigurationID: "ece.kibana"},
+						},
+					},
+				}},
+			},
+		},
+		InstanceConfigurations: []*models.InstanceConfigurationInfo{
+			{ID: "ece.data.highstorage", DiscreteSizes: &models.DiscreteSizes{Resource: ec.String("storage")}},
+			{ID: "ece.ml", DiscreteSizes: &models.DiscreteSizes{Resource: ec.String("memory")}},
+			{ID: "ece.kibana", DiscreteSizes: &models.DiscreteSizes{Resource: ec.String("memory")}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		resources map[string][]interface{}
+		err       error
+	}{
+		{
+			name: "succeeds when the size resources match the instance configurations",
+			resources: map[string][]interface{}{
+				"elasticsearch": {map[string]interface{}{
+					"topology": []interface{}{
+						map[string]interface{}{"id": "hot_content", "size": "120g", "size_resource": "storage"},
+						map[string]interface{}{"id": "ml", "size": "1g", "size_resource": "memory"},
+					},
+				}},
+			},
+		},
+		{
+			name: "fails when the size resources don't match the instance configurations",
+			resources: map[string][]interface{}{
+				"elasticsearch": {map[string]interface{}{
+					"hot_content": []interface{}{map[string]interface{}{"size": "120g", "size_resource": "storage"}},
+					"ml":          []interface{}{map[string]interface{}{"size": "30g", "size_resource": "storage"}},
+				}},
+				"kibana": {map[string]interface{}{
+					"topology": []interface{}{
+						map[string]interface{}{"size": "30g", "size_resource": "storage"},
+					},
+				}},
+			},
+			err: multierror.NewPrefixed("invalid size_resource",
+				errors.New(`elasticsearch topology "ml": size_resource "storage" doesn't match the "ece.ml" instance configuration size resource "memory"`),
+				errors.New(`kibana topology [0]: size_resource "storage" doesn't match the "ece.kibana" instance configuration size resource "memory"`),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSizeResources(tt.resources, template)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/snaprepoapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+const snapshotRepositoryKey = "elasticsearch.0.snapshot_repository"
+
+// validateSnapshotRepositoryDiff fails the plan when the referenced platform
+// snapshot repository doesn't exist in the region the deployment targets.
+func validateSnapshotRepositoryDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange(snapshotRepositoryKey) || !d.NewValueKnown(snapshotRepositoryKey) {
+		return nil
+	}
+
+	name, ok := d.Get(snapshotRepositoryKey).(string)
+	if !ok || name == "" {
+		return nil
+	}
+
+	m, ok := meta.(*util.ProviderMeta)
+	if !ok {
+		return nil
+	}
+	client := m.API
+
+	if _, err := snaprepoapi.Get(snaprepoapi.GetParams{
+		API:    client,
+		Region: d.Get("region").(string),
+		Name:   name,
+	}); err != nil {
+		return fmt.Errorf(
+			`elasticsearch snapshot_repository: failed obtaining the "%s" snapshot repository: %w`,
+			name, err,
+		)
+	}
+
+	return nil
+}
+
+// expandSnapshotRepository sets the reference to a platform snapshot
+// repository in the Elasticsearch cluster settings.
+func expandSnapshotRepository(name string, settings *models.ElasticsearchClusterSettings) {
+	settings.Snapshot = &models.ClusterSnapshotSettings{
+		Repository: &models.ClusterSnapshotRepositoryInfo{
+			Reference: &models.ClusterSnapshotRepositoryReference{
+				RepositoryName: name,
+			},
+		},
+	}
+}
+
+// flattenSnapshotRepository returns the name of the referenced platform
+// snapshot repository, if any.
+func flattenSnapshotRepository(in *models.ClusterSnapshotSettings) string {
+	if in == nil || in.Repository == nil || in.Repository.Reference == nil {
+		return ""
+	}
+	return in.Repository.Reference.RepositoryName
+}
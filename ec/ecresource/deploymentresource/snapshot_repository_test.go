@@ -0,0 +1,76 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandSnapshotRepository(t *testing.T) {
+	settings := &models.ElasticsearchClusterSettings{}
+	expandSnapshotRepository("my-repo", settings)
+
+	assert.Equal(t, &models.ElasticsearchClusterSettings{
+		Snapshot: &models.ClusterSnapshotSettings{
+			Repository: &models.ClusterSnapshotRepositoryInfo{
+				Reference: &models.ClusterSnapshotRepositoryReference{
+					RepositoryName: "my-repo",
+				},
+			},
+		},
+	}, settings)
+}
+
+func Test_flattenSnapshotRepository(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *models.ClusterSnapshotSettings
+		want string
+	}{
+		{
+			name: "returns empty when there are no snapshot settings",
+		},
+		{
+			name: "returns empty when the repository isn't a reference",
+			in: &models.ClusterSnapshotSettings{
+				Repository: &models.ClusterSnapshotRepositoryInfo{
+					Default: map[string]interface{}{},
+				},
+			},
+		},
+		{
+			name: "returns the referenced repository name",
+			in: &models.ClusterSnapshotSettings{
+				Repository: &models.ClusterSnapshotRepositoryInfo{
+					Reference: &models.ClusterSnapshotRepositoryReference{
+						RepositoryName: "my-repo",
+					},
+				},
+			},
+			want: "my-repo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, flattenSnapshotRepository(tt.in))
+		})
+	}
+}
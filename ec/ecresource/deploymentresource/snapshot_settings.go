@@ -0,0 +1,114 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"regexp"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var snapshotIntervalRegexp = regexp.MustCompile(`^[1-9][0-9]*(d|h|min)$`)
+
+func newSnapshotSettingsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Optional snapshot settings for the Elasticsearch cluster, which control how often snapshots are taken and how many are kept.",
+		Optional:    true,
+		Computed:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enabled": {
+					Description: "Whether the scheduled snapshots are enabled. Defaults to `true`.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+				"interval": {
+					Description:  "Optional interval between snapshots, expressed in days (`d`), hours (`h`) or minutes (`min`), e.g. `30min`. Defaults to the interval set by the platform.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.StringMatch(snapshotIntervalRegexp, "must be a positive number followed by one of the d, h or min units"),
+				},
+				"retention_count": {
+					Description:  "Optional number of snapshots to keep. Defaults to the retention set by the platform.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// expandSnapshotSettings sets the snapshot cadence and retention in the
+// Elasticsearch cluster settings, keeping any snapshot repository reference.
+func expandSnapshotSettings(raw []interface{}, settings *models.ElasticsearchClusterSettings) {
+	for _, rawSnapshot := range raw {
+		snapshot, ok := rawSnapshot.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if settings.Snapshot == nil {
+			settings.Snapshot = &models.ClusterSnapshotSettings{}
+		}
+
+		if enabled, ok := snapshot["enabled"].(bool); ok {
+			settings.Snapshot.Enabled = ec.Bool(enabled)
+		}
+
+		if interval, ok := snapshot["interval"].(string); ok && interval != "" {
+			settings.Snapshot.Interval = interval
+		}
+
+		if count, ok := snapshot["retention_count"].(int); ok && count > 0 {
+			settings.Snapshot.Retention = &models.ClusterSnapshotRetention{
+				Snapshots: int32(count),
+			}
+		}
+	}
+}
+
+// flattenSnapshotSettings returns the snapshot cadence and retention of the
+// Elasticsearch cluster, or nil when no snapshot settings are returned.
+func flattenSnapshotSettings(in *models.ClusterSnapshotSettings) []interface{} {
+	if in == nil || (in.Enabled == nil && in.Interval == "" && in.Retention == nil) {
+		return nil
+	}
+
+	m := map[string]interface{}{
+		"enabled": in.Enabled == nil || *in.Enabled,
+	}
+
+	if in.Interval != "" {
+		m["interval"] = in.Interval
+	}
+
+	if in.Retention != nil && in.Retention.Snapshots > 0 {
+		m["retention_count"] = int(in.Retention.Snapshots)
+	}
+
+	return []interface{}{m}
+}
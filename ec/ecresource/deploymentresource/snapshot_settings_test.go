@@ -0,0 +1,141 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandSnapshotSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      []interface{}
+		settings *models.ElasticsearchClusterSettings
+		want     *models.ElasticsearchClusterSettings
+	}{
+		{
+			name:     "leaves the settings untouched without a snapshot block",
+			settings: &models.ElasticsearchClusterSettings{},
+			want:     &models.ElasticsearchClusterSettings{},
+		},
+		{
+			name: "sets the interval and retention",
+			raw: []interface{}{map[string]interface{}{
+				"enabled":         true,
+				"interval":        "30min",
+				"retention_count": 50,
+			}},
+			settings: &models.ElasticsearchClusterSettings{},
+			want: &models.ElasticsearchClusterSettings{
+				Snapshot: &models.ClusterSnapshotSettings{
+					Enabled:  ec.Bool(true),
+					Interval: "30min",
+					Retention: &models.ClusterSnapshotRetention{
+						Snapshots: 50,
+					},
+				},
+			},
+		},
+		{
+			name: "keeps the snapshot repository reference",
+			raw: []interface{}{map[string]interface{}{
+				"enabled": false,
+			}},
+			settings: &models.ElasticsearchClusterSettings{
+				Snapshot: &models.ClusterSnapshotSettings{
+					Repository: &models.ClusterSnapshotRepositoryInfo{
+						Reference: &models.ClusterSnapshotRepositoryReference{
+							RepositoryName: "my-repo",
+						},
+					},
+				},
+			},
+			want: &models.ElasticsearchClusterSettings{
+				Snapshot: &models.ClusterSnapshotSettings{
+					Enabled: ec.Bool(false),
+					Repository: &models.ClusterSnapshotRepositoryInfo{
+						Reference: &models.ClusterSnapshotRepositoryReference{
+							RepositoryName: "my-repo",
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expandSnapshotSettings(tt.raw, tt.settings)
+			assert.Equal(t, tt.want, tt.settings)
+		})
+	}
+}
+
+func Test_flattenSnapshotSettings(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *models.ClusterSnapshotSettings
+		want []interface{}
+	}{
+		{
+			name: "returns nil when there are no snapshot settings",
+		},
+		{
+			name: "returns nil when only the repository is set",
+			in: &models.ClusterSnapshotSettings{
+				Repository: &models.ClusterSnapshotRepositoryInfo{
+					Reference: &models.ClusterSnapshotRepositoryReference{
+						RepositoryName: "my-repo",
+					},
+				},
+			},
+		},
+		{
+			name: "flattens the interval and retention",
+			in: &models.ClusterSnapshotSettings{
+				Enabled:  ec.Bool(true),
+				Interval: "4h",
+				Retention: &models.ClusterSnapshotRetention{
+					Snapshots: 100,
+				},
+			},
+			want: []interface{}{map[string]interface{}{
+				"enabled":         true,
+				"interval":        "4h",
+				"retention_count": 100,
+			}},
+		},
+		{
+			name: "flattens disabled snapshots",
+			in: &models.ClusterSnapshotSettings{
+				Enabled: ec.Bool(false),
+			},
+			want: []interface{}{map[string]interface{}{
+				"enabled": false,
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, flattenSnapshotSettings(tt.in))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+
+	semver "github.com/blang/semver/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// stackFeatureVersions are the minimum stack versions supporting each of the
+// "stack_features".
+var stackFeatureVersions = map[string]semver.Version{
+	"supports_node_roles":          dataTiersVersion,
+	"supports_autoscaling":         semver.MustParse("7.11.0"),
+	"supports_integrations_server": semver.MustParse("8.0.0"),
+}
+
+// flattenStackFeatures returns the features supported by the stack version.
+// Versions which can't be parsed return nil.
+func flattenStackFeatures(version string) map[string]interface{} {
+	v, err := semver.Parse(version)
+	if err != nil {
+		return nil
+	}
+
+	features := make(map[string]interface{}, len(stackFeatureVersions))
+	for name, min := range stackFeatureVersions {
+		features[name] = v.GE(min)
+	}
+	return features
+}
+
+// stackFeaturesDiff sets the stack_features of the planned version, so they
+// are known at plan time when the version changes.
+func stackFeaturesDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.HasChange("version") {
+		return nil
+	}
+
+	if !d.NewValueKnown("version") {
+		return d.SetNewComputed("stack_features")
+	}
+
+	features := flattenStackFeatures(d.Get("version").(string))
+	if features == nil {
+		return d.SetNewComputed("stack_features")
+	}
+	return d.SetNew("stack_features", features)
+}
@@ -0,0 +1,70 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flattenStackFeatures(t *testing.T) {
+	tests := []struct {
+		version string
+		want    map[string]interface{}
+	}{
+		{
+			version: "7.9.2",
+			want: map[string]interface{}{
+				"supports_node_roles":          false,
+				"supports_autoscaling":         false,
+				"supports_integrations_server": false,
+			},
+		},
+		{
+			version: "7.10.0",
+			want: map[string]interface{}{
+				"supports_node_roles":          true,
+				"supports_autoscaling":         false,
+				"supports_integrations_server": false,
+			},
+		},
+		{
+			version: "7.17.3",
+			want: map[string]interface{}{
+				"supports_node_roles":          true,
+				"supports_autoscaling":         true,
+				"supports_integrations_server": false,
+			},
+		},
+		{
+			version: "8.0.0",
+			want: map[string]interface{}{
+				"supports_node_roles":          true,
+				"supports_autoscaling":         true,
+				"supports_integrations_server": true,
+			},
+		},
+		{version: "invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, flattenStackFeatures(tt.version))
+		})
+	}
+}
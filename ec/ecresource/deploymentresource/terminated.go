@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// restoreTerminatedDiff plans the restore of a terminated deployment when
+// "restore_if_terminated" is set, rather than leaving the deployment to be
+// re-created with a different ID.
+func restoreTerminatedDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.Get("terminated").(bool) {
+		return nil
+	}
+
+	if !d.Get("restore_if_terminated").(bool) {
+		return nil
+	}
+
+	return d.SetNew("terminated", false)
+}
+
+// restoreTerminated restores a terminated deployment, including the data of
+// its Elasticsearch resources from their latest snapshot, and waits until
+// the deployment resources are running again.
+func restoreTerminated(ctx context.Context, d *schema.ResourceData, client *api.API) error {
+	if !d.HasChange("terminated") || d.Get("terminated").(bool) {
+		return nil
+	}
+
+	if _, err := deploymentapi.Restore(deploymentapi.RestoreParams{
+		API:             client,
+		DeploymentID:    d.Id(),
+		RestoreSnapshot: true,
+	}); err != nil {
+		return multierror.NewPrefixed("failed restoring the terminated deployment", err)
+	}
+
+	if err := waitForPlanCompletion(ctx, client, d.Id(), planTimeouts(d)); err != nil {
+		return multierror.NewPrefixed("failed tracking restore progress", err)
+	}
+
+	return nil
+}
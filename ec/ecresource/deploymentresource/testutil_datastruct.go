@@ -76,14 +76,21 @@ func newSampleLegacyDeployment() map[string]interface{} {
 		"alias":                  "my-deployment",
 		"name":                   "my_deployment_name",
 		"deployment_template_id": "aws-io-optimized-v2",
+		"resolved_template_id":   "aws-io-optimized-v2",
 		"region":                 "us-east-1",
 		"version":                "7.7.0",
-		"elasticsearch":          []interface{}{newElasticsearchSample()},
-		"kibana":                 []interface{}{newKibanaSample()},
-		"apm":                    []interface{}{newApmSample()},
-		"enterprise_search":      []interface{}{newEnterpriseSearchSample()},
-		"observability":          []interface{}{newObservabilitySample()},
-		"traffic_filter":         []interface{}{"0.0.0.0/0", "192.168.10.0/24"},
+		"resolved_version":       "7.7.0",
+		"stack_features": map[string]interface{}{
+			"supports_node_roles":          false,
+			"supports_autoscaling":         false,
+			"supports_integrations_server": false,
+		},
+		"elasticsearch":     []interface{}{newElasticsearchSample()},
+		"kibana":            []interface{}{newKibanaSample()},
+		"apm":               []interface{}{newApmSample()},
+		"enterprise_search": []interface{}{newEnterpriseSearchSample()},
+		"observability":     []interface{}{newObservabilitySample()},
+		"traffic_filter":    []interface{}{"0.0.0.0/0", "192.168.10.0/24"},
 	}
 }
 
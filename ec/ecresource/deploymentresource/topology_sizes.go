@@ -0,0 +1,126 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// validateTopologySizeDiff fails the plan when any topology size is smaller
+// than the minimum size of its instance configuration, or doesn't match any
+// of the size steps up to the largest one. The integrations_server sizes are
+// validated by validateIntegrationsServerSizeDiff. The template is only
+// obtained when any of the resource kinds with a sized topology has changed.
+func validateTopologySizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, k := range []string{"deployment_template_id", "region"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	var resources = make(map[string][]interface{})
+	for _, kind := range sizedResourceKinds {
+		if kind == "integrations_server" || !d.HasChange(kind) || !d.NewValueKnown(kind) {
+			continue
+		}
+
+		raw, _ := d.Get(kind).([]interface{})
+		for _, topology := range rawTopologies(kind, raw) {
+			if size, _ := topology["size"].(string); size != "" {
+				resources[kind] = raw
+				break
+			}
+		}
+	}
+
+	if len(resources) == 0 {
+		return nil
+	}
+
+	template, err := getDiffTemplate(ctx, meta.(*util.ProviderMeta).API,
+		d.Get("deployment_template_id").(string), d.Get("region").(string),
+	)
+	if err != nil {
+		return multierror.NewPrefixed(
+			"failed obtaining the deployment template instance configurations", err,
+		)
+	}
+
+	return validateTopologySizes(resources, template)
+}
+
+// validateTopologySizes validates the topology sizes against the discrete
+// sizes of their instance configurations, returning an error which lists the
+// allowed sizes otherwise. Sizes larger than the largest discrete size are
+// left for the API to validate, since they can be spread across zones.
+func validateTopologySizes(resources map[string][]interface{}, template *models.DeploymentTemplateInfoV2) error {
+	merr := multierror.NewPrefixed("invalid topology size")
+	for _, kind := range sizedResourceKinds {
+		raw, ok := resources[kind]
+		if !ok {
+			continue
+		}
+
+		tplICs := templateInstanceConfigurations(kind, template)
+		for i, topology := range rawTopologies(kind, raw) {
+			size, err := util.ParseTopologySize(topology)
+			// Invalid sizes are reported when the resources are expanded.
+			if err != nil || size == nil || size.Value == nil {
+				continue
+			}
+
+			// A zero size disables the topology element, it's always allowed.
+			if *size.Value == 0 {
+				continue
+			}
+
+			icID := topologyInstanceConfiguration(topology, i, tplICs)
+			sizes := sortedSizes(discreteSizes(template.InstanceConfigurations, icID, *size.Resource))
+			if len(sizes) == 0 || containsSize(sizes, *size.Value) || *size.Value > sizes[len(sizes)-1] {
+				continue
+			}
+
+			reason := "doesn't match any of the sizes"
+			if *size.Value < sizes[0] {
+				reason = "is smaller than the minimum size"
+			}
+
+			merr = merr.Append(fmt.Errorf(
+				`%s topology %s: size "%s" %s of the "%s" instance configuration, allowed sizes: %s`,
+				kind, topologyName(topology, i), util.MemoryToState(*size.Value),
+				reason, icID, formatSizes(sizes),
+			))
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
+func sortedSizes(sizes []int32) []int32 {
+	var result = append([]int32(nil), sizes...)
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
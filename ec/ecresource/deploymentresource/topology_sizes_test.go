@@ -0,0 +1,113 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateTopologySizes(t *testing.T) {
+	template := &models.DeploymentTemplateInfoV2{
+		DeploymentTemplate: &models.DeploymentCreateRequest{
+			Resources: &models.DeploymentCreateResources{
+				Elasticsearch: []*models.ElasticsearchPayload{{
+					Plan: &models.ElasticsearchClusterPlan{
+						ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+							{ID: "hot_content", InstanceConfigurationID: "aws.data.highio.i3"},
+							{ID: "ml", InstanceConfigurationID: "aws.ml.m5d"},
+						},
+					},
+				}},
+				Kibana: []*models.KibanaPayload{{
+					Plan: &models.KibanaClusterPlan{
+						ClusterTopology: []*models.KibanaClusterTopologyElement{
+							{InstanceConfigurationID: "aws.kibana.r5d"},
+						},
+					},
+				}},
+			},
+		},
+		InstanceConfigurations: []*models.InstanceConfigurationInfo{
+			{ID: "aws.data.highio.i3", DiscreteSizes: &models.DiscreteSizes{
+				Resource: ec.String("memory"), Sizes: []int32{4096, 1024, 2048, 8192},
+			}},
+			{ID: "aws.ml.m5d", DiscreteSizes: &models.DiscreteSizes{
+				Resource: ec.String("memory"), Sizes: []int32{1024, 2048, 4096},
+			}},
+			{ID: "aws.kibana.r5d", DiscreteSizes: &models.DiscreteSizes{
+				Resource: ec.String("memory"), Sizes: []int32{1024, 2048, 4096, 8192},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		resources map[string][]interface{}
+		err       error
+	}{
+		{
+			name: "succeeds when the sizes are allowed",
+			resources: map[string][]interface{}{
+				"elasticsearch": {map[string]interface{}{
+					"topology": []interface{}{
+						// Sizes larger than the largest discrete size are
+						// spread across zones.
+						map[string]interface{}{"id": "hot_content", "size": "16g"},
+						map[string]interface{}{"id": "ml", "size": "0g"},
+					},
+				}},
+				"kibana": {map[string]interface{}{
+					"topology": []interface{}{map[string]interface{}{"size": "1g"}},
+				}},
+			},
+		},
+		{
+			name: "fails when the sizes are below the minimum or between steps",
+			resources: map[string][]interface{}{
+				"elasticsearch": {map[string]interface{}{
+					"hot_content": []interface{}{map[string]interface{}{"size": "0.5g"}},
+					"ml":          []interface{}{map[string]interface{}{"size": "3g"}},
+				}},
+				"kibana": {map[string]interface{}{
+					"topology": []interface{}{map[string]interface{}{"size": "0.5g"}},
+				}},
+			},
+			err: multierror.NewPrefixed("invalid topology size",
+				errors.New(`elasticsearch topology "hot_content": size "0.5g" is smaller than the minimum size of the "aws.data.highio.i3" instance configuration, allowed sizes: 1g, 2g, 4g, 8g`),
+				errors.New(`elasticsearch topology "ml": size "3g" doesn't match any of the sizes of the "aws.ml.m5d" instance configuration, allowed sizes: 1g, 2g, 4g`),
+				errors.New(`kibana topology [0]: size "0.5g" is smaller than the minimum size of the "aws.kibana.r5d" instance configuration, allowed sizes: 1g, 2g, 4g, 8g`),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTopologySizes(tt.resources, template)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// checkUniqueNameDiff fails the plan when "enforce_unique_name" is set and a
+// deployment with the same name already exists. Only deployments which are
+// yet to be created are checked.
+func checkUniqueNameDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" || !d.Get("enforce_unique_name").(bool) {
+		return nil
+	}
+
+	// When the name is interpolated from another resource, it won't be known
+	// until apply time, so the check can't be performed.
+	if !d.NewValueKnown("name") {
+		return nil
+	}
+
+	name := d.Get("name").(string)
+	if name == "" {
+		return nil
+	}
+
+	return checkUniqueName(meta.(*util.ProviderMeta).API, name)
+}
+
+// checkUniqueName searches for deployments with the exact same name, returning
+// an error when any are found.
+func checkUniqueName(client *api.API, name string) error {
+	res, err := deploymentapi.Search(deploymentapi.SearchParams{
+		API:     client,
+		Request: newNameSearchRequest(name),
+	})
+	if err != nil {
+		return multierror.NewPrefixed("failed checking deployment name uniqueness", err)
+	}
+
+	if len(res.Deployments) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(res.Deployments))
+	for _, dep := range res.Deployments {
+		if dep.ID != nil {
+			ids = append(ids, *dep.ID)
+		}
+	}
+
+	return fmt.Errorf(
+		`deployment name "%s" is already in use by: %s. Choose a different name or unset "enforce_unique_name"`,
+		name, strings.Join(ids, ", "),
+	)
+}
+
+func newNameSearchRequest(name string) *models.SearchRequest {
+	return &models.SearchRequest{
+		Size: 10,
+		Sort: []interface{}{"id"},
+		Query: &models.QueryContainer{
+			Term: map[string]models.TermQuery{
+				// The "keyword" field ensures the name isn't analyzed, so
+				// only exact matches are returned.
+				"name.keyword": {Value: &name},
+			},
+		},
+	}
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checkUniqueName(t *testing.T) {
+	type args struct {
+		client *api.API
+		name   string
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "returns nil when no deployments match the name",
+			args: args{
+				name: "my-deployment",
+				client: api.NewMock(mock.New200StructResponse(models.DeploymentsSearchResponse{
+					ReturnCount: ec.Int32(0),
+					Deployments: []*models.DeploymentSearchResponse{},
+				})),
+			},
+		},
+		{
+			name: "returns an error when a deployment with the same name exists",
+			args: args{
+				name: "my-deployment",
+				client: api.NewMock(mock.New200StructResponse(models.DeploymentsSearchResponse{
+					ReturnCount: ec.Int32(1),
+					Deployments: []*models.DeploymentSearchResponse{
+						{ID: ec.String(mock.ValidClusterID), Name: ec.String("my-deployment")},
+					},
+				})),
+			},
+			err: errors.New(`deployment name "my-deployment" is already in use by: ` + mock.ValidClusterID + `. Choose a different name or unset "enforce_unique_name"`),
+		},
+		{
+			name: "returns an error when the search fails",
+			args: args{
+				name: "my-deployment",
+				client: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+					Code: "some", Message: "message",
+				})),
+			},
+			err: errors.New("failed checking deployment name uniqueness: 1 error occurred:\n\t* api error: some: message\n\n"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkUniqueName(tt.args.client, tt.args.name)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -19,41 +19,94 @@ package deploymentresource
 
 import (
 	"context"
+	"errors"
 	"strings"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
 )
 
 // Update syncs the remote state with the local.
 func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api.API)
+	m := meta.(*util.ProviderMeta)
+	client := m.API
+
+	if err := restoreTerminated(ctx, d, client); err != nil {
+		return diag.FromErr(err)
+	}
 
+	var diags diag.Diagnostics
 	if hasDeploymentChange(d) {
-		if err := updateDeployment(ctx, d, client); err != nil {
+		if err := updateOIDCClientSecrets(client, d, false); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := updateDeployment(ctx, d, m); err != nil {
+			return errorDiagnostics(err)
+		}
+		diags = dataMigrationsWarning(d)
+
+		if err := updateOIDCClientSecrets(client, d, true); err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
+	// The planned data migrations only apply to the current plan.
+	if err := d.Set("expected_data_migrations", nil); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := handleTrafficFilterChange(d, client); err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := handleRestartTrigger(ctx, d, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := handleSecretTokenRotation(d, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := handleMaintenanceMode(d, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := handleKeystoreContents(d, client); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := handleRemoteClusters(d, client); err != nil {
 		return diag.FromErr(err)
 	}
 
-	return readResource(ctx, d, meta)
+	if err := resetPasswordOnImport(d, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags = append(diags, deprecatedInstanceConfigurationsWarning(d)...)
+	return append(diags, readResource(ctx, d, meta)...)
 }
 
-func updateDeployment(_ context.Context, d *schema.ResourceData, client *api.API) error {
-	req, err := updateResourceToModel(d, client)
+func updateDeployment(ctx context.Context, d *schema.ResourceData, meta *util.ProviderMeta) error {
+	client := meta.API
+	req, err := updateResourceToModel(ctx, d, meta)
 	if err != nil {
 		return err
 	}
+	partialUpdate(d, req)
+
+	var rollback *models.DeploymentUpdateRequest
+	if d.Get("rollback_on_failure").(bool) {
+		if rollback, err = getRollbackRequest(client, d.Id()); err != nil {
+			return multierror.NewPrefixed("failed obtaining the last successful plan", err)
+		}
+	}
 
 	res, err := deploymentapi.Update(deploymentapi.UpdateParams{
 		API:          client,
@@ -65,21 +118,104 @@ func updateDeployment(_ context.Context, d *schema.ResourceData, client *api.API
 		},
 	})
 	if err != nil {
-		return multierror.NewPrefixed("failed updating deployment", err)
+		var es []*models.ElasticsearchPayload
+		if req.Resources != nil {
+			es = req.Resources.Elasticsearch
+		}
+		return newPayloadError(d, "failed updating deployment", err, es)
 	}
 
-	if err := WaitForPlanCompletion(client, d.Id()); err != nil {
-		return multierror.NewPrefixed("failed tracking update progress", err)
+	if err := trackUpdate(ctx, d, meta, rollback); err != nil {
+		return err
 	}
 
 	return parseCredentials(d, res.Resources)
 }
 
+// trackUpdate waits for the update plan to finish, re-applying the specified
+// rollback request when it fails.
+func trackUpdate(ctx context.Context, d *schema.ResourceData, meta *util.ProviderMeta, rollback *models.DeploymentUpdateRequest) error {
+	err := waitForPlanCompletion(ctx, meta.API, d.Id(), planTimeouts(d))
+	if err == nil {
+		return nil
+	}
+
+	merr := multierror.NewPrefixed("failed tracking update progress", err)
+	if rollback == nil || !shouldRollback(ctx, err) {
+		return merr
+	}
+
+	rollbackCtx, cancel := context.WithTimeout(ctx, rollbackTimeout)
+	defer cancel()
+
+	merr = merr.Append(rollbackDeployment(rollbackCtx, meta.API, d.Id(), rollback))
+
+	// Once rolled back, the state is refreshed so it reflects the plan
+	// which is running, rather than the one which failed to apply.
+	if diags := readResource(rollbackCtx, d, meta); diags.HasError() {
+		merr = merr.Append(errors.New(diags[0].Summary))
+	}
+	return merr
+}
+
+// shouldRollback returns true when the update plan has failed. The update
+// isn't rolled back when the operation is interrupted or times out, since the
+// plan may still be running and the rollback would be applied on top of it.
+func shouldRollback(ctx context.Context, err error) bool {
+	var planErr *planFailedError
+	return ctx.Err() == nil && errors.As(err, &planErr)
+}
+
+// localAttributes are only used by the provider and never sent to the API,
+// changes to them, or to any of their nested attributes, such as the
+// "expected_data_migrations.#" count, don't require the deployment to be
+// updated.
+var localAttributes = []string{
+	"enforce_unique_name",
+	"verify_docker_images",
+	"docker_registry_auth",
+	"rollback_on_failure",
+	"expose_credentials",
+	"store_elasticsearch_password",
+	"reset_elasticsearch_password_on_import",
+	"restore_if_terminated",
+	"skip_destroy_if_terminated",
+	"destroy_mode",
+	"terminated",
+	"expected_data_migrations",
+	"deprecated_instance_configurations",
+	"prune_orphans",
+	"update_strategy",
+	"observability_adopted",
+	"restart_trigger",
+	"generate_ref_ids",
+	"stack_features",
+}
+
+// directUpdatePrefixes are the attribute prefixes whose changes are applied
+// without a deployment plan, either through dedicated APIs, such as the
+// traffic filter associations, or by the provider itself.
+var directUpdatePrefixes = []string{
+	"traffic_filter",
+	"generated_ref_ids.",
+	"plan_timeouts",
+	rotateSecretTokenKey,
+	keystoreContentsKey,
+}
+
 // hasDeploymentChange checks if there's any change in the resource attributes
-// except in the "traffic_filter" prefixed keys. If so, it returns true.
+// except in the ones which are updated without a deployment plan, such as the
+// "traffic_filter" and "maintenance_mode" ones, and the local attributes. If
+// so, it returns true.
 func hasDeploymentChange(d *schema.ResourceData) bool {
 	for attr := range d.State().Attributes {
-		if strings.HasPrefix(attr, "traffic_filter") {
+		if hasDirectUpdatePrefix(attr) {
+			continue
+		}
+		if isLocalAttribute(attr) {
+			continue
+		}
+		if isMaintenanceModeAttribute(attr) {
 			continue
 		}
 		// Check if any of the resource attributes has a change.
@@ -89,3 +225,21 @@ func hasDeploymentChange(d *schema.ResourceData) bool {
 	}
 	return false
 }
+
+func isLocalAttribute(attr string) bool {
+	for _, name := range localAttributes {
+		if attr == name || strings.HasPrefix(attr, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDirectUpdatePrefix(attr string) bool {
+	for _, prefix := range directUpdatePrefixes {
+		if strings.HasPrefix(attr, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -18,9 +18,15 @@
 package deploymentresource
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 
@@ -57,6 +63,38 @@ func Test_hasDeploymentChange(t *testing.T) {
 		},
 	})
 
+	changesToEnforceUniqueName := util.NewResourceData(t, util.ResDataParams{
+		ID:     mock.ValidClusterID,
+		Schema: newSchema(),
+		State: map[string]interface{}{
+			"enforce_unique_name": true,
+		},
+	})
+
+	changesToComputedLists := util.NewResourceData(t, util.ResDataParams{
+		ID:     mock.ValidClusterID,
+		Schema: newSchema(),
+		State: map[string]interface{}{
+			"expected_data_migrations": []interface{}{
+				`elasticsearch topology "hot_content": size changes from "4g" to "8g"`,
+			},
+			"deprecated_instance_configurations": []interface{}{
+				`kibana topology [0]: instance configuration "aws.kibana.r5d" is deprecated`,
+			},
+		},
+	})
+
+	changesToPlanTimeouts := util.NewResourceData(t, util.ResDataParams{
+		ID:     mock.ValidClusterID,
+		Schema: newSchema(),
+		State: map[string]interface{}{
+			"traffic_filter": []interface{}{"1.1.1.1"},
+			"plan_timeouts": []interface{}{map[string]interface{}{
+				"elasticsearch": "2h",
+			}},
+		},
+	})
+
 	type args struct {
 		d *schema.ResourceData
 	}
@@ -75,6 +113,21 @@ func Test_hasDeploymentChange(t *testing.T) {
 			args: args{d: changesToTrafficFilter},
 			want: false,
 		},
+		{
+			name: "when a new resource has some changes in traffic_filter and plan_timeouts",
+			args: args{d: changesToPlanTimeouts},
+			want: false,
+		},
+		{
+			name: "when a new resource has some changes in enforce_unique_name",
+			args: args{d: changesToEnforceUniqueName},
+			want: false,
+		},
+		{
+			name: "when a new resource has some changes in the computed local lists",
+			args: args{d: changesToComputedLists},
+			want: false,
+		},
 		{
 			name: "when a new resource is has some changes in name",
 			args: args{d: changesToName},
@@ -93,3 +146,88 @@ func Test_hasDeploymentChange(t *testing.T) {
 		})
 	}
 }
+
+func Test_trackUpdate(t *testing.T) {
+	d := util.NewResourceData(t, util.ResDataParams{
+		ID:     mock.ValidClusterID,
+		Schema: newSchema(),
+		State: map[string]interface{}{
+			"rollback_on_failure": true,
+		},
+	})
+
+	expiredCtx, cancelExpired := context.WithDeadline(context.Background(), time.Now())
+	defer cancelExpired()
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "doesn't roll back the update when it times out",
+			ctx:  expiredCtx,
+			want: "stopped waiting for the deployment plan to finish",
+		},
+		{
+			name: "doesn't roll back the update when it's interrupted",
+			ctx:  canceledCtx,
+			want: "interrupted while waiting for the deployment plan to finish",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The mock has no responses, so sending the rollback request
+			// would fail the rollback.
+			err := trackUpdate(tt.ctx, d, util.NewMockMeta(), &models.DeploymentUpdateRequest{
+				PruneOrphans: ec.Bool(true),
+			})
+			assert.Contains(t, err.Error(), tt.want)
+			assert.NotContains(t, err.Error(), "failed rolling back to the last successful plan")
+		})
+	}
+}
+
+func Test_shouldRollback(t *testing.T) {
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	failed := &planFailedError{err: errors.New("plan failed")}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{
+			name: "rolls back the failed plans",
+			ctx:  context.Background(),
+			err:  failed,
+			want: true,
+		},
+		{
+			name: "rolls back the wrapped failed plans",
+			ctx:  context.Background(),
+			err:  fmt.Errorf("tracking: %w", failed),
+			want: true,
+		},
+		{
+			name: "doesn't roll back the plans which may still be running",
+			ctx:  context.Background(),
+			err:  errors.New(`stopped waiting for the kibana plan to finish after 1m0s`),
+		},
+		{
+			name: "doesn't roll back once the context is done",
+			ctx:  canceledCtx,
+			err:  failed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, shouldRollback(tt.ctx, tt.err))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// validateUserSettingsJSON fails the plan when the JSON user settings aren't
+// a valid JSON object, rather than sending the malformed settings to the API.
+func validateUserSettingsJSON(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if value == "" {
+		return nil, nil
+	}
+
+	var settings map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &settings); err != nil {
+		return nil, []error{fmt.Errorf("%s must be a valid JSON object: %w", k, err)}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,57 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateUserSettingsJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		err   string
+	}{
+		{name: "accepts an empty value"},
+		{name: "accepts a JSON object", value: `{"action.auto_create_index": true}`},
+		{
+			name:  "rejects malformed JSON",
+			value: `{"action.auto_create_index": true`,
+			err:   "user_settings_json must be a valid JSON object: unexpected end of JSON input",
+		},
+		{
+			name:  "rejects a JSON array",
+			value: `["action.auto_create_index"]`,
+			err:   "user_settings_json must be a valid JSON object: json: cannot unmarshal array into Go value of type map[string]interface {}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := validateUserSettingsJSON(tt.value, "user_settings_json")
+			if tt.err == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			if assert.Len(t, errs, 1) {
+				assert.EqualError(t, errs[0], tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"fmt"
+)
+
+// maxUserSettingsSize is the maximum size in bytes of each of the user
+// settings fields accepted by the API.
+const maxUserSettingsSize = 64 * 1024
+
+// validateUserSettingsSize fails the plan when the user settings exceed the
+// size accepted by the API, which otherwise rejects them with an error that
+// doesn't mention the limit.
+func validateUserSettingsSize(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if size := len(value); size > maxUserSettingsSize {
+		return nil, []error{fmt.Errorf(
+			"%s is %d bytes, which exceeds the maximum user settings size of %d bytes",
+			k, size, maxUserSettingsSize,
+		)}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,53 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateUserSettingsSize(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		err   string
+	}{
+		{name: "accepts an empty value"},
+		{name: "accepts settings up to the limit", value: strings.Repeat("a", maxUserSettingsSize)},
+		{
+			name:  "rejects settings over the limit",
+			value: strings.Repeat("a", maxUserSettingsSize+1),
+			err:   "user_settings_yaml is 65537 bytes, which exceeds the maximum user settings size of 65536 bytes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := validateUserSettingsSize(tt.value, "user_settings_yaml")
+			if tt.err == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			if assert.Len(t, errs, 1) {
+				assert.EqualError(t, errs[0], tt.err)
+			}
+		})
+	}
+}
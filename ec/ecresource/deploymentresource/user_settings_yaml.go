@@ -0,0 +1,46 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// suppressYAMLDiff suppresses the differences between two YAML user settings
+// documents which are semantically equal, such as the ones caused by key
+// order, quoting, indentation or comments. Invalid documents are compared as
+// they are, so that the API reports the error.
+func suppressYAMLDiff(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+
+	var oldValue, newValue interface{}
+	if err := yaml.Unmarshal([]byte(old), &oldValue); err != nil {
+		return false
+	}
+
+	if err := yaml.Unmarshal([]byte(new), &newValue); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(oldValue, newValue)
+}
@@ -0,0 +1,76 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_suppressYAMLDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want bool
+	}{
+		{
+			name: "suppresses identical documents",
+			old:  "action.auto_create_index: true",
+			new:  "action.auto_create_index: true",
+			want: true,
+		},
+		{
+			name: "suppresses key order, quoting and comment changes",
+			old:  "xpack.security.enabled: true\naction.auto_create_index: 'logs-*'\n",
+			new:  "# Index creation\naction.auto_create_index: \"logs-*\"\nxpack.security.enabled: true # enabled\n",
+			want: true,
+		},
+		{
+			name: "suppresses indentation changes",
+			old:  "xpack:\n  security:\n    enabled: true\n",
+			new:  "xpack:\n    security:\n        enabled: true\n",
+			want: true,
+		},
+		{
+			name: "doesn't suppress value changes",
+			old:  "xpack.security.enabled: true",
+			new:  "xpack.security.enabled: false",
+			want: false,
+		},
+		{
+			name: "doesn't suppress type changes",
+			old:  "http.max_content_length: 100",
+			new:  "http.max_content_length: '100'",
+			want: false,
+		},
+		{
+			name: "doesn't suppress invalid documents",
+			old:  "xpack.security.enabled: true",
+			new:  "xpack.security.enabled: [true",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := suppressYAMLDiff("user_settings_yaml", tt.old, tt.new, nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
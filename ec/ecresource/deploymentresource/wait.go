@@ -18,25 +18,320 @@
 package deploymentresource
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/elastic/cloud-sdk-go/pkg/plan"
-	"github.com/elastic/cloud-sdk-go/pkg/plan/planutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	ecutil "github.com/elastic/terraform-provider-ec/ec/internal/util"
 )
 
 const (
 	defaultPollPlanFrequency = 2 * time.Second
 	defaultMaxPlanRetry      = 4
+
+	// planProgressLogFrequency is how often the aggregated plan progress
+	// of the deployment resources is logged while waiting for the plans.
+	planProgressLogFrequency = 30 * time.Second
 )
 
-// WaitForPlanCompletion waits for a pending plan to finish.
-func WaitForPlanCompletion(client *api.API, id string) error {
-	return planutil.Wait(plan.TrackChangeParams{
+// resourceKinds are the deployment resource kinds, Elasticsearch first.
+var resourceKinds = []string{
+	util.Elasticsearch, util.Kibana, util.Apm, util.IntegrationsServer,
+	util.EnterpriseSearch,
+}
+
+// WaitForPlanCompletion waits for a pending plan to finish. It stops waiting
+// once the context is done, which happens when the resource operation exceeds
+// its configured timeout.
+func WaitForPlanCompletion(ctx context.Context, client *api.API, id string) error {
+	return waitForPlanCompletion(ctx, client, id, nil)
+}
+
+// waitForPlanCompletion waits for a pending plan to finish like
+// WaitForPlanCompletion, failing the plans of the resource kinds which take
+// longer than their timeout. When the context is done, such as when the
+// operation is interrupted, the plans stop being polled right away and the
+// last step of each resource is reported.
+func waitForPlanCompletion(ctx context.Context, client *api.API, id string, timeouts map[string]time.Duration) error {
+	progress := newPlanProgress()
+	kinds := deploymentKinds(ecutil.ContextClient(ctx, client), id)
+
+	stop := progress.logEvery(ctx, id, planProgressLogFrequency)
+	err := trackPlans(ctx, client, id, kinds, timeouts, progress)
+	stop()
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf(
+			`stopped waiting for the deployment plan to finish, it may still be running%s: %w. Increase the operation timeout with the "timeouts" block`,
+			progress.describe(), ctx.Err(),
+		)
+	case ctx.Err() != nil:
+		return fmt.Errorf(
+			`interrupted while waiting for the deployment plan to finish, it may still be running%s: %w`,
+			progress.describe(), ctx.Err(),
+		)
+	}
+
+	return err
+}
+
+// deploymentKinds returns the resource kinds which are part of the deployment,
+// falling back to all of the resource kinds when they can't be obtained.
+func deploymentKinds(client *api.API, id string) []string {
+	res, err := deploymentapi.Get(deploymentapi.GetParams{
 		API: client, DeploymentID: id,
+	})
+	if err != nil || res.Resources == nil {
+		return resourceKinds
+	}
+
+	return resourcesKinds(res.Resources)
+}
+
+func resourcesKinds(res *models.DeploymentResources) []string {
+	var kinds []string
+	if len(res.Elasticsearch) > 0 {
+		kinds = append(kinds, util.Elasticsearch)
+	}
+	if len(res.Kibana) > 0 {
+		kinds = append(kinds, util.Kibana)
+	}
+	if len(res.Apm) > 0 {
+		kinds = append(kinds, util.Apm)
+	}
+	if len(res.IntegrationsServer) > 0 {
+		kinds = append(kinds, util.IntegrationsServer)
+	}
+	if len(res.EnterpriseSearch) > 0 {
+		kinds = append(kinds, util.EnterpriseSearch)
+	}
+
+	if len(kinds) == 0 {
+		return resourceKinds
+	}
+	return kinds
+}
+
+// trackPlans tracks the plans of each of the resource kinds concurrently,
+// reporting their progress to a single planProgress, and returning the
+// errors of all the failed plans, and of the ones which took longer than
+// the resource kind timeout.
+func trackPlans(ctx context.Context, client *api.API, id string, kinds []string, timeouts map[string]time.Duration, progress *planProgress) error {
+	var errs = make([]error, len(kinds))
+	var wg sync.WaitGroup
+	for i, kind := range kinds {
+		wg.Add(1)
+		go func(i int, kind string) {
+			defer wg.Done()
+			errs[i] = trackPlan(ctx, client, id, kind, timeouts[kind], progress)
+		}(i, kind)
+	}
+	wg.Wait()
+
+	var merr = multierror.NewPrefixed("found deployment plan errors")
+	if err := merr.Append(errs...).ErrorOrNil(); err != nil {
+		if plansFailed(errs) {
+			return &planFailedError{err: err}
+		}
+		return err
+	}
+	return nil
+}
+
+// planFailedError is returned when all the tracked plans have finished and
+// some of them failed, as opposed to when any of them may still be running,
+// such as when a resource kind plan takes longer than its timeout.
+type planFailedError struct {
+	err error
+}
+
+func (e *planFailedError) Error() string { return e.err.Error() }
+func (e *planFailedError) Unwrap() error { return e.err }
+
+// plansFailed returns true when the tracking errors are only the ones of the
+// plans which finished unsuccessfully.
+func plansFailed(errs []error) bool {
+	var failed bool
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		var planErr *planFailedError
+		if !errors.As(err, &planErr) {
+			return false
+		}
+		failed = true
+	}
+	return failed
+}
+
+func trackPlan(ctx context.Context, client *api.API, id, kind string, timeout time.Duration, progress *planProgress) error {
+	kindCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		kindCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// The plan tracker doesn't take a context, binding its client to it makes
+	// the tracker stop polling the plan and close the channel once it's done.
+	channel, err := plan.TrackChange(plan.TrackChangeParams{
+		API: ecutil.ContextClient(kindCtx, client), DeploymentID: id, Kind: kind,
+		IgnoreDownstream: true,
 		Config: plan.TrackFrequencyConfig{
 			PollFrequency: defaultPollPlanFrequency,
 			MaxRetries:    defaultMaxPlanRetry,
 		},
 	})
+	if err != nil {
+		return multierror.NewPrefixed("plan track change", err)
+	}
+
+	err = streamPlan(kindCtx, channel, progress.report)
+	if err != nil && timeout > 0 && ctx.Err() == nil && kindCtx.Err() != nil {
+		return fmt.Errorf(
+			`stopped waiting for the %s plan to finish after %s, it may still be running. Increase the "%s" timeout in the "plan_timeouts" block`,
+			kind, timeout, kind,
+		)
+	}
+	if err != nil && kindCtx.Err() == nil {
+		return &planFailedError{err: err}
+	}
+	return err
+}
+
+// streamPlan reports the plan changes sent to the channel until it's closed,
+// returning the errors of the failed plans like plan.StreamFunc. It returns
+// as soon as the context is done, draining the channel in the background
+// until the plan tracker closes it, so the tracker goroutine isn't left
+// blocked sending to it.
+func streamPlan(ctx context.Context, channel <-chan plan.TrackResponse, report func(plan.TrackResponse)) error {
+	var merr = multierror.NewPrefixed("found deployment plan errors")
+	for {
+		select {
+		case <-ctx.Done():
+			go func() {
+				for range channel {
+				}
+			}()
+			return ctx.Err()
+		case res, ok := <-channel:
+			if !ok {
+				return merr.ErrorOrNil()
+			}
+
+			report(res)
+			if res.Err != nil && res.Finished && res.Err != plan.ErrPlanFinished {
+				res.Err = apierror.NewJSONError(res.Err)
+				merr = merr.Append(res)
+			}
+		}
+	}
+}
+
+// planProgress aggregates the plan progress of all the deployment resources,
+// keeping the last step of each of them.
+type planProgress struct {
+	mu    sync.Mutex
+	steps map[string]string
+}
+
+func newPlanProgress() *planProgress {
+	return &planProgress{steps: make(map[string]string)}
+}
+
+func (p *planProgress) report(res plan.TrackResponse) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if res.Step != "" {
+		p.steps[res.Kind+"/"+res.RefID] = res.Step
+	}
+}
+
+// describe returns the last reported step of each of the resources, sorted
+// by resource, or an empty string when no steps have been reported.
+func (p *planProgress) describe() string {
+	steps := p.lastSteps()
+	if len(steps) == 0 {
+		return ""
+	}
+
+	var resources = make([]string, 0, len(steps))
+	for resource, step := range steps {
+		resources = append(resources, resource+": "+step)
+	}
+	sort.Strings(resources)
+
+	return " (last steps: " + strings.Join(resources, ", ") + ")"
+}
+
+// lastSteps returns the last reported step of each of the resources.
+func (p *planProgress) lastSteps() map[string]string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var result = make(map[string]string, len(p.steps))
+	for k, v := range p.steps {
+		result[k] = v
+	}
+	return result
+}
+
+// logEvery logs the last step of each of the resources at the given
+// interval until the returned function is called or the context is done.
+// Nothing is logged while no steps have been reported.
+func (p *planProgress) logEvery(ctx context.Context, id string, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				p.log(ctx, id)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+		<-stopped
+	}
+}
+
+// log logs the last step of each of the resources, if any.
+func (p *planProgress) log(ctx context.Context, id string) {
+	steps := p.lastSteps()
+	if len(steps) == 0 {
+		return
+	}
+
+	fields := make(map[string]interface{}, len(steps)+1)
+	for resource, step := range steps {
+		fields[resource] = step
+	}
+	fields["deployment_id"] = id
+	tflog.Info(ctx, "waiting for the deployment plans to finish", fields)
 }
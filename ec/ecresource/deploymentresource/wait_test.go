@@ -0,0 +1,183 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentresource
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/plan"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_resourcesKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		res  *models.DeploymentResources
+		want []string
+	}{
+		{
+			name: "returns all the kinds when the deployment has no resources",
+			res:  &models.DeploymentResources{},
+			want: resourceKinds,
+		},
+		{
+			name: "returns the kinds of the deployment resources",
+			res: &models.DeploymentResources{
+				Elasticsearch:      []*models.ElasticsearchResourceInfo{{}},
+				Kibana:             []*models.KibanaResourceInfo{{}},
+				IntegrationsServer: []*models.IntegrationsServerResourceInfo{{}},
+			},
+			want: []string{"elasticsearch", "kibana", "integrations_server"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resourcesKinds(tt.res))
+		})
+	}
+}
+
+func Test_planProgress_report(t *testing.T) {
+	progress := newPlanProgress()
+	for _, res := range []plan.TrackResponse{
+		{Kind: "elasticsearch", RefID: "main-elasticsearch", Step: "plan-validation"},
+		{Kind: "kibana", RefID: "main-kibana", Step: "plan-validation"},
+		{Kind: "elasticsearch", RefID: "main-elasticsearch", Step: "rolling-upgrade"},
+		{Kind: "kibana", RefID: "main-kibana"},
+	} {
+		progress.report(res)
+	}
+
+	assert.Equal(t, map[string]string{
+		"elasticsearch/main-elasticsearch": "rolling-upgrade",
+		"kibana/main-kibana":               "plan-validation",
+	}, progress.lastSteps())
+}
+
+func Test_planProgress_describe(t *testing.T) {
+	progress := newPlanProgress()
+	assert.Empty(t, progress.describe())
+
+	progress.report(plan.TrackResponse{Kind: "kibana", RefID: "main-kibana", Step: "plan-validation"})
+	progress.report(plan.TrackResponse{Kind: "elasticsearch", RefID: "main-elasticsearch", Step: "rolling-upgrade"})
+	assert.Equal(t,
+		" (last steps: elasticsearch/main-elasticsearch: rolling-upgrade, kibana/main-kibana: plan-validation)",
+		progress.describe(),
+	)
+}
+
+func Test_planProgress_logEvery(t *testing.T) {
+	t.Run("logs the last steps of the resources until stopped", func(t *testing.T) {
+		var output bytes.Buffer
+		ctx := tflogtest.RootLogger(context.Background(), &output)
+
+		progress := newPlanProgress()
+		progress.report(plan.TrackResponse{Kind: "kibana", RefID: "main-kibana", Step: "plan-validation"})
+
+		stop := progress.logEvery(ctx, "some-id", time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+		stop()
+
+		logged := output.Len()
+		time.Sleep(5 * time.Millisecond)
+		assert.Equal(t, logged, output.Len())
+
+		entries, err := tflogtest.MultilineJSONDecode(&output)
+		assert.NoError(t, err)
+		if assert.NotEmpty(t, entries) {
+			assert.Equal(t, "waiting for the deployment plans to finish", entries[0]["@message"])
+			assert.Equal(t, "some-id", entries[0]["deployment_id"])
+			assert.Equal(t, "plan-validation", entries[0]["kibana/main-kibana"])
+		}
+	})
+
+	t.Run("doesn't log when no steps have been reported", func(t *testing.T) {
+		var output bytes.Buffer
+		ctx := tflogtest.RootLogger(context.Background(), &output)
+
+		stop := newPlanProgress().logEvery(ctx, "some-id", time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+		stop()
+
+		assert.Empty(t, output.String())
+	})
+}
+
+func Test_streamPlan(t *testing.T) {
+	t.Run("returns the errors of the failed plans once the channel is closed", func(t *testing.T) {
+		channel := make(chan plan.TrackResponse, 2)
+		channel <- plan.TrackResponse{Kind: "kibana", RefID: "main-kibana", Finished: true, Err: plan.ErrPlanFinished}
+		channel <- plan.TrackResponse{Kind: "elasticsearch", RefID: "main-elasticsearch", Finished: true, Err: errors.New("some failure")}
+		close(channel)
+
+		var reported int
+		err := streamPlan(context.Background(), channel, func(plan.TrackResponse) { reported++ })
+		assert.Error(t, err)
+		assert.Equal(t, 2, reported)
+	})
+
+	t.Run("returns nil when all the plans succeed", func(t *testing.T) {
+		channel := make(chan plan.TrackResponse, 1)
+		channel <- plan.TrackResponse{Kind: "kibana", RefID: "main-kibana", Finished: true, Err: plan.ErrPlanFinished}
+		close(channel)
+
+		assert.NoError(t, streamPlan(context.Background(), channel, func(plan.TrackResponse) {}))
+	})
+
+	t.Run("stops consuming the channel when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// The channel is never closed, like the one of a plan which is
+		// still running.
+		err := streamPlan(ctx, make(chan plan.TrackResponse), func(plan.TrackResponse) {})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("drains the channel once the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		channel := make(chan plan.TrackResponse)
+		var reported int
+		err := streamPlan(ctx, channel, func(plan.TrackResponse) { reported++ })
+		assert.ErrorIs(t, err, context.Canceled)
+
+		// The sends of the plan tracker don't block once streamPlan returns.
+		select {
+		case channel <- plan.TrackResponse{Kind: "kibana", RefID: "main-kibana"}:
+		case <-time.After(time.Second):
+			t.Fatal("the channel isn't drained")
+		}
+		close(channel)
+		assert.Zero(t, reported)
+	})
+}
+
+func Test_plansFailed(t *testing.T) {
+	failed := &planFailedError{err: errors.New("plan failed")}
+	assert.True(t, plansFailed([]error{nil, failed}))
+	assert.False(t, plansFailed([]error{nil, nil}))
+	assert.False(t, plansFailed([]error{failed, errors.New("stopped waiting for the kibana plan to finish")}))
+}
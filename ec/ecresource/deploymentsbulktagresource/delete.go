@@ -0,0 +1,58 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsbulktagresource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// deleteResource removes the tag and the traffic filter association from all
+// the deployments they were applied to.
+func deleteResource(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+
+	s := expandSettings(d.Get("tag"), d.Get("traffic_filter_id"))
+	ids := util.ItemsToString(d.Get("deployment_ids").(*schema.Set).List())
+
+	merr := multierror.NewPrefixed("failed removing bulk deployment tag")
+	var remaining []string
+	for _, id := range ids {
+		if err := s.remove(client, id); err != nil {
+			merr = merr.Append(fmt.Errorf("deployment %s: %w", id, err))
+			remaining = append(remaining, id)
+		}
+	}
+
+	if err := merr.ErrorOrNil(); err != nil {
+		// Only the deployments which failed are kept, so they are retried.
+		if serr := d.Set("deployment_ids", remaining); serr != nil {
+			return diag.FromErr(serr)
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsbulktagresource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// membershipDiff searches the deployments matching the query, so any change
+// in the matching deployments shows up in the plan.
+func membershipDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("query") {
+		return nil
+	}
+
+	m, ok := meta.(*util.ProviderMeta)
+	if !ok || m.API == nil {
+		return nil
+	}
+	client := m.API
+
+	desired, err := searchDeployments(client, expandQuery(d.Get("query").([]interface{})))
+	if err != nil {
+		return err
+	}
+
+	var current []string
+	if ids, ok := d.Get("deployment_ids").(*schema.Set); ok {
+		current = util.ItemsToString(ids.List())
+	}
+
+	if equal(current, desired) {
+		return nil
+	}
+
+	return d.SetNew("deployment_ids", desired)
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	var set = make(map[string]bool, len(a))
+	for _, id := range a {
+		set[id] = true
+	}
+	for _, id := range b {
+		if !set[id] {
+			return false
+		}
+	}
+	return true
+}
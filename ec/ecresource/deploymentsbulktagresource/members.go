@@ -0,0 +1,254 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsbulktagresource
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymenttagresource"
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// appliedSearchSize is the size of the search which obtains the deployments
+// which have the tag set.
+const appliedSearchSize = 10000
+
+// settings are the tag and traffic filter association applied to the
+// matching deployments.
+type settings struct {
+	tag      *tag
+	filterID string
+}
+
+type tag struct {
+	key, value string
+}
+
+func expandSettings(rawTag interface{}, filterID interface{}) settings {
+	var result settings
+	if raw, _ := rawTag.([]interface{}); len(raw) > 0 {
+		if m, ok := raw[0].(map[string]interface{}); ok {
+			key, _ := m["key"].(string)
+			value, _ := m["value"].(string)
+			result.tag = &tag{key: key, value: value}
+		}
+	}
+	result.filterID, _ = filterID.(string)
+	return result
+}
+
+// changed returns the settings in s which are no longer in next, and need to
+// be removed from the deployments which are still matched.
+func (s settings) changed(next settings) settings {
+	var result settings
+	if s.tag != nil && (next.tag == nil || *s.tag != *next.tag) {
+		result.tag = s.tag
+	}
+	if s.filterID != next.filterID {
+		result.filterID = s.filterID
+	}
+	return result
+}
+
+func (s settings) empty() bool {
+	return s.tag == nil && s.filterID == ""
+}
+
+// apply sets the tag and associates the traffic filter with the deployment.
+func (s settings) apply(client *api.API, id string) error {
+	if s.tag != nil {
+		value := s.tag.value
+		if err := deploymenttagresource.UpdateTag(client, id, s.tag.key, &value); err != nil {
+			return err
+		}
+	}
+
+	if s.filterID != "" {
+		return trafficfilterapi.CreateAssociation(trafficfilterapi.CreateAssociationParams{
+			API: client, ID: s.filterID, EntityID: id, EntityType: "deployment",
+		})
+	}
+
+	return nil
+}
+
+// remove removes the tag and the traffic filter association from the
+// deployment. Deployments which no longer exist are ignored.
+func (s settings) remove(client *api.API, id string) error {
+	if s.tag != nil {
+		if err := deploymenttagresource.UpdateTag(client, id, s.tag.key, nil); err != nil {
+			if util.DeploymentNotFound(err) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	if s.filterID != "" {
+		if err := trafficfilterapi.DeleteAssociation(trafficfilterapi.DeleteAssociationParams{
+			API: client, ID: s.filterID, EntityID: id, EntityType: "deployment",
+		}); err != nil && !apierror.IsRuntimeStatusCode(err, 404) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appliedDeployments returns which of the deployments have all the settings
+// applied, so the ones changed outside of Terraform are applied again.
+func appliedDeployments(client *api.API, s settings, ids []string) ([]string, error) {
+	var applied = make(map[string]bool, len(ids))
+	for _, id := range ids {
+		applied[id] = true
+	}
+
+	if s.tag != nil {
+		tagged, err := searchDeployments(client, &models.SearchRequest{
+			Size:  appliedSearchSize,
+			Query: newTagQuery(s.tag.key, s.tag.value),
+		})
+		if err != nil {
+			return nil, err
+		}
+		applied = intersect(applied, tagged)
+	}
+
+	if s.filterID != "" {
+		res, err := trafficfilterapi.Get(trafficfilterapi.GetParams{
+			API: client, ID: s.filterID, IncludeAssociations: true,
+		})
+		if err != nil && !apierror.IsRuntimeStatusCode(err, 404) {
+			return nil, err
+		}
+
+		// A removed traffic filter has no associations.
+		var associated []string
+		for _, a := range associations(res) {
+			if a.EntityType != nil && *a.EntityType == "deployment" && a.ID != nil {
+				associated = append(associated, *a.ID)
+			}
+		}
+		applied = intersect(applied, associated)
+	}
+
+	return sortedKeys(applied), nil
+}
+
+// reconcile applies the settings to the deployments matching the query and
+// removes them from the deployments which are no longer matched, setting the
+// deployments which have the settings applied in the state, even when some
+// of them fail.
+func reconcile(client *api.API, d *schema.ResourceData) error {
+	desired, err := searchDeployments(client, expandQuery(d.Get("query").([]interface{})))
+	if err != nil {
+		return err
+	}
+
+	oldTag, newTag := d.GetChange("tag")
+	oldFilter, newFilter := d.GetChange("traffic_filter_id")
+	prev := expandSettings(oldTag, oldFilter)
+	next := expandSettings(newTag, newFilter)
+	changed := prev.changed(next)
+
+	var current []string
+	if !d.IsNewResource() {
+		oldIDs, _ := d.GetChange("deployment_ids")
+		current = util.ItemsToString(oldIDs.(*schema.Set).List())
+	}
+
+	var wanted = make(map[string]bool, len(desired))
+	for _, id := range desired {
+		wanted[id] = true
+	}
+
+	merr := multierror.NewPrefixed("failed reconciling the deployments")
+	var applied = make(map[string]bool, len(current))
+	for _, id := range current {
+		applied[id] = true
+
+		var remove = changed
+		if !wanted[id] {
+			remove = prev
+		}
+		if remove.empty() {
+			continue
+		}
+
+		if err := remove.remove(client, id); err != nil {
+			merr = merr.Append(fmt.Errorf("deployment %s: %w", id, err))
+			continue
+		}
+
+		if !wanted[id] {
+			delete(applied, id)
+		}
+	}
+
+	for _, id := range desired {
+		if applied[id] && changed.empty() {
+			continue
+		}
+
+		if err := next.apply(client, id); err != nil {
+			merr = merr.Append(fmt.Errorf("deployment %s: %w", id, err))
+			continue
+		}
+		applied[id] = true
+	}
+
+	if err := d.Set("deployment_ids", sortedKeys(applied)); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+func associations(res *models.TrafficFilterRulesetInfo) []*models.FilterAssociation {
+	if res == nil {
+		return nil
+	}
+	return res.Associations
+}
+
+func intersect(set map[string]bool, ids []string) map[string]bool {
+	var result = make(map[string]bool)
+	for _, id := range ids {
+		if set[id] {
+			result[id] = true
+		}
+	}
+	return result
+}
+
+func sortedKeys(set map[string]bool) []string {
+	var result = make([]string, 0, len(set))
+	for k := range set {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
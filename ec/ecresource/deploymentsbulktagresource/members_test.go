@@ -0,0 +1,75 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsbulktagresource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_settingsChanged(t *testing.T) {
+	prev := settings{tag: &tag{key: "team", value: "search"}, filterID: "filter-1"}
+	tests := []struct {
+		name string
+		next settings
+		want settings
+	}{
+		{
+			name: "nothing changed",
+			next: prev,
+			want: settings{},
+		},
+		{
+			name: "tag value changed",
+			next: settings{tag: &tag{key: "team", value: "observability"}, filterID: "filter-1"},
+			want: settings{tag: prev.tag},
+		},
+		{
+			name: "tag removed",
+			next: settings{filterID: "filter-1"},
+			want: settings{tag: prev.tag},
+		},
+		{
+			name: "traffic filter changed",
+			next: settings{tag: &tag{key: "team", value: "search"}, filterID: "filter-2"},
+			want: settings{filterID: "filter-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := prev.changed(tt.next)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want.empty(), got.empty())
+		})
+	}
+}
+
+func Test_expandSettings(t *testing.T) {
+	got := expandSettings([]interface{}{map[string]interface{}{
+		"key": "team", "value": "search",
+	}}, "filter-1")
+	assert.Equal(t, settings{tag: &tag{key: "team", value: "search"}, filterID: "filter-1"}, got)
+
+	assert.True(t, expandSettings([]interface{}{}, "").empty())
+}
+
+func Test_intersect(t *testing.T) {
+	got := intersect(map[string]bool{"a": true, "b": true, "c": true}, []string{"b", "c", "d"})
+	assert.Equal(t, []string{"b", "c"}, sortedKeys(got))
+}
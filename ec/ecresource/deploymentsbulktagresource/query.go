@@ -0,0 +1,130 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsbulktagresource
+
+import (
+	"sort"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// expandQuery expands the flattened query into a deployments search request.
+// An empty query matches all the deployments.
+func expandQuery(raw []interface{}) *models.SearchRequest {
+	var q map[string]interface{}
+	if len(raw) > 0 {
+		q, _ = raw[0].(map[string]interface{})
+	}
+
+	var queries []*models.QueryContainer
+	if prefix, ok := q["name_prefix"].(string); ok && prefix != "" {
+		queries = append(queries, &models.QueryContainer{
+			Prefix: map[string]models.PrefixQuery{
+				"name.keyword": {Value: ec.String(prefix)},
+			},
+		})
+	}
+
+	if tplID, ok := q["deployment_template_id"].(string); ok && tplID != "" {
+		queries = append(queries, &models.QueryContainer{
+			Nested: &models.NestedQuery{
+				Path: ec.String("resources.elasticsearch"),
+				Query: &models.QueryContainer{
+					Term: map[string]models.TermQuery{
+						"resources.elasticsearch.info.plan_info.current.plan.deployment_template.id": {
+							Value: ec.String(tplID),
+						},
+					},
+				},
+			},
+		})
+	}
+
+	tags, _ := q["tags"].(map[string]interface{})
+	var keys = make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		queries = append(queries, newTagQuery(k, tags[k].(string)))
+	}
+
+	var size = 100
+	if s, ok := q["size"].(int); ok && s > 0 {
+		size = s
+	}
+
+	req := models.SearchRequest{
+		Size: int32(size),
+		Sort: []interface{}{"id"},
+	}
+
+	if len(queries) > 0 {
+		req.Query = &models.QueryContainer{
+			Bool: &models.BoolQuery{Filter: queries},
+		}
+	}
+
+	return &req
+}
+
+// newTagQuery returns a nested query matching the deployments with the tag.
+func newTagQuery(key, value string) *models.QueryContainer {
+	return &models.QueryContainer{
+		Nested: &models.NestedQuery{
+			Path: ec.String("metadata.tags"),
+			Query: &models.QueryContainer{
+				Bool: &models.BoolQuery{
+					Filter: []*models.QueryContainer{
+						{Term: map[string]models.TermQuery{
+							"metadata.tags.key": {Value: ec.String(key)},
+						}},
+						{Term: map[string]models.TermQuery{
+							"metadata.tags.value": {Value: ec.String(value)},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// searchDeployments returns the sorted IDs of the deployments matching the
+// search request.
+func searchDeployments(client *api.API, req *models.SearchRequest) ([]string, error) {
+	res, err := deploymentapi.Search(deploymentapi.SearchParams{
+		API: client, Request: req,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids = make([]string, 0, len(res.Deployments))
+	for _, dep := range res.Deployments {
+		if dep.ID != nil {
+			ids = append(ids, *dep.ID)
+		}
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
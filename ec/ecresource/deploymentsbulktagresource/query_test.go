@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsbulktagresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  []interface{}
+		want *models.SearchRequest
+	}{
+		{
+			name: "empty query matches all the deployments",
+			raw:  []interface{}{map[string]interface{}{"size": 100}},
+			want: &models.SearchRequest{Size: 100, Sort: []interface{}{"id"}},
+		},
+		{
+			name: "expands all the query fields",
+			raw: []interface{}{map[string]interface{}{
+				"name_prefix":            "prod-",
+				"deployment_template_id": "aws-io-optimized-v2",
+				"tags":                   map[string]interface{}{"team": "search", "env": "prod"},
+				"size":                   20,
+			}},
+			want: &models.SearchRequest{
+				Size: 20,
+				Sort: []interface{}{"id"},
+				Query: &models.QueryContainer{
+					Bool: &models.BoolQuery{Filter: []*models.QueryContainer{
+						{Prefix: map[string]models.PrefixQuery{
+							"name.keyword": {Value: ec.String("prod-")},
+						}},
+						{Nested: &models.NestedQuery{
+							Path: ec.String("resources.elasticsearch"),
+							Query: &models.QueryContainer{
+								Term: map[string]models.TermQuery{
+									"resources.elasticsearch.info.plan_info.current.plan.deployment_template.id": {
+										Value: ec.String("aws-io-optimized-v2"),
+									},
+								},
+							},
+						}},
+						newTagQuery("env", "prod"),
+						newTagQuery("team", "search"),
+					}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, expandQuery(tt.raw))
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsbulktagresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// newSchema returns the schema for an "ec_deployments_bulk_tag" resource.
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"query": {
+			Type:        schema.TypeList,
+			Description: "Required search matching the deployments the tag and traffic filter association are applied to",
+			Required:    true,
+			MaxItems:    1,
+			Elem:        newQuerySchema(),
+		},
+		"tag": {
+			Type:         schema.TypeList,
+			Description:  "Optional tag set on all the matching deployments",
+			Optional:     true,
+			MaxItems:     1,
+			AtLeastOneOf: []string{"tag", "traffic_filter_id"},
+			Elem:         newTagSchema(),
+		},
+		"traffic_filter_id": {
+			Type:         schema.TypeString,
+			Description:  "Optional ID of the traffic filter ruleset associated with all the matching deployments",
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		// Computed
+		"deployment_ids": {
+			Type:        schema.TypeSet,
+			Description: "Computed IDs of the deployments the tag and traffic filter association are applied to",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func newQuerySchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Description: "Optional prefix the deployment names must start with",
+				Optional:    true,
+			},
+			"deployment_template_id": {
+				Type:        schema.TypeString,
+				Description: "Optional deployment template ID the deployments must be running on",
+				Optional:    true,
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Description: "Optional map of tags the deployments must have",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"size": {
+				Type:         schema.TypeInt,
+				Description:  "Optional maximum number of matching deployments, defaults to 100",
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(1, 10000),
+			},
+		},
+	}
+}
+
+func newTagSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:         schema.TypeString,
+				Description:  "Required tag key",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Description: "Required tag value",
+				Required:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,87 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsetresource
+
+import (
+	"context"
+	"sync"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+// operation changes a single deployment of the set.
+type operation func(ctx context.Context) error
+
+// runBatches runs the operations in batches of batchSize, running at most
+// parallelism operations at the same time within a batch. Once a batch has
+// failed, the following batches aren't run.
+func runBatches(ctx context.Context, ops []operation, batchSize, parallelism int) error {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	for start := 0; start < len(ops); start += batchSize {
+		end := start + batchSize
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		if err := runBatch(ctx, ops[start:end], parallelism); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func runBatch(ctx context.Context, ops []operation, parallelism int) error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var merr = multierror.NewPrefixed("failed applying the deployment set changes")
+	var sem = make(chan struct{}, parallelism)
+
+	for _, op := range ops {
+		if err := ctx.Err(); err != nil {
+			mu.Lock()
+			merr = merr.Append(err)
+			mu.Unlock()
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(op operation) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := op(ctx); err != nil {
+				mu.Lock()
+				merr = merr.Append(err)
+				mu.Unlock()
+			}
+		}(op)
+	}
+
+	wg.Wait()
+	return merr.ErrorOrNil()
+}
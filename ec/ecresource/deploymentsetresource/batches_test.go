@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsetresource
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_runBatches(t *testing.T) {
+	t.Run("runs all the operations within the parallelism", func(t *testing.T) {
+		var running, maxRunning, ran int32
+		var ops []operation
+		for i := 0; i < 7; i++ {
+			ops = append(ops, func(context.Context) error {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					max := atomic.LoadInt32(&maxRunning)
+					if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+						break
+					}
+				}
+				atomic.AddInt32(&ran, 1)
+				atomic.AddInt32(&running, -1)
+				return nil
+			})
+		}
+
+		assert.NoError(t, runBatches(context.Background(), ops, 3, 2))
+		assert.Equal(t, int32(7), ran)
+		assert.LessOrEqual(t, maxRunning, int32(2))
+	})
+
+	t.Run("stops after a failed batch", func(t *testing.T) {
+		var mu sync.Mutex
+		var ran []int
+		op := func(i int, err error) operation {
+			return func(context.Context) error {
+				mu.Lock()
+				ran = append(ran, i)
+				mu.Unlock()
+				return err
+			}
+		}
+		ops := []operation{
+			op(0, nil), op(1, errors.New("failed")), op(2, nil), op(3, nil),
+		}
+
+		err := runBatches(context.Background(), ops, 2, 1)
+		assert.EqualError(t, err, "failed applying the deployment set changes: 1 error occurred:\n\t* failed\n\n")
+		assert.Equal(t, []int{0, 1}, ran)
+	})
+
+	t.Run("doesn't start operations once the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var ran bool
+		err := runBatches(ctx, []operation{func(context.Context) error {
+			ran = true
+			return nil
+		}}, 1, 1)
+		assert.Error(t, err)
+		assert.False(t, ran)
+	})
+}
@@ -0,0 +1,51 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsetresource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// deleteResource shuts down and deletes all the deployments of the set, in batches.
+func deleteResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+	ms := newMembers(d.Get("deployments"))
+
+	var ops []operation
+	for _, m := range ms.list() {
+		ops = append(ops, shutdownOperation(client, ms, m))
+	}
+
+	err := runBatches(ctx, ops,
+		d.Get("batch_size").(int), d.Get("max_parallelism").(int),
+	)
+	if err != nil {
+		if serr := d.Set("deployments", ms.flatten()); serr != nil {
+			return diag.FromErr(serr)
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,471 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsetresource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deploymentsize"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deptemplateapi"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource"
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+const hotContentTopologyID = "hot_content"
+
+// member is a deployment of the deployment set.
+type member struct {
+	index int
+	name  string
+	id    string
+}
+
+// members holds the deployments of the set by index. It's safe to use from
+// concurrent operations.
+type members struct {
+	mu sync.Mutex
+	m  map[int]member
+}
+
+func newMembers(raw interface{}) *members {
+	var result = members{m: make(map[int]member)}
+	list, _ := raw.([]interface{})
+	for _, r := range list {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		index, _ := m["index"].(int)
+		name, _ := m["name"].(string)
+		id, _ := m["deployment_id"].(string)
+		if id != "" {
+			result.m[index] = member{index: index, name: name, id: id}
+		}
+	}
+	return &result
+}
+
+func (ms *members) get(index int) (member, bool) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	m, ok := ms.m[index]
+	return m, ok
+}
+
+func (ms *members) set(m member) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.m[m.index] = m
+}
+
+func (ms *members) remove(index int) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.m, index)
+}
+
+// list returns the deployments of the set sorted by index.
+func (ms *members) list() []member {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	var result = make([]member, 0, len(ms.m))
+	for _, m := range ms.m {
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].index < result[j].index })
+	return result
+}
+
+func (ms *members) flatten() []interface{} {
+	var result = make([]interface{}, 0, len(ms.m))
+	for _, m := range ms.list() {
+		result = append(result, map[string]interface{}{
+			"index":         m.index,
+			"name":          m.name,
+			"deployment_id": m.id,
+		})
+	}
+	return result
+}
+
+// spec is the shape shared by all the deployments of the set.
+type spec struct {
+	namePattern string
+	region      string
+	templateID  string
+	version     string
+	size        string
+	zoneCount   int
+	tags        map[string]interface{}
+}
+
+func newSpec(d *schema.ResourceData) spec {
+	tags, _ := d.Get("tags").(map[string]interface{})
+	return spec{
+		namePattern: d.Get("name_pattern").(string),
+		region:      d.Get("region").(string),
+		templateID:  d.Get("deployment_template_id").(string),
+		version:     d.Get("version").(string),
+		size:        d.Get("elasticsearch_size").(string),
+		zoneCount:   d.Get("elasticsearch_zone_count").(int),
+		tags:        tags,
+	}
+}
+
+// name returns the name of the deployment with the specified index.
+func (s spec) name(index int) string {
+	return strings.ReplaceAll(s.namePattern, indexPlaceholder, strconv.Itoa(index))
+}
+
+// applyElasticsearch sets the configured size and zone count of the
+// Elasticsearch "hot_content" topology elements.
+func (s spec) applyElasticsearch(resources []*models.ElasticsearchPayload) error {
+	var size *models.TopologySize
+	if s.size != "" {
+		val, err := deploymentsize.ParseGb(s.size)
+		if err != nil {
+			return err
+		}
+		size = &models.TopologySize{Value: ec.Int32(val), Resource: ec.String("memory")}
+	}
+
+	for _, res := range resources {
+		if res == nil || res.Plan == nil {
+			continue
+		}
+
+		for _, t := range res.Plan.ClusterTopology {
+			if t == nil || t.ID != hotContentTopologyID {
+				continue
+			}
+
+			if size != nil {
+				t.Size = size
+			}
+
+			if s.zoneCount > 0 {
+				t.ZoneCount = int32(s.zoneCount)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s spec) metadataTags() []*models.MetadataItem {
+	var result = make([]*models.MetadataItem, 0, len(s.tags))
+	for k, v := range s.tags {
+		result = append(result, &models.MetadataItem{
+			Key: ec.String(k), Value: ec.String(v.(string)),
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return *result[i].Key < *result[j].Key
+	})
+
+	return result
+}
+
+// getTemplate returns the deployment template the deployments of the set are
+// created from.
+func getTemplate(client *api.API, s spec) (*models.DeploymentCreateRequest, error) {
+	res, err := deptemplateapi.Get(deptemplateapi.GetParams{
+		API:                        client,
+		TemplateID:                 s.templateID,
+		Region:                     s.region,
+		HideInstanceConfigurations: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.DeploymentTemplate == nil {
+		return nil, fmt.Errorf("deployment template %s has no deployment definition", s.templateID)
+	}
+
+	return res.DeploymentTemplate, nil
+}
+
+// newCreateRequest returns a create request for a deployment of the set from
+// a copy of the deployment template, so it can be used concurrently.
+func newCreateRequest(template *models.DeploymentCreateRequest, s spec, index int) (*models.DeploymentCreateRequest, error) {
+	b, err := template.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var req models.DeploymentCreateRequest
+	if err := req.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+
+	req.Name = s.name(index)
+	if req.Resources != nil {
+		if err := s.applyElasticsearch(req.Resources.Elasticsearch); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(s.tags) > 0 {
+		if req.Metadata == nil {
+			req.Metadata = &models.DeploymentCreateMetadata{}
+		}
+		req.Metadata.Tags = s.metadataTags()
+	}
+
+	return &req, nil
+}
+
+// createOperation creates the deployment with the specified index. The
+// deployment is added to the set as soon as it's been created, so it's kept
+// in the state even when its plan fails.
+func createOperation(client *api.API, ms *members, template *models.DeploymentCreateRequest, s spec, index int) operation {
+	return func(ctx context.Context) error {
+		client := util.ContextClient(ctx, client)
+		req, err := newCreateRequest(template, s, index)
+		if err != nil {
+			return fmt.Errorf("deployment %s: %w", s.name(index), err)
+		}
+
+		res, err := deploymentapi.Create(deploymentapi.CreateParams{
+			API:     client,
+			Request: req,
+			Overrides: &deploymentapi.PayloadOverrides{
+				Name:    req.Name,
+				Version: s.version,
+				Region:  s.region,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed creating deployment %s: %w", req.Name, err)
+		}
+
+		ms.set(member{index: index, name: req.Name, id: *res.ID})
+
+		if err := deploymentresource.WaitForPlanCompletion(ctx, client, *res.ID); err != nil {
+			return fmt.Errorf("failed tracking deployment %s create progress: %w", req.Name, err)
+		}
+
+		return nil
+	}
+}
+
+// newMemberUpdateRequest returns the request which applies the deployment
+// set shape to the deployment, and whether it changes the deployment plan.
+// Only the name and tags are updated when the plan doesn't change, so no plan
+// is applied, and a nil request is returned when the deployment already has
+// the deployment set shape.
+func newMemberUpdateRequest(res *models.DeploymentGetResponse, s spec, index int) (*models.DeploymentUpdateRequest, bool, error) {
+	current := deploymentapi.NewUpdateRequest(res)
+	if err := deploymentapi.OverrideCreateOrUpdateRequest(current, &deploymentapi.PayloadOverrides{
+		Region: s.region,
+	}); err != nil {
+		return nil, false, err
+	}
+
+	req := deploymentapi.NewUpdateRequest(res)
+	req.Name = s.name(index)
+	if err := s.applyElasticsearch(req.Resources.Elasticsearch); err != nil {
+		return nil, false, err
+	}
+	req.Metadata = &models.DeploymentUpdateMetadata{Tags: s.metadataTags()}
+	if err := deploymentapi.OverrideCreateOrUpdateRequest(req, &deploymentapi.PayloadOverrides{
+		Version: s.version,
+		Region:  s.region,
+	}); err != nil {
+		return nil, false, err
+	}
+
+	planChanged := !reflect.DeepEqual(current.Resources, req.Resources)
+	if !planChanged {
+		var tags []*models.MetadataItem
+		if res.Metadata != nil {
+			tags = res.Metadata.Tags
+		}
+		if req.Name == current.Name && sameTags(tags, req.Metadata.Tags) {
+			return nil, false, nil
+		}
+		req.Resources = &models.DeploymentUpdateResources{}
+	}
+
+	return req, planChanged, nil
+}
+
+// sameTags returns true when both lists hold the same tags in any order.
+func sameTags(a, b []*models.MetadataItem) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	tags := make(map[string]string, len(a))
+	for _, t := range a {
+		if t != nil && t.Key != nil && t.Value != nil {
+			tags[*t.Key] = *t.Value
+		}
+	}
+	for _, t := range b {
+		if t == nil || t.Key == nil || t.Value == nil {
+			return false
+		}
+		if v, ok := tags[*t.Key]; !ok || v != *t.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// updateOperation applies the deployment set shape to an existing deployment,
+// leaving it untouched when it already has the shape.
+func updateOperation(client *api.API, ms *members, s spec, m member) operation {
+	return func(ctx context.Context) error {
+		client := util.ContextClient(ctx, client)
+		res, err := util.GetDeployment(client, m.id)
+		if err != nil {
+			return fmt.Errorf("failed reading deployment %s: %w", m.id, err)
+		}
+
+		req, planChanged, err := newMemberUpdateRequest(res, s, m.index)
+		if err != nil {
+			return fmt.Errorf("deployment %s: %w", m.id, err)
+		}
+		if req == nil {
+			return nil
+		}
+
+		if _, err := deploymentapi.Update(deploymentapi.UpdateParams{
+			API:          client,
+			DeploymentID: m.id,
+			Request:      req,
+		}); err != nil {
+			return fmt.Errorf("failed updating deployment %s: %w", m.id, err)
+		}
+
+		ms.set(member{index: m.index, name: req.Name, id: m.id})
+		if !planChanged {
+			return nil
+		}
+
+		if err := deploymentresource.WaitForPlanCompletion(ctx, client, m.id); err != nil {
+			return fmt.Errorf("failed tracking deployment %s update progress: %w", m.id, err)
+		}
+
+		return nil
+	}
+}
+
+// shutdownOperation shuts down and deletes a deployment, removing it from
+// the set.
+func shutdownOperation(client *api.API, ms *members, m member) operation {
+	return func(ctx context.Context) error {
+		client := util.ContextClient(ctx, client)
+		if _, err := deploymentapi.Shutdown(deploymentapi.ShutdownParams{
+			API: client, DeploymentID: m.id,
+		}); err != nil {
+			if alreadyDestroyed(err) {
+				ms.remove(m.index)
+				return nil
+			}
+			return fmt.Errorf("failed shutting down deployment %s: %w", m.id, err)
+		}
+
+		if err := deploymentresource.WaitForPlanCompletion(ctx, client, m.id); err != nil {
+			return fmt.Errorf("failed tracking deployment %s shutdown progress: %w", m.id, err)
+		}
+
+		// The deployment is kept in the set when it can't be deleted, so the
+		// deletion is retried by the next apply.
+		if _, err := deploymentapi.Delete(deploymentapi.DeleteParams{
+			API: client, DeploymentID: m.id,
+		}); err != nil && !alreadyDeleted(err) {
+			return fmt.Errorf("failed deleting deployment %s: %w", m.id, err)
+		}
+
+		ms.remove(m.index)
+		return nil
+	}
+}
+
+func alreadyDestroyed(err error) bool {
+	var destroyed *deployments.ShutdownDeploymentNotFound
+	return errors.As(err, &destroyed)
+}
+
+func alreadyDeleted(err error) bool {
+	var deleted *deployments.DeleteDeploymentNotFound
+	return errors.As(err, &deleted)
+}
+
+func deploymentNotFound(err error) bool {
+	var notFound *deployments.GetDeploymentNotFound
+	return errors.As(err, &notFound)
+}
+
+// apply creates, updates and shuts down the deployments of the set so it
+// matches the configuration. When updateExisting is set, the existing deployments
+// are updated to the configured shape.
+func apply(ctx context.Context, d *schema.ResourceData, client *api.API, ms *members, updateExisting bool) error {
+	s := newSpec(d)
+	ctxClient := util.ContextClient(ctx, client)
+	count := d.Get("deployment_count").(int)
+
+	var ops []operation
+	for _, m := range ms.list() {
+		if m.index >= count {
+			ops = append(ops, shutdownOperation(client, ms, m))
+		}
+	}
+
+	var template *models.DeploymentCreateRequest
+	for i := 0; i < count; i++ {
+		if m, ok := ms.get(i); ok {
+			if updateExisting {
+				ops = append(ops, updateOperation(client, ms, s, m))
+			}
+			continue
+		}
+
+		if template == nil {
+			var err error
+			if template, err = getTemplate(ctxClient, s); err != nil {
+				return multierror.NewPrefixed("failed obtaining the deployment template", err)
+			}
+		}
+		ops = append(ops, createOperation(client, ms, template, s, i))
+	}
+
+	return runBatches(ctx, ops,
+		d.Get("batch_size").(int), d.Get("max_parallelism").(int),
+	)
+}
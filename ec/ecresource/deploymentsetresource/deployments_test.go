@@ -0,0 +1,211 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsetresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newCreateRequest(t *testing.T) {
+	template := &models.DeploymentCreateRequest{
+		Resources: &models.DeploymentCreateResources{
+			Elasticsearch: []*models.ElasticsearchPayload{{
+				Plan: &models.ElasticsearchClusterPlan{
+					ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+						{
+							ID:        "hot_content",
+							ZoneCount: 2,
+							Size:      &models.TopologySize{Value: ec.Int32(4096), Resource: ec.String("memory")},
+						},
+						{
+							ID:   "warm",
+							Size: &models.TopologySize{Value: ec.Int32(0), Resource: ec.String("memory")},
+						},
+					},
+				},
+			}},
+		},
+	}
+	s := spec{
+		namePattern: "tenant-{index}",
+		size:        "8g",
+		zoneCount:   3,
+		tags:        map[string]interface{}{"tenant": "acme", "owner": "sre"},
+	}
+
+	got, err := newCreateRequest(template, s, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.DeploymentCreateRequest{
+		Name: "tenant-4",
+		Resources: &models.DeploymentCreateResources{
+			Elasticsearch: []*models.ElasticsearchPayload{{
+				Plan: &models.ElasticsearchClusterPlan{
+					ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+						{
+							ID:        "hot_content",
+							ZoneCount: 3,
+							Size:      &models.TopologySize{Value: ec.Int32(8192), Resource: ec.String("memory")},
+						},
+						{
+							ID:   "warm",
+							Size: &models.TopologySize{Value: ec.Int32(0), Resource: ec.String("memory")},
+						},
+					},
+				},
+			}},
+		},
+		Metadata: &models.DeploymentCreateMetadata{Tags: []*models.MetadataItem{
+			{Key: ec.String("owner"), Value: ec.String("sre")},
+			{Key: ec.String("tenant"), Value: ec.String("acme")},
+		}},
+	}, got)
+
+	// The template is left untouched so it can be reused.
+	assert.Equal(t, "", template.Name)
+	assert.Equal(t, int32(2), template.Resources.Elasticsearch[0].Plan.ClusterTopology[0].ZoneCount)
+
+	_, err = newCreateRequest(template, spec{namePattern: "{index}", size: "huge"}, 0)
+	assert.Error(t, err)
+}
+
+func Test_newMemberUpdateRequest(t *testing.T) {
+	newResponse := func(name string, size int32, tags ...string) *models.DeploymentGetResponse {
+		var metadata []*models.MetadataItem
+		for i := 0; i+1 < len(tags); i += 2 {
+			metadata = append(metadata, &models.MetadataItem{Key: ec.String(tags[i]), Value: ec.String(tags[i+1])})
+		}
+		return &models.DeploymentGetResponse{
+			ID:       ec.String("some-id"),
+			Name:     ec.String(name),
+			Metadata: &models.DeploymentMetadata{Tags: metadata},
+			Resources: &models.DeploymentResources{
+				Elasticsearch: []*models.ElasticsearchResourceInfo{{
+					RefID:  ec.String("main-elasticsearch"),
+					Region: ec.String("us-east-1"),
+					Info: &models.ElasticsearchClusterInfo{
+						PlanInfo: &models.ElasticsearchClusterPlansInfo{
+							Current: &models.ElasticsearchClusterPlanInfo{
+								Plan: &models.ElasticsearchClusterPlan{
+									Elasticsearch: &models.ElasticsearchConfiguration{Version: "8.4.3"},
+									ClusterTopology: []*models.ElasticsearchClusterTopologyElement{{
+										ID:        "hot_content",
+										ZoneCount: 2,
+										Size:      &models.TopologySize{Value: ec.Int32(size), Resource: ec.String("memory")},
+									}},
+								},
+							},
+						},
+					},
+				}},
+			},
+		}
+	}
+	s := spec{
+		namePattern: "tenant-{index}",
+		region:      "us-east-1",
+		version:     "8.4.3",
+		size:        "4g",
+		zoneCount:   2,
+		tags:        map[string]interface{}{"tenant": "acme"},
+	}
+
+	t.Run("leaves the deployments with the set shape untouched", func(t *testing.T) {
+		req, planChanged, err := newMemberUpdateRequest(newResponse("tenant-1", 4096, "tenant", "acme"), s, 1)
+		assert.NoError(t, err)
+		assert.Nil(t, req)
+		assert.False(t, planChanged)
+	})
+
+	t.Run("only updates the name and tags when the plan doesn't change", func(t *testing.T) {
+		req, planChanged, err := newMemberUpdateRequest(newResponse("old-1", 4096, "tenant", "other"), s, 1)
+		assert.NoError(t, err)
+		assert.False(t, planChanged)
+		if assert.NotNil(t, req) {
+			assert.Equal(t, "tenant-1", req.Name)
+			assert.Equal(t, &models.DeploymentUpdateResources{}, req.Resources)
+			assert.Equal(t, []*models.MetadataItem{
+				{Key: ec.String("tenant"), Value: ec.String("acme")},
+			}, req.Metadata.Tags)
+		}
+	})
+
+	t.Run("updates the plan when the shape changes", func(t *testing.T) {
+		req, planChanged, err := newMemberUpdateRequest(newResponse("tenant-1", 8192, "tenant", "acme"), s, 1)
+		assert.NoError(t, err)
+		assert.True(t, planChanged)
+		if assert.NotNil(t, req) && assert.Len(t, req.Resources.Elasticsearch, 1) {
+			assert.Equal(t, ec.Int32(4096), req.Resources.Elasticsearch[0].Plan.ClusterTopology[0].Size.Value)
+		}
+	})
+
+	t.Run("updates the plan when the version changes", func(t *testing.T) {
+		upgrade := s
+		upgrade.version = "8.5.0"
+		req, planChanged, err := newMemberUpdateRequest(newResponse("tenant-1", 4096, "tenant", "acme"), upgrade, 1)
+		assert.NoError(t, err)
+		assert.True(t, planChanged)
+		if assert.NotNil(t, req) && assert.Len(t, req.Resources.Elasticsearch, 1) {
+			assert.Equal(t, "8.5.0", req.Resources.Elasticsearch[0].Plan.Elasticsearch.Version)
+		}
+	})
+}
+
+func Test_sameTags(t *testing.T) {
+	a := []*models.MetadataItem{
+		{Key: ec.String("owner"), Value: ec.String("sre")},
+		{Key: ec.String("tenant"), Value: ec.String("acme")},
+	}
+	b := []*models.MetadataItem{
+		{Key: ec.String("tenant"), Value: ec.String("acme")},
+		{Key: ec.String("owner"), Value: ec.String("sre")},
+	}
+	assert.True(t, sameTags(a, b))
+	assert.False(t, sameTags(a, b[:1]))
+	assert.False(t, sameTags(a, []*models.MetadataItem{
+		{Key: ec.String("owner"), Value: ec.String("sre")},
+		{Key: ec.String("tenant"), Value: ec.String("other")},
+	}))
+}
+
+func Test_members(t *testing.T) {
+	ms := newMembers([]interface{}{
+		map[string]interface{}{"index": 2, "name": "tenant-2", "deployment_id": "id-2"},
+		map[string]interface{}{"index": 0, "name": "tenant-0", "deployment_id": "id-0"},
+		map[string]interface{}{"index": 1, "name": "tenant-1", "deployment_id": ""},
+	})
+
+	ms.set(member{index: 3, name: "tenant-3", id: "id-3"})
+	ms.remove(2)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"index": 0, "name": "tenant-0", "deployment_id": "id-0"},
+		map[string]interface{}{"index": 3, "name": "tenant-3", "deployment_id": "id-3"},
+	}, ms.flatten())
+}
+
+func Test_validateNamePattern(t *testing.T) {
+	_, errs := validateNamePattern("tenant-{index}", "name_pattern")
+	assert.Empty(t, errs)
+
+	_, errs = validateNamePattern("tenant", "name_pattern")
+	assert.EqualError(t, errs[0], `name_pattern must contain "{index}" so each deployment gets a unique name`)
+}
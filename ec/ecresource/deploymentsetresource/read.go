@@ -0,0 +1,59 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsetresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// read refreshes the deployments of the set, removing the ones which no
+// longer exist so they're created again on the next apply.
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+	ms := newMembers(d.Get("deployments"))
+
+	var merr = multierror.NewPrefixed("failed reading the deployment set")
+	for _, m := range ms.list() {
+		res, err := util.GetDeployment(client, m.id)
+		if err != nil {
+			if deploymentNotFound(err) {
+				ms.remove(m.index)
+				continue
+			}
+			merr = merr.Append(err)
+			continue
+		}
+
+		if res.Name != nil {
+			m.name = *res.Name
+			ms.set(m)
+		}
+	}
+
+	if err := d.Set("deployments", ms.flatten()); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return diag.FromErr(merr.ErrorOrNil())
+}
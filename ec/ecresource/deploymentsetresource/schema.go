@@ -0,0 +1,136 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsetresource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// indexPlaceholder is replaced with the index of each deployment in the
+// deployment set name pattern.
+const indexPlaceholder = "{index}"
+
+// newSchema returns the schema for an "ec_deployment_set" resource.
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name_pattern": {
+			Type:         schema.TypeString,
+			Description:  fmt.Sprintf(`Required name pattern of the deployments, where %q is replaced with the index of each deployment, e.g. "tenant-%s"`, indexPlaceholder, indexPlaceholder),
+			Required:     true,
+			ValidateFunc: validateNamePattern,
+		},
+		"deployment_count": {
+			Type:         schema.TypeInt,
+			Description:  "Required number of deployments in the set",
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Description: "Required ESS region where the deployments are created",
+			Required:    true,
+			ForceNew:    true,
+		},
+		"deployment_template_id": {
+			Type:        schema.TypeString,
+			Description: "Required deployment template ID the deployments are created from",
+			Required:    true,
+			ForceNew:    true,
+		},
+		"version": {
+			Type:        schema.TypeString,
+			Description: "Required Elastic Stack version of the deployments",
+			Required:    true,
+		},
+		"elasticsearch_size": {
+			Type:        schema.TypeString,
+			Description: `Optional size of the Elasticsearch "hot_content" topology element of each deployment, e.g. "8g". Defaults to the deployment template value`,
+			Optional:    true,
+		},
+		"elasticsearch_zone_count": {
+			Type:         schema.TypeInt,
+			Description:  `Optional number of zones of the Elasticsearch "hot_content" topology element of each deployment. Defaults to the deployment template value`,
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(1, 3),
+		},
+		"tags": {
+			Type:        schema.TypeMap,
+			Description: "Optional map of tags set on each deployment",
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"batch_size": {
+			Type:         schema.TypeInt,
+			Description:  "Optional number of deployments changed in each batch. Batches are applied one after another, and the following batches aren't applied when a batch fails. Defaults to 1",
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"max_parallelism": {
+			Type:         schema.TypeInt,
+			Description:  "Optional maximum number of deployments changed at the same time within a batch. Defaults to 1",
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+
+		// Computed
+		"deployments": {
+			Type:        schema.TypeList,
+			Description: "Deployments of the set, ordered by index",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"index": {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"deployment_id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateNamePattern(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if !strings.Contains(v, indexPlaceholder) {
+		return nil, []error{fmt.Errorf(
+			"%s must contain %q so each deployment gets a unique name", k, indexPlaceholder,
+		)}
+	}
+
+	return nil, nil
+}
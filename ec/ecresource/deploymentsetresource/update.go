@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentsetresource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// shapeAttributes are the attributes which change the existing deployments
+// of the set when updated.
+var shapeAttributes = []string{
+	"name_pattern", "version", "elasticsearch_size", "elasticsearch_zone_count", "tags",
+}
+
+func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+	ms := newMembers(d.Get("deployments"))
+
+	err := apply(ctx, d, client, ms, d.HasChanges(shapeAttributes...))
+	if serr := d.Set("deployments", ms.flatten()); serr != nil {
+		return diag.FromErr(serr)
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return read(ctx, d, meta)
+}
+
+// missingDeploymentsDiff marks the deployments as changed when the number of
+// deployments or their names change, or when deployments of the set have
+// been deleted outside of Terraform so they're created again.
+func missingDeploymentsDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	deployments, _ := d.Get("deployments").([]interface{})
+	if d.HasChanges("deployment_count", "name_pattern") || len(deployments) != d.Get("deployment_count").(int) {
+		return d.SetNewComputed("deployments")
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymenttagresource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_read(t *testing.T) {
+	id := tagID(mock.ValidClusterID, "owner")
+	newRD := func(value string) *schema.ResourceData {
+		return util.NewResourceData(t, util.ResDataParams{
+			ID:     id,
+			Schema: newSchema(),
+			State: map[string]interface{}{
+				"deployment_id": mock.ValidClusterID,
+				"key":           "owner",
+				"value":         value,
+			},
+		})
+	}
+
+	wantTC404 := newRD("search-team")
+	wantTC404.SetId("")
+	wantRemoved := newRD("search-team")
+	wantRemoved.SetId("")
+
+	type args struct {
+		d    *schema.ResourceData
+		meta interface{}
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   diag.Diagnostics
+		wantRD *schema.ResourceData
+	}{
+		{
+			name: "returns an error when it receives a 500",
+			args: args{
+				d: newRD("search-team"),
+				meta: util.NewMockMeta(mock.NewErrorResponse(500, mock.APIError{
+					Code: "some", Message: "message",
+				})),
+			},
+			want: diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "failed reading deployment tag: 1 error occurred:\n\t* api error: some: message\n\n",
+			}},
+			wantRD: newRD("search-team"),
+		},
+		{
+			name: "unsets the state when the deployment is not found",
+			args: args{
+				d: newRD("search-team"),
+				meta: util.NewMockMeta(mock.NewErrorResponse(404, mock.APIError{
+					Code: "some", Message: "message",
+				})),
+			},
+			wantRD: wantTC404,
+		},
+		{
+			name: "unsets the state when the tag has been removed",
+			args: args{
+				d: newRD("search-team"),
+				meta: util.NewMockMeta(mock.New200StructResponse(models.DeploymentGetResponse{
+					ID:       ec.String(mock.ValidClusterID),
+					Metadata: &models.DeploymentMetadata{},
+				})),
+			},
+			wantRD: wantRemoved,
+		},
+		{
+			name: "updates the value when it has changed",
+			args: args{
+				d: newRD("search-team"),
+				meta: util.NewMockMeta(mock.New200StructResponse(models.DeploymentGetResponse{
+					ID: ec.String(mock.ValidClusterID),
+					Metadata: &models.DeploymentMetadata{Tags: []*models.MetadataItem{
+						{Key: ec.String("env"), Value: ec.String("prod")},
+						{Key: ec.String("owner"), Value: ec.String("platform-team")},
+					}},
+				})),
+			},
+			wantRD: newRD("platform-team"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := read(context.Background(), tt.args.d, tt.args.meta)
+			assert.Equal(t, tt.want, got)
+
+			var want interface{}
+			if s := tt.wantRD.State(); s != nil {
+				want = s.Attributes
+			}
+
+			var gotState interface{}
+			if s := tt.args.d.State(); s != nil {
+				gotState = s.Attributes
+			}
+
+			assert.Equal(t, want, gotState)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymenttagresource
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// tagID returns the resource ID from the deployment ID and the tag key.
+func tagID(deploymentID, key string) string {
+	return deploymentID + "/" + key
+}
+
+// parseTagID returns the deployment ID and tag key from the resource ID.
+func parseTagID(id string) (deploymentID, key string, err error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf(
+			`invalid ID "%s": expected format <deployment_id>/<key>`, id,
+		)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// getDeployment obtains the deployment with only its metadata.
+func getDeployment(client *api.API, id string) (*models.DeploymentGetResponse, error) {
+	return deploymentapi.Get(deploymentapi.GetParams{
+		API: client, DeploymentID: id,
+		QueryParams: deputil.QueryParams{ShowMetadata: true},
+	})
+}
+
+// getTag returns the value of the tag key set in the deployment, and whether
+// the key is set at all.
+func getTag(res *models.DeploymentGetResponse, key string) (string, bool) {
+	if res.Metadata == nil {
+		return "", false
+	}
+
+	for _, tag := range res.Metadata.Tags {
+		if tag.Key != nil && *tag.Key == key {
+			var value string
+			if tag.Value != nil {
+				value = *tag.Value
+			}
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// setTag returns the deployment tags with the key set to the value. When the
+// value is nil, the key is removed instead.
+func setTag(tags []*models.MetadataItem, key string, value *string) []*models.MetadataItem {
+	var result = make([]*models.MetadataItem, 0, len(tags)+1)
+	for _, tag := range tags {
+		if tag.Key != nil && *tag.Key == key {
+			continue
+		}
+		result = append(result, tag)
+	}
+
+	if value != nil {
+		result = append(result, &models.MetadataItem{
+			Key: ec.String(key), Value: value,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return *result[i].Key < *result[j].Key
+	})
+
+	return result
+}
+
+// deploymentLocks serializes the tag updates of each deployment, so tags of
+// the same deployment managed by different resources aren't lost when they
+// are created or destroyed concurrently.
+var deploymentLocks = struct {
+	sync.Mutex
+	locks map[string]*sync.Mutex
+}{locks: make(map[string]*sync.Mutex)}
+
+// lockDeployment locks the tag updates of the deployment and returns the
+// function which unlocks them.
+func lockDeployment(deploymentID string) func() {
+	deploymentLocks.Lock()
+	mu, ok := deploymentLocks.locks[deploymentID]
+	if !ok {
+		mu = new(sync.Mutex)
+		deploymentLocks.locks[deploymentID] = mu
+	}
+	deploymentLocks.Unlock()
+
+	mu.Lock()
+	return mu.Unlock
+}
+
+// UpdateTag reads the deployment tags and updates the deployment with the
+// key set to the value, or removed when the value is nil. Since the API has
+// no tag specific endpoints, the whole set of tags is sent, any other tags
+// set on the deployment are preserved. The updates of the same deployment
+// are serialized, though changes made outside of the provider between the
+// read and the update are still overwritten.
+func UpdateTag(client *api.API, deploymentID, key string, value *string) error {
+	defer lockDeployment(deploymentID)()
+
+	res, err := getDeployment(client, deploymentID)
+	if err != nil {
+		return err
+	}
+
+	var tags []*models.MetadataItem
+	if res.Metadata != nil {
+		tags = res.Metadata.Tags
+	}
+
+	var name string
+	if res.Name != nil {
+		name = *res.Name
+	}
+
+	_, err = deploymentapi.Update(deploymentapi.UpdateParams{
+		API:          client,
+		DeploymentID: deploymentID,
+		Request: &models.DeploymentUpdateRequest{
+			Name:         name,
+			Alias:        res.Alias,
+			PruneOrphans: ec.Bool(false),
+			Metadata: &models.DeploymentUpdateMetadata{
+				Tags: setTag(tags, key, value),
+			},
+		},
+	})
+	return err
+}
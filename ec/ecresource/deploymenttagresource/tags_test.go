@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymenttagresource
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseTagID(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		wantDeploymentID string
+		wantKey          string
+		err              error
+	}{
+		{
+			name:             "parses the deployment ID and key",
+			id:               "320b7b540dfc967a7a649c18e2fce4ed/owner",
+			wantDeploymentID: "320b7b540dfc967a7a649c18e2fce4ed",
+			wantKey:          "owner",
+		},
+		{
+			name:             "keeps any slashes in the key",
+			id:               "320b7b540dfc967a7a649c18e2fce4ed/team/owner",
+			wantDeploymentID: "320b7b540dfc967a7a649c18e2fce4ed",
+			wantKey:          "team/owner",
+		},
+		{
+			name: "fails when there's no key",
+			id:   "320b7b540dfc967a7a649c18e2fce4ed",
+			err:  errors.New(`invalid ID "320b7b540dfc967a7a649c18e2fce4ed": expected format <deployment_id>/<key>`),
+		},
+		{
+			name: "fails when the key is empty",
+			id:   "320b7b540dfc967a7a649c18e2fce4ed/",
+			err:  errors.New(`invalid ID "320b7b540dfc967a7a649c18e2fce4ed/": expected format <deployment_id>/<key>`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deploymentID, key, err := parseTagID(tt.id)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.wantDeploymentID, deploymentID)
+			assert.Equal(t, tt.wantKey, key)
+		})
+	}
+}
+
+func Test_setTag(t *testing.T) {
+	existing := []*models.MetadataItem{
+		{Key: ec.String("owner"), Value: ec.String("search-team")},
+		{Key: ec.String("env"), Value: ec.String("prod")},
+	}
+	type args struct {
+		tags  []*models.MetadataItem
+		key   string
+		value *string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*models.MetadataItem
+	}{
+		{
+			name: "adds the tag to empty tags",
+			args: args{key: "owner", value: ec.String("search-team")},
+			want: []*models.MetadataItem{
+				{Key: ec.String("owner"), Value: ec.String("search-team")},
+			},
+		},
+		{
+			name: "adds the tag preserving the existing ones",
+			args: args{tags: existing, key: "cost-center", value: ec.String("1234")},
+			want: []*models.MetadataItem{
+				{Key: ec.String("cost-center"), Value: ec.String("1234")},
+				{Key: ec.String("env"), Value: ec.String("prod")},
+				{Key: ec.String("owner"), Value: ec.String("search-team")},
+			},
+		},
+		{
+			name: "replaces the value of an existing tag",
+			args: args{tags: existing, key: "owner", value: ec.String("platform-team")},
+			want: []*models.MetadataItem{
+				{Key: ec.String("env"), Value: ec.String("prod")},
+				{Key: ec.String("owner"), Value: ec.String("platform-team")},
+			},
+		},
+		{
+			name: "removes the tag when the value is nil",
+			args: args{tags: existing, key: "owner"},
+			want: []*models.MetadataItem{
+				{Key: ec.String("env"), Value: ec.String("prod")},
+			},
+		},
+		{
+			name: "removing a tag which isn't set returns an empty slice",
+			args: args{key: "owner"},
+			want: []*models.MetadataItem{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := setTag(tt.args.tags, tt.args.key, tt.args.value)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_lockDeployment(t *testing.T) {
+	unlock := lockDeployment("320b7b540dfc967a7a649c18e2fce4ed")
+
+	t.Run("doesn't block other deployments", func(t *testing.T) {
+		lockDeployment("d3d14b7b540dfc967a7a649c18e2fce4")()
+	})
+
+	var wg sync.WaitGroup
+	var locked = make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer lockDeployment("320b7b540dfc967a7a649c18e2fce4ed")()
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("the deployment was locked twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	wg.Wait()
+	<-locked
+}
@@ -22,16 +22,17 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/eskeystoreapi"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
 )
 
 // create will create an item in the Elasticsearch keystore
 func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api.API)
+	client := meta.(*util.ProviderMeta).API
 	deploymentID := d.Get("deployment_id").(string)
 	settingName := d.Get("setting_name").(string)
 
@@ -20,16 +20,17 @@ package elasticsearchkeystoreresource
 import (
 	"context"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/eskeystoreapi"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
 )
 
 // delete will delete an existing element in the Elasticsearch keystore
 func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api.API)
+	client := meta.(*util.ProviderMeta).API
 	contents := expandModel(d)
 	settingName := d.Get("setting_name").(string)
 
@@ -20,17 +20,18 @@ package elasticsearchkeystoreresource
 import (
 	"context"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/eskeystoreapi"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
 )
 
 // read queries the remote Elasticsearch keystore state and updates the local state.
 func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var client = meta.(*api.API)
+	var client = meta.(*util.ProviderMeta).API
 	deploymentID := d.Get("deployment_id").(string)
 
 	res, err := eskeystoreapi.Get(eskeystoreapi.GetParams{
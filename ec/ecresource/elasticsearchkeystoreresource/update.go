@@ -20,16 +20,17 @@ package elasticsearchkeystoreresource
 import (
 	"context"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/eskeystoreapi"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
 )
 
 // update will update an existing element in the Elasticsearch keystore
 func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var client = meta.(*api.API)
+	var client = meta.(*util.ProviderMeta).API
 	deploymentID := d.Get("deployment_id").(string)
 
 	_, err := eskeystoreapi.Update(eskeystoreapi.UpdateParams{
@@ -21,7 +21,6 @@ import (
 	"context"
 	"testing"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -57,7 +56,7 @@ func Test_createResource(t *testing.T) {
 			name: "returns an error when it receives a 500",
 			args: args{
 				d: tc500Err,
-				meta: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				meta: util.NewMockMeta(mock.NewErrorResponse(500, mock.APIError{
 					Code: "some", Message: "message",
 				})),
 			},
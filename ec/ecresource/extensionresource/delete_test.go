@@ -21,7 +21,6 @@ import (
 	"context"
 	"testing"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -81,7 +80,7 @@ func Test_deleteResource(t *testing.T) {
 			name: "returns nil when it receives a 200",
 			args: args{
 				d:    tc200,
-				meta: api.NewMock(mock.New200Response(nil)),
+				meta: util.NewMockMeta(mock.New200Response(nil)),
 			},
 			want:   nil,
 			wantRD: wantTC200,
@@ -90,7 +89,7 @@ func Test_deleteResource(t *testing.T) {
 			name: "returns an error when it receives a 500",
 			args: args{
 				d: tc500Err,
-				meta: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				meta: util.NewMockMeta(mock.NewErrorResponse(500, mock.APIError{
 					Code: "some", Message: "message",
 				})),
 			},
@@ -106,7 +105,7 @@ func Test_deleteResource(t *testing.T) {
 			name: "returns nil and unsets the state when the error is known",
 			args: args{
 				d: tc404Err,
-				meta: api.NewMock(mock.NewErrorResponse(404, mock.APIError{
+				meta: util.NewMockMeta(mock.NewErrorResponse(404, mock.APIError{
 					Code: "some", Message: "message",
 				})),
 			},
@@ -25,7 +25,6 @@ import (
 
 	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -86,7 +85,7 @@ func Test_readResource(t *testing.T) {
 			name: "returns nil when it receives a 200",
 			args: args{
 				d: tc200,
-				meta: api.NewMock(mock.New200StructResponse(models.Extension{
+				meta: util.NewMockMeta(mock.New200StructResponse(models.Extension{
 					Name:          ec.String("my_extension"),
 					ExtensionType: ec.String("bundle"),
 					Description:   "my description",
@@ -106,7 +105,7 @@ func Test_readResource(t *testing.T) {
 			name: "returns an error when it receives a 500",
 			args: args{
 				d: tc500Err,
-				meta: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				meta: util.NewMockMeta(mock.NewErrorResponse(500, mock.APIError{
 					Code: "some", Message: "message",
 				})),
 			},
@@ -122,7 +121,7 @@ func Test_readResource(t *testing.T) {
 			name: "returns nil and unsets the state when the error is known",
 			args: args{
 				d: tc404Err,
-				meta: api.NewMock(mock.NewErrorResponse(404, mock.APIError{
+				meta: util.NewMockMeta(mock.NewErrorResponse(404, mock.APIError{
 					Code: "some", Message: "message",
 				})),
 			},
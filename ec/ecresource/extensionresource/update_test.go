@@ -21,7 +21,6 @@ import (
 	"context"
 	"testing"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
@@ -88,7 +87,7 @@ func Test_updateResource(t *testing.T) {
 			name: "returns nil when it receives a 200 without file_path",
 			args: args{
 				d: tc200withoutFilePath,
-				meta: api.NewMock(
+				meta: util.NewMockMeta(
 					mock.New200StructResponse(models.Extension{ // update request response
 						Name:          ec.String("updated_extension"),
 						ExtensionType: ec.String("bundle"),
@@ -122,7 +121,7 @@ func Test_updateResource(t *testing.T) {
 			name: "returns nil when it receives a 200 with file_path",
 			args: args{
 				d: tc200withFilePath,
-				meta: api.NewMock(
+				meta: util.NewMockMeta(
 					mock.New200StructResponse(models.Extension{ // update request response
 						Name:          ec.String("updated_extension"),
 						ExtensionType: ec.String("bundle"),
@@ -157,7 +156,7 @@ func Test_updateResource(t *testing.T) {
 			name: "returns an error when it receives a 500",
 			args: args{
 				d: tc500Err,
-				meta: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				meta: util.NewMockMeta(mock.NewErrorResponse(500, mock.APIError{
 					Code: "some", Message: "message",
 				})),
 			},
@@ -0,0 +1,58 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fleetenrollmenttokenresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// createResource creates the Fleet enrollment API key, using its ID as the
+// resource ID. The deployment is checked to have a Fleet Server first, since
+// the enrollment token is useless without it.
+func createResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+	deploymentID := d.Get("deployment_id").(string)
+
+	res, err := getDeployment(client, deploymentID)
+	if err != nil {
+		return diag.FromErr(multierror.NewPrefixed("failed creating fleet enrollment token", err))
+	}
+
+	if _, err := fleetURL(res); err != nil {
+		return diag.FromErr(multierror.NewPrefixed("failed creating fleet enrollment token", err))
+	}
+
+	key, err := createEnrollmentKey(ctx, client, deploymentID,
+		d.Get("kibana_ref_id").(string), enrollmentKeyRequest{
+			PolicyID: d.Get("policy_id").(string),
+			Name:     d.Get("name").(string),
+		},
+	)
+	if err != nil {
+		return diag.FromErr(multierror.NewPrefixed("failed creating fleet enrollment token", err))
+	}
+
+	d.SetId(key.ID)
+	return readResource(ctx, d, meta)
+}
@@ -0,0 +1,125 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fleetenrollmenttokenresource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+// enrollmentKeysPath is the Fleet API path of the enrollment API keys.
+const enrollmentKeysPath = "api/fleet/enrollment_api_keys"
+
+// enrollmentKey is a Fleet enrollment API key.
+type enrollmentKey struct {
+	ID       string `json:"id"`
+	APIKey   string `json:"api_key"`
+	Name     string `json:"name"`
+	PolicyID string `json:"policy_id"`
+	Active   bool   `json:"active"`
+}
+
+// enrollmentKeyRequest is the Fleet enrollment API key creation request.
+type enrollmentKeyRequest struct {
+	PolicyID string `json:"policy_id"`
+	Name     string `json:"name,omitempty"`
+}
+
+// enrollmentKeyResponse is the response returned by the Fleet API for a single
+// enrollment API key.
+type enrollmentKeyResponse struct {
+	Item *enrollmentKey `json:"item"`
+}
+
+// createEnrollmentKey creates a Fleet enrollment API key assigned to the
+// agent policy.
+func createEnrollmentKey(ctx context.Context, client *api.API, deploymentID, refID string, req enrollmentKeyRequest) (*enrollmentKey, error) {
+	b, err := proxyRequest(ctx, client, http.MethodPost,
+		deploymentID, refID, enrollmentKeysPath, req,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseEnrollmentKey(b)
+}
+
+// getEnrollmentKey obtains a Fleet enrollment API key.
+func getEnrollmentKey(ctx context.Context, client *api.API, deploymentID, refID, id string) (*enrollmentKey, error) {
+	b, err := proxyRequest(ctx, client, http.MethodGet,
+		deploymentID, refID, enrollmentKeysPath+"/"+id, nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseEnrollmentKey(b)
+}
+
+// deleteEnrollmentKey revokes and deletes a Fleet enrollment API key.
+func deleteEnrollmentKey(ctx context.Context, client *api.API, deploymentID, refID, id string) error {
+	_, err := proxyRequest(ctx, client, http.MethodDelete,
+		deploymentID, refID, enrollmentKeysPath+"/"+id, nil,
+	)
+	return err
+}
+
+func parseEnrollmentKey(b []byte) (*enrollmentKey, error) {
+	var res enrollmentKeyResponse
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, fmt.Errorf("failed parsing the enrollment API key: %w", err)
+	}
+
+	if res.Item == nil {
+		return nil, errors.New("the Fleet API returned no enrollment API key")
+	}
+
+	return res.Item, nil
+}
+
+// getDeployment obtains the deployment without any of its plans.
+func getDeployment(client *api.API, id string) (*models.DeploymentGetResponse, error) {
+	return deploymentapi.Get(deploymentapi.GetParams{
+		API: client, DeploymentID: id,
+	})
+}
+
+// fleetURL returns the Fleet Server URL of the deployment Integrations Server.
+func fleetURL(res *models.DeploymentGetResponse) (string, error) {
+	if res.Resources != nil {
+		for _, is := range res.Resources.IntegrationsServer {
+			if is.Info == nil || is.Info.Metadata == nil {
+				continue
+			}
+			for _, url := range is.Info.Metadata.ServicesUrls {
+				if url.Service != nil && *url.Service == "fleet" && url.URL != nil {
+					return *url.URL, nil
+				}
+			}
+		}
+	}
+
+	return "", errors.New("the deployment has no Integrations Server with a Fleet Server URL")
+}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fleetenrollmenttokenresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDeployment(urls ...*models.ServiceURL) *models.DeploymentGetResponse {
+	return &models.DeploymentGetResponse{
+		Resources: &models.DeploymentResources{
+			IntegrationsServer: []*models.IntegrationsServerResourceInfo{{
+				Info: &models.IntegrationsServerInfo{
+					Metadata: &models.ClusterMetadataInfo{ServicesUrls: urls},
+				},
+			}},
+		},
+	}
+}
+
+func Test_fleetURL(t *testing.T) {
+	tests := []struct {
+		name string
+		res  *models.DeploymentGetResponse
+		want string
+		err  error
+	}{
+		{
+			name: "returns the fleet service URL",
+			res: newDeployment(
+				&models.ServiceURL{Service: ec.String("apm"), URL: ec.String("https://my-deployment.apm.us-east-1.aws.found.io")},
+				&models.ServiceURL{Service: ec.String("fleet"), URL: ec.String("https://my-deployment.fleet.us-east-1.aws.found.io")},
+			),
+			want: "https://my-deployment.fleet.us-east-1.aws.found.io",
+		},
+		{
+			name: "fails when the integrations server has no fleet service",
+			res: newDeployment(
+				&models.ServiceURL{Service: ec.String("apm"), URL: ec.String("https://my-deployment.apm.us-east-1.aws.found.io")},
+			),
+			err: errors.New("the deployment has no Integrations Server with a Fleet Server URL"),
+		},
+		{
+			name: "fails when the deployment has no integrations server",
+			res:  &models.DeploymentGetResponse{Resources: &models.DeploymentResources{}},
+			err:  errors.New("the deployment has no Integrations Server with a Fleet Server URL"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fleetURL(tt.res)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseEnrollmentKey(t *testing.T) {
+	got, err := parseEnrollmentKey([]byte(`{"item":{"id":"some-id","api_key":"some-key","name":"terraform","policy_id":"fleet-server-policy","active":true,"created_at":"2022-11-08T10:25:12.000Z"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &enrollmentKey{
+		ID:       "some-id",
+		APIKey:   "some-key",
+		Name:     "terraform",
+		PolicyID: "fleet-server-policy",
+		Active:   true,
+	}, got)
+
+	_, err = parseEnrollmentKey([]byte(`{}`))
+	assert.EqualError(t, err, "the Fleet API returned no enrollment API key")
+}
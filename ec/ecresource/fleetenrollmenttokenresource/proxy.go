@@ -0,0 +1,140 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fleetenrollmenttokenresource
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+// proxyRequest sends a request to the deployment Kibana through the Elastic
+// Cloud API proxy, returning the raw response body. The generated client
+// decodes the proxied responses as a GenericResponse, which drops the Kibana
+// response fields, so the response is read as is instead.
+func proxyRequest(ctx context.Context, client *api.API, method, deploymentID, refID, path string, body interface{}) ([]byte, error) {
+	var res []byte
+	opts := []deployments.ClientOption{
+		withKibanaRequest(body), withRawResponse(&res),
+	}
+
+	var err error
+	switch method {
+	case http.MethodPost:
+		_, err = client.V1API.Deployments.PostDeploymentResourceProxyRequests(
+			deployments.NewPostDeploymentResourceProxyRequestsParams().
+				WithContext(ctx).
+				WithDeploymentID(deploymentID).
+				WithResourceKind("kibana").
+				WithRefID(refID).
+				WithProxyPath(path).
+				WithXManagementRequest("true"),
+			client.AuthWriter, opts...,
+		)
+	case http.MethodDelete:
+		_, err = client.V1API.Deployments.DeleteDeploymentResourceProxyRequests(
+			deployments.NewDeleteDeploymentResourceProxyRequestsParams().
+				WithContext(ctx).
+				WithDeploymentID(deploymentID).
+				WithResourceKind("kibana").
+				WithRefID(refID).
+				WithProxyPath(path).
+				WithXManagementRequest("true"),
+			client.AuthWriter, opts...,
+		)
+	default:
+		_, err = client.V1API.Deployments.GetDeploymentResourceProxyRequests(
+			deployments.NewGetDeploymentResourceProxyRequestsParams().
+				WithContext(ctx).
+				WithDeploymentID(deploymentID).
+				WithResourceKind("kibana").
+				WithRefID(refID).
+				WithProxyPath(path).
+				WithXManagementRequest("true"),
+			client.AuthWriter, opts...,
+		)
+	}
+
+	return res, err
+}
+
+// withKibanaRequest sets the "kbn-xsrf" header required by the Kibana APIs
+// and, when set, sends the body as JSON rather than as a JSON string.
+func withKibanaRequest(body interface{}) deployments.ClientOption {
+	return func(op *runtime.ClientOperation) {
+		params := op.Params
+		op.Params = runtime.ClientRequestWriterFunc(func(req runtime.ClientRequest, reg strfmt.Registry) error {
+			if err := params.WriteToRequest(req, reg); err != nil {
+				return err
+			}
+
+			if err := req.SetHeaderParam("kbn-xsrf", "true"); err != nil {
+				return err
+			}
+
+			if body == nil {
+				return nil
+			}
+
+			b, err := json.Marshal(body)
+			if err != nil {
+				return err
+			}
+			return req.SetBodyParam(json.RawMessage(b))
+		})
+	}
+}
+
+// withRawResponse reads the proxied response body into res, returning a
+// *runtime.APIError with the body when the response isn't successful.
+func withRawResponse(res *[]byte) deployments.ClientOption {
+	return func(op *runtime.ClientOperation) {
+		op.Reader = runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, _ runtime.Consumer) (interface{}, error) {
+			b, err := io.ReadAll(response.Body())
+			if err != nil {
+				return nil, err
+			}
+
+			if response.Code() >= http.StatusMultipleChoices {
+				return nil, runtime.NewAPIError(op.ID, string(b), response.Code())
+			}
+
+			*res = b
+			return successResponse(op.ID), nil
+		})
+	}
+}
+
+// successResponse returns the typed success response expected by the
+// generated client method, which panics on any other type.
+func successResponse(id string) interface{} {
+	switch id {
+	case "post-deployment-resource-proxy-requests":
+		return &deployments.PostDeploymentResourceProxyRequestsOK{}
+	case "delete-deployment-resource-proxy-requests":
+		return &deployments.DeleteDeploymentResourceProxyRequestsOK{}
+	default:
+		return &deployments.GetDeploymentResourceProxyRequestsOK{}
+	}
+}
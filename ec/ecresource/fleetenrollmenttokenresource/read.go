@@ -0,0 +1,89 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fleetenrollmenttokenresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func readResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+	deploymentID := d.Get("deployment_id").(string)
+
+	res, err := getDeployment(client, deploymentID)
+	if err != nil {
+		if util.DeploymentNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(multierror.NewPrefixed("failed reading fleet enrollment token", err))
+	}
+
+	// The enrollment token is gone along with the Integrations Server.
+	url, err := fleetURL(res)
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	key, err := getEnrollmentKey(ctx, client,
+		deploymentID, d.Get("kibana_ref_id").(string), d.Id(),
+	)
+	if err != nil {
+		if apierror.IsRuntimeStatusCode(err, 404) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(multierror.NewPrefixed("failed reading fleet enrollment token", err))
+	}
+
+	// Revoked enrollment tokens can't be used to enroll agents anymore.
+	if !key.Active {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("fleet_url", url); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("api_key_id", key.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("api_key", key.APIKey); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("name", key.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("policy_id", key.PolicyID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
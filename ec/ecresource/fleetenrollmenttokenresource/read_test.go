@@ -0,0 +1,96 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fleetenrollmenttokenresource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_readResource(t *testing.T) {
+	newRD := func() *schema.ResourceData {
+		return util.NewResourceData(t, util.ResDataParams{
+			ID:     "some-id",
+			Schema: newSchema(),
+			State: map[string]interface{}{
+				"deployment_id": mock.ValidClusterID,
+				"policy_id":     "fleet-server-policy",
+				"kibana_ref_id": "main-kibana",
+			},
+		})
+	}
+	deployment := mock.New200StructResponse(newDeployment(&models.ServiceURL{
+		Service: ec.String("fleet"),
+		URL:     ec.String("https://my-deployment.fleet.us-east-1.aws.found.io"),
+	}))
+
+	t.Run("reads the enrollment token and the fleet URL", func(t *testing.T) {
+		d := newRD()
+		got := readResource(context.Background(), d, util.NewMockMeta(deployment,
+			mock.New200Response(mock.NewStringBody(
+				`{"item":{"id":"some-id","api_key":"some-key","name":"terraform","policy_id":"fleet-server-policy","active":true}}`,
+			)),
+		))
+		assert.Nil(t, got)
+		assert.Equal(t, "some-id", d.Id())
+		assert.Equal(t, "https://my-deployment.fleet.us-east-1.aws.found.io", d.Get("fleet_url"))
+		assert.Equal(t, "some-key", d.Get("api_key"))
+		assert.Equal(t, "terraform", d.Get("name"))
+	})
+
+	t.Run("unsets the id when the enrollment token has been revoked", func(t *testing.T) {
+		d := newRD()
+		got := readResource(context.Background(), d, util.NewMockMeta(deployment,
+			mock.New200Response(mock.NewStringBody(
+				`{"item":{"id":"some-id","api_key":"some-key","policy_id":"fleet-server-policy","active":false}}`,
+			)),
+		))
+		assert.Nil(t, got)
+		assert.Equal(t, "", d.Id())
+	})
+
+	t.Run("unsets the id when the enrollment token doesn't exist", func(t *testing.T) {
+		d := newRD()
+		got := readResource(context.Background(), d, util.NewMockMeta(deployment,
+			mock.New404Response(mock.NewStringBody(`{"statusCode":404,"error":"Not Found"}`)),
+		))
+		assert.Nil(t, got)
+		assert.Equal(t, "", d.Id())
+	})
+
+	t.Run("returns an error when the deployment can't be read", func(t *testing.T) {
+		d := newRD()
+		got := readResource(context.Background(), d, util.NewMockMeta(
+			mock.NewErrorResponse(500, mock.APIError{Code: "some", Message: "message"}),
+		))
+		assert.Equal(t, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "failed reading fleet enrollment token: 1 error occurred:\n\t* api error: some: message\n\n",
+		}}, got)
+		assert.Equal(t, "some-id", d.Id())
+	})
+}
@@ -0,0 +1,76 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fleetenrollmenttokenresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// newSchema returns the schema for an "ec_deployment_fleet_enrollment_token"
+// resource.
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"deployment_id": {
+			Type:         schema.TypeString,
+			Description:  "Required ID of the deployment, which must have an Integrations Server",
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"policy_id": {
+			Type:         schema.TypeString,
+			Description:  "Required ID of the Fleet agent policy the enrolled agents are assigned to",
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Optional name of the enrollment token, Fleet generates one when not set",
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+		},
+		"kibana_ref_id": {
+			Type:        schema.TypeString,
+			Description: `Optional ref_id of the Kibana resource the Fleet API is requested through, defaults to "main-kibana"`,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     "main-kibana",
+		},
+
+		// Computed
+		"fleet_url": {
+			Type:        schema.TypeString,
+			Description: "Computed Fleet Server URL the agents enroll against",
+			Computed:    true,
+		},
+		"api_key_id": {
+			Type:        schema.TypeString,
+			Description: "Computed ID of the enrollment API key",
+			Computed:    true,
+		},
+		"api_key": {
+			Type:        schema.TypeString,
+			Description: "Computed enrollment token, passed to the agents as --enrollment-token",
+			Computed:    true,
+			Sensitive:   true,
+		},
+	}
+}
@@ -0,0 +1,84 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package instanceconfigurationresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/instanceconfigapi"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// createResource creates the instance configuration, using the ID generated
+// by the API as the resource ID.
+func createResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+
+	cfg, err := expandInstanceConfiguration(d)
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed creating instance configuration", err),
+		)
+	}
+
+	res, err := instanceconfigapi.Create(instanceconfigapi.CreateParams{
+		API:    client,
+		Config: cfg,
+		Region: configurationRegion(d),
+	})
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed creating instance configuration", err),
+		)
+	}
+
+	if res.ID != nil {
+		d.SetId(*res.ID)
+	}
+
+	return readResource(ctx, d, meta)
+}
+
+func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+
+	cfg, err := expandInstanceConfiguration(d)
+	if err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed updating instance configuration", err),
+		)
+	}
+
+	cfg.ID = d.Id()
+	if err := instanceconfigapi.Update(instanceconfigapi.UpdateParams{
+		API:    client,
+		ID:     d.Id(),
+		Config: cfg,
+		Region: configurationRegion(d),
+	}); err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed updating instance configuration", err),
+		)
+	}
+
+	return readResource(ctx, d, meta)
+}
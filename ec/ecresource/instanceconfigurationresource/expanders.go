@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package instanceconfigurationresource
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deploymentsize"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// expandInstanceConfiguration expands the resource attributes into the
+// instance configuration model.
+func expandInstanceConfiguration(d *schema.ResourceData) (*models.InstanceConfiguration, error) {
+	sizes, err := expandSizes(d.Get("sizes").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	defaultSize, err := deploymentsize.ParseGb(d.Get("default_size").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	if !containsSize(sizes, defaultSize) {
+		return nil, fmt.Errorf(
+			`default_size "%s" must be one of the sizes`, d.Get("default_size"),
+		)
+	}
+
+	res := models.InstanceConfiguration{
+		Name:         ec.String(d.Get("name").(string)),
+		Description:  d.Get("description").(string),
+		InstanceType: ec.String(d.Get("instance_type").(string)),
+		DiscreteSizes: &models.DiscreteSizes{
+			DefaultSize: ec.Int32(defaultSize),
+			Resource:    ec.String(d.Get("size_resource").(string)),
+			Sizes:       sizes,
+		},
+	}
+
+	for _, nodeType := range d.Get("node_types").([]interface{}) {
+		if t, ok := nodeType.(string); ok && t != "" {
+			res.NodeTypes = append(res.NodeTypes, t)
+		}
+	}
+
+	if v, ok := d.GetOk("storage_multiplier"); ok {
+		res.StorageMultiplier = v.(float64)
+	}
+
+	if v, ok := d.GetOk("cpu_multiplier"); ok {
+		res.CPUMultiplier = v.(float64)
+	}
+
+	if v, ok := d.GetOk("max_zones"); ok {
+		res.MaxZones = int32(v.(int))
+	}
+
+	if filter, ok := d.Get("allocator_filter").(string); ok && filter != "" {
+		var query models.QueryContainer
+		if err := json.Unmarshal([]byte(filter), &query); err != nil {
+			return nil, fmt.Errorf("failed expanding allocator_filter: %w", err)
+		}
+		res.AllocatorFilter = &query
+	}
+
+	return &res, nil
+}
+
+// expandSizes parses the flattened sizes into their value in megabytes.
+func expandSizes(raw []interface{}) ([]int32, error) {
+	var sizes = make([]int32, 0, len(raw))
+	for _, rawSize := range raw {
+		size, err := deploymentsize.ParseGb(rawSize.(string))
+		if err != nil {
+			return nil, err
+		}
+		sizes = append(sizes, size)
+	}
+
+	return sizes, nil
+}
+
+func containsSize(sizes []int32, size int32) bool {
+	for _, s := range sizes {
+		if s == size {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package instanceconfigurationresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func newInstanceConfiguration() map[string]interface{} {
+	return map[string]interface{}{
+		"name":             "highcpu",
+		"description":      "High CPU Elasticsearch instances",
+		"instance_type":    "elasticsearch",
+		"node_types":       []interface{}{"data", "ingest", "master"},
+		"sizes":            []interface{}{"1g", "2g", "4g"},
+		"default_size":     "2g",
+		"region":           "ece-region",
+		"allocator_filter": `{"bool":{"must":[{"term":{"metadata.instance_family":{"value":"highcpu"}}}]}}`,
+	}
+}
+
+func Test_expandInstanceConfiguration(t *testing.T) {
+	tests := []struct {
+		name  string
+		state map[string]interface{}
+		want  *models.InstanceConfiguration
+		err   error
+	}{
+		{
+			name:  "expands the instance configuration",
+			state: newInstanceConfiguration(),
+			want: &models.InstanceConfiguration{
+				Name:         ec.String("highcpu"),
+				Description:  "High CPU Elasticsearch instances",
+				InstanceType: ec.String("elasticsearch"),
+				NodeTypes:    []string{"data", "ingest", "master"},
+				DiscreteSizes: &models.DiscreteSizes{
+					DefaultSize: ec.Int32(2048),
+					Resource:    ec.String("memory"),
+					Sizes:       []int32{1024, 2048, 4096},
+				},
+				AllocatorFilter: &models.QueryContainer{
+					Bool: &models.BoolQuery{
+						Must: []*models.QueryContainer{{
+							Term: map[string]models.TermQuery{
+								"metadata.instance_family": {Value: ec.String("highcpu")},
+							},
+						}},
+					},
+				},
+			},
+		},
+		{
+			name: "expands the multipliers and the max zones when set",
+			state: func() map[string]interface{} {
+				state := newInstanceConfiguration()
+				delete(state, "allocator_filter")
+				delete(state, "node_types")
+				state["size_resource"] = "storage"
+				state["storage_multiplier"] = 32.0
+				state["cpu_multiplier"] = 0.5
+				state["max_zones"] = 2
+				return state
+			}(),
+			want: &models.InstanceConfiguration{
+				Name:              ec.String("highcpu"),
+				Description:       "High CPU Elasticsearch instances",
+				InstanceType:      ec.String("elasticsearch"),
+				StorageMultiplier: 32,
+				CPUMultiplier:     0.5,
+				MaxZones:          2,
+				DiscreteSizes: &models.DiscreteSizes{
+					DefaultSize: ec.Int32(2048),
+					Resource:    ec.String("storage"),
+					Sizes:       []int32{1024, 2048, 4096},
+				},
+			},
+		},
+		{
+			name: "fails when the default size isn't one of the sizes",
+			state: func() map[string]interface{} {
+				state := newInstanceConfiguration()
+				state["default_size"] = "8g"
+				return state
+			}(),
+			err: errors.New(`default_size "8g" must be one of the sizes`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := util.NewResourceData(t, util.ResDataParams{
+				ID:     "highcpu",
+				State:  tt.state,
+				Schema: newSchema(),
+			})
+
+			got, err := expandInstanceConfiguration(d)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
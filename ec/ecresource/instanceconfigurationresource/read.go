@@ -0,0 +1,152 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package instanceconfigurationresource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/instanceconfigapi"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_configuration_instances"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func readResource(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+
+	res, err := instanceconfigapi.Get(instanceconfigapi.GetParams{
+		API:    client,
+		ID:     d.Id(),
+		Region: configurationRegion(d),
+	})
+	if err != nil {
+		if configurationNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(
+			multierror.NewPrefixed("failed reading instance configuration", err),
+		)
+	}
+
+	// Instance configurations are soft deleted, so they're still returned.
+	if res.DeletedOn != nil {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("region", configurationRegion(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := modelToState(d, res); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func modelToState(d *schema.ResourceData, res *models.InstanceConfiguration) error {
+	if res.Name != nil {
+		if err := d.Set("name", *res.Name); err != nil {
+			return err
+		}
+	}
+
+	if err := d.Set("description", res.Description); err != nil {
+		return err
+	}
+
+	if res.InstanceType != nil {
+		if err := d.Set("instance_type", *res.InstanceType); err != nil {
+			return err
+		}
+	}
+
+	if err := d.Set("node_types", res.NodeTypes); err != nil {
+		return err
+	}
+
+	if err := d.Set("storage_multiplier", res.StorageMultiplier); err != nil {
+		return err
+	}
+
+	if err := d.Set("cpu_multiplier", res.CPUMultiplier); err != nil {
+		return err
+	}
+
+	if err := d.Set("max_zones", int(res.MaxZones)); err != nil {
+		return err
+	}
+
+	if ds := res.DiscreteSizes; ds != nil {
+		if ds.DefaultSize != nil {
+			if err := d.Set("default_size", util.MemoryToState(*ds.DefaultSize)); err != nil {
+				return err
+			}
+		}
+
+		if ds.Resource != nil {
+			if err := d.Set("size_resource", *ds.Resource); err != nil {
+				return err
+			}
+		}
+
+		var sizes = make([]interface{}, 0, len(ds.Sizes))
+		for _, s := range ds.Sizes {
+			sizes = append(sizes, util.MemoryToState(s))
+		}
+		if err := d.Set("sizes", sizes); err != nil {
+			return err
+		}
+	}
+
+	var filter string
+	if res.AllocatorFilter != nil {
+		b, err := json.Marshal(res.AllocatorFilter)
+		if err != nil {
+			return err
+		}
+		filter = string(b)
+	}
+
+	return d.Set("allocator_filter", filter)
+}
+
+// configurationRegion returns the region of the instance configuration,
+// which isn't set in the state when the instance configuration is imported.
+func configurationRegion(d *schema.ResourceData) string {
+	if region, ok := d.Get("region").(string); ok && region != "" {
+		return region
+	}
+	return eceRegion
+}
+
+// configurationNotFound returns true when the error is the not found response
+// of either obtaining or deleting the instance configuration.
+func configurationNotFound(err error) bool {
+	var getNotFound *platform_configuration_instances.GetInstanceConfigurationNotFound
+	var deleteNotFound *platform_configuration_instances.DeleteInstanceConfigurationNotFound
+	return errors.As(err, &getNotFound) || errors.As(err, &deleteNotFound)
+}
@@ -0,0 +1,109 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package instanceconfigurationresource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_readResource(t *testing.T) {
+	newRD := func() *schema.ResourceData {
+		return util.NewResourceData(t, util.ResDataParams{
+			ID:     "highcpu",
+			State:  newInstanceConfiguration(),
+			Schema: newSchema(),
+		})
+	}
+
+	newModel := func() models.InstanceConfiguration {
+		return models.InstanceConfiguration{
+			ID:                "highcpu",
+			Name:              ec.String("highcpu"),
+			Description:       "High CPU Elasticsearch instances",
+			InstanceType:      ec.String("elasticsearch"),
+			NodeTypes:         []string{"data", "ingest", "master"},
+			StorageMultiplier: 32,
+			CPUMultiplier:     0.5,
+			MaxZones:          3,
+			DiscreteSizes: &models.DiscreteSizes{
+				DefaultSize: ec.Int32(2048),
+				Resource:    ec.String("memory"),
+				Sizes:       []int32{1024, 2048, 4096, 8192},
+			},
+		}
+	}
+
+	t.Run("refreshes the instance configuration", func(t *testing.T) {
+		d := newRD()
+		got := readResource(context.Background(), d, util.NewMockMeta(
+			mock.New200StructResponse(newModel()),
+		))
+		assert.Nil(t, got)
+		assert.Equal(t, "highcpu", d.Id())
+		assert.Equal(t, []interface{}{"1g", "2g", "4g", "8g"}, d.Get("sizes"))
+		assert.Equal(t, "2g", d.Get("default_size"))
+		assert.Equal(t, 32.0, d.Get("storage_multiplier"))
+		assert.Equal(t, 3, d.Get("max_zones"))
+		assert.Equal(t, "", d.Get("allocator_filter"))
+		assert.Equal(t, "ece-region", d.Get("region"))
+	})
+
+	t.Run("unsets the id when the instance configuration has been deleted", func(t *testing.T) {
+		d := newRD()
+		deleted := newModel()
+		deletedOn := strfmt.DateTime{}
+		deleted.DeletedOn = &deletedOn
+		got := readResource(context.Background(), d, util.NewMockMeta(
+			mock.New200StructResponse(deleted),
+		))
+		assert.Nil(t, got)
+		assert.Equal(t, "", d.Id())
+	})
+
+	t.Run("unsets the id when the instance configuration doesn't exist", func(t *testing.T) {
+		d := newRD()
+		got := readResource(context.Background(), d, util.NewMockMeta(
+			mock.NewErrorResponse(404, mock.APIError{Code: "some", Message: "message"}),
+		))
+		assert.Nil(t, got)
+		assert.Equal(t, "", d.Id())
+	})
+
+	t.Run("returns an error when it receives a 500", func(t *testing.T) {
+		d := newRD()
+		got := readResource(context.Background(), d, util.NewMockMeta(
+			mock.NewErrorResponse(500, mock.APIError{Code: "some", Message: "message"}),
+		))
+		assert.Equal(t, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "failed reading instance configuration: 1 error occurred:\n\t* api error: some: message\n\n",
+		}}, got)
+		assert.Equal(t, "highcpu", d.Id())
+	})
+}
@@ -0,0 +1,116 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package instanceconfigurationresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// eceRegion is the region of all the Elastic Cloud Enterprise installations.
+const eceRegion = "ece-region"
+
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Description:  "Required name of the instance configuration",
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Description: "Optional description of the instance configuration",
+			Optional:    true,
+		},
+		"instance_type": {
+			Type:        schema.TypeString,
+			Description: "Required type of the instances, one of elasticsearch, kibana, apm, integrations_server or enterprise_search",
+			Required:    true,
+			ForceNew:    true,
+			ValidateFunc: validation.StringInSlice([]string{
+				"elasticsearch", "kibana", "apm", "integrations_server", "enterprise_search",
+			}, false),
+		},
+		"node_types": {
+			Type:        schema.TypeList,
+			Description: "Optional node types supported by the Elasticsearch instances, such as master, data, ingest or ml",
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"storage_multiplier": {
+			Type:         schema.TypeFloat,
+			Description:  "Optional ratio between the instances storage and memory, computed by the API when not set",
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.FloatAtLeast(0),
+		},
+		"cpu_multiplier": {
+			Type:         schema.TypeFloat,
+			Description:  "Optional ratio between the instances CPU and memory, computed by the API when not set",
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.FloatAtLeast(0),
+		},
+		"max_zones": {
+			Type:         schema.TypeInt,
+			Description:  "Optional maximum number of availability zones the instances can be spread across, computed by the API when not set",
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"size_resource": {
+			Type:         schema.TypeString,
+			Description:  `Optional resource the sizes are expressed in, one of "memory" or "storage". Defaults to "memory"`,
+			Optional:     true,
+			Default:      "memory",
+			ValidateFunc: validation.StringInSlice([]string{"memory", "storage"}, false),
+		},
+		"sizes": {
+			Type:        schema.TypeList,
+			Description: `Required list of the sizes the instances can have, such as "1g" or "0.5g"`,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"default_size": {
+			Type:        schema.TypeString,
+			Description: "Required default size of the instances, which must be one of the sizes",
+			Required:    true,
+		},
+		"allocator_filter": {
+			Type:             schema.TypeString,
+			Description:      "Optional JSON encoded Elasticsearch query matched against the allocators metadata, such as the tags set by ec_allocator_tag, to select where the instances are allocated",
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: structure.SuppressJsonDiff,
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Description: `Optional region where the instance configuration is created, defaults to "ece-region"`,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     eceRegion,
+		},
+	}
+}
@@ -0,0 +1,67 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package keystorerotationresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/eskeystoreapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// read checks that the keystore settings still exist. Like the keystore
+// resource, the values aren't returned by the API, so they can't be
+// reconciled with the configuration.
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var client = meta.(*util.ProviderMeta).API
+
+	res, err := eskeystoreapi.Get(eskeystoreapi.GetParams{
+		API:          client,
+		DeploymentID: d.Get("deployment_id").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(modelToState(d, res))
+}
+
+// modelToState removes the secrets which no longer exist in the keystore
+// from the state, so they are written again on the next apply. When none of
+// them exist, the resource is marked as destroyed.
+func modelToState(d *schema.ResourceData, res *models.KeystoreContents) error {
+	secrets, _ := d.Get("secrets").(map[string]interface{})
+
+	var existing = make(map[string]interface{}, len(secrets))
+	for name, value := range secrets {
+		if _, ok := res.Secrets[name]; ok {
+			existing[name] = value
+		}
+	}
+
+	if len(existing) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	return d.Set("secrets", existing)
+}
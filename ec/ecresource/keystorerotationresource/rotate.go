@@ -0,0 +1,123 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package keystorerotationresource
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/eskeystoreapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// now returns the current time, it's a variable so it can be overridden in
+// the tests.
+var now = time.Now
+
+// rotationDiff marks last_rotated as computed when the keystore settings are
+// going to be rewritten.
+func rotationDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() != "" && d.HasChanges("keeper", "secrets", "as_file") {
+		return d.SetNewComputed("last_rotated")
+	}
+	return nil
+}
+
+// rotate writes all the keystore settings and unsets the ones which are no
+// longer part of the policy, then records the rotation time.
+func rotate(d *schema.ResourceData, client *api.API, removed []string) error {
+	secrets, _ := d.Get("secrets").(map[string]interface{})
+	contents := expandSecrets(secrets, d.Get("as_file").(bool))
+	unsetSecrets(contents, removed)
+
+	if _, err := eskeystoreapi.Update(eskeystoreapi.UpdateParams{
+		API:          client,
+		DeploymentID: d.Get("deployment_id").(string),
+		Contents:     contents,
+	}); err != nil {
+		return multierror.NewPrefixed("failed rotating the keystore settings", err)
+	}
+
+	return d.Set("last_rotated", now().UTC().Format(time.RFC3339))
+}
+
+// expandSecrets returns the keystore contents which set all the secrets.
+func expandSecrets(secrets map[string]interface{}, asFile bool) *models.KeystoreContents {
+	contents := &models.KeystoreContents{
+		Secrets: make(map[string]models.KeystoreSecret, len(secrets)),
+	}
+
+	for name, raw := range secrets {
+		strVal, _ := raw.(string)
+
+		// Tries to unmarshal the contents of the value into an `interface{}`,
+		// if it fails, then the contents aren't a JSON object.
+		var value interface{}
+		if err := json.Unmarshal([]byte(strVal), &value); err != nil {
+			value = strVal
+		}
+
+		contents.Secrets[name] = models.KeystoreSecret{
+			AsFile: ec.Bool(asFile),
+			Value:  value,
+		}
+	}
+
+	return contents
+}
+
+// unsetSecrets adds the named secrets without a value to the contents, which
+// removes them from the keystore since the Update API patches it.
+func unsetSecrets(contents *models.KeystoreContents, names []string) {
+	for _, name := range names {
+		contents.Secrets[name] = models.KeystoreSecret{}
+	}
+}
+
+// removedSecrets returns the sorted names of the secrets which were removed.
+func removedSecrets(prev, next interface{}) []string {
+	oldSecrets, _ := prev.(map[string]interface{})
+	newSecrets, _ := next.(map[string]interface{})
+
+	var removed []string
+	for name := range oldSecrets {
+		if _, ok := newSecrets[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+
+	return removed
+}
+
+// secretNames returns the sorted names of the secrets.
+func secretNames(secrets map[string]interface{}) []string {
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package keystorerotationresource
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandSecrets(t *testing.T) {
+	got := expandSecrets(map[string]interface{}{
+		"s3.client.default.secret_key":        "some-secret",
+		"gcs.client.default.credentials_file": `{"type":"service_account"}`,
+	}, false)
+
+	assert.Equal(t, &models.KeystoreContents{Secrets: map[string]models.KeystoreSecret{
+		"s3.client.default.secret_key": {AsFile: ec.Bool(false), Value: "some-secret"},
+		"gcs.client.default.credentials_file": {AsFile: ec.Bool(false), Value: map[string]interface{}{
+			"type": "service_account",
+		}},
+	}}, got)
+}
+
+func Test_removedSecrets(t *testing.T) {
+	got := removedSecrets(
+		map[string]interface{}{"a": "1", "b": "2", "c": "3"},
+		map[string]interface{}{"b": "2"},
+	)
+	assert.Equal(t, []string{"a", "c"}, got)
+
+	assert.Nil(t, removedSecrets(nil, map[string]interface{}{"a": "1"}))
+}
+
+func Test_rotate(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	now = func() time.Time {
+		return time.Date(2022, 10, 16, 10, 30, 0, 0, time.UTC)
+	}
+
+	newRD := func() *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, newSchema(), map[string]interface{}{
+			"deployment_id": mock.ValidClusterID,
+			"keeper":        "2022-10-16",
+			"secrets": map[string]interface{}{
+				"s3.client.default.secret_key": "some-secret",
+			},
+		})
+		d.SetId("some-id")
+		return d
+	}
+
+	tests := []struct {
+		name    string
+		client  *api.API
+		removed []string
+		want    string
+		err     error
+	}{
+		{
+			name: "writes the secrets and records the rotation time",
+			client: api.NewMock(
+				mock.New200StructResponse(models.DeploymentGetResponse{
+					Resources: &models.DeploymentResources{
+						Elasticsearch: []*models.ElasticsearchResourceInfo{{
+							RefID: ec.String("main-elasticsearch"),
+						}},
+					},
+				}),
+				mock.New200StructResponse(models.KeystoreContents{}),
+			),
+			removed: []string{"s3.client.default.access_key"},
+			want:    "2022-10-16T10:30:00Z",
+		},
+		{
+			name: "returns the error writing the secrets",
+			client: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				Code: "some", Message: "message",
+			})),
+			err: errors.New("failed rotating the keystore settings: 1 error occurred:\n\t* api error: some: message\n\n"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newRD()
+			err := rotate(d, tt.client, tt.removed)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, d.Get("last_rotated"))
+		})
+	}
+}
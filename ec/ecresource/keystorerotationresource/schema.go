@@ -0,0 +1,63 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package keystorerotationresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// newSchema returns the schema for an "ec_deployment_keystore_rotation_policy"
+// resource.
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"deployment_id": {
+			Type:        schema.TypeString,
+			Description: "Required deployment ID of the Deployment that holds the Elasticsearch cluster where the keystore settings are written to",
+			Required:    true,
+			ForceNew:    true,
+		},
+		"keeper": {
+			Type:        schema.TypeString,
+			Description: "Optional arbitrary value which, when changed, rotates all the keystore settings, such as a timestamp rotated on a schedule",
+			Optional:    true,
+		},
+		"secrets": {
+			Type:             schema.TypeMap,
+			Description:      "Required keystore settings values keyed by setting name, usually supplied by a generator such as a random password. The values can either be strings or JSON objects stored as JSON strings",
+			Required:         true,
+			Sensitive:        true,
+			ValidateDiagFunc: validation.MapKeyLenBetween(1, 255),
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"as_file": {
+			Type:        schema.TypeBool,
+			Description: "Optionally stores the keystore settings as files. Defaults to false",
+			Optional:    true,
+		},
+
+		// Computed
+		"last_rotated": {
+			Type:        schema.TypeString,
+			Description: "Time when the keystore settings were last written, in RFC3339 format",
+			Computed:    true,
+		},
+	}
+}
@@ -0,0 +1,64 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationinvitationresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/organizations"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// create invites the user to the organization. Any existing invitations for
+// the same email, such as stale expired ones, are cancelled first, so the
+// invitation is always (re)sent with the configured expiration.
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+	orgID := d.Get("organization_id").(string)
+	email := d.Get("email").(string)
+
+	existing, err := listInvitations(client, orgID, email)
+	if err != nil {
+		return diag.FromErr(multierror.NewPrefixed("failed obtaining organization invitations", err))
+	}
+
+	if err := cancelInvitations(client, orgID, existing); err != nil {
+		return diag.FromErr(multierror.NewPrefixed("failed cancelling existing organization invitations", err))
+	}
+
+	if _, err := client.V1API.Organizations.CreateOrganizationInvitations(
+		organizations.NewCreateOrganizationInvitationsParams().
+			WithOrganizationID(orgID).
+			WithBody(&models.OrganizationInvitationRequest{
+				Emails:    []string{email},
+				ExpiresIn: d.Get("expires_in").(string),
+			}),
+		client.AuthWriter,
+	); err != nil {
+		return diag.FromErr(multierror.NewPrefixed("failed creating organization invitation", apierror.Wrap(err)))
+	}
+
+	d.SetId(invitationID(orgID, email))
+	return read(ctx, d, meta)
+}
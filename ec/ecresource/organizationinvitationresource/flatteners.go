@@ -0,0 +1,58 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationinvitationresource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// flatten sets the invitation in the state. The pending invitation is nil
+// once the user has accepted it, in which case its timestamps are kept as
+// they are in the state.
+func flatten(d *schema.ResourceData, orgID, email string, pending *models.OrganizationInvitation, accepted bool) error {
+	if err := d.Set("organization_id", orgID); err != nil {
+		return err
+	}
+
+	if err := d.Set("email", email); err != nil {
+		return err
+	}
+
+	if err := d.Set("accepted", accepted); err != nil {
+		return err
+	}
+
+	if pending == nil {
+		return nil
+	}
+
+	if pending.CreatedAt != nil {
+		if err := d.Set("created_at", pending.CreatedAt.String()); err != nil {
+			return err
+		}
+	}
+
+	if pending.ExpiresAt != nil {
+		if err := d.Set("expires_at", pending.ExpiresAt.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
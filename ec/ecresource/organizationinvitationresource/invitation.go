@@ -0,0 +1,130 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationinvitationresource
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/organizations"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+// invitationID returns the resource ID of the invitation of an email to an
+// organization.
+func invitationID(orgID, email string) string {
+	return orgID + "/" + email
+}
+
+// parseInvitationID returns the organization ID and the email of a resource
+// ID in the "<organization_id>/<email>" format.
+func parseInvitationID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf(
+			`invalid organization invitation ID "%s", expected format "<organization_id>/<email>"`, id,
+		)
+	}
+	return parts[0], parts[1], nil
+}
+
+// listInvitations returns the invitations of the organization matching the
+// email, including the expired ones.
+func listInvitations(client *api.API, orgID, email string) ([]*models.OrganizationInvitation, error) {
+	res, err := client.V1API.Organizations.ListOrganizationInvitations(
+		organizations.NewListOrganizationInvitationsParams().
+			WithOrganizationID(orgID),
+		client.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	var result []*models.OrganizationInvitation
+	for _, inv := range res.Payload.Invitations {
+		if inv.Email != nil && strings.EqualFold(*inv.Email, email) {
+			result = append(result, inv)
+		}
+	}
+	return result, nil
+}
+
+// pendingInvitation returns the pending, non-expired invitation, if any.
+func pendingInvitation(invitations []*models.OrganizationInvitation) *models.OrganizationInvitation {
+	for _, inv := range invitations {
+		if inv.Expired != nil && *inv.Expired {
+			continue
+		}
+		return inv
+	}
+	return nil
+}
+
+// isMember returns true when the email belongs to a member of the
+// organization.
+func isMember(client *api.API, orgID, email string) (bool, error) {
+	res, err := client.V1API.Organizations.ListOrganizationMembers(
+		organizations.NewListOrganizationMembersParams().
+			WithOrganizationID(orgID),
+		client.AuthWriter,
+	)
+	if err != nil {
+		return false, apierror.Wrap(err)
+	}
+
+	for _, member := range res.Payload.Members {
+		if strings.EqualFold(member.Email, email) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// cancelInvitations deletes the invitations, either pending or expired.
+func cancelInvitations(client *api.API, orgID string, invitations []*models.OrganizationInvitation) error {
+	var tokens []string
+	for _, inv := range invitations {
+		if inv.Token != nil {
+			tokens = append(tokens, *inv.Token)
+		}
+	}
+
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	if _, err := client.V1API.Organizations.DeleteOrganizationInvitations(
+		organizations.NewDeleteOrganizationInvitationsParams().
+			WithOrganizationID(orgID).
+			WithInvitationTokens(strings.Join(tokens, ",")),
+		client.AuthWriter,
+	); err != nil {
+		return apierror.Wrap(err)
+	}
+
+	return nil
+}
+
+func organizationNotFound(err error) bool {
+	var membersNotFound *organizations.ListOrganizationMembersNotFound
+	var invitationsNotFound *organizations.ListOrganizationInvitationsNotFound
+	return errors.As(err, &membersNotFound) || errors.As(err, &invitationsNotFound)
+}
@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationinvitationresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// read queries the remote organization invitation and updates the local
+// state. Once the user has accepted the invitation, the resource is kept in
+// the state as accepted. Expired or cancelled invitations are removed from
+// the state, so they're resent on the next apply.
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+
+	orgID, email, err := parseInvitationID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	invitations, err := listInvitations(client, orgID, email)
+	if err != nil {
+		if organizationNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(multierror.NewPrefixed("failed obtaining organization invitations", err))
+	}
+
+	var accepted bool
+	pending := pendingInvitation(invitations)
+	if pending == nil {
+		if accepted, err = isMember(client, orgID, email); err != nil {
+			return diag.FromErr(multierror.NewPrefixed("failed obtaining organization members", err))
+		}
+
+		if !accepted {
+			d.SetId("")
+			return nil
+		}
+	}
+
+	if err := flatten(d, orgID, email, pending, accepted); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
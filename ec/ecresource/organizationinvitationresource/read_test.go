@@ -0,0 +1,136 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationinvitationresource
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+const (
+	mockOrganizationID = "1234567890"
+	mockEmail          = "invited@example.com"
+)
+
+func newSampleOrganizationInvitation() map[string]interface{} {
+	return map[string]interface{}{
+		"organization_id": mockOrganizationID,
+		"email":           mockEmail,
+	}
+}
+
+func newInvitations(expired bool) models.OrganizationInvitations {
+	createdAt := strfmt.DateTime(time.Date(2022, 10, 1, 10, 0, 0, 0, time.UTC))
+	expiresAt := strfmt.DateTime(time.Date(2022, 10, 8, 10, 0, 0, 0, time.UTC))
+	return models.OrganizationInvitations{Invitations: []*models.OrganizationInvitation{
+		{
+			Email:     ec.String("someone-else@example.com"),
+			Token:     ec.String("other-token"),
+			Expired:   ec.Bool(false),
+			CreatedAt: &createdAt,
+			ExpiresAt: &expiresAt,
+		},
+		{
+			Email:     ec.String(mockEmail),
+			Token:     ec.String("token"),
+			Expired:   ec.Bool(expired),
+			CreatedAt: &createdAt,
+			ExpiresAt: &expiresAt,
+		},
+	}}
+}
+
+func Test_read(t *testing.T) {
+	id := invitationID(mockOrganizationID, mockEmail)
+
+	t.Run("flattens the pending invitation", func(t *testing.T) {
+		d := util.NewResourceData(t, util.ResDataParams{
+			ID: id, State: newSampleOrganizationInvitation(), Schema: newSchema(),
+		})
+
+		got := read(context.Background(), d, util.NewRegionlessMockMeta(
+			mock.New200StructResponse(newInvitations(false)),
+		))
+		assert.Nil(t, got)
+		assert.Equal(t, id, d.Id())
+		assert.Equal(t, false, d.Get("accepted"))
+		assert.Equal(t, "2022-10-01T10:00:00.000Z", d.Get("created_at"))
+		assert.Equal(t, "2022-10-08T10:00:00.000Z", d.Get("expires_at"))
+	})
+
+	t.Run("sets the invitation as accepted when the user is a member", func(t *testing.T) {
+		d := util.NewResourceData(t, util.ResDataParams{
+			ID: id, State: newSampleOrganizationInvitation(), Schema: newSchema(),
+		})
+
+		got := read(context.Background(), d, util.NewRegionlessMockMeta(
+			mock.New200StructResponse(models.OrganizationInvitations{}),
+			mock.New200StructResponse(models.OrganizationMemberships{
+				Members: []*models.OrganizationMembership{{Email: mockEmail, UserID: ec.String("1")}},
+			}),
+		))
+		assert.Nil(t, got)
+		assert.Equal(t, id, d.Id())
+		assert.Equal(t, true, d.Get("accepted"))
+	})
+
+	t.Run("unsets the state when the invitation has expired", func(t *testing.T) {
+		d := util.NewResourceData(t, util.ResDataParams{
+			ID: id, State: newSampleOrganizationInvitation(), Schema: newSchema(),
+		})
+
+		got := read(context.Background(), d, util.NewRegionlessMockMeta(
+			mock.New200StructResponse(newInvitations(true)),
+			mock.New200StructResponse(models.OrganizationMemberships{}),
+		))
+		assert.Nil(t, got)
+		assert.Equal(t, "", d.Id())
+	})
+
+	t.Run("returns an error when it receives a 500", func(t *testing.T) {
+		d := util.NewResourceData(t, util.ResDataParams{
+			ID: id, State: newSampleOrganizationInvitation(), Schema: newSchema(),
+		})
+
+		got := read(context.Background(), d, util.NewRegionlessMockMeta(
+			mock.NewErrorResponse(500, mock.APIError{Code: "some", Message: "message"}),
+		))
+		assert.Equal(t, "failed obtaining organization invitations: 1 error occurred:\n\t* api error: some: message\n\n", got[0].Summary)
+		assert.Equal(t, id, d.Id())
+	})
+}
+
+func Test_parseInvitationID(t *testing.T) {
+	orgID, email, err := parseInvitationID("1234567890/invited@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, mockOrganizationID, orgID)
+	assert.Equal(t, mockEmail, email)
+
+	_, _, err = parseInvitationID("invited@example.com")
+	assert.Equal(t, errors.New(`invalid organization invitation ID "invited@example.com", expected format "<organization_id>/<email>"`), err)
+}
@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationinvitationresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// newSchema returns the schema for an "ec_organization_invitation" resource.
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"organization_id": {
+			Type:         schema.TypeString,
+			Description:  "Required organization ID the user is invited to",
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"email": {
+			Type:         schema.TypeString,
+			Description:  "Required email address of the invited user",
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"expires_in": {
+			Type:        schema.TypeString,
+			Description: `Optional expiration period of the invitation, such as "7d" or "72h". Defaults to the API default. Changing it resends the invitation`,
+			Optional:    true,
+			ForceNew:    true,
+		},
+
+		// Computed attributes
+		"created_at": {
+			Type:        schema.TypeString,
+			Description: "Computed creation time of the invitation",
+			Computed:    true,
+		},
+		"expires_at": {
+			Type:        schema.TypeString,
+			Description: "Computed expiration time of the invitation",
+			Computed:    true,
+		},
+		"accepted": {
+			Type:        schema.TypeBool,
+			Description: "Computed flag which is set once the user has accepted the invitation and is a member of the organization",
+			Computed:    true,
+		},
+	}
+}
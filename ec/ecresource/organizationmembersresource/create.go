@@ -0,0 +1,47 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationmembersresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// create invites the users which aren't members of the organization yet.
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+	orgID := d.Get("organization_id").(string)
+
+	current, err := getMembership(client, orgID)
+	if err != nil {
+		return diag.FromErr(multierror.NewPrefixed("failed obtaining organization members", err))
+	}
+
+	emails := util.ItemsToString(d.Get("emails").(*schema.Set).List())
+	if err := invite(client, orgID, current, emails, d.Get("invitation_expires_in").(string)); err != nil {
+		return diag.FromErr(multierror.NewPrefixed("failed inviting organization members", err))
+	}
+
+	d.SetId(orgID)
+	return read(ctx, d, meta)
+}
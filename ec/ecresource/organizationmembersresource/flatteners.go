@@ -0,0 +1,62 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationmembersresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// flatten sets the managed members and pending invitations in the state. Only
+// the emails which are part of the configuration are considered, so members
+// managed outside of Terraform don't cause any drift.
+func flatten(d *schema.ResourceData, current *membership) error {
+	var emails, pending []interface{}
+	var members = make([]interface{}, 0)
+	for _, raw := range d.Get("emails").(*schema.Set).List() {
+		email := raw.(string)
+
+		if member := current.member(email); member != nil {
+			emails = append(emails, email)
+
+			var m = map[string]interface{}{
+				"email": member.Email,
+				"name":  member.Name,
+			}
+			if member.UserID != nil {
+				m["user_id"] = *member.UserID
+			}
+			members = append(members, m)
+			continue
+		}
+
+		if current.invitation(email) != nil {
+			emails = append(emails, email)
+			pending = append(pending, email)
+		}
+	}
+
+	if err := d.Set("emails", schema.NewSet(schema.HashString, emails)); err != nil {
+		return err
+	}
+
+	if err := d.Set("members", members); err != nil {
+		return err
+	}
+
+	return d.Set("pending_invitations", schema.NewSet(schema.HashString, pending))
+}
@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationmembersresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_flatten(t *testing.T) {
+	type want struct {
+		emails  []string
+		members []interface{}
+		pending []string
+	}
+	tests := []struct {
+		name    string
+		current *membership
+		want    want
+	}{
+		{
+			name: "flattens the managed members and pending invitations",
+			current: &membership{
+				members: []*models.OrganizationMembership{
+					{Email: "member@example.com", Name: "Member", UserID: ec.String("111")},
+					{Email: "unmanaged@example.com", Name: "Unmanaged", UserID: ec.String("222")},
+				},
+				invitations: []*models.OrganizationInvitation{
+					{Email: ec.String("invited@example.com"), Token: ec.String("abc"), Expired: ec.Bool(false)},
+				},
+			},
+			want: want{
+				emails: []string{"invited@example.com", "member@example.com"},
+				members: []interface{}{map[string]interface{}{
+					"email":   "member@example.com",
+					"name":    "Member",
+					"user_id": "111",
+				}},
+				pending: []string{"invited@example.com"},
+			},
+		},
+		{
+			name: "removes the emails which are neither members nor have a valid invitation",
+			current: &membership{
+				invitations: []*models.OrganizationInvitation{
+					{Email: ec.String("invited@example.com"), Token: ec.String("abc"), Expired: ec.Bool(true)},
+				},
+			},
+			want: want{members: []interface{}{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := util.NewResourceData(t, util.ResDataParams{
+				ID:     mockOrganizationID,
+				State:  newSampleOrganizationMembers(),
+				Schema: newSchema(),
+			})
+
+			err := flatten(d, tt.current)
+			assert.NoError(t, err)
+
+			assert.ElementsMatch(t, tt.want.emails,
+				util.ItemsToString(d.Get("emails").(*schema.Set).List()),
+			)
+			assert.Equal(t, tt.want.members, d.Get("members"))
+			assert.ElementsMatch(t, tt.want.pending,
+				util.ItemsToString(d.Get("pending_invitations").(*schema.Set).List()),
+			)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationmembersresource
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/organizations"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+// membership contains the organization's members and pending invitations.
+type membership struct {
+	members     []*models.OrganizationMembership
+	invitations []*models.OrganizationInvitation
+}
+
+// getMembership obtains the organization members and invitations.
+func getMembership(client *api.API, orgID string) (*membership, error) {
+	members, err := client.V1API.Organizations.ListOrganizationMembers(
+		organizations.NewListOrganizationMembersParams().
+			WithOrganizationID(orgID),
+		client.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	invitations, err := client.V1API.Organizations.ListOrganizationInvitations(
+		organizations.NewListOrganizationInvitationsParams().
+			WithOrganizationID(orgID),
+		client.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return &membership{
+		members:     members.Payload.Members,
+		invitations: invitations.Payload.Invitations,
+	}, nil
+}
+
+// member returns the organization member matching the email, if any.
+func (m *membership) member(email string) *models.OrganizationMembership {
+	for _, member := range m.members {
+		if strings.EqualFold(member.Email, email) {
+			return member
+		}
+	}
+	return nil
+}
+
+// invitation returns the pending, non-expired invitation matching the email,
+// if any.
+func (m *membership) invitation(email string) *models.OrganizationInvitation {
+	for _, inv := range m.invitations {
+		if inv.Email == nil || !strings.EqualFold(*inv.Email, email) {
+			continue
+		}
+		if inv.Expired != nil && *inv.Expired {
+			continue
+		}
+		return inv
+	}
+	return nil
+}
+
+// invite invites the users which aren't members or have a pending invitation
+// to the organization.
+func invite(client *api.API, orgID string, current *membership, emails []string, expiresIn string) error {
+	var pending []string
+	for _, email := range emails {
+		if current.member(email) != nil || current.invitation(email) != nil {
+			continue
+		}
+		pending = append(pending, email)
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if _, err := client.V1API.Organizations.CreateOrganizationInvitations(
+		organizations.NewCreateOrganizationInvitationsParams().
+			WithOrganizationID(orgID).
+			WithBody(&models.OrganizationInvitationRequest{
+				Emails:    pending,
+				ExpiresIn: expiresIn,
+			}),
+		client.AuthWriter,
+	); err != nil {
+		return apierror.Wrap(err)
+	}
+
+	return nil
+}
+
+// remove removes the organization members and deletes any pending invitations
+// matching the emails.
+func remove(client *api.API, orgID string, current *membership, emails []string) error {
+	var userIDs, tokens []string
+	for _, email := range emails {
+		if member := current.member(email); member != nil && member.UserID != nil {
+			userIDs = append(userIDs, *member.UserID)
+		}
+		if inv := current.invitation(email); inv != nil && inv.Token != nil {
+			tokens = append(tokens, *inv.Token)
+		}
+	}
+
+	if len(userIDs) > 0 {
+		if _, err := client.V1API.Organizations.DeleteOrganizationMemberships(
+			organizations.NewDeleteOrganizationMembershipsParams().
+				WithOrganizationID(orgID).
+				WithUserIds(strings.Join(userIDs, ",")),
+			client.AuthWriter,
+		); err != nil {
+			return apierror.Wrap(err)
+		}
+	}
+
+	if len(tokens) > 0 {
+		if _, err := client.V1API.Organizations.DeleteOrganizationInvitations(
+			organizations.NewDeleteOrganizationInvitationsParams().
+				WithOrganizationID(orgID).
+				WithInvitationTokens(strings.Join(tokens, ",")),
+			client.AuthWriter,
+		); err != nil {
+			return apierror.Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+func organizationNotFound(err error) bool {
+	var membersNotFound *organizations.ListOrganizationMembersNotFound
+	var invitationsNotFound *organizations.ListOrganizationInvitationsNotFound
+	return errors.As(err, &membersNotFound) || errors.As(err, &invitationsNotFound)
+}
@@ -0,0 +1,84 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationmembersresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// newSchema returns the schema for an "ec_organization_members" resource.
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"organization_id": {
+			Type:         schema.TypeString,
+			Description:  "Required organization ID where the members will be managed",
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"emails": {
+			Type:        schema.TypeSet,
+			Description: "Required set of email addresses of the organization members. Users which aren't members yet are invited to the organization",
+			Set:         schema.HashString,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+		"invitation_expires_in": {
+			Type:        schema.TypeString,
+			Description: `Optional expiration period of the invitations sent to new members, such as "7d" or "72h". Defaults to the API default`,
+			Optional:    true,
+		},
+
+		// Computed attributes
+		"members": {
+			Type:        schema.TypeList,
+			Description: "Computed list of the managed users which have accepted the invitation",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"user_id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"email": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+		"pending_invitations": {
+			Type:        schema.TypeSet,
+			Description: "Computed set of the managed email addresses with a pending invitation",
+			Set:         schema.HashString,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
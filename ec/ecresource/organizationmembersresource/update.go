@@ -0,0 +1,58 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package organizationmembersresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// update invites the added users and removes the users which are no longer
+// part of the configured emails.
+func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+
+	if !d.HasChange("emails") {
+		return read(ctx, d, meta)
+	}
+
+	current, err := getMembership(client, d.Id())
+	if err != nil {
+		return diag.FromErr(multierror.NewPrefixed("failed obtaining organization members", err))
+	}
+
+	oldRaw, newRaw := d.GetChange("emails")
+	oldSet, newSet := oldRaw.(*schema.Set), newRaw.(*schema.Set)
+
+	removed := util.ItemsToString(oldSet.Difference(newSet).List())
+	if err := remove(client, d.Id(), current, removed); err != nil {
+		return diag.FromErr(multierror.NewPrefixed("failed removing organization members", err))
+	}
+
+	added := util.ItemsToString(newSet.Difference(oldSet).List())
+	if err := invite(client, d.Id(), current, added, d.Get("invitation_expires_in").(string)); err != nil {
+		return diag.FromErr(multierror.NewPrefixed("failed inviting organization members", err))
+	}
+
+	return read(ctx, d, meta)
+}
@@ -0,0 +1,63 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package planexportresource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	content, diags := export(ctx, d, meta.(*util.ProviderMeta))
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(checksum(content))
+	return diags
+}
+
+// update exports the payload again once the deployment configuration changes.
+func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	_, diags := export(ctx, d, meta.(*util.ProviderMeta))
+	return diags
+}
+
+// export renders the configured deployment payload and writes it to the file.
+func export(ctx context.Context, d *schema.ResourceData, meta *util.ProviderMeta) ([]byte, diag.Diagnostics) {
+	filename := d.Get("filename").(string)
+
+	content, err := render(ctx, d, meta)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	if err := writeFile(filename, content); err != nil {
+		return nil, diag.Errorf("failed writing the deployment payload to %s: %s", filename, err)
+	}
+
+	if err := d.Set("content", string(content)); err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return content, diag.FromErr(d.Set("content_sha256", checksum(content)))
+}
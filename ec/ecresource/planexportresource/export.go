@@ -0,0 +1,156 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package planexportresource
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource"
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+const redactedValue = "REDACTED"
+
+// sensitiveKeys are the substrings of the setting names whose string values
+// are redacted from the exported payload.
+var sensitiveKeys = []string{"password", "secret", "token", "credentials", "api_key"}
+
+// render returns the sanitized payload the deployment resource sends for the
+// configured deployment, as indented JSON. It's the update payload when the
+// "deployment_id" is set, and the create one otherwise.
+func render(ctx context.Context, d *schema.ResourceData, meta *util.ProviderMeta) ([]byte, error) {
+	if d.Get("deployment_id").(string) != "" {
+		req, err := deploymentresource.UpdatePayload(ctx, d, meta)
+		if err != nil {
+			return nil, err
+		}
+		return marshalPayload(req)
+	}
+
+	req, err := deploymentresource.CreatePayload(ctx, d, meta)
+	if err != nil {
+		return nil, err
+	}
+	return marshalPayload(req)
+}
+
+// marshalPayload marshals the payload with the values of any setting which
+// looks sensitive redacted.
+func marshalPayload(req interface{}) ([]byte, error) {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return nil, err
+	}
+	redact(payload)
+
+	b, err = json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '\n'), nil
+}
+
+// redact replaces the string values of the sensitive settings, including the
+// ones in the YAML user settings documents, returning true if any was
+// redacted. The JSON user settings are already part of the payload objects.
+func redact(v interface{}) bool {
+	var redacted bool
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, elem := range value {
+			if s, ok := elem.(string); ok {
+				if isSensitive(k) {
+					value[k], redacted = redactedValue, true
+				} else if strings.HasSuffix(k, "_yaml") {
+					if doc, ok := redactYAML(s); ok {
+						value[k], redacted = doc, true
+					}
+				}
+				continue
+			}
+			if redact(elem) {
+				redacted = true
+			}
+		}
+	case []interface{}:
+		for _, elem := range value {
+			if redact(elem) {
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
+
+// redactYAML returns the YAML document with its sensitive settings redacted,
+// and true if any was. Documents which can't be parsed are fully redacted,
+// since their settings can't be checked.
+func redactYAML(s string) (string, bool) {
+	var doc interface{}
+	if err := yaml.Unmarshal([]byte(s), &doc); err != nil {
+		return redactedValue, true
+	}
+
+	if !redact(doc) {
+		return s, false
+	}
+
+	b, err := yaml.Marshal(doc)
+	if err != nil {
+		return redactedValue, true
+	}
+	return string(b), true
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeFile writes the content to the file, creating its parent directories.
+func writeFile(filename string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, content, 0644)
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
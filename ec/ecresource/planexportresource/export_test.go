@@ -0,0 +1,221 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package planexportresource
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func Test_marshalPayload(t *testing.T) {
+	got, err := marshalPayload(&models.DeploymentUpdateRequest{
+		Name:         "my-deployment",
+		PruneOrphans: ec.Bool(false),
+		Resources: &models.DeploymentUpdateResources{
+			Elasticsearch: []*models.ElasticsearchPayload{{
+				RefID:  ec.String("main-elasticsearch"),
+				Region: ec.String("us-east-1"),
+				Plan: &models.ElasticsearchClusterPlan{
+					Elasticsearch: &models.ElasticsearchConfiguration{
+						Version: "8.4.3",
+						UserSettingsJSON: map[string]interface{}{
+							"xpack.notification.email.account.work.smtp.password": "some-password",
+							"xpack.security.authc.token.enabled":                  true,
+						},
+					},
+				},
+			}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+  "name": "my-deployment",
+  "prune_orphans": false,
+  "resources": {
+    "apm": null,
+    "appsearch": null,
+    "elasticsearch": [
+      {
+        "plan": {
+          "cluster_topology": null,
+          "elasticsearch": {
+            "user_settings_json": {
+              "xpack.notification.email.account.work.smtp.password": "REDACTED",
+              "xpack.security.authc.token.enabled": true
+            },
+            "version": "8.4.3"
+          }
+        },
+        "ref_id": "main-elasticsearch",
+        "region": "us-east-1"
+      }
+    ],
+    "enterprise_search": null,
+    "integrations_server": null,
+    "kibana": null
+  }
+}
+`, string(got))
+}
+
+func Test_redactYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		want     string
+		redacted bool
+	}{
+		{
+			name: "keeps the documents without sensitive settings",
+			doc:  "# Enables the token service\nxpack.security.authc.token.enabled: true\n",
+			want: "# Enables the token service\nxpack.security.authc.token.enabled: true\n",
+		},
+		{
+			name: "redacts the sensitive settings",
+			doc: `xpack.security.authc.token.enabled: true
+xpack.security.authc.realms.oidc.oidc1:
+  rp.client_secret: some-secret
+`,
+			want: `xpack.security.authc.realms.oidc.oidc1:
+    rp.client_secret: REDACTED
+xpack.security.authc.token.enabled: true
+`,
+			redacted: true,
+		},
+		{
+			name:     "redacts the documents which can't be parsed",
+			doc:      "xpack.notification.email.account.work.smtp.secure_password: [some-password",
+			want:     redactedValue,
+			redacted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, redacted := redactYAML(tt.doc)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.redacted, redacted)
+		})
+	}
+}
+
+func newTemplateResponse() mock.Response {
+	return mock.New200StructResponse(models.DeploymentTemplateInfoV2{
+		ID: ec.String("aws-io-optimized-v2"),
+		DeploymentTemplate: &models.DeploymentCreateRequest{
+			Resources: &models.DeploymentCreateResources{
+				Elasticsearch: []*models.ElasticsearchPayload{{
+					RefID:  ec.String("main-elasticsearch"),
+					Region: ec.String("us-east-1"),
+					Plan: &models.ElasticsearchClusterPlan{
+						Elasticsearch: &models.ElasticsearchConfiguration{},
+						ClusterTopology: []*models.ElasticsearchClusterTopologyElement{{
+							ID:                      "hot_content",
+							InstanceConfigurationID: "aws.data.highio.i3",
+							ZoneCount:               2,
+							Size: &models.TopologySize{
+								Resource: ec.String("memory"),
+								Value:    ec.Int32(8192),
+							},
+						}},
+					},
+					Settings: &models.ElasticsearchClusterSettings{},
+				}},
+			},
+		},
+	})
+}
+
+func newExportConfig(filename, deploymentID string) map[string]interface{} {
+	return map[string]interface{}{
+		"deployment_id":          deploymentID,
+		"filename":               filename,
+		"name":                   "my-deployment",
+		"region":                 "us-east-1",
+		"version":                "8.4.3",
+		"deployment_template_id": "aws-io-optimized-v2",
+		"elasticsearch": []interface{}{map[string]interface{}{
+			"config": []interface{}{map[string]interface{}{
+				"user_settings_yaml": "xpack.notification.email.account.work.smtp.secure_password: some-password\n",
+			}},
+		}},
+	}
+}
+
+func Test_render(t *testing.T) {
+	t.Run("renders the create payload of the configured deployment", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, newSchema(), newExportConfig("payload.json", ""))
+
+		got, err := render(context.Background(), d, util.NewMockMeta(newTemplateResponse()))
+		assert.NoError(t, err)
+		assert.Contains(t, string(got), `"name": "my-deployment"`)
+		assert.Contains(t, string(got), `"instance_configuration_id": "aws.data.highio.i3"`)
+		assert.Contains(t, string(got), `secure_password: REDACTED`)
+		assert.NotContains(t, string(got), "some-password")
+		assert.NotContains(t, string(got), "prune_orphans")
+	})
+
+	t.Run("renders the update payload when the deployment_id is set", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, newSchema(), newExportConfig("payload.json", mock.ValidClusterID))
+
+		got, err := render(context.Background(), d, util.NewMockMeta(newTemplateResponse()))
+		assert.NoError(t, err)
+		assert.Contains(t, string(got), `"prune_orphans": false`)
+		assert.Contains(t, string(got), `"instance_configuration_id": "aws.data.highio.i3"`)
+		assert.NotContains(t, string(got), "some-password")
+	})
+}
+
+func Test_lifecycle(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "exports", "payload.json")
+	d := schema.TestResourceDataRaw(t, newSchema(), newExportConfig(filename, ""))
+
+	diags := create(context.Background(), d, util.NewMockMeta(newTemplateResponse()))
+	if !assert.Nil(t, diags) {
+		return
+	}
+	content, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, d.Get("content"), string(content))
+	assert.Equal(t, checksum(content), d.Get("content_sha256"))
+	assert.NotEmpty(t, d.Id())
+
+	assert.Nil(t, read(context.Background(), d, nil))
+	assert.NotEmpty(t, d.Id(), "an unmodified file is kept")
+
+	assert.NoError(t, os.WriteFile(filename, []byte("{}"), 0644))
+	assert.Nil(t, read(context.Background(), d, nil))
+	assert.Empty(t, d.Id(), "a modified file is exported again")
+
+	d.SetId(checksum(content))
+	assert.Nil(t, delete(context.Background(), d, nil))
+	_, err = os.Stat(filename)
+	assert.True(t, os.IsNotExist(err))
+
+	assert.Nil(t, read(context.Background(), d, nil))
+	assert.Empty(t, d.Id(), "a deleted file is exported again")
+}
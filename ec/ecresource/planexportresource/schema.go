@@ -0,0 +1,62 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package planexportresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource"
+)
+
+// newSchema returns the schema for an "ec_deployment_plan_export" resource,
+// which is configured like an "ec_deployment" resource.
+func newSchema() map[string]*schema.Schema {
+	s := deploymentresource.Schema()
+
+	s["deployment_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Optional deployment ID of the Deployment the exported payload updates. When set, the update payload is exported, otherwise the create one",
+		Optional:    true,
+	}
+	s["filename"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Required path of the file the payload is written to. Missing parent directories are created",
+		Required:    true,
+		ForceNew:    true,
+	}
+	s["keeper"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Optional arbitrary value which, when changed, exports the payload again",
+		Optional:    true,
+		ForceNew:    true,
+	}
+
+	// Computed
+	s["content"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The exported payload, as indented JSON",
+		Computed:    true,
+	}
+	s["content_sha256"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "SHA256 checksum of the exported payload, hex encoded",
+		Computed:    true,
+	}
+
+	return s
+}
@@ -0,0 +1,70 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package snapshotrepositoryresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/snaprepoapi"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// createResource creates the snapshot repository, using its name as the ID.
+func createResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+	if err := setRepository(meta.(*util.ProviderMeta).API, d, name); err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed creating snapshot repository", err),
+		)
+	}
+
+	d.SetId(name)
+	return readResource(ctx, d, meta)
+}
+
+func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := setRepository(meta.(*util.ProviderMeta).API, d, d.Id()); err != nil {
+		return diag.FromErr(
+			multierror.NewPrefixed("failed updating snapshot repository", err),
+		)
+	}
+
+	return readResource(ctx, d, meta)
+}
+
+func setRepository(client *api.API, d *schema.ResourceData, name string) error {
+	repoType, cfg, err := expandRepository(
+		d.Get("s3").([]interface{}), d.Get("generic").([]interface{}),
+	)
+	if err != nil {
+		return err
+	}
+
+	return snaprepoapi.Set(snaprepoapi.SetParams{
+		API:    client,
+		Region: repositoryRegion(d),
+		Name:   name,
+		Type:   repoType,
+		Config: cfg,
+	})
+}
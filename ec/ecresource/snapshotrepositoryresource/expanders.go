@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package snapshotrepositoryresource
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/snaprepoapi"
+)
+
+const s3Type = "s3"
+
+// expandRepository returns the snapshot repository type and settings from
+// either its "s3" or its "generic" block.
+func expandRepository(s3, generic []interface{}) (string, snaprepoapi.GenericConfig, error) {
+	for _, raw := range s3 {
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var cfg = snaprepoapi.GenericConfig{}
+		for _, k := range []string{"region", "bucket", "access_key", "secret_key", "endpoint"} {
+			if v, ok := m[k].(string); ok && v != "" {
+				cfg[k] = v
+			}
+		}
+
+		for _, k := range []string{"server_side_encryption", "path_style_access"} {
+			if v, ok := m[k].(bool); ok && v {
+				cfg[k] = v
+			}
+		}
+
+		return s3Type, cfg, nil
+	}
+
+	for _, raw := range generic {
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var cfg snaprepoapi.GenericConfig
+		if settings, ok := m["settings"].(string); ok && settings != "" {
+			if err := json.Unmarshal([]byte(settings), &cfg); err != nil {
+				return "", nil, fmt.Errorf("failed expanding generic settings: %w", err)
+			}
+		}
+
+		repoType, _ := m["type"].(string)
+		return repoType, cfg, nil
+	}
+
+	return "", nil, fmt.Errorf(`one of "s3" or "generic" must be set`)
+}
@@ -0,0 +1,89 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package snapshotrepositoryresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/snaprepoapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandRepository(t *testing.T) {
+	type args struct {
+		s3      []interface{}
+		generic []interface{}
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantType string
+		want     snaprepoapi.GenericConfig
+		err      error
+	}{
+		{
+			name: "expands an s3 repository",
+			args: args{s3: []interface{}{map[string]interface{}{
+				"region":                 "us-east-1",
+				"bucket":                 "my-bucket",
+				"access_key":             "my-access-key",
+				"secret_key":             "my-secret-key",
+				"endpoint":               "",
+				"server_side_encryption": true,
+				"path_style_access":      false,
+			}}},
+			wantType: "s3",
+			want: snaprepoapi.GenericConfig{
+				"region":                 "us-east-1",
+				"bucket":                 "my-bucket",
+				"access_key":             "my-access-key",
+				"secret_key":             "my-secret-key",
+				"server_side_encryption": true,
+			},
+		},
+		{
+			name: "expands a generic repository",
+			args: args{generic: []interface{}{map[string]interface{}{
+				"type":     "gcs",
+				"settings": `{"bucket":"my-bucket","client":"default"}`,
+			}}},
+			wantType: "gcs",
+			want: snaprepoapi.GenericConfig{
+				"bucket": "my-bucket",
+				"client": "default",
+			},
+		},
+		{
+			name: "fails when none of the repository blocks are set",
+			err:  errors.New(`one of "s3" or "generic" must be set`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotType, got, err := expandRepository(tt.args.s3, tt.args.generic)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantType, gotType)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
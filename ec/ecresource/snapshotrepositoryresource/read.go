@@ -0,0 +1,130 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package snapshotrepositoryresource
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/snaprepoapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// repositoryConfig is the snapshot repository configuration returned by the
+// API.
+type repositoryConfig struct {
+	Type     string                 `json:"type"`
+	Settings map[string]interface{} `json:"settings"`
+}
+
+func readResource(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+
+	res, err := snaprepoapi.Get(snaprepoapi.GetParams{
+		API:    client,
+		Region: repositoryRegion(d),
+		Name:   d.Id(),
+	})
+	if err != nil {
+		if apierror.IsRuntimeStatusCode(err, 404) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(multierror.NewPrefixed("failed reading snapshot repository", err))
+	}
+
+	if err := d.Set("region", repositoryRegion(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := modelToState(d, res); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// modelToState refreshes the repository name and its non-sensitive settings.
+// The credentials and the generic settings are kept as they are in the state,
+// since the API may not return them as they were set.
+func modelToState(d *schema.ResourceData, res *models.RepositoryConfig) error {
+	if res.RepositoryName != nil {
+		if err := d.Set("name", *res.RepositoryName); err != nil {
+			return err
+		}
+	}
+
+	b, err := json.Marshal(res.Config)
+	if err != nil {
+		return err
+	}
+
+	var cfg repositoryConfig
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return err
+	}
+
+	if cfg.Type != s3Type {
+		generic, _ := d.Get("generic").([]interface{})
+		var m map[string]interface{}
+		if len(generic) > 0 {
+			m, _ = generic[0].(map[string]interface{})
+		}
+		if m == nil {
+			m = make(map[string]interface{})
+		}
+		m["type"] = cfg.Type
+		return d.Set("generic", []interface{}{m})
+	}
+
+	s3, _ := d.Get("s3").([]interface{})
+	var m map[string]interface{}
+	if len(s3) > 0 {
+		m, _ = s3[0].(map[string]interface{})
+	}
+	if m == nil {
+		m = make(map[string]interface{})
+	}
+
+	for _, k := range []string{"region", "bucket", "endpoint"} {
+		v, _ := cfg.Settings[k].(string)
+		m[k] = v
+	}
+
+	for _, k := range []string{"server_side_encryption", "path_style_access"} {
+		v, _ := cfg.Settings[k].(bool)
+		m[k] = v
+	}
+
+	return d.Set("s3", []interface{}{m})
+}
+
+// repositoryRegion returns the region of the snapshot repository, which isn't
+// set in the state when the repository is imported.
+func repositoryRegion(d *schema.ResourceData) string {
+	if region, ok := d.Get("region").(string); ok && region != "" {
+		return region
+	}
+	return eceRegion
+}
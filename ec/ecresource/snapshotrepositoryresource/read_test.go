@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package snapshotrepositoryresource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func newS3Repository() map[string]interface{} {
+	return map[string]interface{}{
+		"name":   "my-repo",
+		"region": "ece-region",
+		"s3": []interface{}{map[string]interface{}{
+			"region":     "us-east-1",
+			"bucket":     "my-bucket",
+			"access_key": "my-access-key",
+			"secret_key": "my-secret-key",
+		}},
+	}
+}
+
+func Test_readResource(t *testing.T) {
+	tc200 := util.NewResourceData(t, util.ResDataParams{
+		ID:     "my-repo",
+		State:  newS3Repository(),
+		Schema: newSchema(),
+	})
+
+	tc404 := util.NewResourceData(t, util.ResDataParams{
+		ID:     "my-repo",
+		State:  newS3Repository(),
+		Schema: newSchema(),
+	})
+
+	tc500 := util.NewResourceData(t, util.ResDataParams{
+		ID:     "my-repo",
+		State:  newS3Repository(),
+		Schema: newSchema(),
+	})
+
+	t.Run("refreshes the repository keeping the credentials", func(t *testing.T) {
+		got := readResource(context.Background(), tc200, util.NewMockMeta(
+			mock.New200StructResponse(models.RepositoryConfig{
+				RepositoryName: ec.String("my-repo"),
+				Config: map[string]interface{}{
+					"type": "s3",
+					"settings": map[string]interface{}{
+						"region":                 "us-west-2",
+						"bucket":                 "my-bucket",
+						"server_side_encryption": true,
+					},
+				},
+			}),
+		))
+		assert.Nil(t, got)
+		assert.Equal(t, "my-repo", tc200.Id())
+		assert.Equal(t, "us-west-2", tc200.Get("s3.0.region"))
+		assert.Equal(t, true, tc200.Get("s3.0.server_side_encryption"))
+		assert.Equal(t, "my-access-key", tc200.Get("s3.0.access_key"))
+		assert.Equal(t, "my-secret-key", tc200.Get("s3.0.secret_key"))
+	})
+
+	t.Run("unsets the id when the repository doesn't exist", func(t *testing.T) {
+		got := readResource(context.Background(), tc404, util.NewMockMeta(
+			mock.NewErrorResponse(404, mock.APIError{Code: "some", Message: "message"}),
+		))
+		assert.Nil(t, got)
+		assert.Equal(t, "", tc404.Id())
+	})
+
+	t.Run("returns an error when it receives a 500", func(t *testing.T) {
+		got := readResource(context.Background(), tc500, util.NewMockMeta(
+			mock.NewErrorResponse(500, mock.APIError{Code: "some", Message: "message"}),
+		))
+		assert.Equal(t, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "failed reading snapshot repository: 1 error occurred:\n\t* api error: some: message\n\n",
+		}}, got)
+		assert.Equal(t, "my-repo", tc500.Id())
+	})
+}
@@ -0,0 +1,125 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package snapshotrepositoryresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// eceRegion is the region of all the Elastic Cloud Enterprise installations.
+const eceRegion = "ece-region"
+
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Required name of the snapshot repository",
+			Required:    true,
+			ForceNew:    true,
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Description: `Optional region where the snapshot repository is created, defaults to "ece-region"`,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     eceRegion,
+		},
+		"s3": {
+			Type:         schema.TypeList,
+			Description:  "S3 compatible snapshot repository settings",
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: []string{"s3", "generic"},
+			Elem:         newS3Schema(),
+		},
+		"generic": {
+			Type:        schema.TypeList,
+			Description: "Snapshot repository settings for any of the Elasticsearch supported repository types, such as gcs, azure or fs",
+			Optional:    true,
+			MaxItems:    1,
+			Elem:        newGenericSchema(),
+		},
+	}
+}
+
+func newS3Schema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Description: "Required S3 region",
+				Required:    true,
+			},
+			"bucket": {
+				Type:        schema.TypeString,
+				Description: "Required name of the S3 bucket",
+				Required:    true,
+			},
+			"access_key": {
+				Type:        schema.TypeString,
+				Description: "Required S3 access key",
+				Required:    true,
+				Sensitive:   true,
+			},
+			"secret_key": {
+				Type:        schema.TypeString,
+				Description: "Required S3 secret key",
+				Required:    true,
+				Sensitive:   true,
+			},
+			"server_side_encryption": {
+				Type:        schema.TypeBool,
+				Description: "Optional flag which enables the server side encryption of the stored files",
+				Optional:    true,
+			},
+			"endpoint": {
+				Type:        schema.TypeString,
+				Description: "Optional endpoint of an S3 compatible storage service",
+				Optional:    true,
+			},
+			"path_style_access": {
+				Type:        schema.TypeBool,
+				Description: "Optional flag which makes the client use path style access, required by some S3 compatible storage services",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func newGenericSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Description:  `Required repository type, such as "gcs", "azure" or "fs"`,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"settings": {
+				Type:             schema.TypeString,
+				Description:      "Required JSON encoded repository settings, which may include credentials",
+				Required:         true,
+				Sensitive:        true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+		},
+	}
+}
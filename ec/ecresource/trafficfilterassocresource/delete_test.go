@@ -21,7 +21,6 @@ import (
 	"context"
 	"testing"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
 	"github.com/elastic/terraform-provider-ec/ec/internal/util"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -67,7 +66,7 @@ func Test_delete(t *testing.T) {
 			name: "returns an error when it receives a 500",
 			args: args{
 				d: tc500Err,
-				meta: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				meta: util.NewMockMeta(mock.NewErrorResponse(500, mock.APIError{
 					Code: "some", Message: "message",
 				})),
 			},
@@ -83,7 +82,7 @@ func Test_delete(t *testing.T) {
 			name: "returns nil and unsets the state when the error is known",
 			args: args{
 				d: tc404Err,
-				meta: api.NewMock(mock.NewErrorResponse(404, mock.APIError{
+				meta: util.NewMockMeta(mock.NewErrorResponse(404, mock.APIError{
 					Code: "some", Message: "message",
 				})),
 			},
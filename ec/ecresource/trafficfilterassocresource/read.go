@@ -20,7 +20,6 @@ package trafficfilterassocresource
 import (
 	"context"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -31,7 +30,7 @@ import (
 // read queries the remote deployment traffic filter ruleset association and
 // updates the local state.
 func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var client = meta.(*api.API)
+	var client = meta.(*util.ProviderMeta).API
 	res, err := trafficfilterapi.Get(trafficfilterapi.GetParams{
 		API:                 client,
 		ID:                  d.Get("traffic_filter_id").(string),
@@ -0,0 +1,144 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfiltercloneresource
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// ipRulesetType is the only ruleset type which can be cloned, since the
+// rest of the types reference endpoints which only exist in their region.
+const ipRulesetType = "ip"
+
+// getSource returns the source ruleset, failing when it can't be cloned.
+func getSource(client *api.API, id string) (*models.TrafficFilterRulesetInfo, error) {
+	res, err := trafficfilterapi.Get(trafficfilterapi.GetParams{
+		API: client, ID: id,
+	})
+	if err != nil {
+		return nil, multierror.NewPrefixed("failed obtaining the source ruleset", err)
+	}
+
+	if res.Type == nil || *res.Type != ipRulesetType {
+		var rulesetType string
+		if res.Type != nil {
+			rulesetType = *res.Type
+		}
+		return nil, fmt.Errorf(
+			`source ruleset %s has type "%s", only "%s" rulesets can be cloned into another region`,
+			id, rulesetType, ipRulesetType,
+		)
+	}
+
+	return res, nil
+}
+
+// flattenRules returns the rule sources and descriptions sorted by source,
+// so the rules of the source and the cloned ruleset can be compared.
+func flattenRules(rules []*models.TrafficFilterRule) []interface{} {
+	var result = make([]interface{}, 0, len(rules))
+	for _, r := range rules {
+		if r == nil {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"source":      r.Source,
+			"description": r.Description,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i].(map[string]interface{}), result[j].(map[string]interface{})
+		return a["source"].(string) < b["source"].(string)
+	})
+
+	return result
+}
+
+// expandModel returns the cloned ruleset request with the source ruleset
+// rules.
+func expandModel(d *schema.ResourceData, source *models.TrafficFilterRulesetInfo) *models.TrafficFilterRulesetRequest {
+	name := d.Get("name").(string)
+	if name == "" && source.Name != nil {
+		name = *source.Name
+	}
+
+	description := d.Get("description").(string)
+	if description == "" {
+		description = source.Description
+	}
+
+	var rules = make([]*models.TrafficFilterRule, 0, len(source.Rules))
+	for _, r := range source.Rules {
+		if r == nil {
+			continue
+		}
+		rules = append(rules, &models.TrafficFilterRule{
+			Source:      r.Source,
+			Description: r.Description,
+		})
+	}
+
+	return &models.TrafficFilterRulesetRequest{
+		Name:             ec.String(name),
+		Type:             ec.String(ipRulesetType),
+		Region:           ec.String(d.Get("region").(string)),
+		Description:      description,
+		IncludeByDefault: ec.Bool(d.Get("include_by_default").(bool)),
+		Rules:            rules,
+	}
+}
+
+// syncRulesDiff plans an update whenever the rules of the source ruleset
+// differ from the cloned ones, so the clone is kept in sync.
+func syncRulesDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("source_ruleset_id") {
+		return nil
+	}
+
+	source, err := getSource(meta.(*util.ProviderMeta).API, d.Get("source_ruleset_id").(string))
+	if err != nil {
+		return err
+	}
+
+	rules := flattenRules(source.Rules)
+	if current, _ := d.Get("rule").([]interface{}); rulesEqual(current, rules) {
+		return nil
+	}
+
+	return d.SetNew("rule", rules)
+}
+
+func rulesEqual(a, b []interface{}) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
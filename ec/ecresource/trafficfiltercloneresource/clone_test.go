@@ -0,0 +1,102 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfiltercloneresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSourceRuleset(rulesetType string) *models.TrafficFilterRulesetInfo {
+	return &models.TrafficFilterRulesetInfo{
+		ID:          ec.String("source-id"),
+		Name:        ec.String("office"),
+		Description: "approved office IPs",
+		Region:      ec.String("us-east-1"),
+		Type:        ec.String(rulesetType),
+		Rules: []*models.TrafficFilterRule{
+			{ID: "rule-2", Source: "192.168.0.0/24", Description: "vpn"},
+			{ID: "rule-1", Source: "10.0.0.1"},
+		},
+	}
+}
+
+func Test_getSource(t *testing.T) {
+	client := api.NewMock(mock.New200Response(mock.NewStructBody(newSourceRuleset("ip"))))
+	got, err := getSource(client, "source-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "office", *got.Name)
+
+	client = api.NewMock(mock.New200Response(mock.NewStructBody(newSourceRuleset("vpce"))))
+	_, err = getSource(client, "source-id")
+	assert.EqualError(t, err, `source ruleset source-id has type "vpce", only "ip" rulesets can be cloned into another region`)
+}
+
+func Test_flattenRules(t *testing.T) {
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"source": "10.0.0.1", "description": ""},
+		map[string]interface{}{"source": "192.168.0.0/24", "description": "vpn"},
+	}, flattenRules(newSourceRuleset("ip").Rules))
+}
+
+func Test_expandModel(t *testing.T) {
+	source := newSourceRuleset("ip")
+	rules := []*models.TrafficFilterRule{
+		{Source: "192.168.0.0/24", Description: "vpn"},
+		{Source: "10.0.0.1"},
+	}
+
+	t.Run("defaults to the source name and description", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, newSchema(), map[string]interface{}{
+			"source_ruleset_id": "source-id",
+			"region":            "eu-west-1",
+		})
+		assert.Equal(t, &models.TrafficFilterRulesetRequest{
+			Name:             ec.String("office"),
+			Type:             ec.String("ip"),
+			Region:           ec.String("eu-west-1"),
+			Description:      "approved office IPs",
+			IncludeByDefault: ec.Bool(false),
+			Rules:            rules,
+		}, expandModel(d, source))
+	})
+
+	t.Run("uses the configured settings", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, newSchema(), map[string]interface{}{
+			"source_ruleset_id":  "source-id",
+			"region":             "eu-west-1",
+			"name":               "office-eu",
+			"description":        "approved office IPs (EU)",
+			"include_by_default": true,
+		})
+		assert.Equal(t, &models.TrafficFilterRulesetRequest{
+			Name:             ec.String("office-eu"),
+			Type:             ec.String("ip"),
+			Region:           ec.String("eu-west-1"),
+			Description:      "approved office IPs (EU)",
+			IncludeByDefault: ec.Bool(true),
+			Rules:            rules,
+		}, expandModel(d, source))
+	})
+}
@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfiltercloneresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// read refreshes the cloned ruleset. The source ruleset is compared with it
+// when planning, see syncRulesDiff.
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var client = meta.(*util.ProviderMeta).API
+
+	res, err := trafficfilterapi.Get(trafficfilterapi.GetParams{
+		API: client, ID: d.Id(),
+	})
+	if err != nil {
+		if util.TrafficFilterNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(modelToState(d, res))
+}
+
+func modelToState(d *schema.ResourceData, res *models.TrafficFilterRulesetInfo) error {
+	if res.Name != nil {
+		if err := d.Set("name", *res.Name); err != nil {
+			return err
+		}
+	}
+
+	if res.Region != nil {
+		if err := d.Set("region", *res.Region); err != nil {
+			return err
+		}
+	}
+
+	if err := d.Set("description", res.Description); err != nil {
+		return err
+	}
+
+	if res.IncludeByDefault != nil {
+		if err := d.Set("include_by_default", *res.IncludeByDefault); err != nil {
+			return err
+		}
+	}
+
+	return d.Set("rule", flattenRules(res.Rules))
+}
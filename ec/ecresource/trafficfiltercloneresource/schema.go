@@ -0,0 +1,79 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfiltercloneresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// newSchema returns the schema for an
+// "ec_deployment_traffic_filter_ruleset_clone" resource.
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"source_ruleset_id": {
+			Type:         schema.TypeString,
+			Description:  `Required ID of the "ip" traffic filtering ruleset whose rules are cloned`,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Description: "Required region where the ruleset is cloned",
+			Required:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Optional name of the cloned ruleset. Defaults to the source ruleset name",
+			Optional:    true,
+			Computed:    true,
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Description: "Optional description of the cloned ruleset. Defaults to the source ruleset description",
+			Optional:    true,
+			Computed:    true,
+		},
+		"include_by_default": {
+			Type:        schema.TypeBool,
+			Description: "Optionally apply the cloned ruleset to all the new deployments in the region. Defaults to false",
+			Optional:    true,
+			Default:     false,
+		},
+
+		// Computed
+		"rule": {
+			Type:        schema.TypeList,
+			Description: "Rules of the cloned ruleset, kept in sync with the source ruleset ones",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"source": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"description": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
@@ -20,15 +20,16 @@ package trafficfilterresource
 import (
 	"context"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
 )
 
 // Create will create a new deployment traffic filter ruleset
 func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var client = meta.(*api.API)
+	var client = meta.(*util.ProviderMeta).API
 	res, err := trafficfilterapi.Create(trafficfilterapi.CreateParams{
 		API: client, Req: expandModel(d),
 	})
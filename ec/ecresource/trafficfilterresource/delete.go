@@ -21,7 +21,6 @@ import (
 	"context"
 	"errors"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/trafficfilterapi"
 	"github.com/elastic/cloud-sdk-go/pkg/client/deployments_traffic_filter"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -32,7 +31,7 @@ import (
 
 // Delete will delete an existing deployment traffic filter ruleset
 func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var client = meta.(*api.API)
+	var client = meta.(*util.ProviderMeta).API
 
 	res, err := trafficfilterapi.Get(trafficfilterapi.GetParams{
 		API: client, ID: d.Id(), IncludeAssociations: true,
@@ -21,7 +21,6 @@ import (
 	"context"
 	"testing"
 
-	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
 	"github.com/elastic/cloud-sdk-go/pkg/models"
 	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
@@ -103,7 +102,7 @@ func Test_delete(t *testing.T) {
 			name: "returns an error when it receives a 500",
 			args: args{
 				d: tc500Err,
-				meta: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				meta: util.NewMockMeta(mock.NewErrorResponse(500, mock.APIError{
 					Code: "some", Message: "message",
 				})),
 			},
@@ -119,7 +118,7 @@ func Test_delete(t *testing.T) {
 			name: "returns nil and unsets the state when the error is known",
 			args: args{
 				d: tc404Err,
-				meta: api.NewMock(mock.NewErrorResponse(404, mock.APIError{
+				meta: util.NewMockMeta(mock.NewErrorResponse(404, mock.APIError{
 					Code: "some", Message: "message",
 				})),
 			},
@@ -130,7 +129,7 @@ func Test_delete(t *testing.T) {
 			name: "returns error when the error is unknown",
 			args: args{
 				d: tc404AssocErr,
-				meta: api.NewMock(
+				meta: util.NewMockMeta(
 					mock.New200StructResponse(models.TrafficFilterRulesetInfo{
 						Associations: []*models.FilterAssociation{
 							{ID: ec.String("some id"), EntityType: ec.String("deployment")},
@@ -152,7 +151,7 @@ func Test_delete(t *testing.T) {
 			name: "returns nil and unsets the state when the error is known",
 			args: args{
 				d: tc404DeleteErr,
-				meta: api.NewMock(
+				meta: util.NewMockMeta(
 					mock.New200StructResponse(models.TrafficFilterRulesetInfo{
 						Associations: []*models.FilterAssociation{
 							{ID: ec.String("some id"), EntityType: ec.String("deployment")},
@@ -171,7 +170,7 @@ func Test_delete(t *testing.T) {
 			name: "returns error when the delete returns a 500 error",
 			args: args{
 				d: tc500DeleteErr,
-				meta: api.NewMock(
+				meta: util.NewMockMeta(
 					mock.New200StructResponse(models.TrafficFilterRulesetInfo{
 						Associations: []*models.FilterAssociation{
 							{ID: ec.String("some id"), EntityType: ec.String("deployment")},
@@ -41,7 +41,7 @@ func expandModel(d *schema.ResourceData) *models.TrafficFilterRulesetRequest {
 		}
 
 		rule := models.TrafficFilterRule{
-			Source: m["source"].(string),
+			Source: normalizeSource(m["source"].(string)),
 		}
 
 		if val, ok := m["id"]; ok {
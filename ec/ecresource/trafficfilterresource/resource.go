@@ -33,6 +33,7 @@ func Resource() *schema.Resource {
 		ReadContext:   read,
 		UpdateContext: update,
 		DeleteContext: delete,
+		CustomizeDiff: validateRulesDiff,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -21,6 +21,7 @@ import (
 	"bytes"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // newSchema returns the schema for an "ec_deployment_traffic_filter" resource.
@@ -32,9 +33,10 @@ func newSchema() map[string]*schema.Schema {
 			Required:    true,
 		},
 		"type": {
-			Type:        schema.TypeString,
-			Description: `Required type of the ruleset ("ip", "vpce" or "azure_private_endpoint")`,
-			Required:    true,
+			Type:         schema.TypeString,
+			Description:  `Required type of the ruleset ("ip", "vpce", "azure_private_endpoint" or "gcp_private_service_connect_endpoint")`,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(rulesetTypes, false),
 		},
 		"region": {
 			Type:        schema.TypeString,
@@ -50,9 +52,10 @@ func newSchema() map[string]*schema.Schema {
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"source": {
-						Type:        schema.TypeString,
-						Description: "Required traffic filter source: IP address, CIDR mask, or VPC endpoint ID, not required when the type is azure_private_endpoint",
-						Optional:    true,
+						Type:             schema.TypeString,
+						Description:      "Required traffic filter source: IP address, CIDR mask, VPC endpoint ID or Private Service Connect connection ID, not required when the type is azure_private_endpoint",
+						Optional:         true,
+						DiffSuppressFunc: suppressEquivalentSourceDiff,
 					},
 
 					"description": {
@@ -63,13 +66,13 @@ func newSchema() map[string]*schema.Schema {
 
 					"azure_endpoint_name": {
 						Type:        schema.TypeString,
-						Description: "Optional Azure endpoint name",
+						Description: "Optional Azure endpoint name, required when the type is azure_private_endpoint",
 						Optional:    true,
 					},
 
 					"azure_endpoint_guid": {
 						Type:        schema.TypeString,
-						Description: "Optional Azure endpoint GUID",
+						Description: "Optional Azure endpoint GUID, required when the type is azure_private_endpoint",
 						Optional:    true,
 					},
 
@@ -100,7 +103,7 @@ func trafficFilterRuleHash(v interface{}) int {
 	var buf bytes.Buffer
 	m := v.(map[string]interface{})
 	if m["source"] != nil {
-		buf.WriteString(m["source"].(string))
+		buf.WriteString(normalizeSource(m["source"].(string)))
 	}
 	if m["description"] != nil {
 		buf.WriteString(m["description"].(string))
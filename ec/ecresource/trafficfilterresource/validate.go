@@ -0,0 +1,164 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfilterresource
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Supported traffic filter ruleset types.
+const (
+	ipType     = "ip"
+	vpceType   = "vpce"
+	azureType  = "azure_private_endpoint"
+	gcpPSCType = "gcp_private_service_connect_endpoint"
+)
+
+const (
+	sourceKey    = "source"
+	azureNameKey = "azure_endpoint_name"
+	azureGUIDKey = "azure_endpoint_guid"
+)
+
+var rulesetTypes = []string{ipType, vpceType, azureType, gcpPSCType}
+
+// validateRulesDiff fails the plan when any of the rule fields don't match
+// the ruleset type.
+func validateRulesDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	// When any of the values are interpolated from other resources, they
+	// won't be known until apply time, so the check can't be performed.
+	if !d.NewValueKnown("type") || !d.NewValueKnown("rule") {
+		return nil
+	}
+
+	return validateRules(d.Get("type").(string), d.Get("rule").(*schema.Set).List())
+}
+
+// validateRules ensures that the rules only set the fields which are
+// applicable to the ruleset type:
+//   - "ip", "vpce" and "gcp_private_service_connect_endpoint" rules require a
+//     source (IP address or CIDR mask, VPC endpoint ID or PSC connection ID).
+//   - "azure_private_endpoint" rules require the azure_endpoint_name and
+//     azure_endpoint_guid, but not a source.
+func validateRules(ruleType string, rules []interface{}) error {
+	merr := multierror.NewPrefixed("invalid traffic filter rules")
+	for _, r := range rules {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name := ruleName(m)
+
+		set := func(key string) bool {
+			v, ok := m[key].(string)
+			return ok && v != ""
+		}
+
+		switch ruleType {
+		case azureType:
+			if set(sourceKey) {
+				merr = merr.Append(fmt.Errorf(
+					`%s: "%s" cannot be set when the type is "%s"`, name, sourceKey, ruleType,
+				))
+			}
+			for _, key := range []string{azureNameKey, azureGUIDKey} {
+				if !set(key) {
+					merr = merr.Append(fmt.Errorf(
+						`%s: "%s" is required when the type is "%s"`, name, key, ruleType,
+					))
+				}
+			}
+		case ipType, vpceType, gcpPSCType:
+			if ruleType == ipType && set(sourceKey) && !validIPSource(m[sourceKey].(string)) {
+				merr = merr.Append(fmt.Errorf(
+					`%s: "%s" must be a valid IP address or CIDR mask when the type is "%s"`,
+					name, sourceKey, ruleType,
+				))
+			}
+			if !set(sourceKey) {
+				merr = merr.Append(fmt.Errorf(
+					`%s: "%s" is required when the type is "%s"`, name, sourceKey, ruleType,
+				))
+			}
+			for _, key := range []string{azureNameKey, azureGUIDKey} {
+				if set(key) {
+					merr = merr.Append(fmt.Errorf(
+						`%s: "%s" can only be set when the type is "%s"`, name, key, azureType,
+					))
+				}
+			}
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// ruleName identifies the rule in the validation errors by the first of its
+// source, azure_endpoint_name or description fields which is set, since the
+// rules are a set and their position doesn't match the configuration.
+func ruleName(m map[string]interface{}) string {
+	for _, key := range []string{sourceKey, azureNameKey, "description"} {
+		if v, ok := m[key].(string); ok && v != "" {
+			return fmt.Sprintf(`rule with %s "%s"`, key, v)
+		}
+	}
+	return "rule without source"
+}
+
+// validIPSource returns whether the source is an IP address or a CIDR mask.
+func validIPSource(source string) bool {
+	if net.ParseIP(source) != nil {
+		return true
+	}
+	_, _, err := net.ParseCIDR(source)
+	return err == nil
+}
+
+// normalizeSource returns the source in the same form as the API returns it:
+// IP addresses and CIDR masks are written in their canonical form and the
+// single host masks (/32 and /128) are stripped. Any other sources, such as
+// endpoint IDs, are returned as they are.
+func normalizeSource(source string) string {
+	if ip := net.ParseIP(source); ip != nil {
+		return ip.String()
+	}
+
+	ip, ipNet, err := net.ParseCIDR(source)
+	if err != nil {
+		return source
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	if ones == bits {
+		return ip.String()
+	}
+
+	return fmt.Sprintf("%s/%d", ip.String(), ones)
+}
+
+// suppressEquivalentSourceDiff suppresses the differences between sources
+// which are equal once normalized, such as "192.168.1.1/32" and
+// "192.168.1.1".
+func suppressEquivalentSourceDiff(_, old, new string, _ *schema.ResourceData) bool {
+	return normalizeSource(old) == normalizeSource(new)
+}
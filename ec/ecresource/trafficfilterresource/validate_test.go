@@ -0,0 +1,145 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trafficfilterresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateRules(t *testing.T) {
+	type args struct {
+		ruleType string
+		rules    []interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		errs []string
+	}{
+		{
+			name: "ip rules with a source",
+			args: args{ruleType: "ip", rules: []interface{}{
+				map[string]interface{}{"source": "0.0.0.0/0"},
+			}},
+		},
+		{
+			name: "gcp psc rules with a connection id",
+			args: args{ruleType: "gcp_private_service_connect_endpoint", rules: []interface{}{
+				map[string]interface{}{"source": "18446744072646845332"},
+			}},
+		},
+		{
+			name: "azure rules with an endpoint name and guid",
+			args: args{ruleType: "azure_private_endpoint", rules: []interface{}{
+				map[string]interface{}{
+					"azure_endpoint_name": "my-azure-pl",
+					"azure_endpoint_guid": "78c64959-fd88-41cc-81ac-1cfcdb1ac32e",
+				},
+			}},
+		},
+		{
+			name: "ip rules with an invalid source",
+			args: args{ruleType: "ip", rules: []interface{}{
+				map[string]interface{}{"source": "1.1.1.1/33"},
+			}},
+			errs: []string{
+				`rule with source "1.1.1.1/33": "source" must be a valid IP address or CIDR mask when the type is "ip"`,
+			},
+		},
+		{
+			name: "vpce rules without a source",
+			args: args{ruleType: "vpce", rules: []interface{}{
+				map[string]interface{}{"description": "some"},
+			}},
+			errs: []string{
+				`rule with description "some": "source" is required when the type is "vpce"`,
+			},
+		},
+		{
+			name: "gcp psc rules with azure fields",
+			args: args{ruleType: "gcp_private_service_connect_endpoint", rules: []interface{}{
+				map[string]interface{}{
+					"source":              "18446744072646845332",
+					"azure_endpoint_name": "my-azure-pl",
+				},
+			}},
+			errs: []string{
+				`rule with source "18446744072646845332": "azure_endpoint_name" can only be set when the type is "azure_private_endpoint"`,
+			},
+		},
+		{
+			name: "azure rules with a source and without a guid",
+			args: args{ruleType: "azure_private_endpoint", rules: []interface{}{
+				map[string]interface{}{
+					"source":              "0.0.0.0/0",
+					"azure_endpoint_name": "my-azure-pl",
+				},
+			}},
+			errs: []string{
+				`rule with source "0.0.0.0/0": "source" cannot be set when the type is "azure_private_endpoint"`,
+				`rule with source "0.0.0.0/0": "azure_endpoint_guid" is required when the type is "azure_private_endpoint"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRules(tt.args.ruleType, tt.args.rules)
+			if tt.errs == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			// The errors are sorted by multierror, so they're compared
+			// regardless of their order.
+			var merr *multierror.Prefixed
+			if assert.True(t, errors.As(err, &merr)) {
+				var got []string
+				for _, e := range merr.Errors {
+					got = append(got, e.Error())
+				}
+				assert.ElementsMatch(t, tt.errs, got)
+			}
+		})
+	}
+}
+
+func Test_normalizeSource(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{source: "192.168.1.1", want: "192.168.1.1"},
+		{source: "192.168.1.1/32", want: "192.168.1.1"},
+		{source: "192.168.1.0/24", want: "192.168.1.0/24"},
+		{source: "0.0.0.0/0", want: "0.0.0.0/0"},
+		{source: "2001:0db8:0000:0000:0000:0000:0000:0001", want: "2001:db8::1"},
+		{source: "2001:db8::1/128", want: "2001:db8::1"},
+		{source: "2001:DB8::/32", want: "2001:db8::/32"},
+		{source: "vpce-00000000000", want: "vpce-00000000000"},
+		{source: "18446744072646845332", want: "18446744072646845332"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizeSource(tt.source))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trustedenvironmentresource
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// expandRelationship returns the configured trust relationship along with its
+// kind and the key which identifies it within its kind.
+func expandRelationship(d *schema.ResourceData) (kind, key string, rel *relationship) {
+	for _, kind := range trustKinds {
+		raw, ok := d.Get(kind).([]interface{})
+		if !ok || len(raw) == 0 || raw[0] == nil {
+			continue
+		}
+
+		m := raw[0].(map[string]interface{})
+		switch kind {
+		case accountTrust:
+			acc := expandAccount(m)
+			return kind, *acc.AccountID, &relationship{account: acc}
+		case externalTrust:
+			ext := expandExternal(m)
+			return kind, *ext.TrustRelationshipID, &relationship{external: ext}
+		case directTrust:
+			dir := expandDirect(m)
+			return kind, *dir.Name, &relationship{direct: dir}
+		}
+	}
+
+	return "", "", nil
+}
+
+func expandAccount(m map[string]interface{}) *models.AccountTrustRelationship {
+	var res = models.AccountTrustRelationship{
+		AccountID:      ec.String(m["account_id"].(string)),
+		TrustAll:       ec.Bool(m["trust_all"].(bool)),
+		TrustAllowlist: expandAllowlist(m),
+	}
+
+	if name, ok := m["name"].(string); ok {
+		res.Name = name
+	}
+
+	return &res
+}
+
+func expandExternal(m map[string]interface{}) *models.ExternalTrustRelationship {
+	return &models.ExternalTrustRelationship{
+		TrustRelationshipID: ec.String(m["relationship_id"].(string)),
+		TrustAll:            ec.Bool(m["trust_all"].(bool)),
+		TrustAllowlist:      expandAllowlist(m),
+	}
+}
+
+func expandDirect(m map[string]interface{}) *models.DirectTrustRelationship {
+	var res = models.DirectTrustRelationship{
+		Name:           ec.String(m["name"].(string)),
+		TrustAll:       ec.Bool(m["trust_all"].(bool)),
+		TrustAllowlist: expandAllowlist(m),
+	}
+
+	if t, ok := m["type"].(string); ok {
+		res.Type = t
+	}
+
+	if scope, ok := m["scope_id"].(string); ok {
+		res.ScopeID = scope
+	}
+
+	if names, ok := m["additional_node_names"].(*schema.Set); ok && names.Len() > 0 {
+		res.AdditionalNodeNames = util.ItemsToString(names.List())
+	}
+
+	if certs, ok := m["certificates"].([]interface{}); ok {
+		for _, pem := range util.ItemsToString(certs) {
+			res.Certificates = append(res.Certificates, &models.TrustedCertificate{
+				Pem: ec.String(pem),
+			})
+		}
+	}
+
+	return &res
+}
+
+func expandAllowlist(m map[string]interface{}) []string {
+	if al, ok := m["trust_allowlist"].(*schema.Set); ok && al.Len() > 0 {
+		return util.ItemsToString(al.List())
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trustedenvironmentresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+// modelToState sets the trust relationship in the state, the block of any
+// other kind is cleared.
+func modelToState(d *schema.ResourceData, deploymentID string, rel *relationship) error {
+	if err := d.Set("deployment_id", deploymentID); err != nil {
+		return err
+	}
+
+	var account, external, direct []interface{}
+	switch {
+	case rel.account != nil:
+		account = []interface{}{flattenAccount(rel)}
+	case rel.external != nil:
+		external = []interface{}{flattenExternal(rel)}
+	case rel.direct != nil:
+		direct = []interface{}{flattenDirect(rel)}
+	}
+
+	if err := d.Set(accountTrust, account); err != nil {
+		return err
+	}
+
+	if err := d.Set(externalTrust, external); err != nil {
+		return err
+	}
+
+	return d.Set(directTrust, direct)
+}
+
+func flattenAccount(rel *relationship) map[string]interface{} {
+	acc := rel.account
+	return map[string]interface{}{
+		"account_id":      deref(acc.AccountID),
+		"name":            acc.Name,
+		"trust_all":       acc.TrustAll != nil && *acc.TrustAll,
+		"trust_allowlist": flattenStringSet(acc.TrustAllowlist),
+	}
+}
+
+func flattenExternal(rel *relationship) map[string]interface{} {
+	ext := rel.external
+	return map[string]interface{}{
+		"relationship_id": deref(ext.TrustRelationshipID),
+		"trust_all":       ext.TrustAll != nil && *ext.TrustAll,
+		"trust_allowlist": flattenStringSet(ext.TrustAllowlist),
+	}
+}
+
+func flattenDirect(rel *relationship) map[string]interface{} {
+	dir := rel.direct
+
+	var certificates []interface{}
+	for _, cert := range dir.Certificates {
+		if cert != nil && cert.Pem != nil {
+			certificates = append(certificates, *cert.Pem)
+		}
+	}
+
+	// The API omits the type when it's the default one.
+	var t = dir.Type
+	if t == "" {
+		t = "generic"
+	}
+
+	return map[string]interface{}{
+		"name":                  deref(dir.Name),
+		"type":                  t,
+		"scope_id":              dir.ScopeID,
+		"trust_all":             dir.TrustAll != nil && *dir.TrustAll,
+		"trust_allowlist":       flattenStringSet(dir.TrustAllowlist),
+		"additional_node_names": flattenStringSet(dir.AdditionalNodeNames),
+		"certificates":          certificates,
+		"uid":                   dir.UID,
+	}
+}
+
+func flattenStringSet(values []string) *schema.Set {
+	return schema.NewSet(schema.HashString, util.StringToItems(values...))
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,82 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trustedenvironmentresource
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_modelToState(t *testing.T) {
+	const deploymentID = "320b7b540dfc967a7a649c18e2fce4ed"
+	const pem = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----"
+
+	trust := &models.ElasticsearchClusterTrustSettings{
+		Accounts: []*models.AccountTrustRelationship{
+			{AccountID: ec.String("1234567"), Name: "prod", TrustAll: ec.Bool(true)},
+		},
+		Direct: []*models.DirectTrustRelationship{{
+			Name:           ec.String("on-prem"),
+			ScopeID:        "abc123",
+			TrustAll:       ec.Bool(false),
+			TrustAllowlist: []string{"cluster-1"},
+			UID:            "some-uid",
+			Certificates: []*models.TrustedCertificate{
+				{Pem: ec.String(pem), Metadata: &models.CertificateMetaData{}},
+			},
+		}},
+	}
+
+	t.Run("flattens an account relationship", func(t *testing.T) {
+		rd := schema.TestResourceDataRaw(t, newSchema(), nil)
+		rel, ok := findRelationship(trust, accountTrust, "1234567")
+		assert.True(t, ok)
+
+		assert.NoError(t, modelToState(rd, deploymentID, rel))
+		assert.Equal(t, deploymentID, rd.Get("deployment_id"))
+		assert.Equal(t, "1234567", rd.Get("account.0.account_id"))
+		assert.Equal(t, "prod", rd.Get("account.0.name"))
+		assert.Equal(t, true, rd.Get("account.0.trust_all"))
+		assert.Empty(t, rd.Get("direct"))
+	})
+
+	t.Run("flattens a direct relationship", func(t *testing.T) {
+		rd := schema.TestResourceDataRaw(t, newSchema(), nil)
+		rel, ok := findRelationship(trust, directTrust, "on-prem")
+		assert.True(t, ok)
+
+		assert.NoError(t, modelToState(rd, deploymentID, rel))
+		assert.Equal(t, "on-prem", rd.Get("direct.0.name"))
+		assert.Equal(t, "generic", rd.Get("direct.0.type"))
+		assert.Equal(t, "abc123", rd.Get("direct.0.scope_id"))
+		assert.Equal(t, false, rd.Get("direct.0.trust_all"))
+		assert.Equal(t, []interface{}{"cluster-1"}, rd.Get("direct.0.trust_allowlist").(*schema.Set).List())
+		assert.Equal(t, []interface{}{pem}, rd.Get("direct.0.certificates"))
+		assert.Equal(t, "some-uid", rd.Get("direct.0.uid"))
+		assert.Empty(t, rd.Get("account"))
+	})
+
+	t.Run("doesn't find a missing relationship", func(t *testing.T) {
+		_, ok := findRelationship(trust, externalTrust, "ece")
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,63 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trustedenvironmentresource
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
+)
+
+func read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*util.ProviderMeta).API
+	deploymentID, kind, key, err := parseTrustID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := getDeployment(client, deploymentID)
+	if err != nil {
+		if util.DeploymentNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(multierror.NewPrefixed("failed reading deployment trust relationship", err))
+	}
+
+	es, err := elasticsearchResource(res)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The trust relationship has been removed outside of Terraform.
+	rel, ok := findRelationship(trustSettings(es), kind, key)
+	if !ok {
+		d.SetId("")
+		return nil
+	}
+
+	if err := modelToState(d, deploymentID, rel); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
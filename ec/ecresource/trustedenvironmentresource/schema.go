@@ -0,0 +1,179 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trustedenvironmentresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var trustKinds = []string{accountTrust, externalTrust, directTrust}
+
+// newSchema returns the schema for an "ec_trusted_environment" resource.
+func newSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"deployment_id": {
+			Type:        schema.TypeString,
+			Description: "Required ID of the deployment whose Elasticsearch resource trusts the environment",
+			Required:    true,
+			ForceNew:    true,
+		},
+		accountTrust: {
+			Type:         schema.TypeList,
+			Description:  "Optional trust relationship with the clusters of an Elastic Cloud account",
+			Optional:     true,
+			ForceNew:     true,
+			MaxItems:     1,
+			ExactlyOneOf: trustKinds,
+			Elem:         newAccountSchema(),
+		},
+		externalTrust: {
+			Type:         schema.TypeList,
+			Description:  "Optional trust relationship with the clusters of an external environment, such as an ECE installation",
+			Optional:     true,
+			ForceNew:     true,
+			MaxItems:     1,
+			ExactlyOneOf: trustKinds,
+			Elem:         newExternalSchema(),
+		},
+		directTrust: {
+			Type:         schema.TypeList,
+			Description:  "Optional trust relationship with the clusters using the specified CA certificates",
+			Optional:     true,
+			ForceNew:     true,
+			MaxItems:     1,
+			ExactlyOneOf: trustKinds,
+			Elem:         newDirectSchema(),
+		},
+	}
+}
+
+func newAccountSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:         schema.TypeString,
+				Description:  "Required ID of the trusted account",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Optional human readable name of the trust relationship",
+				Optional:    true,
+			},
+			"trust_all": {
+				Type:        schema.TypeBool,
+				Description: "Required flag to trust all the clusters of the account, `trust_allowlist` is ignored when set",
+				Required:    true,
+			},
+			"trust_allowlist": newTrustAllowlistSchema(),
+		},
+	}
+}
+
+func newExternalSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"relationship_id": {
+				Type:         schema.TypeString,
+				Description:  "Required ID of the external trust relationship",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"trust_all": {
+				Type:        schema.TypeBool,
+				Description: "Required flag to trust all the clusters of the environment, `trust_allowlist` is ignored when set",
+				Required:    true,
+			},
+			"trust_allowlist": newTrustAllowlistSchema(),
+		},
+	}
+}
+
+func newDirectSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "Required human readable name of the trust relationship",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Description:  `Optional type of the trusted environment, one of "ESS", "ECE" or "generic"`,
+				Optional:     true,
+				Default:      "generic",
+				ValidateFunc: validation.StringInSlice([]string{"ESS", "ECE", "generic"}, false),
+			},
+			"scope_id": {
+				Type:        schema.TypeString,
+				Description: "Optional scope ID of the trusted clusters, usually an organization or environment ID. Required when `trust_all` is set or `trust_allowlist` isn't empty",
+				Optional:    true,
+			},
+			"trust_all": {
+				Type:        schema.TypeBool,
+				Description: "Required flag to trust all the clusters matching the `scope_id`, `trust_allowlist` is ignored when set",
+				Required:    true,
+			},
+			"trust_allowlist": newTrustAllowlistSchema(),
+			"additional_node_names": {
+				Type:        schema.TypeSet,
+				Description: "Optional list of node names to trust in addition to the ones matching the `scope_id` and `trust_allowlist`",
+				Optional:    true,
+				Set:         schema.HashString,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"certificates": {
+				Type:        schema.TypeList,
+				Description: "Required list of the PEM encoded public CA certificates to trust. Multiple certificates can be set to rotate them",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+
+			// Computed
+			"uid": {
+				Type:        schema.TypeString,
+				Description: "Identifier generated by Elastic Cloud for the trust relationship",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func newTrustAllowlistSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Description: "Optional list of the cluster IDs to trust, only used when `trust_all` is false",
+		Optional:    true,
+		Set:         schema.HashString,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
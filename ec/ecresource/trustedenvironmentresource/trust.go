@@ -0,0 +1,238 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trustedenvironmentresource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource"
+)
+
+const (
+	accountTrust  = "account"
+	externalTrust = "external"
+	directTrust   = "direct"
+)
+
+// relationship is a single trust relationship of any of the trust kinds,
+// only the field matching its kind is set.
+type relationship struct {
+	account  *models.AccountTrustRelationship
+	external *models.ExternalTrustRelationship
+	direct   *models.DirectTrustRelationship
+}
+
+// trustID returns the resource ID from the deployment ID, the kind of trust
+// relationship and the key which identifies it within its kind.
+func trustID(deploymentID, kind, key string) string {
+	return strings.Join([]string{deploymentID, kind, key}, "/")
+}
+
+// parseTrustID returns the deployment ID, trust kind and key from the
+// resource ID.
+func parseTrustID(id string) (deploymentID, kind, key string, err error) {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" || !validKind(parts[1]) {
+		return "", "", "", fmt.Errorf(
+			`invalid ID "%s": expected format <deployment_id>/<account|external|direct>/<key>`, id,
+		)
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
+
+func validKind(kind string) bool {
+	for _, k := range trustKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// getDeployment obtains the deployment with its current plans and settings.
+func getDeployment(client *api.API, id string) (*models.DeploymentGetResponse, error) {
+	return deploymentapi.Get(deploymentapi.GetParams{
+		API: client, DeploymentID: id,
+		QueryParams: deputil.QueryParams{ShowPlans: true, ShowSettings: true},
+	})
+}
+
+// elasticsearchResource returns the Elasticsearch resource of the deployment.
+func elasticsearchResource(res *models.DeploymentGetResponse) (*models.ElasticsearchResourceInfo, error) {
+	if res.Resources == nil || len(res.Resources.Elasticsearch) == 0 {
+		return nil, errors.New("the deployment has no Elasticsearch resource")
+	}
+
+	return res.Resources.Elasticsearch[0], nil
+}
+
+// trustSettings returns the trust settings of the Elasticsearch resource.
+func trustSettings(es *models.ElasticsearchResourceInfo) *models.ElasticsearchClusterTrustSettings {
+	if es.Info == nil || es.Info.Settings == nil {
+		return nil
+	}
+	return es.Info.Settings.Trust
+}
+
+// findRelationship returns the trust relationship identified by the kind and
+// key, and whether it is set at all.
+func findRelationship(trust *models.ElasticsearchClusterTrustSettings, kind, key string) (*relationship, bool) {
+	if trust == nil {
+		return nil, false
+	}
+
+	switch kind {
+	case accountTrust:
+		for _, acc := range trust.Accounts {
+			if acc.AccountID != nil && *acc.AccountID == key {
+				return &relationship{account: acc}, true
+			}
+		}
+	case externalTrust:
+		for _, ext := range trust.External {
+			if ext.TrustRelationshipID != nil && *ext.TrustRelationshipID == key {
+				return &relationship{external: ext}, true
+			}
+		}
+	case directTrust:
+		for _, dir := range trust.Direct {
+			if dir.Name != nil && *dir.Name == key {
+				return &relationship{direct: dir}, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// setRelationship returns the trust settings with the relationship identified
+// by the kind and key replaced by rel, or removed when rel is nil. Any other
+// trust relationships are preserved.
+func setRelationship(trust *models.ElasticsearchClusterTrustSettings, kind, key string, rel *relationship) *models.ElasticsearchClusterTrustSettings {
+	var result models.ElasticsearchClusterTrustSettings
+	if trust != nil {
+		result = *trust
+	}
+
+	switch kind {
+	case accountTrust:
+		accounts := make([]*models.AccountTrustRelationship, 0, len(result.Accounts)+1)
+		for _, acc := range result.Accounts {
+			if acc.AccountID == nil || *acc.AccountID != key {
+				accounts = append(accounts, acc)
+			}
+		}
+		if rel != nil && rel.account != nil {
+			accounts = append(accounts, rel.account)
+		}
+		result.Accounts = accounts
+	case externalTrust:
+		external := make([]*models.ExternalTrustRelationship, 0, len(result.External)+1)
+		for _, ext := range result.External {
+			if ext.TrustRelationshipID == nil || *ext.TrustRelationshipID != key {
+				external = append(external, ext)
+			}
+		}
+		if rel != nil && rel.external != nil {
+			external = append(external, rel.external)
+		}
+		result.External = external
+	case directTrust:
+		direct := make([]*models.DirectTrustRelationship, 0, len(result.Direct)+1)
+		var uid string
+		for _, dir := range result.Direct {
+			if dir.Name != nil && *dir.Name == key {
+				uid = dir.UID
+				continue
+			}
+			direct = append(direct, dir)
+		}
+		if rel != nil && rel.direct != nil {
+			// Keeping the generated UID makes the API update the existing
+			// relationship instead of replacing it.
+			if rel.direct.UID == "" {
+				rel.direct.UID = uid
+			}
+			direct = append(direct, rel.direct)
+		}
+		result.Direct = direct
+	}
+
+	return &result
+}
+
+// updateTrust reads the deployment trust settings and updates the deployment
+// with the relationship identified by the kind and key set to rel, or removed
+// when rel is nil. Since the API has no trust specific endpoints, the current
+// Elasticsearch plan is sent along with the whole set of trust relationships,
+// once the update is submitted it waits for the resulting plan to finish.
+func updateTrust(ctx context.Context, client *api.API, deploymentID, kind, key string, rel *relationship) error {
+	res, err := getDeployment(client, deploymentID)
+	if err != nil {
+		return err
+	}
+
+	es, err := elasticsearchResource(res)
+	if err != nil {
+		return err
+	}
+
+	if es.Info == nil || es.Info.PlanInfo == nil || es.Info.PlanInfo.Current == nil ||
+		es.Info.PlanInfo.Current.Plan == nil {
+		return errors.New("the deployment Elasticsearch resource has no current plan")
+	}
+
+	var name string
+	if res.Name != nil {
+		name = *res.Name
+	}
+
+	if _, err := deploymentapi.Update(deploymentapi.UpdateParams{
+		API:          client,
+		DeploymentID: deploymentID,
+		Request: &models.DeploymentUpdateRequest{
+			Name:         name,
+			Alias:        res.Alias,
+			PruneOrphans: ec.Bool(false),
+			Resources: &models.DeploymentUpdateResources{
+				Elasticsearch: []*models.ElasticsearchPayload{{
+					RefID:  es.RefID,
+					Region: es.Region,
+					Plan:   es.Info.PlanInfo.Current.Plan,
+					Settings: &models.ElasticsearchClusterSettings{
+						Trust: setRelationship(trustSettings(es), kind, key, rel),
+					},
+				}},
+			},
+		},
+	}); err != nil {
+		return err
+	}
+
+	return deploymentresource.WaitForPlanCompletion(ctx, client, deploymentID)
+}
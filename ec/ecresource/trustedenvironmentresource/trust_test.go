@@ -0,0 +1,166 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trustedenvironmentresource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseTrustID(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		wantDeploymentID string
+		wantKind         string
+		wantKey          string
+		err              error
+	}{
+		{
+			name:             "parses the deployment ID, kind and key",
+			id:               "320b7b540dfc967a7a649c18e2fce4ed/account/1234567",
+			wantDeploymentID: "320b7b540dfc967a7a649c18e2fce4ed",
+			wantKind:         "account",
+			wantKey:          "1234567",
+		},
+		{
+			name:             "keeps any slashes in the key",
+			id:               "320b7b540dfc967a7a649c18e2fce4ed/direct/on-prem/dc1",
+			wantDeploymentID: "320b7b540dfc967a7a649c18e2fce4ed",
+			wantKind:         "direct",
+			wantKey:          "on-prem/dc1",
+		},
+		{
+			name: "fails when the kind is unknown",
+			id:   "320b7b540dfc967a7a649c18e2fce4ed/remote/1234567",
+			err:  errors.New(`invalid ID "320b7b540dfc967a7a649c18e2fce4ed/remote/1234567": expected format <deployment_id>/<account|external|direct>/<key>`),
+		},
+		{
+			name: "fails when there's no key",
+			id:   "320b7b540dfc967a7a649c18e2fce4ed/external",
+			err:  errors.New(`invalid ID "320b7b540dfc967a7a649c18e2fce4ed/external": expected format <deployment_id>/<account|external|direct>/<key>`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deploymentID, kind, key, err := parseTrustID(tt.id)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.wantDeploymentID, deploymentID)
+			assert.Equal(t, tt.wantKind, kind)
+			assert.Equal(t, tt.wantKey, key)
+		})
+	}
+}
+
+func Test_setRelationship(t *testing.T) {
+	existing := func() *models.ElasticsearchClusterTrustSettings {
+		return &models.ElasticsearchClusterTrustSettings{
+			Accounts: []*models.AccountTrustRelationship{
+				{AccountID: ec.String("1234567"), TrustAll: ec.Bool(true)},
+				{AccountID: ec.String("7654321"), TrustAll: ec.Bool(true)},
+			},
+			External: []*models.ExternalTrustRelationship{
+				{TrustRelationshipID: ec.String("ece"), TrustAll: ec.Bool(true)},
+			},
+			Direct: []*models.DirectTrustRelationship{
+				{Name: ec.String("on-prem"), UID: "some-uid", TrustAll: ec.Bool(true)},
+			},
+		}
+	}
+	type args struct {
+		trust *models.ElasticsearchClusterTrustSettings
+		kind  string
+		key   string
+		rel   *relationship
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.ElasticsearchClusterTrustSettings
+	}{
+		{
+			name: "adds a relationship to empty trust settings",
+			args: args{kind: externalTrust, key: "ece", rel: &relationship{
+				external: &models.ExternalTrustRelationship{
+					TrustRelationshipID: ec.String("ece"), TrustAll: ec.Bool(true),
+				},
+			}},
+			want: &models.ElasticsearchClusterTrustSettings{
+				Accounts: nil,
+				External: []*models.ExternalTrustRelationship{
+					{TrustRelationshipID: ec.String("ece"), TrustAll: ec.Bool(true)},
+				},
+				Direct: nil,
+			},
+		},
+		{
+			name: "replaces an account relationship and keeps the rest",
+			args: args{trust: existing(), kind: accountTrust, key: "1234567", rel: &relationship{
+				account: &models.AccountTrustRelationship{
+					AccountID: ec.String("1234567"), TrustAll: ec.Bool(false),
+					TrustAllowlist: []string{"abc"},
+				},
+			}},
+			want: &models.ElasticsearchClusterTrustSettings{
+				Accounts: []*models.AccountTrustRelationship{
+					{AccountID: ec.String("7654321"), TrustAll: ec.Bool(true)},
+					{
+						AccountID: ec.String("1234567"), TrustAll: ec.Bool(false),
+						TrustAllowlist: []string{"abc"},
+					},
+				},
+				External: existing().External,
+				Direct:   existing().Direct,
+			},
+		},
+		{
+			name: "replaces a direct relationship keeping its generated uid",
+			args: args{trust: existing(), kind: directTrust, key: "on-prem", rel: &relationship{
+				direct: &models.DirectTrustRelationship{
+					Name: ec.String("on-prem"), TrustAll: ec.Bool(false),
+				},
+			}},
+			want: &models.ElasticsearchClusterTrustSettings{
+				Accounts: existing().Accounts,
+				External: existing().External,
+				Direct: []*models.DirectTrustRelationship{
+					{Name: ec.String("on-prem"), UID: "some-uid", TrustAll: ec.Bool(false)},
+				},
+			},
+		},
+		{
+			name: "removes a relationship",
+			args: args{trust: existing(), kind: externalTrust, key: "ece"},
+			want: &models.ElasticsearchClusterTrustSettings{
+				Accounts: existing().Accounts,
+				External: []*models.ExternalTrustRelationship{},
+				Direct:   existing().Direct,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := setRelationship(tt.args.trust, tt.args.kind, tt.args.key, tt.args.rel)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// APIKeySource obtains the API key from a file or from the output of a
+// command, so short-lived API keys can be rotated while the provider runs.
+// The API key is cached until Refresh is called.
+type APIKeySource struct {
+	// File which contains the API key.
+	File string
+
+	// Command which prints the API key to its standard output, run through
+	// the system shell.
+	Command string
+
+	mu  sync.Mutex
+	key string
+}
+
+// Key returns the cached API key, reading it when it hasn't been read yet.
+func (s *APIKeySource) Key() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.key != "" {
+		return s.key, nil
+	}
+
+	return s.refresh()
+}
+
+// Refresh reads the API key again, replacing the cached one.
+func (s *APIKeySource) Refresh() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.refresh()
+}
+
+func (s *APIKeySource) refresh() (string, error) {
+	key, err := s.read()
+	if err != nil {
+		return "", err
+	}
+
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", errors.New("the obtained API key is empty")
+	}
+
+	s.key = key
+	return key, nil
+}
+
+func (s *APIKeySource) read() (string, error) {
+	if s.File != "" {
+		b, err := os.ReadFile(s.File)
+		if err != nil {
+			return "", fmt.Errorf("failed reading apikey_file: %w", err)
+		}
+		return string(b), nil
+	}
+
+	if s.Command != "" {
+		var stdout, stderr bytes.Buffer
+		cmd := shellCommand(s.Command)
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("failed running apikey_command: %w: %s",
+				err, strings.TrimSpace(stderr.String()),
+			)
+		}
+		return stdout.String(), nil
+	}
+
+	return "", errors.New("one of apikey_file or apikey_command must be set")
+}
+
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
+
+// APIKeyTransport is an http.RoundTripper which authenticates the requests
+// with the API key obtained from an APIKeySource. When a request obtains a
+// 401 (Unauthorized), the API key is read again and the request is retried
+// once with the new API key, so rotated API keys are picked up mid-operation.
+type APIKeyTransport struct {
+	rt     http.RoundTripper
+	source *APIKeySource
+}
+
+// NewAPIKeyTransport wraps the specified http.RoundTripper with an
+// APIKeyTransport.
+func NewAPIKeyTransport(rt http.RoundTripper, source *APIKeySource) *APIKeyTransport {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	return &APIKeyTransport{rt: rt, source: source}
+}
+
+// RoundTrip performs the http request with the current API key, retrying it
+// with a new one when the response is a 401.
+func (t *APIKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, err := t.source.Key()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.rt.RoundTrip(withAPIKey(req, key))
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+
+	// The body can't be sent again if it can't be re-obtained.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return res, nil
+	}
+
+	newKey, err := t.source.Refresh()
+	if err != nil || newKey == key {
+		return res, nil
+	}
+
+	retry := withAPIKey(req, newKey)
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := req.GetBody()
+		if err != nil {
+			return res, nil
+		}
+		retry.Body = body
+	}
+
+	drainBody(res)
+	return t.rt.RoundTrip(retry)
+}
+
+// withAPIKey returns a copy of the request with the API key Authorization
+// header, replacing the one set by the API client.
+func withAPIKey(req *http.Request, key string) *http.Request {
+	r := req.Clone(req.Context())
+	r.Header.Set("Authorization", "ApiKey "+key)
+	return r
+}
@@ -0,0 +1,124 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeySource(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "apikey")
+	if err := os.WriteFile(file, []byte("first\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	source := &APIKeySource{File: file}
+	key, err := source.Key()
+	assert.NoError(t, err)
+	assert.Equal(t, "first", key)
+
+	if err := os.WriteFile(file, []byte("second"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The key is cached until it's refreshed.
+	key, err = source.Key()
+	assert.NoError(t, err)
+	assert.Equal(t, "first", key)
+
+	key, err = source.Refresh()
+	assert.NoError(t, err)
+	assert.Equal(t, "second", key)
+
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = source.Refresh()
+	assert.EqualError(t, err, "the obtained API key is empty")
+
+	_, err = (&APIKeySource{File: filepath.Join(t.TempDir(), "missing")}).Key()
+	assert.ErrorContains(t, err, "failed reading apikey_file")
+}
+
+func TestAPIKeySource_command(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("requires a POSIX shell")
+	}
+
+	key, err := (&APIKeySource{Command: "echo some-key"}).Key()
+	assert.NoError(t, err)
+	assert.Equal(t, "some-key", key)
+
+	_, err = (&APIKeySource{Command: "echo some error >&2; exit 1"}).Key()
+	assert.ErrorContains(t, err, "failed running apikey_command")
+	assert.ErrorContains(t, err, "some error")
+}
+
+func TestAPIKeyTransport_RoundTrip(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "apikey")
+	if err := os.WriteFile(file, []byte("expired"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Header.Get("Authorization") != "ApiKey rotated" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		b := new(strings.Builder)
+		if r.Body != nil {
+			_, _ = io.Copy(b, r.Body)
+		}
+		_, _ = w.Write([]byte(b.String()))
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewAPIKeyTransport(nil, &APIKeySource{File: file})}
+
+	// The API key hasn't been rotated yet.
+	res, err := client.Post(srv.URL, "application/json", strings.NewReader("{}"))
+	assert.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	if err := os.WriteFile(file, []byte("rotated"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The rotated API key is read and the request is sent again with its body.
+	res, err = client.Post(srv.URL, "application/json", strings.NewReader(`{"a":1}`))
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, `{"a":1}`, string(body))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/client"
+	"github.com/go-openapi/runtime"
+)
+
+// ContextClient returns a copy of the API client whose operations are aborted
+// once the context is done, and fail right away afterwards. Since the
+// cloud-sdk-go APIs don't take a context, it allows cancelling the requests,
+// and the plan tracking goroutines which poll the API, when the resource
+// operation is interrupted or times out.
+func ContextClient(ctx context.Context, c *api.API) *api.API {
+	if c == nil || c.V1API == nil {
+		return c
+	}
+
+	return &api.API{
+		AuthWriter: c.AuthWriter,
+		V1API: client.New(&contextTransport{
+			ctx: ctx, transport: c.V1API.Transport,
+		}, nil),
+	}
+}
+
+type contextTransport struct {
+	ctx       context.Context
+	transport runtime.ClientTransport
+}
+
+func (t *contextTransport) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	if err := t.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// The operation context holds values such as the region, so it's kept
+	// and cancelled when the client context is done.
+	opCtx := op.Context
+	if opCtx == nil {
+		opCtx = context.Background()
+	}
+	opCtx, cancel := context.WithCancel(opCtx)
+	defer cancel()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-t.ctx.Done():
+			cancel()
+		case <-done:
+		}
+	}()
+
+	op.Context = opCtx
+	return t.transport.Submit(op)
+}
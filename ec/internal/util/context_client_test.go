@@ -0,0 +1,60 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextClient(t *testing.T) {
+	newClient := func() *api.API {
+		return api.NewMock(mock.New200StructResponse(models.DeploymentGetResponse{
+			ID: ec.String(mock.ValidClusterID),
+		}))
+	}
+
+	t.Run("submits the operations while the context isn't done", func(t *testing.T) {
+		client := ContextClient(context.Background(), newClient())
+		res, err := client.V1API.Deployments.GetDeployment(
+			deployments.NewGetDeploymentParams().WithDeploymentID(mock.ValidClusterID),
+			client.AuthWriter,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, mock.ValidClusterID, *res.Payload.ID)
+	})
+
+	t.Run("fails the operations once the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		client := ContextClient(ctx, newClient())
+		_, err := client.V1API.Deployments.GetDeployment(
+			deployments.NewGetDeploymentParams().WithDeploymentID(mock.ValidClusterID),
+			client.AuthWriter,
+		)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
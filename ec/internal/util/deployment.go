@@ -0,0 +1,58 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// GetDeployment obtains the deployment with only the sections which are
+// needed to populate the Terraform state. Compared to deploymentapi.Get, it
+// leaves out the plan logs, plan history and system alerts, which make up
+// most of the payload on deployments with a long history.
+//
+// The plan defaults are still requested since the flatteners rely on the
+// defaulted plan fields (e.g. zone_count) to avoid perpetual diffs.
+//
+// The deployment is always requested in full, since the deployments API
+// doesn't support conditional requests.
+func GetDeployment(client *api.API, id string) (*models.DeploymentGetResponse, error) {
+	res, err := client.V1API.Deployments.GetDeployment(
+		deployments.NewGetDeploymentParams().
+			WithDeploymentID(id).
+			WithShowPlans(ec.Bool(true)).
+			WithShowPlanDefaults(ec.Bool(true)).
+			WithShowMetadata(ec.Bool(true)).
+			WithShowSettings(ec.Bool(true)).
+			WithShowPlanLogs(ec.Bool(false)).
+			WithShowPlanHistory(ec.Bool(false)).
+			WithShowSecurity(ec.Bool(false)).
+			WithConvertLegacyPlans(ec.Bool(false)).
+			WithShowSystemAlerts(ec.Int64(0)),
+		client.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDeployment(t *testing.T) {
+	const id = "320b7b540dfc967a7a649c18e2fce4ed"
+	type args struct {
+		client *api.API
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.DeploymentGetResponse
+		err  string
+	}{
+		{
+			name: "requests the deployment without plan history, logs or alerts",
+			args: args{client: api.NewMock(mock.New200ResponseAssertion(
+				&mock.RequestAssertion{
+					Header: api.DefaultReadMockHeaders,
+					Host:   api.DefaultMockHost,
+					Path:   "/api/v1/deployments/" + id,
+					Method: "GET",
+					Query: url.Values{
+						"convert_legacy_plans": {"false"},
+						"show_metadata":        {"true"},
+						"show_plan_defaults":   {"true"},
+						"show_plan_history":    {"false"},
+						"show_plan_logs":       {"false"},
+						"show_plans":           {"true"},
+						"show_security":        {"false"},
+						"show_settings":        {"true"},
+						"show_system_alerts":   {"0"},
+					},
+				},
+				mock.NewStructBody(models.DeploymentGetResponse{ID: ec.String(id)}),
+			))},
+			want: &models.DeploymentGetResponse{ID: ec.String(id)},
+		},
+		{
+			name: "returns the API error",
+			args: args{client: api.NewMock(mock.NewErrorResponse(500, mock.APIError{
+				Code: "some", Message: "message",
+			}))},
+			err: "api error: 1 error occurred:\n\t* some: message\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetDeployment(tt.args.client, id)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DiagnosticsBundleEnv is the environment variable which, when set to a
+	// file path, makes the provider write a support diagnostics bundle to it.
+	// The bundle can be attached to bug reports to speed up their triage.
+	DiagnosticsBundleEnv = "EC_DIAGNOSTICS_BUNDLE"
+
+	// maxDiagnosticsInteractions is the number of most recent API
+	// interactions which are kept in the bundle.
+	maxDiagnosticsInteractions = 100
+
+	// maxDiagnosticsBodySize caps the size of the captured response bodies.
+	maxDiagnosticsBodySize = 4096
+
+	redactedValue = "[REDACTED]"
+)
+
+// redactedHeaders contains the headers which are never written to the bundle.
+var redactedHeaders = map[string]struct{}{
+	"Authorization": {},
+	"Cookie":        {},
+	"Set-Cookie":    {},
+	"X-Api-Key":     {},
+}
+
+// DiagnosticsBundle contains the information which is useful to triage
+// provider issues.
+type DiagnosticsBundle struct {
+	ProviderVersion string                   `json:"provider_version"`
+	GoVersion       string                   `json:"go_version"`
+	Platform        string                   `json:"platform"`
+	Endpoint        string                   `json:"endpoint"`
+	UpdatedAt       time.Time                `json:"updated_at"`
+	Interactions    []DiagnosticsInteraction `json:"interactions"`
+}
+
+// DiagnosticsInteraction is a redacted record of a single API interaction.
+// Request bodies are never recorded since they may contain secrets, and
+// response bodies are only recorded for unsuccessful responses.
+type DiagnosticsInteraction struct {
+	Time            time.Time         `json:"time"`
+	Method          string            `json:"method"`
+	URL             string            `json:"url"`
+	Duration        string            `json:"duration"`
+	StatusCode      int               `json:"status_code,omitempty"`
+	Error           string            `json:"error,omitempty"`
+	RequestHeaders  map[string]string `json:"request_headers,omitempty"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+	ResponseBody    string            `json:"response_body,omitempty"`
+}
+
+// DiagnosticsTransport is an http.RoundTripper which records the most recent
+// API interactions, writing them to the bundle file after each interaction.
+type DiagnosticsTransport struct {
+	rt   http.RoundTripper
+	path string
+
+	mu     sync.Mutex
+	bundle DiagnosticsBundle
+}
+
+// NewDiagnosticsTransport wraps the specified http.RoundTripper with a
+// DiagnosticsTransport which writes the bundle to the specified path.
+func NewDiagnosticsTransport(rt http.RoundTripper, path, version, endpoint string) *DiagnosticsTransport {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	return &DiagnosticsTransport{
+		rt:   rt,
+		path: path,
+		bundle: DiagnosticsBundle{
+			ProviderVersion: version,
+			GoVersion:       runtime.Version(),
+			Platform:        runtime.GOOS + "/" + runtime.GOARCH,
+			Endpoint:        endpoint,
+		},
+	}
+}
+
+// RoundTrip performs the http request, recording the interaction.
+func (t *DiagnosticsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	res, err := t.rt.RoundTrip(req)
+
+	interaction := DiagnosticsInteraction{
+		Time:           start.UTC(),
+		Method:         req.Method,
+		URL:            req.URL.String(),
+		Duration:       time.Since(start).String(),
+		RequestHeaders: redactHeaders(req.Header),
+	}
+
+	if err != nil {
+		interaction.Error = err.Error()
+	}
+
+	if res != nil {
+		interaction.StatusCode = res.StatusCode
+		interaction.ResponseHeaders = redactHeaders(res.Header)
+		if res.StatusCode >= http.StatusBadRequest {
+			interaction.ResponseBody = peekBody(res)
+		}
+	}
+
+	t.record(interaction)
+	return res, err
+}
+
+// Bundle returns a copy of the current bundle.
+func (t *DiagnosticsTransport) Bundle() DiagnosticsBundle {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bundle := t.bundle
+	bundle.Interactions = append([]DiagnosticsInteraction(nil), t.bundle.Interactions...)
+	return bundle
+}
+
+func (t *DiagnosticsTransport) record(interaction DiagnosticsInteraction) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.bundle.Interactions = append(t.bundle.Interactions, interaction)
+	if over := len(t.bundle.Interactions) - maxDiagnosticsInteractions; over > 0 {
+		t.bundle.Interactions = t.bundle.Interactions[over:]
+	}
+	t.bundle.UpdatedAt = time.Now().UTC()
+
+	// Writing the bundle is best effort, failing to write it must never
+	// cause the API interaction to fail.
+	_ = t.write()
+}
+
+func (t *DiagnosticsTransport) write() error {
+	b, err := json.MarshalIndent(t.bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(t.path, b, 0600)
+}
+
+func redactHeaders(h http.Header) map[string]string {
+	if len(h) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(h))
+	for k, v := range h {
+		if _, ok := redactedHeaders[http.CanonicalHeaderKey(k)]; ok {
+			result[k] = redactedValue
+			continue
+		}
+		result[k] = strings.Join(v, ", ")
+	}
+	return result
+}
+
+// peekBody reads up to maxDiagnosticsBodySize bytes of the response body,
+// restoring the body so it can still be consumed by the caller.
+func peekBody(res *http.Response) string {
+	if res.Body == nil || res.Body == http.NoBody {
+		return ""
+	}
+
+	b, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return ""
+	}
+
+	if len(b) > maxDiagnosticsBodySize {
+		b = b[:maxDiagnosticsBodySize]
+	}
+	return string(b)
+}
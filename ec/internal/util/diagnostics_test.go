@@ -0,0 +1,106 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnosticsTransport_RoundTrip(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "some=cookie")
+		w.Header().Set("X-Request-Id", "some-request-id")
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":[{"code":"some","message":"message"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"password":"some-password"}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	tr := NewDiagnosticsTransport(nil, path, "0.5.0", srv.URL)
+
+	for _, p := range []string{"/ok", "/fail"} {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+p, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "ApiKey some-key")
+
+		res, err := tr.RoundTrip(req)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		// The body must still be readable by the caller.
+		b, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		assert.NoError(t, err)
+		assert.NotEmpty(t, b)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var bundle DiagnosticsBundle
+	if err := json.Unmarshal(b, &bundle); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "0.5.0", bundle.ProviderVersion)
+	assert.Equal(t, srv.URL, bundle.Endpoint)
+	assert.NotEmpty(t, bundle.Platform)
+	if !assert.Len(t, bundle.Interactions, 2) {
+		return
+	}
+
+	ok, fail := bundle.Interactions[0], bundle.Interactions[1]
+	assert.Equal(t, http.StatusOK, ok.StatusCode)
+	assert.Equal(t, redactedValue, ok.RequestHeaders["Authorization"])
+	assert.Equal(t, redactedValue, ok.ResponseHeaders["Set-Cookie"])
+	assert.Equal(t, "some-request-id", ok.ResponseHeaders["X-Request-Id"])
+	assert.Empty(t, ok.ResponseBody)
+
+	assert.Equal(t, http.StatusBadRequest, fail.StatusCode)
+	assert.Equal(t, `{"errors":[{"code":"some","message":"message"}]}`, fail.ResponseBody)
+	assert.NotContains(t, string(b), "some-key")
+	assert.NotContains(t, string(b), "some-password")
+}
+
+func TestDiagnosticsTransport_record(t *testing.T) {
+	tr := NewDiagnosticsTransport(nil, filepath.Join(t.TempDir(), "bundle.json"), "", "")
+	for i := 0; i < maxDiagnosticsInteractions+10; i++ {
+		tr.record(DiagnosticsInteraction{StatusCode: i})
+	}
+
+	bundle := tr.Bundle()
+	assert.Len(t, bundle.Interactions, maxDiagnosticsInteractions)
+	assert.Equal(t, 10, bundle.Interactions[0].StatusCode)
+}
@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// EndpointOverride holds the API endpoint settings of a data source which
+// replace the provider ones.
+type EndpointOverride struct {
+	Endpoint string
+	Insecure bool
+	CACert   string
+}
+
+// Key returns a string which identifies the endpoint override settings.
+func (o EndpointOverride) Key() string {
+	return fmt.Sprintf("endpoint=%s\ninsecure=%t\ncacert=%s\n", o.Endpoint, o.Insecure, o.CACert)
+}
+
+// ClientFactory returns an API client built with the provider settings and
+// the endpoint override settings.
+type ClientFactory func(EndpointOverride) (*api.API, error)
+
+// WithEndpointOverride adds the "endpoint_override" block to a data source,
+// which reads it with an API client pointing to the overridden endpoint
+// when the block is set.
+func WithEndpointOverride(r *schema.Resource) *schema.Resource {
+	r.Schema["endpoint_override"] = endpointOverrideSchema()
+
+	read := r.ReadContext
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		m := meta.(*ProviderMeta)
+		client, err := overrideClient(m, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		overridden := *m
+		overridden.API = client
+		return read(ctx, d, &overridden)
+	}
+
+	return r
+}
+
+func endpointOverrideSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Optional API endpoint settings which replace the provider ones for this data source",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"endpoint": {
+					Type:         schema.TypeString,
+					Description:  "Optional endpoint the data source points to. Defaults to the provider endpoint",
+					Optional:     true,
+					ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+				},
+				"insecure": {
+					Type:        schema.TypeBool,
+					Description: "Optionally skip the TLS validation of the endpoint. Defaults to false",
+					Optional:    true,
+				},
+				"cacert": {
+					Type:         schema.TypeString,
+					Description:  "Optional PEM encoded CA certificates trusted in addition to the system ones, e.g. for an ECE installation with a self-signed certificate",
+					Optional:     true,
+					ValidateFunc: validateCACert,
+				},
+			},
+		},
+	}
+}
+
+func validateCACert(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if v != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(v)) {
+		return nil, []error{fmt.Errorf("%s must contain at least one PEM encoded certificate", k)}
+	}
+
+	return nil, nil
+}
+
+// overrideClient returns the API client for the data source endpoint
+// override, or the provider client when the data source has none.
+func overrideClient(meta *ProviderMeta, d *schema.ResourceData) (*api.API, error) {
+	raw, _ := d.Get("endpoint_override").([]interface{})
+	if len(raw) == 0 || raw[0] == nil {
+		return meta.API, nil
+	}
+
+	m := raw[0].(map[string]interface{})
+	var o EndpointOverride
+	o.Endpoint, _ = m["endpoint"].(string)
+	o.Insecure, _ = m["insecure"].(bool)
+	o.CACert, _ = m["cacert"].(string)
+
+	if meta.ClientFactory == nil {
+		return nil, errors.New("endpoint_override: the provider API client doesn't support endpoint overrides")
+	}
+
+	return meta.ClientFactory(o)
+}
@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithEndpointOverride(t *testing.T) {
+	providerClient := api.NewMock()
+	overrideClient := api.NewMock(mock.New200Response(mock.NewStringBody("{}")))
+
+	var got EndpointOverride
+	meta := &ProviderMeta{
+		API: providerClient,
+		ClientFactory: func(o EndpointOverride) (*api.API, error) {
+			got = o
+			return overrideClient, nil
+		},
+	}
+
+	var readClient *api.API
+	r := WithEndpointOverride(&schema.Resource{
+		ReadContext: func(_ context.Context, _ *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			readClient = meta.(*ProviderMeta).API
+			return nil
+		},
+		Schema: map[string]*schema.Schema{},
+	})
+
+	t.Run("uses the provider client without an endpoint override", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+		assert.Nil(t, r.ReadContext(context.Background(), d, meta))
+		assert.Same(t, providerClient, readClient)
+	})
+
+	t.Run("uses the endpoint override client", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"endpoint_override": []interface{}{map[string]interface{}{
+				"endpoint": "https://ece.example.com:12443",
+				"insecure": true,
+			}},
+		})
+		assert.Nil(t, r.ReadContext(context.Background(), d, meta))
+		assert.Same(t, overrideClient, readClient)
+		assert.Equal(t, EndpointOverride{Endpoint: "https://ece.example.com:12443", Insecure: true}, got)
+	})
+
+	t.Run("fails when the provider meta has no client factory", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"endpoint_override": []interface{}{map[string]interface{}{"insecure": true}},
+		})
+		diags := r.ReadContext(context.Background(), d, NewMockMeta())
+		assert.True(t, diags.HasError())
+	})
+}
+
+func Test_validateCACert(t *testing.T) {
+	_, errs := validateCACert("", "cacert")
+	assert.Empty(t, errs)
+
+	_, errs = validateCACert("not a certificate", "cacert")
+	assert.EqualError(t, errs[0], "cacert must contain at least one PEM encoded certificate")
+}
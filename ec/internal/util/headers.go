@@ -0,0 +1,59 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"net/http"
+)
+
+// HeadersTransport is an http.RoundTripper which sets a fixed set of headers
+// on all the requests, such as the headers required by an egress proxy.
+type HeadersTransport struct {
+	rt      http.RoundTripper
+	headers http.Header
+}
+
+// NewHeadersTransport wraps the specified http.RoundTripper with a
+// HeadersTransport.
+func NewHeadersTransport(rt http.RoundTripper, headers map[string]string) *HeadersTransport {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	return &HeadersTransport{rt: rt, headers: NewHeader(headers)}
+}
+
+// RoundTrip performs the http request with the headers set, replacing the
+// values set by the API client.
+func (t *HeadersTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	for k, v := range t.headers {
+		r.Header[k] = v
+	}
+
+	return t.rt.RoundTrip(r)
+}
+
+// NewHeader returns the http.Header with the specified headers.
+func NewHeader(headers map[string]string) http.Header {
+	h := make(http.Header, len(headers))
+	for k, v := range headers {
+		h.Set(k, v)
+	}
+	return h
+}
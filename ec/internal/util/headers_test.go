@@ -0,0 +1,60 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeadersTransport(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewHeadersTransport(nil, map[string]string{
+		"x-egress-token": "some-token",
+		"User-Agent":     "custom-agent",
+	})}
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("User-Agent", "elastic-terraform-provider")
+	req.Header.Set("Authorization", "ApiKey some-key")
+
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	assert.Equal(t, "some-token", got.Get("X-Egress-Token"))
+	assert.Equal(t, "custom-agent", got.Get("User-Agent"))
+	assert.Equal(t, "ApiKey some-key", got.Get("Authorization"))
+
+	// The original request isn't modified.
+	assert.Equal(t, "elastic-terraform-provider", req.Header.Get("User-Agent"))
+	assert.Empty(t, req.Header.Get("X-Egress-Token"))
+}
@@ -44,7 +44,7 @@ func ParseTopologySize(topology map[string]interface{}) (*models.TopologySize, e
 		}
 
 		var sizeResource = defaultSizeResource
-		if sr, ok := topology["size_resource"].(string); ok {
+		if sr, ok := topology["size_resource"].(string); ok && sr != "" {
 			sizeResource = sr
 		}
 
@@ -0,0 +1,60 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+)
+
+// ProviderMeta is the meta of a configured provider instance, which is
+// passed to the CRUD functions of all the resources and data sources.
+type ProviderMeta struct {
+	// API is the client of the configured provider.
+	API *api.API
+
+	// DefaultTags are the provider "default_tags", which are merged into the
+	// tags of the resources supporting them.
+	DefaultTags map[string]string
+
+	// GenerateRefIDs is the provider "generate_ref_ids", which generates the
+	// ref_id of the deployment resources which don't set it.
+	GenerateRefIDs bool
+
+	// ClientFactory builds the API clients of the data sources which
+	// override the provider endpoint settings.
+	ClientFactory ClientFactory
+
+	// Transport is built from the provider TLS, proxy and headers settings,
+	// and is used to reach the services other than the API, such as the
+	// container registries.
+	Transport http.RoundTripper
+}
+
+// HTTPClient returns an HTTP client which uses the provider transport, or the
+// default transport when there's no provider meta.
+func (m *ProviderMeta) HTTPClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport
+	if m != nil && m.Transport != nil {
+		transport = m.Transport
+	}
+
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
@@ -0,0 +1,102 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/client"
+	"github.com/go-openapi/runtime"
+	runtimeclient "github.com/go-openapi/runtime/client"
+)
+
+// globalPaths are the first path segments of the global APIs. cloud-sdk-go
+// only submits the operations of a few path prefixes, such as /deployments,
+// without a region, and fails any other operation when no region is set,
+// which breaks the global APIs.
+var globalPaths = map[string]bool{
+	"billing":       true,
+	"organizations": true,
+	"serverless":    true,
+}
+
+// WithRegionless returns a copy of the API client which submits the
+// operations of the global APIs, such as /organizations or /billing, to
+// the API base path without a region, sharing the transport settings of the
+// API config. Any other operation is submitted through the client transport.
+func WithRegionless(c *api.API, cfg api.Config) (*api.API, error) {
+	u, err := url.Parse(cfg.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	var rt http.RoundTripper
+	if cfg.Client != nil {
+		rt = cfg.Client.Transport
+	}
+
+	// The transport is returned as is when it has already been wrapped by
+	// api.NewAPI.
+	transport, err := api.NewTransport(rt, api.TransportConfig{
+		SkipTLSVerify:   cfg.SkipTLSVerify,
+		ErrorDevice:     cfg.ErrorDevice,
+		VerboseSettings: cfg.VerboseSettings,
+		Timeout:         cfg.Timeout,
+		UserAgent:       cfg.UserAgent,
+		Retries:         cfg.Retries,
+		RetryBackoff:    cfg.RetryBackoff,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return withRegionlessRuntime(c, newRegionlessRuntime(u, &http.Client{Transport: transport})), nil
+}
+
+func withRegionlessRuntime(c *api.API, regionless runtime.ClientTransport) *api.API {
+	return &api.API{
+		AuthWriter: c.AuthWriter,
+		V1API: client.New(&regionlessTransport{
+			transport: c.V1API.Transport, regionless: regionless,
+		}, nil),
+	}
+}
+
+func newRegionlessRuntime(u *url.URL, httpClient *http.Client) runtime.ClientTransport {
+	return api.AddTypeConsumers(runtimeclient.NewWithClient(
+		u.Host, api.DefaultBasePath, []string{u.Scheme}, httpClient,
+	))
+}
+
+// regionlessTransport submits the operations of the global APIs through the
+// regionless runtime, and any other operation through the client transport.
+type regionlessTransport struct {
+	transport  runtime.ClientTransport
+	regionless runtime.ClientTransport
+}
+
+func (t *regionlessTransport) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	segment := strings.SplitN(strings.TrimPrefix(op.PathPattern, "/"), "/", 2)[0]
+	if globalPaths[segment] {
+		return t.regionless.Submit(op)
+	}
+	return t.transport.Submit(op)
+}
@@ -0,0 +1,67 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/client/organizations"
+	"github.com/elastic/cloud-sdk-go/pkg/client/stack"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRegionless(t *testing.T) {
+	orgs := models.OrganizationList{
+		Organizations: []*models.Organization{{ID: ec.String("123"), Name: ec.String("my-org")}},
+	}
+
+	t.Run("submits the global operations without a region", func(t *testing.T) {
+		client := NewRegionlessMock(mock.New200StructResponse(orgs))
+
+		res, err := client.V1API.Organizations.ListOrganizations(
+			organizations.NewListOrganizationsParams(), client.AuthWriter,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, "my-org", *res.Payload.Organizations[0].Name)
+	})
+
+	t.Run("submits the global operations without a region through a context client", func(t *testing.T) {
+		client := ContextClient(context.Background(), NewRegionlessMock(
+			mock.New200StructResponse(orgs),
+		))
+
+		res, err := client.V1API.Organizations.ListOrganizations(
+			organizations.NewListOrganizationsParams(), client.AuthWriter,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, "my-org", *res.Payload.Organizations[0].Name)
+	})
+
+	t.Run("submits any other operation through the client transport", func(t *testing.T) {
+		client := NewRegionlessMock(mock.New200StructResponse(orgs))
+
+		_, err := client.V1API.Stack.GetVersionStacks(
+			stack.NewGetVersionStacksParams(), client.AuthWriter,
+		)
+		assert.EqualError(t, err, "the requested operation requires a region but none has been set")
+	})
+}
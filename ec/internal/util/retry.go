@@ -0,0 +1,186 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	// DefaultRetryableStatusCodes are the HTTP response status codes which are
+	// considered transient when no custom status codes are configured.
+	DefaultRetryableStatusCodes = []int{
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	}
+
+	// DefaultRetryBackoff is the initial backoff used between retried requests.
+	DefaultRetryBackoff = time.Second
+
+	// maxRetryBackoff caps the exponential backoff between retried requests.
+	maxRetryBackoff = 30 * time.Second
+)
+
+// idempotencyTokenParam is the query parameter holding the token which makes
+// the API process a mutating request (i.e. a deployment creation) only once.
+const idempotencyTokenParam = "request_id"
+
+// RetryConfig is used to configure a RetryTransport.
+type RetryConfig struct {
+	// MaxRetries is the maximum number of times a request is retried.
+	MaxRetries int
+
+	// Backoff is the initial cooldown between retries, it doubles on every
+	// subsequent retry.
+	Backoff time.Duration
+
+	// StatusCodes which are retried. Defaults to DefaultRetryableStatusCodes.
+	StatusCodes []int
+}
+
+// RetryTransport is an http.RoundTripper which retries requests that obtain a
+// transient response status code. Since the API may already have processed
+// a non idempotent request (i.e. POST) which failed with a 5xx, those requests
+// are only retried when the response is a 429 (Too Many Requests), or when
+// they carry an idempotency token which guarantees they're processed once.
+type RetryTransport struct {
+	rt     http.RoundTripper
+	config RetryConfig
+	codes  map[int]struct{}
+}
+
+// NewRetryTransport wraps the specified http.RoundTripper with a RetryTransport.
+func NewRetryTransport(rt http.RoundTripper, cfg RetryConfig) *RetryTransport {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = DefaultRetryBackoff
+	}
+
+	if len(cfg.StatusCodes) == 0 {
+		cfg.StatusCodes = DefaultRetryableStatusCodes
+	}
+
+	var codes = make(map[int]struct{}, len(cfg.StatusCodes))
+	for _, code := range cfg.StatusCodes {
+		codes[code] = struct{}{}
+	}
+
+	return &RetryTransport{rt: rt, config: cfg, codes: codes}
+}
+
+// Config returns the RetryConfig used by the transport.
+func (t *RetryTransport) Config() RetryConfig { return t.config }
+
+// RoundTrip performs the http request, retrying it up to MaxRetries times
+// when the response has a retryable status code. Since the caller's request
+// can't be modified, each retry sends a clone of it with a new body.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var r = req
+	for attempt := 0; ; attempt++ {
+		res, err := t.rt.RoundTrip(r)
+		if err != nil || attempt >= t.config.MaxRetries || !t.shouldRetry(req, res) {
+			return res, err
+		}
+
+		next, err := cloneRequest(req)
+		if err != nil {
+			return res, nil
+		}
+
+		wait := t.backoff(attempt, res)
+		drainBody(res)
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		r = next
+	}
+}
+
+// cloneRequest clones the request so it can be sent again. The request body
+// has already been consumed, so a new one is obtained.
+func cloneRequest(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return r, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = body
+	return r, nil
+}
+
+func (t *RetryTransport) shouldRetry(req *http.Request, res *http.Response) bool {
+	if _, ok := t.codes[res.StatusCode]; !ok {
+		return false
+	}
+
+	// The body can't be sent again if it can't be re-obtained.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	return res.StatusCode == http.StatusTooManyRequests || isIdempotent(req)
+}
+
+// backoff returns the time to wait before the next attempt, honoring the
+// Retry-After header (in seconds) when it's set in the response.
+func (t *RetryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	if secs, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && secs >= 0 {
+		if wait := time.Duration(secs) * time.Second; wait < maxRetryBackoff {
+			return wait
+		}
+		return maxRetryBackoff
+	}
+
+	wait := t.config.Backoff << attempt
+	if wait <= 0 || wait > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+	return wait
+}
+
+// isIdempotent returns true when the request can be sent multiple times with
+// the same effect, either because of its method or because it carries an
+// idempotency token.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.URL != nil && req.URL.Query().Get(idempotencyTokenParam) != ""
+}
+
+func drainBody(res *http.Response) {
+	if res.Body != nil {
+		res.Body.Close()
+	}
+}
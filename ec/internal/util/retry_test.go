@@ -0,0 +1,200 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryTransport_RoundTrip(t *testing.T) {
+	type args struct {
+		method string
+		query  string
+		body   string
+		codes  []int
+		cfg    RetryConfig
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCode  int
+		wantCalls int32
+	}{
+		{
+			name: "returns the first successful response without retrying",
+			args: args{
+				method: http.MethodGet,
+				codes:  []int{200},
+				cfg:    RetryConfig{MaxRetries: 2, Backoff: time.Millisecond},
+			},
+			wantCode:  200,
+			wantCalls: 1,
+		},
+		{
+			name: "retries a GET request on a 503 until it succeeds",
+			args: args{
+				method: http.MethodGet,
+				codes:  []int{503, 502, 200},
+				cfg:    RetryConfig{MaxRetries: 2, Backoff: time.Millisecond},
+			},
+			wantCode:  200,
+			wantCalls: 3,
+		},
+		{
+			name: "gives up after max retries",
+			args: args{
+				method: http.MethodGet,
+				codes:  []int{503, 503, 503, 200},
+				cfg:    RetryConfig{MaxRetries: 2, Backoff: time.Millisecond},
+			},
+			wantCode:  503,
+			wantCalls: 3,
+		},
+		{
+			name: "does not retry a POST request on a 503",
+			args: args{
+				method: http.MethodPost,
+				body:   `{"some":"body"}`,
+				codes:  []int{503, 200},
+				cfg:    RetryConfig{MaxRetries: 2, Backoff: time.Millisecond},
+			},
+			wantCode:  503,
+			wantCalls: 1,
+		},
+		{
+			name: "retries a POST request with an idempotency token on a 503",
+			args: args{
+				method: http.MethodPost,
+				query:  "?request_id=some-request-id",
+				body:   `{"some":"body"}`,
+				codes:  []int{503, 502, 200},
+				cfg:    RetryConfig{MaxRetries: 2, Backoff: time.Millisecond},
+			},
+			wantCode:  200,
+			wantCalls: 3,
+		},
+		{
+			name: "does not retry a POST request with an empty idempotency token on a 503",
+			args: args{
+				method: http.MethodPost,
+				query:  "?request_id=",
+				body:   `{"some":"body"}`,
+				codes:  []int{503, 200},
+				cfg:    RetryConfig{MaxRetries: 2, Backoff: time.Millisecond},
+			},
+			wantCode:  503,
+			wantCalls: 1,
+		},
+		{
+			name: "retries a POST request on a 429 resending its body",
+			args: args{
+				method: http.MethodPost,
+				body:   `{"some":"body"}`,
+				codes:  []int{429, 200},
+				cfg:    RetryConfig{MaxRetries: 2, Backoff: time.Millisecond},
+			},
+			wantCode:  200,
+			wantCalls: 2,
+		},
+		{
+			name: "does not retry status codes which aren't configured",
+			args: args{
+				method: http.MethodGet,
+				codes:  []int{503, 200},
+				cfg: RetryConfig{
+					MaxRetries:  2,
+					Backoff:     time.Millisecond,
+					StatusCodes: []int{500},
+				},
+			},
+			wantCode:  503,
+			wantCalls: 1,
+		},
+		{
+			name: "does not retry when max retries is 0",
+			args: args{
+				method: http.MethodGet,
+				codes:  []int{503, 200},
+				cfg:    RetryConfig{Backoff: time.Millisecond},
+			},
+			wantCode:  503,
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := atomic.AddInt32(&calls, 1)
+				if tt.args.body != "" {
+					body, _ := io.ReadAll(r.Body)
+					assert.Equal(t, tt.args.body, string(body))
+				}
+				w.WriteHeader(tt.args.codes[call-1])
+			}))
+			defer srv.Close()
+
+			url := srv.URL + tt.args.query
+			req, err := http.NewRequest(tt.args.method, url, nil)
+			if tt.args.body != "" {
+				req, err = http.NewRequest(tt.args.method, url, strings.NewReader(tt.args.body))
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			body := req.Body
+			res, err := NewRetryTransport(nil, tt.args.cfg).RoundTrip(req)
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+
+			// The retries send a clone of the request, leaving it untouched.
+			assert.Equal(t, body, req.Body)
+		})
+	}
+}
+
+func TestRetryTransport_backoff(t *testing.T) {
+	tr := NewRetryTransport(nil, RetryConfig{Backoff: time.Second})
+	header := func(v string) *http.Response {
+		res := &http.Response{Header: make(http.Header)}
+		if v != "" {
+			res.Header.Set("Retry-After", v)
+		}
+		return res
+	}
+
+	assert.Equal(t, time.Second, tr.backoff(0, header("")))
+	assert.Equal(t, 4*time.Second, tr.backoff(2, header("")))
+	assert.Equal(t, maxRetryBackoff, tr.backoff(10, header("")))
+	assert.Equal(t, 5*time.Second, tr.backoff(0, header("5")))
+	assert.Equal(t, maxRetryBackoff, tr.backoff(0, header("3600")))
+}
@@ -37,3 +37,18 @@ func ItemsToString(elems []interface{}) (result []string) {
 
 	return result
 }
+
+// ItemsToStringMap takes in a map[string]interface{} and returns a map of
+// strings.
+func ItemsToStringMap(elems map[string]interface{}) map[string]string {
+	if len(elems) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(elems))
+	for k, v := range elems {
+		result[k] = v.(string)
+	}
+
+	return result
+}
@@ -74,3 +74,26 @@ func TestItemsToString(t *testing.T) {
 		})
 	}
 }
+
+func TestItemsToStringMap(t *testing.T) {
+	tests := []struct {
+		name       string
+		elems      map[string]interface{}
+		wantResult map[string]string
+	}{
+		{
+			name: "empty map returns nil",
+		},
+		{
+			name:       "populated map returns the results as map[string]string{}",
+			elems:      map[string]interface{}{"some": "value", "other": ""},
+			wantResult: map[string]string{"some": "value", "other": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResult := ItemsToStringMap(tt.elems)
+			assert.Equal(t, tt.wantResult, gotResult)
+		})
+	}
+}
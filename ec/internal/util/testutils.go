@@ -20,8 +20,11 @@ package util
 import (
 	"context"
 	"errors"
+	"net/url"
 	"testing"
 
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
 	"github.com/elastic/cloud-sdk-go/pkg/multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -106,3 +109,22 @@ func generateRD(t *testing.T, schemaMap map[string]*schema.Schema, rawAttr map[s
 
 	return result
 }
+
+// NewRegionlessMock returns a mock API client whose global operations, such as
+// the /organizations ones, obtain the mock responses.
+func NewRegionlessMock(res ...mock.Response) *api.API {
+	u := &url.URL{Scheme: "https", Host: api.DefaultMockHost}
+	return withRegionlessRuntime(api.NewMock(), newRegionlessRuntime(u, mock.NewClient(res...)))
+}
+
+// NewMockMeta returns the provider meta of a mocked API client, which is
+// passed to the CRUD functions in the tests.
+func NewMockMeta(res ...mock.Response) *ProviderMeta {
+	return &ProviderMeta{API: api.NewMock(res...)}
+}
+
+// NewRegionlessMockMeta returns the provider meta of a NewRegionlessMock
+// client.
+func NewRegionlessMockMeta(res ...mock.Response) *ProviderMeta {
+	return &ProviderMeta{API: NewRegionlessMock(res...)}
+}
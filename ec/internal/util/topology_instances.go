@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+// FlattenTopologyInstances adds the instance configuration name and the
+// number of instances running in each zone to the flattened topology
+// elements, from the instances which are actually running for the resource.
+// Instances are matched to the topology elements by instance configuration.
+func FlattenTopologyInstances(topology []interface{}, info *models.ClusterTopologyInfo) {
+	if info == nil || len(info.Instances) == 0 {
+		return
+	}
+
+	for _, t := range topology {
+		m, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		id, _ := m["instance_configuration_id"].(string)
+		if id == "" {
+			continue
+		}
+
+		var zones = make(map[string]interface{})
+		for _, instance := range info.Instances {
+			if instance == nil || instance.InstanceConfiguration == nil {
+				continue
+			}
+
+			ic := instance.InstanceConfiguration
+			if ic.ID == nil || *ic.ID != id {
+				continue
+			}
+
+			if ic.Name != nil && *ic.Name != "" {
+				m["instance_configuration_name"] = *ic.Name
+			}
+
+			if instance.Zone != "" {
+				count, _ := zones[instance.Zone].(int)
+				zones[instance.Zone] = count + 1
+			}
+		}
+
+		if len(zones) > 0 {
+			m["zones"] = zones
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenTopologyInstances(t *testing.T) {
+	instance := func(id, name, zone string) *models.ClusterInstanceInfo {
+		return &models.ClusterInstanceInfo{
+			InstanceConfiguration: &models.ClusterInstanceConfigurationInfo{
+				ID:   ec.String(id),
+				Name: ec.String(name),
+			},
+			Zone: zone,
+		}
+	}
+	tests := []struct {
+		name     string
+		topology []interface{}
+		info     *models.ClusterTopologyInfo
+		want     []interface{}
+	}{
+		{
+			name: "leaves the topology untouched without instances",
+			topology: []interface{}{map[string]interface{}{
+				"instance_configuration_id": "aws.kibana.r5d",
+			}},
+			info: &models.ClusterTopologyInfo{},
+			want: []interface{}{map[string]interface{}{
+				"instance_configuration_id": "aws.kibana.r5d",
+			}},
+		},
+		{
+			name: "counts the running instances per zone",
+			topology: []interface{}{map[string]interface{}{
+				"instance_configuration_id": "aws.kibana.r5d",
+				"zone_count":                int32(2),
+			}},
+			info: &models.ClusterTopologyInfo{Instances: []*models.ClusterInstanceInfo{
+				instance("aws.kibana.r5d", "aws.kibana.r5d", "us-east-1a"),
+				instance("aws.kibana.r5d", "aws.kibana.r5d", "us-east-1b"),
+				instance("aws.kibana.r5d", "aws.kibana.r5d", "us-east-1b"),
+				instance("aws.apm.r5d", "aws.apm.r5d", "us-east-1c"),
+				nil,
+			}},
+			want: []interface{}{map[string]interface{}{
+				"instance_configuration_id":   "aws.kibana.r5d",
+				"instance_configuration_name": "aws.kibana.r5d",
+				"zone_count":                  int32(2),
+				"zones": map[string]interface{}{
+					"us-east-1a": 1,
+					"us-east-1b": 2,
+				},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			FlattenTopologyInstances(tt.topology, tt.info)
+			assert.Equal(t, tt.want, tt.topology)
+		})
+	}
+}
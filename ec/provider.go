@@ -27,24 +27,60 @@ import (
 
 	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/deploymentdatasource"
 	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/deploymentsdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/deploymenttemplatesdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/instanceconfigurationdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/manageddeploymentsdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/organizationapikeysdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/organizationdatasource"
 	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/privatelinkdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/providercapabilitiesdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/serverlessregionsdatasource"
 	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/stackdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/trafficfilterassociationsdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/trafficfilterlinkidsdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecdatasource/upgradeassistantdatasource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/allocatortagresource"
 	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentsbulktagresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymentsetresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/deploymenttagresource"
 	"github.com/elastic/terraform-provider-ec/ec/ecresource/elasticsearchkeystoreresource"
 	"github.com/elastic/terraform-provider-ec/ec/ecresource/extensionresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/fleetenrollmenttokenresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/instanceconfigurationresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/keystorerotationresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/organizationinvitationresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/organizationmembersresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/planexportresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/snapshotrepositoryresource"
 	"github.com/elastic/terraform-provider-ec/ec/ecresource/trafficfilterassocresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/trafficfiltercloneresource"
 	"github.com/elastic/terraform-provider-ec/ec/ecresource/trafficfilterresource"
+	"github.com/elastic/terraform-provider-ec/ec/ecresource/trustedenvironmentresource"
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
 )
 
 const (
 	eceOnlyText      = "Available only when targeting ECE Installations or Elasticsearch Service Private"
 	saasRequiredText = "The only valid authentication mechanism for the Elasticsearch Service"
 
-	endpointDesc     = "Endpoint where the terraform provider will point to. Defaults to \"%s\"."
-	insecureDesc     = "Allow the provider to skip TLS validation on its outgoing HTTP calls."
-	timeoutDesc      = "Timeout used for individual HTTP calls. Defaults to \"1m\"."
-	verboseDesc      = "When set, a \"request.log\" file will be written with all outgoing HTTP requests. Defaults to \"false\"."
-	verboseCredsDesc = "When set with verbose, the contents of the Authorization header will not be redacted. Defaults to \"false\"."
+	endpointDesc       = "Endpoint where the terraform provider will point to. Defaults to \"%s\"."
+	insecureDesc       = "Allow the provider to skip TLS validation on its outgoing HTTP calls."
+	timeoutDesc        = "Timeout used for individual HTTP calls. Defaults to \"1m\"."
+	verboseDesc        = "When set, a \"request.log\" file will be written with all outgoing HTTP requests. Defaults to \"false\"."
+	verboseCredsDesc   = "When set with verbose, the contents of the Authorization header will not be redacted. Defaults to \"false\"."
+	maxRetriesDesc     = "Maximum number of times an HTTP call is retried when it obtains one of the retryable status codes. Defaults to \"2\"."
+	retryBackoffDesc   = "Initial cooldown between retried HTTP calls, doubled on every subsequent retry. Defaults to \"1s\"."
+	retryCodesDesc     = "HTTP response status codes which are considered transient and retried. Defaults to [429, 502, 503, 504]."
+	defaultTagsDesc    = "Tags which are merged into the tags of every ec_deployment. Tags set on the resource take precedence."
+	apikeyFileDesc     = "File which contains the API Key to use for API authentication. The file is read again when the API Key is rejected, so it can be rotated while the provider runs."
+	apikeyCommandDesc  = "Command which prints the API Key to use for API authentication to its standard output. The command is run again when the API Key is rejected, so short-lived API Keys can be used."
+	cacertFileDesc     = "Path to a file with the PEM encoded CA certificates trusted in addition to the system ones, for endpoints with certificates signed by a private CA."
+	clientCertDesc     = "Path to a file with the PEM encoded client certificate presented to the endpoint, for mTLS proxies. Requires client_key."
+	clientKeyDesc      = "Path to a file with the PEM encoded private key of the client certificate. Requires client_cert."
+	proxyURLDesc       = "URL of the proxy the HTTP calls are sent through, such as a corporate egress proxy. Defaults to the proxy set in the HTTPS_PROXY environment variable."
+	headersDesc        = "Headers which are set on every HTTP call, and on the CONNECT request sent to the proxy_url, such as the authentication headers required by an egress proxy."
+	generateRefIDsDesc = "When set, the ref_id of the ec_deployment resources which don't set it are generated when the deployment is created, rather than defaulting to \"main-<kind>\". Defaults to \"false\"."
 )
 
 var (
@@ -63,22 +99,46 @@ var (
 // Provider returns a schema.Provider.
 func Provider() *schema.Provider {
 	return &schema.Provider{
-		ConfigureContextFunc: configureAPI,
+		ConfigureContextFunc: configureAPI(newClientCache()),
 		Schema:               newSchema(),
 		DataSourcesMap: map[string]*schema.Resource{
-			"ec_deployment":                           deploymentdatasource.DataSource(),
-			"ec_deployments":                          deploymentsdatasource.DataSource(),
-			"ec_stack":                                stackdatasource.DataSource(),
-			"ec_aws_privatelink_endpoint":             privatelinkdatasource.AwsDataSource(),
-			"ec_azure_privatelink_endpoint":           privatelinkdatasource.AzureDataSource(),
-			"ec_gcp_private_service_connect_endpoint": privatelinkdatasource.GcpDataSource(),
+			"ec_deployment":                             util.WithEndpointOverride(deploymentdatasource.DataSource()),
+			"ec_deployments":                            util.WithEndpointOverride(deploymentsdatasource.DataSource()),
+			"ec_deployment_templates":                   util.WithEndpointOverride(deploymenttemplatesdatasource.DataSource()),
+			"ec_managed_deployments":                    util.WithEndpointOverride(manageddeploymentsdatasource.DataSource()),
+			"ec_stack":                                  util.WithEndpointOverride(stackdatasource.DataSource()),
+			"ec_aws_privatelink_endpoint":               privatelinkdatasource.AwsDataSource(),
+			"ec_aws_privatelink_endpoints":              privatelinkdatasource.AwsEndpointsDataSource(),
+			"ec_azure_privatelink_endpoint":             privatelinkdatasource.AzureDataSource(),
+			"ec_gcp_private_service_connect_endpoint":   privatelinkdatasource.GcpDataSource(),
+			"ec_organization":                           util.WithEndpointOverride(organizationdatasource.DataSource()),
+			"ec_organization_api_keys":                  util.WithEndpointOverride(organizationapikeysdatasource.DataSource()),
+			"ec_instance_configuration":                 util.WithEndpointOverride(instanceconfigurationdatasource.DataSource()),
+			"ec_deployment_traffic_filter_associations": util.WithEndpointOverride(trafficfilterassociationsdatasource.DataSource()),
+			"ec_deployment_upgrade_assistant":           util.WithEndpointOverride(upgradeassistantdatasource.DataSource()),
+			"ec_provider_capabilities":                  util.WithEndpointOverride(providercapabilitiesdatasource.DataSource()),
+			"ec_serverless_regions":                     serverlessregionsdatasource.DataSource(),
+			"ec_traffic_filter_link_ids":                util.WithEndpointOverride(trafficfilterlinkidsdatasource.DataSource()),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"ec_deployment":                            deploymentresource.Resource(),
-			"ec_deployment_elasticsearch_keystore":     elasticsearchkeystoreresource.Resource(),
-			"ec_deployment_traffic_filter":             trafficfilterresource.Resource(),
-			"ec_deployment_traffic_filter_association": trafficfilterassocresource.Resource(),
-			"ec_deployment_extension":                  extensionresource.Resource(),
+			"ec_deployment":                              deploymentresource.Resource(),
+			"ec_deployment_elasticsearch_keystore":       elasticsearchkeystoreresource.Resource(),
+			"ec_deployment_keystore_rotation_policy":     keystorerotationresource.Resource(),
+			"ec_deployment_plan_export":                  planexportresource.Resource(),
+			"ec_deployment_traffic_filter":               trafficfilterresource.Resource(),
+			"ec_deployment_traffic_filter_association":   trafficfilterassocresource.Resource(),
+			"ec_deployment_traffic_filter_ruleset_clone": trafficfiltercloneresource.Resource(),
+			"ec_deployment_extension":                    extensionresource.Resource(),
+			"ec_deployment_tag":                          deploymenttagresource.Resource(),
+			"ec_deployment_set":                          deploymentsetresource.Resource(),
+			"ec_deployments_bulk_tag":                    deploymentsbulktagresource.Resource(),
+			"ec_deployment_fleet_enrollment_token":       fleetenrollmenttokenresource.Resource(),
+			"ec_organization_invitation":                 organizationinvitationresource.Resource(),
+			"ec_organization_members":                    organizationmembersresource.Resource(),
+			"ec_snapshot_repository":                     snapshotrepositoryresource.Resource(),
+			"ec_allocator_tag":                           allocatortagresource.Resource(),
+			"ec_instance_configuration":                  instanceconfigurationresource.Resource(),
+			"ec_trusted_environment":                     trustedenvironmentresource.Resource(),
 		},
 	}
 }
@@ -104,6 +164,22 @@ func newSchema() map[string]*schema.Schema {
 				[]string{"EC_API_KEY"}, "",
 			),
 		},
+		"apikey_file": {
+			Description: apikeyFileDesc,
+			Type:        schema.TypeString,
+			Optional:    true,
+			DefaultFunc: schema.MultiEnvDefaultFunc(
+				[]string{"EC_API_KEY_FILE"}, "",
+			),
+		},
+		"apikey_command": {
+			Description: apikeyCommandDesc,
+			Type:        schema.TypeString,
+			Optional:    true,
+			DefaultFunc: schema.MultiEnvDefaultFunc(
+				[]string{"EC_API_KEY_COMMAND"}, "",
+			),
+		},
 		"username": {
 			Description: usernameDesc,
 			Type:        schema.TypeString,
@@ -131,6 +207,42 @@ func newSchema() map[string]*schema.Schema {
 				false,
 			),
 		},
+		"cacert_file": {
+			Description: cacertFileDesc,
+			Type:        schema.TypeString,
+			Optional:    true,
+			DefaultFunc: schema.EnvDefaultFunc("EC_CACERT_FILE", ""),
+		},
+		"client_cert": {
+			Description:  clientCertDesc,
+			Type:         schema.TypeString,
+			Optional:     true,
+			RequiredWith: []string{"client_key"},
+			DefaultFunc:  schema.EnvDefaultFunc("EC_CLIENT_CERT", ""),
+		},
+		"client_key": {
+			Description:  clientKeyDesc,
+			Type:         schema.TypeString,
+			Optional:     true,
+			RequiredWith: []string{"client_cert"},
+			DefaultFunc:  schema.EnvDefaultFunc("EC_CLIENT_KEY", ""),
+		},
+		"proxy_url": {
+			Description:  proxyURLDesc,
+			Type:         schema.TypeString,
+			Optional:     true,
+			DefaultFunc:  schema.EnvDefaultFunc("EC_PROXY_URL", ""),
+			ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+		},
+		"headers": {
+			Description: headersDesc,
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Sensitive:   true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
 		"timeout": {
 			Description: timeoutDesc,
 			Type:        schema.TypeString,
@@ -139,6 +251,32 @@ func newSchema() map[string]*schema.Schema {
 				[]string{"EC_TIMEOUT"}, defaultTimeout.String(),
 			),
 		},
+		"max_retries": {
+			Description:  maxRetriesDesc,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			DefaultFunc: schema.EnvDefaultFunc(
+				"EC_MAX_RETRIES", DefaultHTTPRetries,
+			),
+		},
+		"retry_backoff": {
+			Description: retryBackoffDesc,
+			Type:        schema.TypeString,
+			Optional:    true,
+			DefaultFunc: schema.EnvDefaultFunc(
+				"EC_RETRY_BACKOFF", util.DefaultRetryBackoff.String(),
+			),
+		},
+		"retryable_status_codes": {
+			Description: retryCodesDesc,
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeInt,
+				ValidateFunc: validation.IntBetween(400, 599),
+			},
+		},
 		"verbose": {
 			Description: verboseDesc,
 			Type:        schema.TypeBool,
@@ -163,5 +301,21 @@ func newSchema() map[string]*schema.Schema {
 				"EC_VERBOSE_FILE", "request.log",
 			),
 		},
+		"default_tags": {
+			Description: defaultTagsDesc,
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"generate_ref_ids": {
+			Description: generateRefIDsDesc,
+			Type:        schema.TypeBool,
+			Optional:    true,
+			DefaultFunc: schema.EnvDefaultFunc(
+				"EC_GENERATE_REF_IDS", false,
+			),
+		},
 	}
 }
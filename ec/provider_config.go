@@ -19,15 +19,26 @@ package ec
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/auth"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/elastic/terraform-provider-ec/ec/internal/util"
 )
 
 const (
@@ -35,23 +46,141 @@ const (
 )
 
 var (
-	// DefaultHTTPRetries to use for the provider's HTTP client.
+	// DefaultHTTPRetries to use for the provider's HTTP client, both for the
+	// requests which time out and, unless "max_retries" is set, for the ones
+	// which obtain a retryable status code.
 	DefaultHTTPRetries = 2
 )
 
-// configureAPI implements schema.ConfigureContextFunc
-func configureAPI(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	cfg, err := newAPIConfig(d)
+// clientCache is a concurrency-safe cache of API clients keyed by the
+// provider settings they were built from. Every provider instance has its own
+// cache, so the clients, and the credentials they hold, don't outlive it.
+type clientCache struct {
+	mu      sync.Mutex
+	clients map[string]*api.API
+}
+
+func newClientCache() *clientCache {
+	return &clientCache{clients: make(map[string]*api.API)}
+}
+
+// get returns the cached client for the key, calling build to create it when
+// it's not cached. The lock is held while building the client, so concurrent
+// configure calls with the same settings only build it once.
+func (c *clientCache) get(key string, build func() (*api.API, error)) (*api.API, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.clients[key]; ok {
+		return client, nil
+	}
+
+	client, err := build()
 	if err != nil {
-		return nil, diag.FromErr(err)
+		return nil, err
 	}
 
+	c.clients[key] = client
+	return client, nil
+}
+
+// newAPI returns the API client of the config, which submits the operations
+// of the global APIs, such as /organizations, without a region.
+func newAPI(cfg api.Config) (*api.API, error) {
 	client, err := api.NewAPI(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return util.WithRegionless(client, cfg)
+}
+
+// configureAPI returns the schema.ConfigureContextFunc of a provider instance,
+// which reuses the cached client when the provider is configured again with
+// the same settings.
+func configureAPI(clients *clientCache) schema.ConfigureContextFunc {
+	return func(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return configureClient(clients, d)
+	}
+}
+
+func configureClient(clients *clientCache, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	key := configKey(d)
+	client, err := clients.get(key, func() (*api.API, error) {
+		cfg, err := newAPIConfig(d)
+		if err != nil {
+			return nil, err
+		}
+
+		return newAPI(cfg)
+	})
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
 
-	return client, nil
+	// The services other than the API don't use the "insecure" setting, which
+	// only applies to the API endpoint.
+	timeout, err := time.ParseDuration(d.Get("timeout").(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	transport, err := newHTTPTransport(d, timeout, false)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	// The endpoint override clients are built with the provider settings of
+	// this configuration, and cached along with the provider client.
+	factory := func(o util.EndpointOverride) (*api.API, error) {
+		return clients.get(key+o.Key(), func() (*api.API, error) {
+			cfg, err := newAPIConfig(d)
+			if err != nil {
+				return nil, err
+			}
+			if cfg, err = overrideAPIConfig(d, cfg, o); err != nil {
+				return nil, err
+			}
+			return newAPI(cfg)
+		})
+	}
+
+	return &util.ProviderMeta{
+		API:            client,
+		DefaultTags:    util.ItemsToStringMap(d.Get("default_tags").(map[string]interface{})),
+		GenerateRefIDs: d.Get("generate_ref_ids").(bool),
+		ClientFactory:  factory,
+		Transport:      transport,
+	}, nil
+}
+
+// configKey returns a digest of all the provider settings which are used to
+// build the API client. The credentials have to be part of the key since the
+// client authenticates every request with the ones it was built from, so a
+// configuration with other credentials must not obtain it. Only the SHA-256
+// digest is kept, never the credentials themselves.
+func configKey(d *schema.ResourceData) string {
+	var keys = make([]string, 0, len(newSchema()))
+	for k := range newSchema() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		v := d.Get(k)
+		if set, ok := v.(*schema.Set); ok {
+			items := make([]string, 0, set.Len())
+			for _, item := range set.List() {
+				items = append(items, fmt.Sprint(item))
+			}
+			sort.Strings(items)
+			v = items
+		}
+		fmt.Fprintf(h, "%s=%v\n", k, v)
+	}
+	fmt.Fprintf(h, "%s=%s\n", util.DiagnosticsBundleEnv, os.Getenv(util.DiagnosticsBundleEnv))
+
+	return hex.EncodeToString(h.Sum(nil))
 }
 
 func newAPIConfig(d *schema.ResourceData) (api.Config, error) {
@@ -62,8 +191,20 @@ func newAPIConfig(d *schema.ResourceData) (api.Config, error) {
 		return cfg, err
 	}
 
+	source, err := apiKeySource(d)
+	if err != nil {
+		return cfg, err
+	}
+
+	apikey := d.Get("apikey").(string)
+	if source != nil {
+		if apikey, err = source.Key(); err != nil {
+			return cfg, err
+		}
+	}
+
 	authWriter, err := auth.NewAuthWriter(auth.Config{
-		APIKey:   d.Get("apikey").(string),
+		APIKey:   apikey,
 		Username: d.Get("username").(string),
 		Password: d.Get("password").(string),
 	})
@@ -71,6 +212,11 @@ func newAPIConfig(d *schema.ResourceData) (api.Config, error) {
 		return cfg, err
 	}
 
+	retryCfg, err := retrySettings(d)
+	if err != nil {
+		return cfg, err
+	}
+
 	verboseCfg, err := verboseSettings(
 		d.Get("verbose_file").(string),
 		d.Get("verbose").(bool),
@@ -80,19 +226,285 @@ func newAPIConfig(d *schema.ResourceData) (api.Config, error) {
 		return cfg, err
 	}
 
+	insecure := d.Get("insecure").(bool)
+	endpoint := d.Get("endpoint").(string)
+	transport, err := newHTTPTransport(d, timeout, insecure)
+	if err != nil {
+		return cfg, err
+	}
+	if path := os.Getenv(util.DiagnosticsBundleEnv); path != "" {
+		transport = util.NewDiagnosticsTransport(transport, path, Version, endpoint)
+	}
+	if source != nil {
+		transport = util.NewAPIKeyTransport(transport, source)
+	}
+
 	return api.Config{
 		ErrorDevice:     os.Stdout,
-		Client:          &http.Client{},
+		Client:          &http.Client{Transport: util.NewRetryTransport(transport, retryCfg)},
 		VerboseSettings: verboseCfg,
 		AuthWriter:      authWriter,
-		Host:            d.Get("endpoint").(string),
-		SkipTLSVerify:   d.Get("insecure").(bool),
+		Host:            endpoint,
+		SkipTLSVerify:   insecure,
 		Timeout:         timeout,
 		UserAgent:       userAgent(Version),
-		Retries:         DefaultHTTPRetries,
+		// The SDK retries the requests which time out, while the retries of
+		// the requests obtaining a transient status code are owned by the
+		// RetryTransport, which is the one configured by "max_retries". The
+		// SDK retries are left as they were, since they also multiply the
+		// timeout of every API call.
+		Retries: DefaultHTTPRetries,
 	}, nil
 }
 
+// overrideAPIConfig returns the API config of a data source endpoint
+// override, replacing the provider endpoint and TLS settings.
+func overrideAPIConfig(d *schema.ResourceData, cfg api.Config, o util.EndpointOverride) (api.Config, error) {
+	if o.Endpoint != "" {
+		cfg.Host = o.Endpoint
+	}
+	cfg.SkipTLSVerify = o.Insecure
+
+	retryCfg, err := retrySettings(d)
+	if err != nil {
+		return cfg, err
+	}
+
+	// The override CA certificates replace the provider ones, while the
+	// client certificates are shared with the provider.
+	tlsCfg, err := readTLSSettings(d)
+	if err != nil {
+		return cfg, err
+	}
+	if o.CACert != "" {
+		tlsCfg.caCert, tlsCfg.caCertSetting = o.CACert, "endpoint_override cacert"
+	}
+
+	proxy, headers, err := proxySettings(d)
+	if err != nil {
+		return cfg, err
+	}
+
+	transport, err := newTLSTransport(cfg.Timeout, o.Insecure, tlsCfg)
+	if err != nil {
+		return cfg, err
+	}
+	transport = withProxy(transport, proxy, headers)
+
+	if path := os.Getenv(util.DiagnosticsBundleEnv); path != "" {
+		transport = util.NewDiagnosticsTransport(transport, path, Version, cfg.Host)
+	}
+
+	source, err := apiKeySource(d)
+	if err != nil {
+		return cfg, err
+	}
+	if source != nil {
+		transport = util.NewAPIKeyTransport(transport, source)
+	}
+	cfg.Client = &http.Client{Transport: util.NewRetryTransport(transport, retryCfg)}
+
+	return cfg, nil
+}
+
+// apiKeySource returns the source of the API key when it's obtained from the
+// "apikey_file" or the "apikey_command", which are re-read when the API key is
+// rejected, or nil when neither is set.
+func apiKeySource(d *schema.ResourceData) (*util.APIKeySource, error) {
+	file := d.Get("apikey_file").(string)
+	command := d.Get("apikey_command").(string)
+	if file == "" && command == "" {
+		return nil, nil
+	}
+
+	if file != "" && command != "" {
+		return nil, errors.New("only one of apikey_file or apikey_command can be specified")
+	}
+
+	if d.Get("apikey").(string) != "" {
+		return nil, errors.New("only one of apikey, apikey_file or apikey_command can be specified")
+	}
+
+	return &util.APIKeySource{File: file, Command: command}, nil
+}
+
+// retrySettings reads the provider retry settings which are shared by all
+// the outgoing HTTP calls.
+func retrySettings(d *schema.ResourceData) (util.RetryConfig, error) {
+	var cfg = util.RetryConfig{
+		MaxRetries:  d.Get("max_retries").(int),
+		StatusCodes: util.DefaultRetryableStatusCodes,
+	}
+
+	backoff, err := time.ParseDuration(d.Get("retry_backoff").(string))
+	if err != nil {
+		return cfg, fmt.Errorf("failed parsing retry_backoff: %w", err)
+	}
+	cfg.Backoff = backoff
+
+	if codes := d.Get("retryable_status_codes").(*schema.Set); codes.Len() > 0 {
+		cfg.StatusCodes = make([]int, 0, codes.Len())
+		for _, code := range codes.List() {
+			cfg.StatusCodes = append(cfg.StatusCodes, code.(int))
+		}
+		sort.Ints(cfg.StatusCodes)
+	}
+
+	return cfg, nil
+}
+
+// proxySettings reads the provider "proxy_url" and "headers" settings.
+func proxySettings(d *schema.ResourceData) (*url.URL, map[string]string, error) {
+	var headers map[string]string
+	if raw, ok := d.Get("headers").(map[string]interface{}); ok && len(raw) > 0 {
+		headers = make(map[string]string, len(raw))
+		for k, v := range raw {
+			headers[k] = v.(string)
+		}
+	}
+
+	rawURL := d.Get("proxy_url").(string)
+	if rawURL == "" {
+		return nil, headers, nil
+	}
+
+	proxy, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed parsing proxy_url: %w", err)
+	}
+
+	return proxy, headers, nil
+}
+
+// newHTTPTransport returns the transport built from the provider TLS, proxy
+// and headers settings, which is used to reach both the API and the other
+// services the provider queries, such as container registries.
+func newHTTPTransport(d *schema.ResourceData, timeout time.Duration, insecure bool) (http.RoundTripper, error) {
+	tlsCfg, err := readTLSSettings(d)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, headers, err := proxySettings(d)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := newTLSTransport(timeout, insecure, tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return withProxy(transport, proxy, headers), nil
+}
+
+// withProxy sends the transport requests through the proxy, which replaces
+// the one set in the environment, and sets the headers on every request and
+// on the CONNECT requests sent to the proxy.
+func withProxy(rt http.RoundTripper, proxy *url.URL, headers map[string]string) http.RoundTripper {
+	transport := rt.(*http.Transport)
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if len(headers) == 0 {
+		return transport
+	}
+
+	transport.ProxyConnectHeader = util.NewHeader(headers)
+	return util.NewHeadersTransport(transport, headers)
+}
+
+// newTransport returns the http.Transport used by the provider's HTTP client.
+// The TLS settings need to be set here since the SDK only configures them when
+// the client transport is an *http.Transport, while the provider wraps it with
+// a RetryTransport which retries requests obtaining a transient status code.
+func newTransport(timeout time.Duration, insecure bool) http.RoundTripper {
+	var transport = http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecure}
+
+	return transport
+}
+
+// tlsSettings are the custom CA certificates trusted by the provider's HTTP
+// client and the client certificates it presents.
+type tlsSettings struct {
+	// caCert are the PEM encoded CA certificates.
+	caCert string
+	// caCertSetting is the setting the CA certificates were obtained from.
+	caCertSetting string
+
+	certificates []tls.Certificate
+}
+
+// readTLSSettings reads the provider "cacert_file", "client_cert" and
+// "client_key" files.
+func readTLSSettings(d *schema.ResourceData) (tlsSettings, error) {
+	var cfg tlsSettings
+	if path := d.Get("cacert_file").(string); path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return cfg, fmt.Errorf("failed reading cacert_file: %w", err)
+		}
+		cfg.caCert, cfg.caCertSetting = string(b), "cacert_file"
+	}
+
+	cert := d.Get("client_cert").(string)
+	key := d.Get("client_key").(string)
+	if cert == "" && key == "" {
+		return cfg, nil
+	}
+
+	if cert == "" || key == "" {
+		return cfg, errors.New("client_cert and client_key must be set together")
+	}
+
+	pair, err := tls.LoadX509KeyPair(cert, key)
+	if err != nil {
+		return cfg, fmt.Errorf("failed loading the client certificate: %w", err)
+	}
+	cfg.certificates = []tls.Certificate{pair}
+
+	return cfg, nil
+}
+
+// newTLSTransport returns the provider http.Transport trusting the custom CA
+// certificates and presenting the client certificates.
+func newTLSTransport(timeout time.Duration, insecure bool, cfg tlsSettings) (http.RoundTripper, error) {
+	transport, err := newCACertTransport(timeout, insecure, cfg.caCert, cfg.caCertSetting)
+	if err != nil {
+		return nil, err
+	}
+
+	transport.(*http.Transport).TLSClientConfig.Certificates = cfg.certificates
+	return transport, nil
+}
+
+// newCACertTransport returns the provider http.Transport trusting the PEM
+// encoded CA certificates in addition to the system ones.
+func newCACertTransport(timeout time.Duration, insecure bool, caCert, setting string) (http.RoundTripper, error) {
+	transport := newTransport(timeout, insecure).(*http.Transport)
+	if caCert == "" {
+		return transport, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM([]byte(caCert)) {
+		return nil, fmt.Errorf("failed parsing the %s PEM certificates", setting)
+	}
+	transport.TLSClientConfig.RootCAs = pool
+
+	return transport, nil
+}
+
 func verboseSettings(name string, verbose, redactAuth bool) (api.VerboseSettings, error) {
 	var cfg api.VerboseSettings
 	if !verbose {
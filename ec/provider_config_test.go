@@ -18,13 +18,22 @@
 package ec
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"os"
 	"path/filepath"
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/elastic/cloud-sdk-go/pkg/api"
 	"github.com/elastic/cloud-sdk-go/pkg/auth"
@@ -125,6 +134,28 @@ func Test_newAPIConfig(t *testing.T) {
 	})
 	apiKeyObj := auth.APIKey("blih")
 
+	apiKeyFile := filepath.Join(t.TempDir(), "apikey")
+	if err := os.WriteFile(apiKeyFile, []byte("bloh\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	apiKeyFileCfg := util.NewResourceData(t, util.ResDataParams{
+		ID:     "whocares",
+		Schema: newSchema(),
+		State: map[string]interface{}{
+			"apikey_file": apiKeyFile,
+		},
+	})
+	apiKeyFileObj := auth.APIKey("bloh")
+
+	apiKeyAndFileCfg := util.NewResourceData(t, util.ResDataParams{
+		ID:     "whocares",
+		Schema: newSchema(),
+		State: map[string]interface{}{
+			"apikey":      "blih",
+			"apikey_file": apiKeyFile,
+		},
+	})
+
 	userPassCfg := util.NewResourceData(t, util.ResDataParams{
 		ID:     "whocares",
 		Schema: newSchema(),
@@ -198,6 +229,67 @@ func Test_newAPIConfig(t *testing.T) {
 			"verbose_credentials": true,
 		},
 	})
+	retryCfg := util.NewResourceData(t, util.ResDataParams{
+		ID:     "whocares",
+		Schema: newSchema(),
+		State: map[string]interface{}{
+			"apikey":                 "blih",
+			"max_retries":            5,
+			"retry_backoff":          "500ms",
+			"retryable_status_codes": []interface{}{503, 429},
+		},
+	})
+	invalidRetryBackoffCfg := util.NewResourceData(t, util.ResDataParams{
+		ID:     "whocares",
+		Schema: newSchema(),
+		State: map[string]interface{}{
+			"apikey":        "blih",
+			"retry_backoff": "invalid",
+		},
+	})
+	// The duration parsing error type depends on the Go version.
+	_, invalidDurationErr := time.ParseDuration("invalid")
+	certFile, keyFile := writeTestCertificate(t)
+	tlsCfg := util.NewResourceData(t, util.ResDataParams{
+		ID:     "whocares",
+		Schema: newSchema(),
+		State: map[string]interface{}{
+			"apikey":      "blih",
+			"cacert_file": certFile,
+			"client_cert": certFile,
+			"client_key":  keyFile,
+		},
+	})
+	invalidCACertCfg := util.NewResourceData(t, util.ResDataParams{
+		ID:     "whocares",
+		Schema: newSchema(),
+		State: map[string]interface{}{
+			"apikey":      "blih",
+			"cacert_file": keyFile,
+		},
+	})
+	missingClientKeyCfg := util.NewResourceData(t, util.ResDataParams{
+		ID:     "whocares",
+		Schema: newSchema(),
+		State: map[string]interface{}{
+			"apikey":      "blih",
+			"client_cert": certFile,
+		},
+	})
+	invalidClientKeyCfg := util.NewResourceData(t, util.ResDataParams{
+		ID:     "whocares",
+		Schema: newSchema(),
+		State: map[string]interface{}{
+			"apikey":      "blih",
+			"client_cert": certFile,
+			"client_key":  certFile,
+		},
+	})
+	defaultRetry := util.RetryConfig{
+		MaxRetries:  DefaultHTTPRetries,
+		Backoff:     util.DefaultRetryBackoff,
+		StatusCodes: util.DefaultRetryableStatusCodes,
+	}
 	type args struct {
 		d *schema.ResourceData
 	}
@@ -205,6 +297,7 @@ func Test_newAPIConfig(t *testing.T) {
 		name         string
 		args         args
 		want         api.Config
+		wantRetry    *util.RetryConfig
 		wantFileName string
 		err          error
 	}{
@@ -228,11 +321,27 @@ func Test_newAPIConfig(t *testing.T) {
 				ErrorDevice: os.Stdout,
 				Host:        api.ESSEndpoint,
 				AuthWriter:  &apiKeyObj,
-				Client:      &http.Client{},
 				Timeout:     defaultTimeout,
 				Retries:     DefaultHTTPRetries,
 			},
 		},
+		{
+			name: "custom config with apikey_file auth succeeds",
+			args: args{d: apiKeyFileCfg},
+			want: api.Config{
+				UserAgent:   fmt.Sprintf(providerUserAgentFmt, Version, api.DefaultUserAgent),
+				ErrorDevice: os.Stdout,
+				Host:        api.ESSEndpoint,
+				AuthWriter:  &apiKeyFileObj,
+				Timeout:     defaultTimeout,
+				Retries:     DefaultHTTPRetries,
+			},
+		},
+		{
+			name: "custom config with apikey and apikey_file fails",
+			args: args{d: apiKeyAndFileCfg},
+			err:  errors.New("only one of apikey, apikey_file or apikey_command can be specified"),
+		},
 		{
 			name: "custom config with username/password auth succeeds",
 			args: args{d: userPassCfg},
@@ -241,7 +350,6 @@ func Test_newAPIConfig(t *testing.T) {
 				ErrorDevice: os.Stdout,
 				Host:        api.ESSEndpoint,
 				AuthWriter:  &userPassObj,
-				Client:      &http.Client{},
 				Timeout:     defaultTimeout,
 				Retries:     DefaultHTTPRetries,
 			},
@@ -254,7 +362,6 @@ func Test_newAPIConfig(t *testing.T) {
 				ErrorDevice:   os.Stdout,
 				Host:          api.ESSEndpoint,
 				AuthWriter:    &apiKeyObj,
-				Client:        &http.Client{},
 				Timeout:       defaultTimeout,
 				Retries:       DefaultHTTPRetries,
 				SkipTLSVerify: true,
@@ -268,7 +375,6 @@ func Test_newAPIConfig(t *testing.T) {
 				ErrorDevice:   os.Stdout,
 				Host:          api.ESSEndpoint,
 				AuthWriter:    &apiKeyObj,
-				Client:        &http.Client{},
 				Timeout:       defaultTimeout,
 				Retries:       DefaultHTTPRetries,
 				SkipTLSVerify: true,
@@ -282,7 +388,6 @@ func Test_newAPIConfig(t *testing.T) {
 				ErrorDevice: os.Stdout,
 				Host:        api.ESSEndpoint,
 				AuthWriter:  &apiKeyObj,
-				Client:      &http.Client{},
 				Timeout:     defaultTimeout,
 				Retries:     DefaultHTTPRetries,
 				VerboseSettings: api.VerboseSettings{
@@ -300,7 +405,6 @@ func Test_newAPIConfig(t *testing.T) {
 				ErrorDevice: os.Stdout,
 				Host:        api.ESSEndpoint,
 				AuthWriter:  &apiKeyObj,
-				Client:      &http.Client{},
 				Timeout:     defaultTimeout,
 				Retries:     DefaultHTTPRetries,
 				VerboseSettings: api.VerboseSettings{
@@ -318,7 +422,6 @@ func Test_newAPIConfig(t *testing.T) {
 				ErrorDevice: os.Stdout,
 				Host:        api.ESSEndpoint,
 				AuthWriter:  &apiKeyObj,
-				Client:      &http.Client{},
 				Timeout:     defaultTimeout,
 				Retries:     DefaultHTTPRetries,
 				VerboseSettings: api.VerboseSettings{
@@ -328,6 +431,57 @@ func Test_newAPIConfig(t *testing.T) {
 			},
 			wantFileName: filepath.Base(customFile.Name()),
 		},
+		{
+			name: "custom config with retry settings succeeds",
+			args: args{d: retryCfg},
+			want: api.Config{
+				UserAgent:   fmt.Sprintf(providerUserAgentFmt, Version, api.DefaultUserAgent),
+				ErrorDevice: os.Stdout,
+				Host:        api.ESSEndpoint,
+				AuthWriter:  &apiKeyObj,
+				Timeout:     defaultTimeout,
+				Retries:     DefaultHTTPRetries,
+			},
+			wantRetry: &util.RetryConfig{
+				MaxRetries:  5,
+				Backoff:     500 * time.Millisecond,
+				StatusCodes: []int{429, 503},
+			},
+		},
+		{
+			name: "custom config with invalid retry_backoff fails",
+			args: args{d: invalidRetryBackoffCfg},
+			err:  fmt.Errorf("failed parsing retry_backoff: %w", invalidDurationErr),
+		},
+		{
+			name: "custom config with cacert_file and client certificate succeeds",
+			args: args{d: tlsCfg},
+			want: api.Config{
+				UserAgent:   fmt.Sprintf(providerUserAgentFmt, Version, api.DefaultUserAgent),
+				ErrorDevice: os.Stdout,
+				Host:        api.ESSEndpoint,
+				AuthWriter:  &apiKeyObj,
+				Timeout:     defaultTimeout,
+				Retries:     DefaultHTTPRetries,
+			},
+		},
+		{
+			name: "custom config with an invalid cacert_file fails",
+			args: args{d: invalidCACertCfg},
+			err:  errors.New("failed parsing the cacert_file PEM certificates"),
+		},
+		{
+			name: "custom config with client_cert and no client_key fails",
+			args: args{d: missingClientKeyCfg},
+			err:  errors.New("client_cert and client_key must be set together"),
+		},
+		{
+			name: "custom config with an invalid client_key fails",
+			args: args{d: invalidClientKeyCfg},
+			err: fmt.Errorf("failed loading the client certificate: %w",
+				errors.New("tls: found a certificate rather than a key in the PEM for the private key"),
+			),
+		},
 		{
 			name: "custom config with verbose and verbose_credentials (invalid file) fails ",
 			args: args{d: verboseInvalidFileCfg},
@@ -354,11 +508,173 @@ func Test_newAPIConfig(t *testing.T) {
 				got.Device = nil
 			}
 
+			if got.Client != nil {
+				wantRetry := defaultRetry
+				if tt.wantRetry != nil {
+					wantRetry = *tt.wantRetry
+				}
+				if rt, ok := got.Client.Transport.(*util.RetryTransport); assert.True(t, ok) {
+					assert.Equal(t, wantRetry, rt.Config())
+				}
+				got.Client = nil
+			}
+
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
+func Test_configureAPI(t *testing.T) {
+	defer unsetECAPIKey(t)()
+
+	newCfg := func(endpoint, apikey string) *schema.ResourceData {
+		return util.NewResourceData(t, util.ResDataParams{
+			ID:     "whocares",
+			Schema: newSchema(),
+			State: map[string]interface{}{
+				"apikey":   apikey,
+				"endpoint": endpoint,
+			},
+		})
+	}
+
+	client := func(meta interface{}) *api.API {
+		return meta.(*util.ProviderMeta).API
+	}
+
+	configure := configureAPI(newClientCache())
+
+	first, diags := configure(context.Background(), newCfg("https://cloud.elastic.co", "blih"))
+	assert.Nil(t, diags)
+
+	second, diags := configure(context.Background(), newCfg("https://cloud.elastic.co", "blih"))
+	assert.Nil(t, diags)
+	assert.Same(t, client(first), client(second), "clients with the same settings should be shared")
+
+	other, diags := configure(context.Background(), newCfg("https://ece.example.com", "blih"))
+	assert.Nil(t, diags)
+	assert.NotSame(t, client(first), client(other), "clients with different settings shouldn't be shared")
+
+	rotated, diags := configure(context.Background(), newCfg("https://cloud.elastic.co", "bloh"))
+	assert.Nil(t, diags)
+	assert.NotSame(t, client(first), client(rotated), "clients with different credentials shouldn't be shared")
+
+	fresh, diags := configureAPI(newClientCache())(context.Background(), newCfg("https://cloud.elastic.co", "blih"))
+	assert.Nil(t, diags)
+	assert.NotSame(t, client(first), client(fresh), "clients shouldn't be shared between provider instances")
+
+	tagged := newCfg("https://cloud.elastic.co", "blih")
+	_ = tagged.Set("default_tags", map[string]interface{}{"team": "search"})
+	meta, diags := configure(context.Background(), tagged)
+	assert.Nil(t, diags)
+	assert.Same(t, client(first), client(meta), "the default tags shouldn't change the shared client")
+	assert.Equal(t, map[string]string{"team": "search"}, meta.(*util.ProviderMeta).DefaultTags)
+	assert.Empty(t, first.(*util.ProviderMeta).DefaultTags)
+
+	refIDs := newCfg("https://cloud.elastic.co", "blih")
+	_ = refIDs.Set("generate_ref_ids", true)
+	meta, diags = configure(context.Background(), refIDs)
+	assert.Nil(t, diags)
+	assert.Same(t, client(first), client(meta), "generate_ref_ids shouldn't change the shared client")
+	assert.True(t, meta.(*util.ProviderMeta).GenerateRefIDs)
+	assert.False(t, first.(*util.ProviderMeta).GenerateRefIDs)
+	assert.NotNil(t, first.(*util.ProviderMeta).Transport)
+
+	override := util.EndpointOverride{Endpoint: "https://ece.example.com:12443", Insecure: true}
+	overridden, err := first.(*util.ProviderMeta).ClientFactory(override)
+	assert.NoError(t, err)
+	assert.NotSame(t, client(first), overridden, "the endpoint override client shouldn't be the provider one")
+	again, err := second.(*util.ProviderMeta).ClientFactory(override)
+	assert.NoError(t, err)
+	assert.Same(t, overridden, again, "endpoint override clients with the same settings should be shared")
+}
+
+func Test_proxySettings(t *testing.T) {
+	d := util.NewResourceData(t, util.ResDataParams{
+		ID:     "whocares",
+		Schema: newSchema(),
+		State: map[string]interface{}{
+			"proxy_url": "http://proxy.example.com:3128",
+			"headers": map[string]interface{}{
+				"X-Egress-Token": "some-token",
+			},
+		},
+	})
+
+	proxy, headers, err := proxySettings(d)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://proxy.example.com:3128", proxy.String())
+	assert.Equal(t, map[string]string{"X-Egress-Token": "some-token"}, headers)
+
+	transport := newTransport(time.Second, false)
+	rt := withProxy(transport, proxy, headers)
+	assert.IsType(t, &util.HeadersTransport{}, rt)
+
+	httpTransport := transport.(*http.Transport)
+	assert.Equal(t, "some-token", httpTransport.ProxyConnectHeader.Get("X-Egress-Token"))
+
+	req, err := http.NewRequest(http.MethodGet, "https://cloud.elastic.co", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := httpTransport.Proxy(req)
+	assert.NoError(t, err)
+	assert.Equal(t, proxy, got)
+
+	d = util.NewResourceData(t, util.ResDataParams{
+		ID:     "whocares",
+		Schema: newSchema(),
+		State: map[string]interface{}{
+			"proxy_url": "http://[::1",
+		},
+	})
+	_, _, err = proxySettings(d)
+	assert.EqualError(t, err, `failed parsing proxy_url: parse "http://[::1": missing ']' in host`)
+}
+
+// writeTestCertificate writes a self-signed certificate and its private key
+// to temporary files, returning their paths.
+func writeTestCertificate(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ec-provider-test"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := os.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err := os.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
 func unsetECAPIKey(t *testing.T) func() {
 	t.Helper()
 	// This is necessary to avoid any EC_API_KEY which might be set to cause
@@ -0,0 +1,58 @@
+terraform {
+  # Check blocks are supported from ">=1.5".
+  required_version = ">= 1.5.0"
+
+  required_providers {
+    ec = {
+      source  = "elastic/ec"
+      version = "0.6.0"
+    }
+  }
+}
+
+provider "ec" {}
+
+variable "expected_version_after_apply" {
+  type        = string
+  description = "Elastic Stack version all the deployment resources must run once the apply finishes"
+  default     = "8.4.3"
+}
+
+variable "deployment_template_id" {
+  type    = string
+  default = "aws-io-optimized-v2"
+}
+
+resource "ec_deployment" "example" {
+  name = "my_example_deployment"
+
+  region                 = "us-east-1"
+  version                = var.expected_version_after_apply
+  deployment_template_id = var.deployment_template_id
+
+  elasticsearch {}
+
+  kibana {}
+
+  lifecycle {
+    # Fails the apply when any of the deployment resources are left running
+    # a different version, for example after a partially failed upgrade.
+    postcondition {
+      condition     = self.resolved_version == var.expected_version_after_apply
+      error_message = "The deployment runs ${self.resolved_version}, expected ${var.expected_version_after_apply}."
+    }
+
+    postcondition {
+      condition     = self.resolved_template_id == var.deployment_template_id
+      error_message = "The deployment runs on the ${self.resolved_template_id} template, expected ${var.deployment_template_id}."
+    }
+  }
+}
+
+# Continuously validated on every plan and apply, without blocking them.
+check "deployment_version" {
+  assert {
+    condition     = ec_deployment.example.resolved_version == var.expected_version_after_apply
+    error_message = "The deployment has drifted to version ${ec_deployment.example.resolved_version}."
+  }
+}